@@ -0,0 +1,261 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/near/borsh-go"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+var (
+	ErrFunctionNotFound    = errors.New("function not found in ABI")
+	ErrDuplicateFunction   = errors.New("duplicate function in ABI")
+	ErrFunctionNameInvalid = errors.New("function name is invalid")
+	ErrTypeInvalid         = errors.New("type is invalid")
+	ErrWrongNumberOfArgs   = errors.New("wrong number of arguments")
+	ErrArgumentMissing     = errors.New("argument is missing")
+	ErrArgumentsInvalid    = errors.New("arguments must be a JSON array or object")
+	ErrNestedOption        = errors.New("option types can only be used at the top level")
+)
+
+// Param is a named, borsh encoded function argument
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Function describes how to call a contract function and how to read its
+// return value. An empty [Returns] means the function returns nothing.
+type Function struct {
+	Name    string  `json:"name"`
+	Args    []Param `json:"args"`
+	Returns string  `json:"returns,omitempty"`
+}
+
+// ABI lists the functions exposed by a contract. Supported types are bool,
+// u8, u16, u32, u64, i8, i16, i32, i64, String, Address, Vec<T> and Option<T>.
+// Vec<u8> values are represented as base64 strings in JSON and Address values
+// use their string representation.
+type ABI struct {
+	Functions []Function `json:"functions"`
+}
+
+// Parse decodes and validates a JSON encoded ABI
+func Parse(b []byte) (*ABI, error) {
+	var a ABI
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, err
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (a *ABI) Validate() error {
+	seen := make(map[string]struct{}, len(a.Functions))
+	for _, f := range a.Functions {
+		if len(f.Name) == 0 {
+			return ErrFunctionNameInvalid
+		}
+		if _, ok := seen[f.Name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateFunction, f.Name)
+		}
+		seen[f.Name] = struct{}{}
+		for _, arg := range f.Args {
+			if _, err := typeOf(arg.Type, true); err != nil {
+				return fmt.Errorf("%s.%s: %w", f.Name, arg.Name, err)
+			}
+		}
+		if len(f.Returns) > 0 {
+			if _, err := typeOf(f.Returns, true); err != nil {
+				return fmt.Errorf("%s returns: %w", f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *ABI) Function(name string) (*Function, error) {
+	for i := range a.Functions {
+		if a.Functions[i].Name == name {
+			return &a.Functions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFunctionNotFound, name)
+}
+
+// EncodeArgs borsh encodes [args] in the order expected by the function.
+// [args] is either a JSON array with positional arguments or a JSON object
+// keyed by argument name.
+func (f *Function) EncodeArgs(args json.RawMessage) ([]byte, error) {
+	rawArgs, err := f.orderArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var callData []byte
+	for i, arg := range f.Args {
+		t, err := typeOf(arg.Type, true)
+		if err != nil {
+			return nil, err
+		}
+		v := reflect.New(t)
+		if err := json.Unmarshal(rawArgs[i], v.Interface()); err != nil {
+			return nil, fmt.Errorf("%s: %w", arg.Name, err)
+		}
+		b, err := borsh.Serialize(v.Elem().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg.Name, err)
+		}
+		callData = append(callData, b...)
+	}
+	return callData, nil
+}
+
+// DecodeResult converts the borsh encoded value returned by the function to JSON
+func (f *Function) DecodeResult(result []byte) (json.RawMessage, error) {
+	if len(f.Returns) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	t, err := typeOf(f.Returns, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// borsh-go decodes None into a pointer to the zero value so options are
+	// unwrapped here instead
+	if t.Kind() == reflect.Ptr {
+		if len(result) == 0 {
+			return nil, ErrTypeInvalid
+		}
+		if result[0] == 0 {
+			return json.RawMessage("null"), nil
+		}
+		t = t.Elem()
+		result = result[1:]
+	}
+
+	v := reflect.New(t)
+	if err := borsh.Deserialize(v.Interface(), result); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v.Elem().Interface())
+}
+
+func (f *Function) orderArgs(args json.RawMessage) ([]json.RawMessage, error) {
+	args = bytes.TrimSpace(args)
+	if len(args) == 0 || bytes.Equal(args, []byte("null")) {
+		if len(f.Args) != 0 {
+			return nil, ErrWrongNumberOfArgs
+		}
+		return nil, nil
+	}
+
+	switch args[0] {
+	case '[':
+		var positional []json.RawMessage
+		if err := json.Unmarshal(args, &positional); err != nil {
+			return nil, err
+		}
+		if len(positional) != len(f.Args) {
+			return nil, fmt.Errorf("%w: expected %d, got %d", ErrWrongNumberOfArgs, len(f.Args), len(positional))
+		}
+		return positional, nil
+	case '{':
+		var named map[string]json.RawMessage
+		if err := json.Unmarshal(args, &named); err != nil {
+			return nil, err
+		}
+		if len(named) != len(f.Args) {
+			return nil, fmt.Errorf("%w: expected %d, got %d", ErrWrongNumberOfArgs, len(f.Args), len(named))
+		}
+		ordered := make([]json.RawMessage, len(f.Args))
+		for i, arg := range f.Args {
+			v, ok := named[arg.Name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrArgumentMissing, arg.Name)
+			}
+			ordered[i] = v
+		}
+		return ordered, nil
+	default:
+		return nil, ErrArgumentsInvalid
+	}
+}
+
+// Address is the borsh representation of [codec.Address] used by contracts
+type Address [codec.AddressLen]byte
+
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(codec.Address(a).String())
+}
+
+func (a *Address) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	addr, err := codec.StringToAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = Address(addr)
+	return nil
+}
+
+var primitives = map[string]reflect.Type{
+	"bool":    reflect.TypeOf(false),
+	"u8":      reflect.TypeOf(uint8(0)),
+	"u16":     reflect.TypeOf(uint16(0)),
+	"u32":     reflect.TypeOf(uint32(0)),
+	"u64":     reflect.TypeOf(uint64(0)),
+	"i8":      reflect.TypeOf(int8(0)),
+	"i16":     reflect.TypeOf(int16(0)),
+	"i32":     reflect.TypeOf(int32(0)),
+	"i64":     reflect.TypeOf(int64(0)),
+	"String":  reflect.TypeOf(""),
+	"Address": reflect.TypeOf(Address{}),
+}
+
+// typeOf maps an ABI type to the go type borsh-go serializes the same way
+func typeOf(t string, topLevel bool) (reflect.Type, error) {
+	t = strings.TrimSpace(t)
+	if primitive, ok := primitives[t]; ok {
+		return primitive, nil
+	}
+	if inner, ok := genericParam(t, "Vec"); ok {
+		elem, err := typeOf(inner, false)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+	}
+	if inner, ok := genericParam(t, "Option"); ok {
+		if !topLevel {
+			return nil, ErrNestedOption
+		}
+		elem, err := typeOf(inner, false)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.PointerTo(elem), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrTypeInvalid, t)
+}
+
+func genericParam(t string, name string) (string, bool) {
+	if !strings.HasPrefix(t, name+"<") || !strings.HasSuffix(t, ">") {
+		return "", false
+	}
+	return t[len(name)+1 : len(t)-1], true
+}
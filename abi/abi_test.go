@@ -0,0 +1,123 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/near/borsh-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec/codectest"
+)
+
+const testABI = `{
+	"functions": [
+		{"name": "transfer", "args": [{"name": "to", "type": "Address"}, {"name": "amount", "type": "u64"}], "returns": "bool"},
+		{"name": "balance", "args": [{"name": "account", "type": "Address"}], "returns": "u64"},
+		{"name": "owners", "args": [], "returns": "Vec<Address>"},
+		{"name": "name", "args": [], "returns": "Option<String>"},
+		{"name": "inc", "args": [{"name": "memo", "type": "Option<String>"}]}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	a, err := Parse([]byte(testABI))
+	require.NoError(err)
+	require.Len(a.Functions, 5)
+
+	_, err = Parse([]byte(`{"functions": [{"name": "f", "args": [{"name": "a", "type": "u128"}]}]}`))
+	require.ErrorIs(err, ErrTypeInvalid)
+
+	_, err = Parse([]byte(`{"functions": [{"name": "f", "args": [{"name": "a", "type": "Vec<Option<u8>>"}]}]}`))
+	require.ErrorIs(err, ErrNestedOption)
+
+	_, err = Parse([]byte(`{"functions": [{"name": "f"}, {"name": "f"}]}`))
+	require.ErrorIs(err, ErrDuplicateFunction)
+
+	_, err = a.Function("missing")
+	require.ErrorIs(err, ErrFunctionNotFound)
+}
+
+func TestEncodeArgs(t *testing.T) {
+	require := require.New(t)
+	addr := codectest.NewRandomAddress()
+
+	a, err := Parse([]byte(testABI))
+	require.NoError(err)
+	transfer, err := a.Function("transfer")
+	require.NoError(err)
+
+	expected, err := borsh.Serialize(struct {
+		To     [33]byte
+		Amount uint64
+	}{addr, 42})
+	require.NoError(err)
+
+	// Positional arguments
+	callData, err := transfer.EncodeArgs(json.RawMessage(`["` + addr.String() + `", 42]`))
+	require.NoError(err)
+	require.Equal(expected, callData)
+
+	// Named arguments
+	callData, err = transfer.EncodeArgs(json.RawMessage(`{"amount": 42, "to": "` + addr.String() + `"}`))
+	require.NoError(err)
+	require.Equal(expected, callData)
+
+	_, err = transfer.EncodeArgs(json.RawMessage(`[42]`))
+	require.ErrorIs(err, ErrWrongNumberOfArgs)
+
+	_, err = transfer.EncodeArgs(json.RawMessage(`{"to": "` + addr.String() + `", "value": 42}`))
+	require.ErrorIs(err, ErrArgumentMissing)
+
+	// Options are encoded with a leading tag
+	inc, err := a.Function("inc")
+	require.NoError(err)
+	callData, err = inc.EncodeArgs(json.RawMessage(`[null]`))
+	require.NoError(err)
+	require.Equal([]byte{0}, callData)
+	callData, err = inc.EncodeArgs(json.RawMessage(`["hi"]`))
+	require.NoError(err)
+	require.Equal([]byte{1, 2, 0, 0, 0, 'h', 'i'}, callData)
+}
+
+func TestDecodeResult(t *testing.T) {
+	require := require.New(t)
+	addr := codectest.NewRandomAddress()
+
+	a, err := Parse([]byte(testABI))
+	require.NoError(err)
+
+	balance, err := a.Function("balance")
+	require.NoError(err)
+	value, err := balance.DecodeResult([]byte{42, 0, 0, 0, 0, 0, 0, 0})
+	require.NoError(err)
+	require.JSONEq(`42`, string(value))
+
+	owners, err := a.Function("owners")
+	require.NoError(err)
+	encoded, err := borsh.Serialize([][33]byte{addr})
+	require.NoError(err)
+	value, err = owners.DecodeResult(encoded)
+	require.NoError(err)
+	require.JSONEq(`["`+addr.String()+`"]`, string(value))
+
+	name, err := a.Function("name")
+	require.NoError(err)
+	value, err = name.DecodeResult([]byte{0})
+	require.NoError(err)
+	require.JSONEq(`null`, string(value))
+	value, err = name.DecodeResult([]byte{1, 2, 0, 0, 0, 'h', 'i'})
+	require.NoError(err)
+	require.JSONEq(`"hi"`, string(value))
+
+	inc, err := a.Function("inc")
+	require.NoError(err)
+	value, err = inc.DecodeResult(nil)
+	require.NoError(err)
+	require.JSONEq(`null`, string(value))
+}
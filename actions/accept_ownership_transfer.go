@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	AcceptOwnershipTransferComputeUnits = 5
+)
+
+var (
+	ErrNotProposedOwner                    = errors.New("ownership transfer was not proposed to the actor")
+	ErrOwnershipTransferStale              = errors.New("ownership transfer was proposed by a previous owner")
+	_                         chain.Action = (*AcceptOwnershipTransfer)(nil)
+)
+
+// AcceptOwnershipTransfer hands the proposed role to the actor. Proposals
+// made by a previous owner can't be accepted anymore.
+type AcceptOwnershipTransfer struct {
+	// Kind of what [Address] is. One of the nconsts.OwnershipKind values.
+	Kind uint8 `serialize:"true" json:"kind"`
+
+	// Address of the asset, dataset or marketplace asset of the listing
+	Address codec.Address `serialize:"true" json:"address"`
+
+	// Role proposed to the actor
+	Role uint8 `serialize:"true" json:"role"`
+}
+
+func (*AcceptOwnershipTransfer) GetTypeID() uint8 {
+	return nconsts.AcceptOwnershipTransferID
+}
+
+func (a *AcceptOwnershipTransfer) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(ownershipInfoKey(a.Kind, a.Address)):                     state.Read | state.Write,
+		string(storage.OwnershipTransferKey(a.Kind, a.Address, a.Role)): state.Read | state.Write,
+	}
+}
+
+func (a *AcceptOwnershipTransfer) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, newOwner, proposer, err := storage.GetOwnershipTransferNoController(ctx, mu, a.Kind, a.Address, a.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOwnershipTransferNotFound
+	}
+	if newOwner != actor {
+		return nil, ErrNotProposedOwner
+	}
+
+	owner, holder, err := getOwnershipHolder(ctx, mu, a.Kind, a.Address, a.Role)
+	if err != nil {
+		return nil, err
+	}
+	if owner != proposer {
+		return nil, ErrOwnershipTransferStale
+	}
+	if err := setOwnershipHolder(ctx, mu, a.Kind, a.Address, a.Role, actor); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteOwnershipTransfer(ctx, mu, a.Kind, a.Address, a.Role); err != nil {
+		return nil, err
+	}
+
+	return &AcceptOwnershipTransferResult{
+		Actor:          actor.String(),
+		Receiver:       actor.String(),
+		PreviousHolder: holder.String(),
+	}, nil
+}
+
+func (*AcceptOwnershipTransfer) ComputeUnits(chain.Rules) uint64 {
+	return AcceptOwnershipTransferComputeUnits
+}
+
+func (*AcceptOwnershipTransfer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalAcceptOwnershipTransfer(p *codec.Packer) (chain.Action, error) {
+	var accept AcceptOwnershipTransfer
+	accept.Kind = p.UnpackByte()
+	p.UnpackAddress(&accept.Address)
+	accept.Role = p.UnpackByte()
+	return &accept, p.Err()
+}
+
+var _ codec.Typed = (*AcceptOwnershipTransferResult)(nil)
+
+type AcceptOwnershipTransferResult struct {
+	Actor          string `serialize:"true" json:"actor"`
+	Receiver       string `serialize:"true" json:"receiver"`
+	PreviousHolder string `serialize:"true" json:"previous_holder"`
+}
+
+func (*AcceptOwnershipTransferResult) GetTypeID() uint8 {
+	return nconsts.AcceptOwnershipTransferID
+}
+
+func UnmarshalAcceptOwnershipTransferResult(p *codec.Packer) (codec.Typed, error) {
+	var result AcceptOwnershipTransferResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.PreviousHolder = p.UnpackString(false)
+	return &result, p.Err()
+}
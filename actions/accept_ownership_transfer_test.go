@@ -0,0 +1,147 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestAcceptOwnershipTransferAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	newOwner := codectest.NewRandomAddress()
+	address := codectest.NewRandomAddress()
+	listingAddress := codectest.NewRandomAddress()
+
+	// newStore sets up [role] of the [kind] at [target] proposed to
+	// [newOwner] by the owner
+	newStore := func(kind uint8, target codec.Address, role uint8) state.Mutable {
+		store := newOwnershipStore(t, owner, address, listingAddress)
+		require.NoError(t, storage.SetOwnershipTransfer(context.Background(), store, kind, target, role, newOwner, owner))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NotProposed",
+			Actor: newOwner,
+			Action: &AcceptOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindAsset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleOwner,
+			},
+			State:       newOwnershipStore(t, owner, address, listingAddress),
+			ExpectedErr: ErrOwnershipTransferNotFound,
+		},
+		{
+			Name:  "ProposedToAnotherAccount",
+			Actor: owner,
+			Action: &AcceptOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindAsset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleOwner,
+			},
+			State:       newStore(nconsts.OwnershipKindAsset, address, nconsts.OwnershipRoleOwner),
+			ExpectedErr: ErrNotProposedOwner,
+		},
+		{
+			Name:  "ProposedByPreviousOwner",
+			Actor: newOwner,
+			Action: &AcceptOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindAsset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleMintAdmin,
+			},
+			State: func() state.Mutable {
+				store := newOwnershipStore(t, owner, address, listingAddress)
+				require.NoError(t, storage.SetOwnershipTransfer(context.Background(), store, nconsts.OwnershipKindAsset, address, nconsts.OwnershipRoleMintAdmin, newOwner, codectest.NewRandomAddress()))
+				return store
+			}(),
+			ExpectedErr: ErrOwnershipTransferStale,
+		},
+		{
+			Name:  "ValidAssetAdmin",
+			Actor: newOwner,
+			Action: &AcceptOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindAsset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleMintAdmin,
+			},
+			State: newStore(nconsts.OwnershipKindAsset, address, nconsts.OwnershipRoleMintAdmin),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, _, _, _, _, _, assetOwner, mintAdmin, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, address)
+				require.NoError(t, err)
+				require.Equal(t, owner, assetOwner)
+				require.Equal(t, newOwner, mintAdmin)
+				exists, _, _, err := storage.GetOwnershipTransferNoController(ctx, store, nconsts.OwnershipKindAsset, address, nconsts.OwnershipRoleMintAdmin)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+			ExpectedOutputs: &AcceptOwnershipTransferResult{
+				Actor:          newOwner.String(),
+				Receiver:       newOwner.String(),
+				PreviousHolder: owner.String(),
+			},
+		},
+		{
+			Name:  "ValidDataset",
+			Actor: newOwner,
+			Action: &AcceptOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindDataset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleOwner,
+			},
+			State: newStore(nconsts.OwnershipKindDataset, address, nconsts.OwnershipRoleOwner),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, _, _, _, _, _, marketplaceAssetAddress, _, _, _, _, _, _, datasetOwner, err := storage.GetDatasetInfoNoController(ctx, store, address)
+				require.NoError(t, err)
+				require.Equal(t, newOwner, datasetOwner)
+				require.Equal(t, listingAddress, marketplaceAssetAddress)
+				// The asset of the dataset keeps its owner
+				_, _, _, _, _, _, _, _, assetOwner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, address)
+				require.NoError(t, err)
+				require.Equal(t, owner, assetOwner)
+			},
+			ExpectedOutputs: &AcceptOwnershipTransferResult{
+				Actor:          newOwner.String(),
+				Receiver:       newOwner.String(),
+				PreviousHolder: owner.String(),
+			},
+		},
+		{
+			Name:  "ValidListing",
+			Actor: newOwner,
+			Action: &AcceptOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindListing,
+				Address: listingAddress,
+				Role:    nconsts.OwnershipRoleOwner,
+			},
+			State: newStore(nconsts.OwnershipKindListing, listingAddress, nconsts.OwnershipRoleOwner),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, _, _, _, _, _, listingOwner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, listingAddress)
+				require.NoError(t, err)
+				require.Equal(t, newOwner, listingOwner)
+			},
+			ExpectedOutputs: &AcceptOwnershipTransferResult{
+				Actor:          newOwner.String(),
+				Receiver:       newOwner.String(),
+				PreviousHolder: owner.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ApproveAssetComputeUnits = 1
+)
+
+var (
+	ErrSpenderInvalid                      = errors.New("spender is invalid")
+	ErrAllowanceExpiryInvalid              = errors.New("allowance expiry block is invalid")
+	_                         chain.Action = (*ApproveAsset)(nil)
+)
+
+type ApproveAsset struct {
+	// AssetAddress the spender is allowed to move
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Spender is allowed to move [Amount] out of the account of the actor with
+	// [TransferAssetFrom]
+	Spender codec.Address `serialize:"true" json:"spender"`
+
+	// Amount replaces any previous allowance of [Spender]. An amount of 0
+	// revokes the allowance.
+	Amount uint64 `serialize:"true" json:"amount"`
+
+	// Last block at which the allowance can be used. 0 never expires.
+	ExpiryBlock uint64 `serialize:"true" json:"expiry_block"`
+}
+
+func (*ApproveAsset) GetTypeID() uint8 {
+	return nconsts.ApproveAssetID
+}
+
+func (a *ApproveAsset) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(a.AssetAddress)):                        state.Read,
+		string(storage.AssetAllowanceKey(a.AssetAddress, actor, a.Spender)): state.All,
+	}
+}
+
+func (a *ApproveAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if a.Spender == codec.EmptyAddress || a.Spender == actor {
+		return nil, ErrSpenderInvalid
+	}
+	// Check that asset exists
+	if _, _, _, _, _, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, a.AssetAddress); err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+
+	if a.Amount == 0 {
+		if err := storage.DeleteAssetAllowance(ctx, mu, a.AssetAddress, actor, a.Spender); err != nil {
+			return nil, err
+		}
+	} else {
+		if a.ExpiryBlock != 0 && a.ExpiryBlock <= emission.GetEmission().GetLastAcceptedBlockHeight() {
+			return nil, ErrAllowanceExpiryInvalid
+		}
+		if err := storage.SetAssetAllowance(ctx, mu, a.AssetAddress, actor, a.Spender, a.Amount, a.ExpiryBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ApproveAssetResult{
+		Actor:       actor.String(),
+		Receiver:    a.Spender.String(),
+		Amount:      a.Amount,
+		ExpiryBlock: a.ExpiryBlock,
+	}, nil
+}
+
+func (*ApproveAsset) ComputeUnits(chain.Rules) uint64 {
+	return ApproveAssetComputeUnits
+}
+
+func (*ApproveAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalApproveAsset(p *codec.Packer) (chain.Action, error) {
+	var approve ApproveAsset
+	p.UnpackAddress(&approve.AssetAddress)
+	p.UnpackAddress(&approve.Spender)
+	approve.Amount = p.UnpackUint64(false)
+	approve.ExpiryBlock = p.UnpackUint64(false)
+	return &approve, p.Err()
+}
+
+var _ codec.Typed = (*ApproveAssetResult)(nil)
+
+type ApproveAssetResult struct {
+	Actor       string `serialize:"true" json:"actor"`
+	Receiver    string `serialize:"true" json:"receiver"`
+	Amount      uint64 `serialize:"true" json:"amount"`
+	ExpiryBlock uint64 `serialize:"true" json:"expiry_block"`
+}
+
+func (*ApproveAssetResult) GetTypeID() uint8 {
+	return nconsts.ApproveAssetID
+}
+
+func UnmarshalApproveAssetResult(p *codec.Packer) (codec.Typed, error) {
+	var result ApproveAssetResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Amount = p.UnpackUint64(false)
+	result.ExpiryBlock = p.UnpackUint64(false)
+	return &result, p.Err()
+}
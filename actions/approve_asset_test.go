@@ -0,0 +1,149 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestApproveAssetAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	actor := codectest.NewRandomAddress()
+	spender := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+
+	newStore := func() state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 0, 0, actor, actor, actor, actor, actor))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "SpenderIsActor",
+			Actor: actor,
+			Action: &ApproveAsset{
+				AssetAddress: assetAddress,
+				Spender:      actor,
+				Amount:       100,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrSpenderInvalid,
+		},
+		{
+			Name:  "AssetDoesNotExist",
+			Actor: actor,
+			Action: &ApproveAsset{
+				AssetAddress: assetAddress,
+				Spender:      spender,
+				Amount:       100,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrAssetDoesNotExist,
+		},
+		{
+			Name:  "ExpiryInPast",
+			Actor: actor,
+			Action: &ApproveAsset{
+				AssetAddress: assetAddress,
+				Spender:      spender,
+				Amount:       100,
+				ExpiryBlock:  100,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrAllowanceExpiryInvalid,
+		},
+		{
+			Name:  "ValidApprove",
+			Actor: actor,
+			Action: &ApproveAsset{
+				AssetAddress: assetAddress,
+				Spender:      spender,
+				Amount:       100,
+				ExpiryBlock:  200,
+			},
+			State: newStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				amount, expiryBlock, err := storage.GetAssetAllowanceNoController(ctx, store, assetAddress, actor, spender)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), amount)
+				require.Equal(t, uint64(200), expiryBlock)
+			},
+			ExpectedOutputs: &ApproveAssetResult{
+				Actor:       actor.String(),
+				Receiver:    spender.String(),
+				Amount:      100,
+				ExpiryBlock: 200,
+			},
+		},
+		{
+			Name:  "RevokeApproval",
+			Actor: actor,
+			Action: &ApproveAsset{
+				AssetAddress: assetAddress,
+				Spender:      spender,
+			},
+			State: func() state.Mutable {
+				store := newStore()
+				require.NoError(t, storage.SetAssetAllowance(context.Background(), store, assetAddress, actor, spender, 100, 0))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				amount, _, err := storage.GetAssetAllowanceNoController(ctx, store, assetAddress, actor, spender)
+				require.NoError(t, err)
+				require.Zero(t, amount)
+			},
+			ExpectedOutputs: &ApproveAssetResult{
+				Actor:    actor.String(),
+				Receiver: spender.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func BenchmarkApproveAsset(b *testing.B) {
+	require := require.New(b)
+	actor := codectest.NewRandomAddress()
+	spender := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+
+	approveAssetBenchmark := &chaintest.ActionBenchmark{
+		Name:  "ApproveAssetBenchmark",
+		Actor: actor,
+		Action: &ApproveAsset{
+			AssetAddress: assetAddress,
+			Spender:      spender,
+			Amount:       100,
+		},
+		CreateState: func() state.Mutable {
+			store := chaintest.NewInMemoryStore()
+			require.NoError(storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 0, 0, actor, actor, actor, actor, actor))
+			return store
+		},
+		Assertion: func(ctx context.Context, b *testing.B, store state.Mutable) {
+			amount, _, err := storage.GetAssetAllowanceNoController(ctx, store, assetAddress, actor, spender)
+			require.NoError(err)
+			require.Equal(uint64(100), amount)
+		},
+	}
+
+	ctx := context.Background()
+	approveAssetBenchmark.Run(ctx, b)
+}
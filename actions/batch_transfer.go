@@ -0,0 +1,186 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	BatchTransferComputeUnits = 1 // Per recipient
+
+	MaxBatchTransferRecipients = 32
+)
+
+var (
+	ErrBatchTransferRecipientsInvalid              = errors.New("batch transfer recipients are invalid")
+	_                                 chain.Action = (*BatchTransfer)(nil)
+)
+
+// BatchTransferEntry is a single payment of a [BatchTransfer]
+type BatchTransferEntry struct {
+	// To is the recipient of the [Value].
+	To codec.Address `serialize:"true" json:"to"`
+
+	// Amount transferred to [To].
+	Value uint64 `serialize:"true" json:"value"`
+
+	// Optional message to accompany the payment.
+	Memo string `serialize:"true" json:"memo"`
+}
+
+type BatchTransfer struct {
+	// AssetAddress to transfer.
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Transfers to apply. Either all of them succeed or none does. Every
+	// recipient can only appear once.
+	Transfers []BatchTransferEntry `serialize:"true" json:"transfers"`
+}
+
+func (*BatchTransfer) GetTypeID() uint8 {
+	return nconsts.BatchTransferID
+}
+
+func (b *BatchTransfer) StateKeys(actor codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.AssetInfoKey(b.AssetAddress)):                  state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(b.AssetAddress, actor)): state.Read | state.Write,
+	}
+	for _, transfer := range b.Transfers {
+		stateKeys.Add(string(storage.AssetAccountBalanceKey(b.AssetAddress, transfer.To)), state.All)
+	}
+	return stateKeys
+}
+
+func (b *BatchTransfer) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if len(b.Transfers) == 0 || len(b.Transfers) > MaxBatchTransferRecipients {
+		return nil, ErrBatchTransferRecipientsInvalid
+	}
+	// Check that asset exists
+	assetType, _, _, _, _, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, b.AssetAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	// The state keys of the collection of an NFT are not known here
+	if assetType == nconsts.AssetNonFungibleTokenID {
+		return nil, ErrNFTTransferInvalid
+	}
+
+	// Check the invariants
+	seenRecipients := make(map[codec.Address]struct{}, len(b.Transfers))
+	var total uint64
+	for _, transfer := range b.Transfers {
+		if actor == transfer.To {
+			return nil, ErrTransferToSelf
+		}
+		if _, ok := seenRecipients[transfer.To]; ok {
+			return nil, ErrBatchTransferRecipientsInvalid
+		}
+		seenRecipients[transfer.To] = struct{}{}
+		if transfer.Value == 0 {
+			return nil, ErrValueZero
+		}
+		if len(transfer.Memo) > storage.MaxTextSize {
+			return nil, ErrMemoTooLarge
+		}
+		if total, err = smath.Add(total, transfer.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check that balance is sufficient for all the transfers
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, b.AssetAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < total {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	var senderBalance uint64
+	receiverBalances := make([]uint64, len(b.Transfers))
+	for i, transfer := range b.Transfers {
+		senderBalance, receiverBalances[i], err = storage.TransferAsset(ctx, mu, b.AssetAddress, actor, transfer.To, transfer.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BatchTransferResult{
+		Actor:            actor.String(),
+		SenderBalance:    senderBalance,
+		ReceiverBalances: receiverBalances,
+	}, nil
+}
+
+func (b *BatchTransfer) ComputeUnits(chain.Rules) uint64 {
+	return BatchTransferComputeUnits * uint64(len(b.Transfers))
+}
+
+func (*BatchTransfer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalBatchTransfer(p *codec.Packer) (chain.Action, error) {
+	var batch BatchTransfer
+	p.UnpackAddress(&batch.AssetAddress)
+	numTransfers := int(p.UnpackInt(true))
+	if numTransfers > MaxBatchTransferRecipients {
+		return nil, ErrBatchTransferRecipientsInvalid
+	}
+	batch.Transfers = make([]BatchTransferEntry, numTransfers)
+	for i := range batch.Transfers {
+		p.UnpackAddress(&batch.Transfers[i].To)
+		batch.Transfers[i].Value = p.UnpackUint64(true)
+		batch.Transfers[i].Memo = p.UnpackString(false)
+	}
+	return &batch, p.Err()
+}
+
+var _ codec.Typed = (*BatchTransferResult)(nil)
+
+type BatchTransferResult struct {
+	Actor            string   `serialize:"true" json:"actor"`
+	SenderBalance    uint64   `serialize:"true" json:"sender_balance"`
+	ReceiverBalances []uint64 `serialize:"true" json:"receiver_balances"`
+}
+
+func (*BatchTransferResult) GetTypeID() uint8 {
+	return nconsts.BatchTransferID
+}
+
+func UnmarshalBatchTransferResult(p *codec.Packer) (codec.Typed, error) {
+	var result BatchTransferResult
+	result.Actor = p.UnpackString(true)
+	result.SenderBalance = p.UnpackUint64(false)
+	numReceivers := int(p.UnpackInt(false))
+	if numReceivers > MaxBatchTransferRecipients {
+		return nil, ErrBatchTransferRecipientsInvalid
+	}
+	result.ReceiverBalances = make([]uint64, numReceivers)
+	for i := range result.ReceiverBalances {
+		result.ReceiverBalances[i] = p.UnpackUint64(false)
+	}
+	return &result, p.Err()
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestBatchTransferAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	recipient1 := codectest.NewRandomAddress()
+	recipient2 := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+
+	// newStore sets up the asset with a balance of 1000 for the actor
+	newStore := func() state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 1000, 0, actor, actor, actor, actor, actor))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, actor, 1000))
+		return store
+	}
+
+	tooManyTransfers := make([]BatchTransferEntry, MaxBatchTransferRecipients+1)
+	for i := range tooManyTransfers {
+		tooManyTransfers[i] = BatchTransferEntry{To: codectest.NewRandomAddress(), Value: 1}
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NoTransfers",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrBatchTransferRecipientsInvalid,
+		},
+		{
+			Name:  "TooManyTransfers",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers:    tooManyTransfers,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrBatchTransferRecipientsInvalid,
+		},
+		{
+			Name:  "DuplicateRecipient",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers: []BatchTransferEntry{
+					{To: recipient1, Value: 100},
+					{To: recipient1, Value: 100},
+				},
+			},
+			State:       newStore(),
+			ExpectedErr: ErrBatchTransferRecipientsInvalid,
+		},
+		{
+			Name:  "TransferToSelf",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers: []BatchTransferEntry{
+					{To: recipient1, Value: 100},
+					{To: actor, Value: 100},
+				},
+			},
+			State:       newStore(),
+			ExpectedErr: ErrTransferToSelf,
+		},
+		{
+			Name:  "ValueZero",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers: []BatchTransferEntry{
+					{To: recipient1, Value: 0},
+				},
+			},
+			State:       newStore(),
+			ExpectedErr: ErrValueZero,
+		},
+		{
+			Name:  "InsufficientBalanceForTotal",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers: []BatchTransferEntry{
+					{To: recipient1, Value: 600},
+					{To: recipient2, Value: 600},
+				},
+			},
+			State:       newStore(),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ValidBatchTransfer",
+			Actor: actor,
+			Action: &BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers: []BatchTransferEntry{
+					{To: recipient1, Value: 100, Memo: "payroll"},
+					{To: recipient2, Value: 250},
+				},
+			},
+			State: newStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(650), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, recipient1)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, recipient2)
+				require.NoError(t, err)
+				require.Equal(t, uint64(250), balance)
+			},
+			ExpectedOutputs: &BatchTransferResult{
+				Actor:            actor.String(),
+				SenderBalance:    650,
+				ReceiverBalances: []uint64{100, 250},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestBatchTransferMarshal(t *testing.T) {
+	require := require.New(t)
+
+	batch := &BatchTransfer{
+		AssetAddress: codectest.NewRandomAddress(),
+		Transfers: []BatchTransferEntry{
+			{To: codectest.NewRandomAddress(), Value: 100, Memo: "payroll"},
+			{To: codectest.NewRandomAddress(), Value: 250},
+		},
+	}
+	b, err := chain.Marshal(batch)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalBatchTransfer(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(batch, unmarshalled)
+
+	result := &BatchTransferResult{
+		Actor:            codectest.NewRandomAddress().String(),
+		SenderBalance:    650,
+		ReceiverBalances: []uint64{100, 250},
+	}
+	b, err = chain.Marshal(result)
+	require.NoError(err)
+	unmarshalledResult, err := UnmarshalBatchTransferResult(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(result, unmarshalledResult)
+}
@@ -44,6 +44,7 @@ func (b *BurnAssetNFT) StateKeys(actor codec.Address) state.Keys {
 		string(storage.AssetAccountBalanceKey(b.AssetAddress, actor)):    state.Read | state.Write,
 		string(storage.AssetInfoKey(b.AssetNftAddress)):                  state.Read | state.Write,
 		string(storage.AssetAccountBalanceKey(b.AssetNftAddress, actor)): state.Read | state.Write,
+		string(storage.NFTOwnershipKey(b.AssetNftAddress)):               state.Write,
 	}
 }
 
@@ -75,7 +76,11 @@ func (b *BurnAssetNFT) Execute(
 		return nil, ErrNFTDoesNotBelongToTheCollection
 	}
 
-	// Burning logic for non-fungible tokens
+	// Burning logic for non-fungible tokens. Only the holder of the NFT can
+	// burn it.
+	if _, err := storage.BurnAsset(ctx, mu, b.AssetNftAddress, actor, 1); err != nil {
+		return nil, err
+	}
 	newBalance, err := storage.BurnAsset(ctx, mu, b.AssetAddress, actor, 1)
 	if err != nil {
 		return nil, err
@@ -83,6 +88,9 @@ func (b *BurnAssetNFT) Execute(
 	if err := storage.DeleteAsset(ctx, mu, b.AssetNftAddress); err != nil {
 		return nil, err
 	}
+	if err := storage.DeleteNFTOwnership(ctx, mu, b.AssetNftAddress); err != nil {
+		return nil, err
+	}
 
 	return &BurnAssetNFTResult{
 		Actor:      actor.String(),
@@ -56,6 +56,23 @@ func TestBurnAssetNFTAction(t *testing.T) {
 			}(),
 			ExpectedErr: ErrNFTDoesNotBelongToTheCollection,
 		},
+		{
+			Name:  "NotNFTOwner",
+			Actor: actor,
+			Action: &BurnAssetNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), []byte(assetAddress.String()), 1, 1, actor, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), []byte(assetAddress.String()), 1, 1, actor, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				// The actor still holds an NFT of the collection but not this one
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, actor, 1))
+				return store
+			}(),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
 		{
 			Name:  "ValidNFTBurn",
 			Actor: actor,
@@ -73,7 +90,7 @@ func TestBurnAssetNFTAction(t *testing.T) {
 
 				// Set balances
 				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, actor, 1))
-				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, actor, 0))
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, actor, 1))
 
 				return store
 			}(),
@@ -87,6 +104,9 @@ func TestBurnAssetNFTAction(t *testing.T) {
 				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor)
 				require.NoError(t, err)
 				require.Equal(t, uint64(0), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(0), balance)
 
 				// Check if the total supply was reduced
 				_, _, _, _, _, _, totalSupply, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, assetAddress)
@@ -130,7 +150,7 @@ func BenchmarkBurnAssetNFT(b *testing.B) {
 
 			// Set balances
 			require.NoError(storage.SetAssetAccountBalance(context.Background(), store, assetAddress, actor, 1))
-			require.NoError(storage.SetAssetAccountBalance(context.Background(), store, nftAddress, actor, 0))
+			require.NoError(storage.SetAssetAccountBalance(context.Background(), store, nftAddress, actor, 1))
 
 			return store
 		},
@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	CancelContributeDatasetComputeUnits = 5
+)
+
+var (
+	ErrDatasetContributionNotExpired              = errors.New("dataset contribution has not timed out yet")
+	_                                chain.Action = (*CancelContributeDataset)(nil)
+)
+
+// CancelContributeDataset lets a contributor take back the collateral of a
+// contribution the dataset owner neither completed nor rejected within
+// ContributionTimeoutBlocks
+type CancelContributeDataset struct {
+	// Contribution ID
+	DatasetContributionID string `serialize:"true" json:"dataset_contribution_id"`
+
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+}
+
+func (*CancelContributeDataset) GetTypeID() uint8 {
+	return nconsts.CancelContributeDatasetID
+}
+
+func (d *CancelContributeDataset) StateKeys(actor codec.Address) state.Keys {
+	datasetContributionID, _ := ids.FromString(d.DatasetContributionID)
+	return state.Keys{
+		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                                   state.Read | state.Write,
+		string(storage.DataCommitmentKey(datasetContributionID)):                                                            state.Write,
+		string(storage.AssetInfoKey(codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID))):            state.Read,
+		string(storage.AssetAccountBalanceKey(dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution, actor)): state.All,
+	}
+}
+
+func (d *CancelContributeDataset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	datasetContributionID, err := ids.FromString(d.DatasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the dataset contribution is pending
+	datasetAddress, _, _, contributor, active, initiatedAt, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	// A contribution deactivated after failing a data availability challenge
+	// keeps the NFT minted when it was completed
+	if active || storage.AssetExists(ctx, mu, codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID)) {
+		return nil, ErrDatasetContributionAlreadyComplete
+	}
+	if datasetAddress != d.DatasetAddress {
+		return nil, ErrDatasetAddressMismatch
+	}
+	if contributor != actor {
+		return nil, ErrDatasetContributorMismatch
+	}
+
+	// Give the dataset owner time to review the contribution
+	dataConfig := dataset.GetDatasetConfig()
+	timeout, err := smath.Add(initiatedAt, dataConfig.ContributionTimeoutBlocks)
+	if err != nil {
+		return nil, err
+	}
+	if emission.GetEmission().GetLastAcceptedBlockHeight() < timeout {
+		return nil, ErrDatasetContributionNotExpired
+	}
+
+	if err := storage.DeleteDatasetContributionInfo(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteDataCommitment(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+
+	// Refund the collateral back to the contributor
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := smath.Add(balance, dataConfig.CollateralAmountForDataContribution)
+	if err != nil {
+		return nil, err
+	}
+	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor, newBalance); err != nil {
+		return nil, err
+	}
+
+	return &CancelContributeDatasetResult{
+		Actor:                    actor.String(),
+		Receiver:                 actor.String(),
+		CollateralAssetAddress:   dataConfig.CollateralAssetAddressForDataContribution.String(),
+		CollateralAmountRefunded: dataConfig.CollateralAmountForDataContribution,
+	}, nil
+}
+
+func (*CancelContributeDataset) ComputeUnits(chain.Rules) uint64 {
+	return CancelContributeDatasetComputeUnits
+}
+
+func (*CancelContributeDataset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalCancelContributeDataset(p *codec.Packer) (chain.Action, error) {
+	var cancel CancelContributeDataset
+	cancel.DatasetContributionID = p.UnpackString(true)
+	p.UnpackAddress(&cancel.DatasetAddress)
+	return &cancel, p.Err()
+}
+
+var _ codec.Typed = (*CancelContributeDatasetResult)(nil)
+
+type CancelContributeDatasetResult struct {
+	Actor                    string `serialize:"true" json:"actor"`
+	Receiver                 string `serialize:"true" json:"receiver"`
+	CollateralAssetAddress   string `serialize:"true" json:"collateral_asset_address"`
+	CollateralAmountRefunded uint64 `serialize:"true" json:"collateral_amount_refunded"`
+}
+
+func (*CancelContributeDatasetResult) GetTypeID() uint8 {
+	return nconsts.CancelContributeDatasetID
+}
+
+func UnmarshalCancelContributeDatasetResult(p *codec.Packer) (codec.Typed, error) {
+	var result CancelContributeDatasetResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.CollateralAssetAddress = p.UnpackString(true)
+	result.CollateralAmountRefunded = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestCancelContributeDatasetAction(t *testing.T) {
+	const (
+		dataLocation   = "default"
+		dataIdentifier = "data_id_1234"
+	)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor)
+	config := dataset.GetDatasetConfig()
+
+	newStore := func(active bool, initiatedAt uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor, active, initiatedAt))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NotContributor",
+			Actor: owner,
+			Action: &CancelContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(false, 0),
+			ExpectedErr: ErrDatasetContributorMismatch,
+		},
+		{
+			Name:  "ContributionAlreadyCompleted",
+			Actor: contributor,
+			Action: &CancelContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(true, 0),
+			ExpectedErr: ErrDatasetContributionAlreadyComplete,
+		},
+		{
+			Name:  "ContributionNotExpired",
+			Actor: contributor,
+			Action: &CancelContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(false, 100-config.ContributionTimeoutBlocks+1),
+			ExpectedErr: ErrDatasetContributionNotExpired,
+		},
+		{
+			Name:  "ValidCancel",
+			Actor: contributor,
+			Action: &CancelContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State: newStore(false, 100-config.ContributionTimeoutBlocks),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				require.False(t, storage.DatasetContributionExists(ctx, store, datasetContributionID))
+
+				// Collateral is refunded to the contributor
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, contributor)
+				require.NoError(t, err)
+				require.Equal(t, config.CollateralAmountForDataContribution, balance)
+			},
+			ExpectedOutputs: &CancelContributeDatasetResult{
+				Actor:                    contributor.String(),
+				Receiver:                 contributor.String(),
+				CollateralAssetAddress:   config.CollateralAssetAddressForDataContribution.String(),
+				CollateralAmountRefunded: config.CollateralAmountForDataContribution,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
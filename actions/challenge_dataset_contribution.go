@@ -0,0 +1,188 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ChallengeDatasetContributionComputeUnits = 5
+)
+
+var (
+	ErrDatasetContributionNotActive                = errors.New("dataset contribution is not active")
+	ErrDataCommitmentNotFound                      = errors.New("dataset contribution did not commit to a data root")
+	ErrDataChallengeAlreadyOpen                    = errors.New("dataset contribution already has an open challenge")
+	ErrCannotChallengeOwnContribution              = errors.New("cannot challenge own contribution")
+	ErrDataChallengeWindowClosed                   = errors.New("dataset contribution can no longer be challenged")
+	_                                 chain.Action = (*ChallengeDatasetContribution)(nil)
+)
+
+type ChallengeDatasetContribution struct {
+	// Contribution ID
+	DatasetContributionID string `serialize:"true" json:"dataset_contribution_id"`
+
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+}
+
+func (*ChallengeDatasetContribution) GetTypeID() uint8 {
+	return nconsts.ChallengeDatasetContributionID
+}
+
+func (d *ChallengeDatasetContribution) StateKeys(actor codec.Address) state.Keys {
+	datasetContributionID, _ := ids.FromString(d.DatasetContributionID)
+	return state.Keys{
+		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                                   state.Read,
+		string(storage.DataCommitmentKey(datasetContributionID)):                                                            state.Read,
+		string(storage.DataCollateralKey(datasetContributionID)):                                                            state.Read,
+		string(storage.DataChallengeKey(datasetContributionID)):                                                             state.All,
+		string(storage.AssetAccountBalanceKey(dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution, actor)): state.Read | state.Write,
+	}
+}
+
+func (d *ChallengeDatasetContribution) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	actionID ids.ID,
+) (codec.Typed, error) {
+	datasetContributionID, err := ids.FromString(d.DatasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only completed contributions can be challenged
+	datasetAddress, _, _, contributor, active, _, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, ErrDatasetContributionNotActive
+	}
+	if datasetAddress != d.DatasetAddress {
+		return nil, ErrDatasetAddressMismatch
+	}
+	if contributor == actor {
+		return nil, ErrCannotChallengeOwnContribution
+	}
+
+	exists, _, chunks, err := storage.GetDataCommitmentNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrDataCommitmentNotFound
+	}
+	exists, _, _, _, _, err = storage.GetDataChallengeNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDataChallengeAlreadyOpen
+	}
+	// Contributions can only be challenged while their collateral is locked
+	exists, _, releaseHeight, err := storage.GetDataCollateralNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || emission.GetEmission().GetLastAcceptedBlockHeight() > releaseHeight {
+		return nil, ErrDataChallengeWindowClosed
+	}
+
+	// Lock the bond of the challenger
+	dataConfig := dataset.GetDatasetConfig()
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < dataConfig.ChallengeBondAmount {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+	newBalance, err := smath.Sub(balance, dataConfig.ChallengeBondAmount)
+	if err != nil {
+		return nil, err
+	}
+	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor, newBalance); err != nil {
+		return nil, err
+	}
+
+	// The chunk to prove is derived from the action ID so that the contributor
+	// cannot know it in advance
+	chunk := dataset.ChallengedChunk(actionID, chunks)
+	deadline, err := smath.Add(emission.GetEmission().GetLastAcceptedBlockHeight(), dataConfig.ChallengeResponseBlocks)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetDataChallenge(ctx, mu, datasetContributionID, actor, dataConfig.ChallengeBondAmount, chunk, deadline); err != nil {
+		return nil, err
+	}
+
+	return &ChallengeDatasetContributionResult{
+		Actor:            actor.String(),
+		Receiver:         contributor.String(),
+		BondAssetAddress: dataConfig.CollateralAssetAddressForDataContribution.String(),
+		BondAmount:       dataConfig.ChallengeBondAmount,
+		ChallengedChunk:  chunk,
+		Deadline:         deadline,
+	}, nil
+}
+
+func (*ChallengeDatasetContribution) ComputeUnits(chain.Rules) uint64 {
+	return ChallengeDatasetContributionComputeUnits
+}
+
+func (*ChallengeDatasetContribution) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalChallengeDatasetContribution(p *codec.Packer) (chain.Action, error) {
+	var challenge ChallengeDatasetContribution
+	challenge.DatasetContributionID = p.UnpackString(true)
+	p.UnpackAddress(&challenge.DatasetAddress)
+	return &challenge, p.Err()
+}
+
+var _ codec.Typed = (*ChallengeDatasetContributionResult)(nil)
+
+type ChallengeDatasetContributionResult struct {
+	Actor            string `serialize:"true" json:"actor"`
+	Receiver         string `serialize:"true" json:"receiver"`
+	BondAssetAddress string `serialize:"true" json:"bond_asset_address"`
+	BondAmount       uint64 `serialize:"true" json:"bond_amount"`
+	ChallengedChunk  uint64 `serialize:"true" json:"challenged_chunk"`
+	Deadline         uint64 `serialize:"true" json:"deadline"`
+}
+
+func (*ChallengeDatasetContributionResult) GetTypeID() uint8 {
+	return nconsts.ChallengeDatasetContributionID
+}
+
+func UnmarshalChallengeDatasetContributionResult(p *codec.Packer) (codec.Typed, error) {
+	var result ChallengeDatasetContributionResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.BondAssetAddress = p.UnpackString(true)
+	result.BondAmount = p.UnpackUint64(false)
+	result.ChallengedChunk = p.UnpackUint64(false)
+	result.Deadline = p.UnpackUint64(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestChallengeDatasetContributionAction(t *testing.T) {
+	const (
+		dataLocation   = "default"
+		dataIdentifier = "data_id_1234"
+	)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	challenger := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor)
+	config := dataset.GetDatasetConfig()
+	data := make([]byte, 4800)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	chunks := dataset.SplitChunks(data)
+	actionID := ids.GenerateTestID()
+
+	newStore := func(active bool, committed bool) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor, active, 0))
+		if committed {
+			require.NoError(t, storage.SetDataCommitment(context.Background(), store, datasetContributionID, dataset.MerkleRoot(chunks), uint64(len(chunks))))
+			require.NoError(t, storage.SetDataCollateral(context.Background(), store, datasetContributionID, config.CollateralAmountForDataContribution, 100))
+		}
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, config.CollateralAssetAddressForDataContribution, challenger, config.ChallengeBondAmount))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "ContributionNotActive",
+			Actor: challenger,
+			Action: &ChallengeDatasetContribution{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(false, true),
+			ExpectedErr: ErrDatasetContributionNotActive,
+		},
+		{
+			Name:  "NoDataCommitment",
+			Actor: challenger,
+			Action: &ChallengeDatasetContribution{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(true, false),
+			ExpectedErr: ErrDataCommitmentNotFound,
+		},
+		{
+			Name:  "OwnContribution",
+			Actor: contributor,
+			Action: &ChallengeDatasetContribution{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(true, true),
+			ExpectedErr: ErrCannotChallengeOwnContribution,
+		},
+		{
+			Name:  "ChallengeAlreadyOpen",
+			Actor: challenger,
+			Action: &ChallengeDatasetContribution{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State: func() state.Mutable {
+				store := newStore(true, true)
+				require.NoError(t, storage.SetDataChallenge(context.Background(), store, datasetContributionID, codectest.NewRandomAddress(), config.ChallengeBondAmount, 0, 110))
+				return store
+			}(),
+			ExpectedErr: ErrDataChallengeAlreadyOpen,
+		},
+		{
+			Name:  "ChallengeWindowClosed",
+			Actor: challenger,
+			Action: &ChallengeDatasetContribution{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State: func() state.Mutable {
+				store := newStore(true, true)
+				require.NoError(t, storage.SetDataCollateral(context.Background(), store, datasetContributionID, config.CollateralAmountForDataContribution, 99))
+				return store
+			}(),
+			ExpectedErr: ErrDataChallengeWindowClosed,
+		},
+		{
+			Name:  "ValidChallenge",
+			Actor: challenger,
+			Action: &ChallengeDatasetContribution{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			ActionID: actionID,
+			State:    newStore(true, true),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, challengerAddr, bond, chunk, deadline, err := storage.GetDataChallengeNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, challenger, challengerAddr)
+				require.Equal(t, config.ChallengeBondAmount, bond)
+				require.Equal(t, dataset.ChallengedChunk(actionID, uint64(len(chunks))), chunk)
+				require.Equal(t, 100+config.ChallengeResponseBlocks, deadline)
+
+				// The bond is locked
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, challenger)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+			},
+			ExpectedOutputs: &ChallengeDatasetContributionResult{
+				Actor:            challenger.String(),
+				Receiver:         contributor.String(),
+				BondAssetAddress: config.CollateralAssetAddressForDataContribution.String(),
+				BondAmount:       config.ChallengeBondAmount,
+				ChallengedChunk:  dataset.ChallengedChunk(actionID, uint64(len(chunks))),
+				Deadline:         100 + config.ChallengeResponseBlocks,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ClaimDatasetChallengeComputeUnits = 5
+)
+
+var (
+	ErrDataChallengerMismatch               = errors.New("data challenger mismatch")
+	ErrDataChallengeNotExpired              = errors.New("data challenge has not expired yet")
+	_                          chain.Action = (*ClaimDatasetChallenge)(nil)
+)
+
+type ClaimDatasetChallenge struct {
+	// Contribution ID
+	DatasetContributionID string `serialize:"true" json:"dataset_contribution_id"`
+
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+
+	// DatasetContributor
+	DatasetContributor codec.Address `serialize:"true" json:"dataset_contributor"`
+}
+
+func (*ClaimDatasetChallenge) GetTypeID() uint8 {
+	return nconsts.ClaimDatasetChallengeID
+}
+
+func (d *ClaimDatasetChallenge) StateKeys(actor codec.Address) state.Keys {
+	datasetContributionID, _ := ids.FromString(d.DatasetContributionID)
+	collateralAssetAddress := dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution
+	return state.Keys{
+		string(storage.DatasetContributionInfoKey(datasetContributionID)):     state.Read | state.Write,
+		string(storage.DataCommitmentKey(datasetContributionID)):              state.Write,
+		string(storage.DataChallengeKey(datasetContributionID)):               state.Read | state.Write,
+		string(storage.DataCollateralKey(datasetContributionID)):              state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(collateralAssetAddress, actor)): state.All,
+	}
+}
+
+func (d *ClaimDatasetChallenge) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	datasetContributionID, err := ids.FromString(d.DatasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, challenger, bond, _, deadline, err := storage.GetDataChallengeNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrDataChallengeNotFound
+	}
+	if challenger != actor {
+		return nil, ErrDataChallengerMismatch
+	}
+	if emission.GetEmission().GetLastAcceptedBlockHeight() <= deadline {
+		return nil, ErrDataChallengeNotExpired
+	}
+
+	datasetAddress, dataLocation, dataIdentifier, contributor, _, initiatedAt, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if datasetAddress != d.DatasetAddress {
+		return nil, ErrDatasetAddressMismatch
+	}
+	if contributor != d.DatasetContributor {
+		return nil, ErrDatasetContributorMismatch
+	}
+
+	// The contributor failed to prove the data is available so the
+	// contribution is deactivated and can no longer be challenged
+	if err := storage.SetDatasetContributionInfo(ctx, mu, datasetContributionID, datasetAddress, dataLocation, dataIdentifier, contributor, false, initiatedAt); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteDataCommitment(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteDataChallenge(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+
+	// Slash the collateral of the contributor that was locked when the
+	// contribution was completed
+	_, slashed, _, err := storage.GetDataCollateralNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteDataCollateral(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+
+	// The challenger gets the bond back along with the slashed collateral
+	amount, err := smath.Add(bond, slashed)
+	if err != nil {
+		return nil, err
+	}
+	dataConfig := dataset.GetDatasetConfig()
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := smath.Add(balance, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor, newBalance); err != nil {
+		return nil, err
+	}
+
+	return &ClaimDatasetChallengeResult{
+		Actor:                   actor.String(),
+		Receiver:                contributor.String(),
+		CollateralAssetAddress:  dataConfig.CollateralAssetAddressForDataContribution.String(),
+		BondAmountRefunded:      bond,
+		CollateralAmountSlashed: slashed,
+	}, nil
+}
+
+func (*ClaimDatasetChallenge) ComputeUnits(chain.Rules) uint64 {
+	return ClaimDatasetChallengeComputeUnits
+}
+
+func (*ClaimDatasetChallenge) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalClaimDatasetChallenge(p *codec.Packer) (chain.Action, error) {
+	var claim ClaimDatasetChallenge
+	claim.DatasetContributionID = p.UnpackString(true)
+	p.UnpackAddress(&claim.DatasetAddress)
+	p.UnpackAddress(&claim.DatasetContributor)
+	return &claim, p.Err()
+}
+
+var _ codec.Typed = (*ClaimDatasetChallengeResult)(nil)
+
+type ClaimDatasetChallengeResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	CollateralAssetAddress  string `serialize:"true" json:"collateral_asset_address"`
+	BondAmountRefunded      uint64 `serialize:"true" json:"bond_amount_refunded"`
+	CollateralAmountSlashed uint64 `serialize:"true" json:"collateral_amount_slashed"`
+}
+
+func (*ClaimDatasetChallengeResult) GetTypeID() uint8 {
+	return nconsts.ClaimDatasetChallengeID
+}
+
+func UnmarshalClaimDatasetChallengeResult(p *codec.Packer) (codec.Typed, error) {
+	var result ClaimDatasetChallengeResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.CollateralAssetAddress = p.UnpackString(true)
+	result.BondAmountRefunded = p.UnpackUint64(false)
+	result.CollateralAmountSlashed = p.UnpackUint64(false)
+	return &result, p.Err()
+}
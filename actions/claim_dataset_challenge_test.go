@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestClaimDatasetChallengeAction(t *testing.T) {
+	const (
+		dataLocation   = "default"
+		dataIdentifier = "data_id_1234"
+	)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	challenger := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor)
+	nftAddress := codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID)
+	config := dataset.GetDatasetConfig()
+
+	newStore := func(deadline uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor, true, 0))
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("Valid Name"), []byte("DATASET-0"), 0, []byte("metadata"), []byte(datasetAddress.String()), 0, 1, contributor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+		require.NoError(t, storage.SetDataCommitment(context.Background(), store, datasetContributionID, make([]byte, storage.DataRootLen), 4))
+		require.NoError(t, storage.SetDataChallenge(context.Background(), store, datasetContributionID, challenger, config.ChallengeBondAmount, 1, deadline))
+		require.NoError(t, storage.SetDataCollateral(context.Background(), store, datasetContributionID, config.CollateralAmountForDataContribution, 150))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, config.CollateralAssetAddressForDataContribution, contributor, 1))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NotChallenger",
+			Actor: codectest.NewRandomAddress(),
+			Action: &ClaimDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+			},
+			State:       newStore(90),
+			ExpectedErr: ErrDataChallengerMismatch,
+		},
+		{
+			Name:  "ChallengeNotExpired",
+			Actor: challenger,
+			Action: &ClaimDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+			},
+			State:       newStore(100),
+			ExpectedErr: ErrDataChallengeNotExpired,
+		},
+		{
+			Name:  "ValidClaim",
+			Actor: challenger,
+			Action: &ClaimDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+			},
+			State: newStore(90),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The contribution is deactivated
+				_, _, _, _, active, _, err := storage.GetDatasetContributionInfoNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.False(t, active)
+				exists, _, _, err := storage.GetDataCommitmentNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.False(t, exists)
+				exists, _, _, _, _, err = storage.GetDataChallengeNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.False(t, exists)
+
+				// The locked collateral is slashed to the challenger and the
+				// balance of the contributor is left untouched
+				exists, _, _, err = storage.GetDataCollateralNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.False(t, exists)
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, contributor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, challenger)
+				require.NoError(t, err)
+				require.Equal(t, config.ChallengeBondAmount+config.CollateralAmountForDataContribution, balance)
+
+				// The deactivated contribution cannot be completed again
+				_, err = (&CompleteContributeDataset{
+					DatasetContributionID: datasetContributionID.String(),
+					DatasetAddress:        datasetAddress,
+					DatasetContributor:    contributor,
+				}).Execute(ctx, nil, store, 0, owner, datasetContributionID)
+				require.Error(t, err)
+			},
+			ExpectedOutputs: &ClaimDatasetChallengeResult{
+				Actor:                   challenger.String(),
+				Receiver:                contributor.String(),
+				CollateralAssetAddress:  config.CollateralAssetAddressForDataContribution.String(),
+				BondAmountRefunded:      config.ChallengeBondAmount,
+				CollateralAmountSlashed: config.CollateralAmountForDataContribution,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
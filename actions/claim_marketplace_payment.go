@@ -5,10 +5,8 @@ package actions
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/emission"
@@ -46,11 +44,18 @@ func (*ClaimMarketplacePayment) GetTypeID() uint8 {
 }
 
 func (c *ClaimMarketplacePayment) StateKeys(actor codec.Address) state.Keys {
-	return state.Keys{
-		string(storage.AssetInfoKey(c.MarketplaceAssetAddress)):              state.Read | state.Write,
-		string(storage.AssetInfoKey(c.PaymentAssetAddress)):                  state.Read | state.Write,
-		string(storage.AssetAccountBalanceKey(c.PaymentAssetAddress, actor)): state.All,
-	}
+	stateKeys := state.Keys{
+		string(storage.AssetInfoKey(c.MarketplaceAssetAddress)):                                 state.Read | state.Write,
+		string(storage.AssetInfoKey(c.PaymentAssetAddress)):                                     state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(c.PaymentAssetAddress, actor)):                    state.All,
+		string(storage.MarketplacePaymentAssetsKey(c.MarketplaceAssetAddress)):                  state.All,
+		string(storage.MarketplacePaymentKey(c.MarketplaceAssetAddress, c.PaymentAssetAddress)): state.All,
+	}
+	// The treasury is credited with the protocol fee
+	if treasury := emission.GetEmission().GetTreasury(); treasury.MarketplaceFeeBasisPoints > 0 {
+		stateKeys.Add(string(storage.AssetAccountBalanceKey(c.PaymentAssetAddress, treasury.Address)), state.All)
+	}
+	return stateKeys
 }
 
 func (c *ClaimMarketplacePayment) Execute(
@@ -61,8 +66,12 @@ func (c *ClaimMarketplacePayment) Execute(
 	actor codec.Address,
 	_ ids.ID,
 ) (codec.Typed, error) {
+	if err := migrateMarketplacePayment(ctx, mu, c.MarketplaceAssetAddress, c.PaymentAssetAddress); err != nil {
+		return nil, err
+	}
+
 	// Check for the asset
-	assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintActor, pauseUnpauseActor, freezeUnfreezeActor, enableDisableKYCAccountActor, err := storage.GetAssetInfoNoController(ctx, mu, c.MarketplaceAssetAddress)
+	assetType, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, c.MarketplaceAssetAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -76,32 +85,22 @@ func (c *ClaimMarketplacePayment) Execute(
 		return nil, ErrWrongOwner
 	}
 
-	// Convert the metdata to a map
-	metadataMap, err := utils.BytesToMap(metadata)
+	// Ensure paymentAssetAddress is supported. Payments are claimed in every
+	// asset the listing was paid in, even if it no longer accepts it.
+	paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, mu, c.MarketplaceAssetAddress)
 	if err != nil {
 		return nil, err
 	}
-	// Ensure paymentAssetAddress is supported
-	if metadataMap["paymentAssetAddress"] != c.PaymentAssetAddress.String() {
+	if _, ok := storage.MarketplacePaymentAssetOf(paymentAssets, c.PaymentAssetAddress); !ok {
 		return nil, ErrPaymentAssetNotSupported
 	}
-
-	// Parse existing values from the metadata
-	paymentRemaining, err := strconv.ParseUint(metadataMap["paymentRemaining"], 10, 64)
+	_, paymentRemaining, paymentClaimed, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, mu, c.MarketplaceAssetAddress, c.PaymentAssetAddress)
 	if err != nil {
 		return nil, err
 	}
 	if paymentRemaining == 0 {
 		return nil, ErrNoPaymentRemaining
 	}
-	paymentClaimed, err := strconv.ParseUint(metadataMap["paymentClaimed"], 10, 64)
-	if err != nil {
-		return nil, err
-	}
-	lastClaimedBlock, err := strconv.ParseUint(metadataMap["lastClaimedBlock"], 10, 64)
-	if err != nil {
-		return nil, err
-	}
 
 	// Store the initial total before updating
 	initialTotal := paymentRemaining + paymentClaimed
@@ -134,21 +133,29 @@ func (c *ClaimMarketplacePayment) Execute(
 	}
 
 	// Now, paymentRemaining, paymentClaimed, and lastClaimedBlock are updated based on the reward accumulated per block
-	// You can now proceed to update these values in the relevant data structure or write them back to metadataMap
-	metadataMap["paymentRemaining"] = strconv.FormatUint(paymentRemaining, 10)
-	metadataMap["paymentClaimed"] = strconv.FormatUint(paymentClaimed, 10)
-	metadataMap["lastClaimedBlock"] = strconv.FormatUint(lastClaimedBlock, 10)
-
-	// Marshal the map back to a JSON byte slice
-	updatedMetadata, err := json.Marshal(metadataMap)
-	if err != nil {
+	if err := storage.UpdateMarketplacePayment(ctx, mu, c.MarketplaceAssetAddress, c.PaymentAssetAddress, paymentRemaining, paymentClaimed, lastClaimedBlock); err != nil {
 		return nil, err
 	}
 
-	// Update the asset with the updated metadata
-	if err := storage.SetAssetInfo(ctx, mu, c.MarketplaceAssetAddress, assetType, name, symbol, decimals, updatedMetadata, uri, totalSupply, maxSupply, owner, mintActor, pauseUnpauseActor, freezeUnfreezeActor, enableDisableKYCAccountActor); err != nil {
-		return nil, err
-	}
+	// The treasury takes the protocol fee out of the claimed payment
+	treasury := emission.GetEmission().GetTreasury()
+	protocolFee := storage.MarketplaceFeeAmount(totalAccumulatedReward, treasury.MarketplaceFeeBasisPoints)
+	treasuryAddress := ""
+	if protocolFee > 0 {
+		treasuryAddress = treasury.Address.String()
+		treasuryBalance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, c.PaymentAssetAddress, treasury.Address)
+		if err != nil {
+			return nil, err
+		}
+		newTreasuryBalance, err := smath.Add(treasuryBalance, protocolFee)
+		if err != nil {
+			return nil, err
+		}
+		if err = storage.SetAssetAccountBalance(ctx, mu, c.PaymentAssetAddress, treasury.Address, newTreasuryBalance); err != nil {
+			return nil, err
+		}
+	}
+	distributedReward := totalAccumulatedReward - protocolFee
 
 	// TODO: Distribute the rewards to all the users who contributed to the dataset
 	// This only distributes the rewards to the owner of the dataset
@@ -156,7 +163,7 @@ func (c *ClaimMarketplacePayment) Execute(
 	if err != nil {
 		return nil, err
 	}
-	newBalance, err := smath.Add(balance, totalAccumulatedReward)
+	newBalance, err := smath.Add(balance, distributedReward)
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +177,10 @@ func (c *ClaimMarketplacePayment) Execute(
 		LastClaimedBlock:  lastClaimedBlock,
 		PaymentClaimed:    paymentClaimed,
 		PaymentRemaining:  paymentRemaining,
-		DistributedReward: totalAccumulatedReward,
+		DistributedReward: distributedReward,
 		DistributedTo:     actor.String(),
+		ProtocolFee:       protocolFee,
+		TreasuryAddress:   treasuryAddress,
 	}, nil
 }
 
@@ -201,6 +210,8 @@ type ClaimMarketplacePaymentResult struct {
 	PaymentRemaining  uint64 `serialize:"true" json:"payment_remaining"`
 	DistributedReward uint64 `serialize:"true" json:"distributed_reward"`
 	DistributedTo     string `serialize:"true" json:"distributed_to"`
+	ProtocolFee       uint64 `serialize:"true" json:"protocol_fee"`
+	TreasuryAddress   string `serialize:"true" json:"treasury_address"`
 }
 
 func (*ClaimMarketplacePaymentResult) GetTypeID() uint8 {
@@ -216,5 +227,7 @@ func UnmarshalClaimMarketplacePaymentResult(p *codec.Packer) (codec.Typed, error
 	result.PaymentRemaining = p.UnpackUint64(false)
 	result.DistributedReward = p.UnpackUint64(false)
 	result.DistributedTo = p.UnpackString(true)
+	result.ProtocolFee = p.UnpackUint64(false)
+	result.TreasuryAddress = p.UnpackString(false)
 	return &result, p.Err()
 }
@@ -44,10 +44,7 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 					"datasetPricePerBlock":    "100",
 					"paymentAssetAddress":     baseAssetAddress.String(),
 					"publisher":               actor.String(),
-					"lastClaimedBlock":        "0",
 					"subscriptions":           "0",
-					"paymentRemaining":        "0",
-					"paymentClaimed":          "0",
 				}
 				metadataBytes, err := utils.MapToBytes(metadata)
 				require.NoError(t, err)
@@ -71,10 +68,7 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 					"datasetPricePerBlock":    "100",
 					"paymentAssetAddress":     baseAssetAddress.String(),
 					"publisher":               actor.String(),
-					"lastClaimedBlock":        "0",
 					"subscriptions":           "0",
-					"paymentRemaining":        "0",
-					"paymentClaimed":          "0",
 				}
 				metadataBytes, err := utils.MapToBytes(metadata)
 				require.NoError(t, err)
@@ -98,14 +92,13 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 					"datasetPricePerBlock":    "100",
 					"paymentAssetAddress":     baseAssetAddress.String(),
 					"publisher":               actor.String(),
-					"lastClaimedBlock":        "50",
 					"subscriptions":           "0",
-					"paymentRemaining":        "0",
-					"paymentClaimed":          "0",
 				}
 				metadataBytes, err := utils.MapToBytes(metadata)
 				require.NoError(t, err)
 				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100))
+				require.NoError(t, storage.UpdateMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 0, 0, 50))
 				return store
 			}(),
 			ExpectedErr: ErrNoPaymentRemaining,
@@ -125,14 +118,13 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 					"datasetPricePerBlock":    "100",
 					"paymentAssetAddress":     baseAssetAddress.String(),
 					"publisher":               actor.String(),
-					"lastClaimedBlock":        "0",
 					"subscriptions":           "0",
-					"paymentRemaining":        "100",
-					"paymentClaimed":          "0",
 				}
 				metadataBytes, err := utils.MapToBytes(metadata)
 				require.NoError(t, err)
 				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100))
+				require.NoError(t, storage.UpdateMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100, 0, 0))
 				require.NoError(t, storage.SetAssetInfo(context.Background(), store, baseAssetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(baseAssetAddress.String()), 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
 				return store
 			}(),
@@ -142,14 +134,12 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, uint64(100), balance) // 100 units claimed
 
-				// Check if metadata was updated correctly
-				_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
-				require.NoError(t, err)
-				metadataMap, err := utils.BytesToMap(metadata)
+				// Check if the payment accounting was updated correctly
+				_, paymentRemaining, paymentClaimed, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
 				require.NoError(t, err)
-				require.Equal(t, "0", metadataMap["paymentRemaining"]) // 1000 - 100 claimed
-				require.Equal(t, "100", metadataMap["paymentClaimed"])
-				require.Equal(t, "100", metadataMap["lastClaimedBlock"])
+				require.Zero(t, paymentRemaining) // 100 - 100 claimed
+				require.Equal(t, uint64(100), paymentClaimed)
+				require.Equal(t, uint64(100), lastClaimedBlock)
 			},
 			ExpectedOutputs: &ClaimMarketplacePaymentResult{
 				Actor:             actor.String(),
@@ -161,6 +151,86 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 				DistributedTo:     actor.String(),
 			},
 		},
+		{
+			Name:  "LegacyPaymentClaim",
+			Actor: actor,
+			Action: &ClaimMarketplacePayment{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     baseAssetAddress,
+			},
+			State: func() state.Mutable {
+				// Listings published before payment assets were kept apart
+				// track their payments in the metadata
+				store := chaintest.NewInMemoryStore()
+				metadata := map[string]string{
+					"datasetAddress":          datasetAddress.String(),
+					"marketplaceAssetAddress": marketplaceAssetAddress.String(),
+					"datasetPricePerBlock":    "1",
+					"paymentAssetAddress":     baseAssetAddress.String(),
+					"publisher":               actor.String(),
+					"subscriptions":           "1",
+					"paymentRemaining":        "100",
+					"paymentClaimed":          "50",
+					"lastClaimedBlock":        "40",
+				}
+				metadataBytes, err := utils.MapToBytes(metadata)
+				require.NoError(t, err)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, baseAssetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(baseAssetAddress.String()), 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, baseAssetAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(60), balance) // 1 unit for each block since block 40
+
+				// The payments were moved out of the metadata
+				pricePerBlock, paymentRemaining, paymentClaimed, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), pricePerBlock)
+				require.Equal(t, uint64(40), paymentRemaining)
+				require.Equal(t, uint64(110), paymentClaimed)
+				require.Equal(t, uint64(100), lastClaimedBlock)
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, []storage.MarketplacePaymentAsset{{Address: baseAssetAddress, Accepted: true, Pending: true}}, paymentAssets)
+				_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				metadataMap, err := utils.BytesToMap(metadata)
+				require.NoError(t, err)
+				require.NotContains(t, metadataMap, "paymentRemaining")
+				require.NotContains(t, metadataMap, "paymentClaimed")
+				require.NotContains(t, metadataMap, "lastClaimedBlock")
+			},
+			ExpectedOutputs: &ClaimMarketplacePaymentResult{
+				Actor:             actor.String(),
+				Receiver:          actor.String(),
+				LastClaimedBlock:  100,
+				PaymentClaimed:    110,
+				PaymentRemaining:  40,
+				DistributedReward: 60,
+				DistributedTo:     actor.String(),
+			},
+		},
+		{
+			Name:  "LegacyPaymentNotMigrated",
+			Actor: actor,
+			Action: &ClaimMarketplacePayment{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     codectest.NewRandomAddress(), // Not the primary payment asset
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				metadataBytes, err := utils.MapToBytes(map[string]string{
+					"paymentAssetAddress": baseAssetAddress.String(),
+					"paymentRemaining":    "100",
+				})
+				require.NoError(t, err)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				return store
+			}(),
+			ExpectedErr: ErrMarketplacePaymentNotMigrated,
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,6 +238,75 @@ func TestClaimMarketplacePaymentAction(t *testing.T) {
 	}
 }
 
+func TestClaimMarketplacePaymentProtocolFee(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	treasury := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), actor)
+	baseAssetAddress := storage.NAIAddress
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+
+	// 2.5% of the payments go to the treasury
+	emission.MockNewEmission(&emission.MockEmission{
+		LastAcceptedBlockHeight: 100,
+		Treasury:                emission.Treasury{Address: treasury, MarketplaceFeeBasisPoints: 250},
+	})
+	defer emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	test := chaintest.ActionTest{
+		Name:  "ValidPaymentClaimWithProtocolFee",
+		Actor: actor,
+		Action: &ClaimMarketplacePayment{
+			MarketplaceAssetAddress: marketplaceAssetAddress,
+			PaymentAssetAddress:     baseAssetAddress,
+		},
+		State: func() state.Mutable {
+			store := chaintest.NewInMemoryStore()
+			metadata := map[string]string{
+				"datasetAddress":          datasetAddress.String(),
+				"marketplaceAssetAddress": marketplaceAssetAddress.String(),
+				"datasetPricePerBlock":    "100",
+				"paymentAssetAddress":     baseAssetAddress.String(),
+				"publisher":               actor.String(),
+				"subscriptions":           "0",
+			}
+			metadataBytes, err := utils.MapToBytes(metadata)
+			require.NoError(t, err)
+			require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+			require.NoError(t, storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100))
+			require.NoError(t, storage.UpdateMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100, 0, 0))
+			require.NoError(t, storage.SetAssetInfo(context.Background(), store, baseAssetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(baseAssetAddress.String()), 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+			return store
+		}(),
+		Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+			// The fee is rounded down in favor of the listing
+			balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, baseAssetAddress, actor)
+			require.NoError(t, err)
+			require.Equal(t, uint64(98), balance)
+			treasuryBalance, err := storage.GetAssetAccountBalanceNoController(ctx, store, baseAssetAddress, treasury)
+			require.NoError(t, err)
+			require.Equal(t, uint64(2), treasuryBalance)
+
+			// The whole payment is claimed from the listing
+			_, paymentRemaining, paymentClaimed, _, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
+			require.NoError(t, err)
+			require.Zero(t, paymentRemaining)
+			require.Equal(t, uint64(100), paymentClaimed)
+		},
+		ExpectedOutputs: &ClaimMarketplacePaymentResult{
+			Actor:             actor.String(),
+			Receiver:          actor.String(),
+			LastClaimedBlock:  100,
+			PaymentClaimed:    100,
+			PaymentRemaining:  0,
+			DistributedReward: 98,
+			DistributedTo:     actor.String(),
+			ProtocolFee:       2,
+			TreasuryAddress:   treasury.String(),
+		},
+	}
+	test.Run(context.Background(), t)
+}
+
 func BenchmarkClaimMarketplacePayment(b *testing.B) {
 	require := require.New(b)
 	actor := codectest.NewRandomAddress()
@@ -192,14 +331,13 @@ func BenchmarkClaimMarketplacePayment(b *testing.B) {
 				"datasetPricePerBlock":    "100",
 				"paymentAssetAddress":     baseAssetAddress.String(),
 				"publisher":               actor.String(),
-				"lastClaimedBlock":        "0",
 				"subscriptions":           "0",
-				"paymentRemaining":        "100",
-				"paymentClaimed":          "0",
 			}
 			metadataBytes, err := utils.MapToBytes(metadata)
 			require.NoError(err)
 			require.NoError(storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+			require.NoError(storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100))
+			require.NoError(storage.UpdateMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100, 0, 0))
 			return store
 		},
 		Assertion: func(ctx context.Context, b *testing.B, store state.Mutable) {
@@ -208,14 +346,12 @@ func BenchmarkClaimMarketplacePayment(b *testing.B) {
 			require.NoError(err)
 			require.Equal(uint64(100), balance) // 100 units claimed
 
-			// Check if metadata was updated correctly
-			_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
-			require.NoError(err)
-			metadataMap, err := utils.BytesToMap(metadata)
+			// Check if the payment accounting was updated correctly
+			_, paymentRemaining, paymentClaimed, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
 			require.NoError(err)
-			require.Equal("0", metadataMap["paymentRemaining"]) // 1000 - 100 claimed
-			require.Equal("100", metadataMap["paymentClaimed"])
-			require.Equal("100", metadataMap["lastClaimedBlock"])
+			require.Zero(paymentRemaining) // 100 - 100 claimed
+			require.Equal(uint64(100), paymentClaimed)
+			require.Equal(uint64(100), lastClaimedBlock)
 		},
 	}
 
@@ -0,0 +1,140 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ClaimVestedComputeUnits = 1
+)
+
+var (
+	ErrVestingScheduleNotFound              = errors.New("vesting schedule not found")
+	ErrNotVestingBeneficiary                = errors.New("not the beneficiary of the vesting schedule")
+	ErrNothingVested                        = errors.New("nothing vested to claim")
+	_                          chain.Action = (*ClaimVested)(nil)
+)
+
+type ClaimVested struct {
+	// VestingAddress of the schedule to claim from
+	VestingAddress codec.Address `serialize:"true" json:"vesting_address"`
+
+	// AssetAddress locked by the schedule
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+}
+
+func (*ClaimVested) GetTypeID() uint8 {
+	return nconsts.ClaimVestedID
+}
+
+func (c *ClaimVested) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.VestingScheduleKey(c.VestingAddress)):                     state.Read | state.Write,
+		string(storage.AssetInfoKey(c.AssetAddress)):                             state.Read,
+		string(storage.AssetAccountBalanceKey(c.AssetAddress, c.VestingAddress)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(c.AssetAddress, actor)):            state.All,
+	}
+}
+
+func (c *ClaimVested) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, asset, beneficiary, funder, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock, err := storage.GetVestingScheduleNoController(ctx, mu, c.VestingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrVestingScheduleNotFound
+	}
+	if beneficiary != actor {
+		return nil, ErrNotVestingBeneficiary
+	}
+	if asset != c.AssetAddress {
+		return nil, ErrVestingScheduleInvalid
+	}
+
+	vested := storage.VestedAmount(amount, startBlock, cliffBlock, endBlock, emission.GetEmission().GetLastAcceptedBlockHeight())
+	if vested <= claimed {
+		return nil, ErrNothingVested
+	}
+	value := vested - claimed
+	claimed = vested
+
+	// The schedule is removed once everything has been claimed
+	if claimed == amount {
+		err = storage.DeleteVestingSchedule(ctx, mu, c.VestingAddress)
+	} else {
+		err = storage.SetVestingSchedule(ctx, mu, c.VestingAddress, asset, beneficiary, funder, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock)
+	}
+	if err != nil {
+		return nil, err
+	}
+	locked, _, err := storage.TransferAsset(ctx, mu, asset, c.VestingAddress, actor, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClaimVestedResult{
+		Actor:    actor.String(),
+		Receiver: c.VestingAddress.String(),
+		Claimed:  value,
+		Locked:   locked,
+	}, nil
+}
+
+func (*ClaimVested) ComputeUnits(chain.Rules) uint64 {
+	return ClaimVestedComputeUnits
+}
+
+func (*ClaimVested) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalClaimVested(p *codec.Packer) (chain.Action, error) {
+	var claim ClaimVested
+	p.UnpackAddress(&claim.VestingAddress)
+	p.UnpackAddress(&claim.AssetAddress)
+	return &claim, p.Err()
+}
+
+var _ codec.Typed = (*ClaimVestedResult)(nil)
+
+type ClaimVestedResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Claimed  uint64 `serialize:"true" json:"claimed"`
+	Locked   uint64 `serialize:"true" json:"locked"` // Amount left in the schedule
+}
+
+func (*ClaimVestedResult) GetTypeID() uint8 {
+	return nconsts.ClaimVestedID
+}
+
+func UnmarshalClaimVestedResult(p *codec.Packer) (codec.Typed, error) {
+	var result ClaimVestedResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Claimed = p.UnpackUint64(true)
+	result.Locked = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestClaimVestedAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	funder := codectest.NewRandomAddress()
+	beneficiary := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), funder)
+	vestingAddress := storage.VestingAddress(funder, assetAddress, beneficiary, codec.EmptyAddress, 1000, 50, 50, 150)
+
+	// newStore sets up a schedule releasing 1000 from block 50 to block 150 of
+	// which [claimed] has already been claimed
+	newStore := func(claimed uint64, cliffBlock uint64) state.Mutable {
+		store := newOrderStore(t, funder, assetAddress)
+		require.NoError(t, storage.SetVestingSchedule(context.Background(), store, vestingAddress, assetAddress, beneficiary, funder, codec.EmptyAddress, 1000, claimed, 50, cliffBlock, 150))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, vestingAddress, 1000-claimed))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "ScheduleNotFound",
+			Actor: beneficiary,
+			Action: &ClaimVested{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+			},
+			State:       newOrderStore(t, funder, assetAddress),
+			ExpectedErr: ErrVestingScheduleNotFound,
+		},
+		{
+			Name:  "NotBeneficiary",
+			Actor: funder,
+			Action: &ClaimVested{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+			},
+			State:       newStore(0, 50),
+			ExpectedErr: ErrNotVestingBeneficiary,
+		},
+		{
+			Name:  "BeforeCliff",
+			Actor: beneficiary,
+			Action: &ClaimVested{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+			},
+			State:       newStore(0, 120),
+			ExpectedErr: ErrNothingVested,
+		},
+		{
+			Name:  "AlreadyClaimed",
+			Actor: beneficiary,
+			Action: &ClaimVested{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+			},
+			State:       newStore(500, 50),
+			ExpectedErr: ErrNothingVested,
+		},
+		{
+			Name:  "ValidClaim",
+			Actor: beneficiary,
+			Action: &ClaimVested{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+			},
+			State: newStore(200, 50),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, _, claimed, _, _, _, err := storage.GetVestingScheduleNoController(ctx, store, vestingAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, uint64(500), claimed)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, beneficiary)
+				require.NoError(t, err)
+				require.Equal(t, uint64(300), balance)
+			},
+			ExpectedOutputs: &ClaimVestedResult{
+				Actor:    beneficiary.String(),
+				Receiver: vestingAddress.String(),
+				Claimed:  300,
+				Locked:   500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+
+	// Everything is unlocked after the end block and the schedule is removed
+	// once claimed
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 200})
+	(&chaintest.ActionTest{
+		Name:  "ClaimAll",
+		Actor: beneficiary,
+		Action: &ClaimVested{
+			VestingAddress: vestingAddress,
+			AssetAddress:   assetAddress,
+		},
+		State: newStore(500, 50),
+		Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+			exists, _, _, _, _, _, _, _, _, _, err := storage.GetVestingScheduleNoController(ctx, store, vestingAddress)
+			require.NoError(t, err)
+			require.False(t, exists)
+		},
+		ExpectedOutputs: &ClaimVestedResult{
+			Actor:    beneficiary.String(),
+			Receiver: vestingAddress.String(),
+			Claimed:  500,
+		},
+	}).Run(context.Background(), t)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	CloseOrderComputeUnits = 5
+)
+
+var (
+	ErrNotOrderOwner              = errors.New("actor is not the owner of the order")
+	_                chain.Action = (*CloseOrder)(nil)
+)
+
+type CloseOrder struct {
+	// OrderAddress of the order to close
+	OrderAddress codec.Address `serialize:"true" json:"order_address"`
+
+	// InAssetAddress of the order that is refunded to the actor
+	InAssetAddress codec.Address `serialize:"true" json:"in_asset_address"`
+
+	// InCollectionAddress is the collection of [InAssetAddress] when the order
+	// sells an NFT and is empty otherwise
+	InCollectionAddress codec.Address `serialize:"true" json:"in_collection_address"`
+}
+
+func (*CloseOrder) GetTypeID() uint8 {
+	return nconsts.CloseOrderID
+}
+
+func (c *CloseOrder) StateKeys(actor codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.OrderKey(c.OrderAddress)):                                 state.Read | state.Write,
+		string(storage.AssetInfoKey(c.InAssetAddress)):                           state.Read,
+		string(storage.AssetAccountBalanceKey(c.InAssetAddress, c.OrderAddress)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(c.InAssetAddress, actor)):          state.All,
+	}
+	if c.InCollectionAddress != codec.EmptyAddress {
+		storage.AddNFTTransferStateKeys(stateKeys, c.InAssetAddress, c.InCollectionAddress, c.OrderAddress, actor)
+	}
+	return stateKeys
+}
+
+func (c *CloseOrder) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, in, _, _, _, owner, err := storage.GetOrderNoController(ctx, mu, c.OrderAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOrderNotFound
+	}
+	if owner != actor {
+		return nil, ErrNotOrderOwner
+	}
+	if in != c.InAssetAddress {
+		return nil, ErrOrderMismatch
+	}
+	if _, err := checkOrderAsset(ctx, mu, in, c.InCollectionAddress); err != nil {
+		return nil, err
+	}
+
+	// Refund whatever was not filled
+	refund, err := storage.GetAssetAccountBalanceNoController(ctx, mu, in, c.OrderAddress)
+	if err != nil {
+		return nil, err
+	}
+	if refund > 0 {
+		if _, _, err := storage.TransferAsset(ctx, mu, in, c.OrderAddress, actor, refund); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.DeleteOrder(ctx, mu, c.OrderAddress); err != nil {
+		return nil, err
+	}
+
+	return &CloseOrderResult{
+		Actor:    actor.String(),
+		Receiver: c.OrderAddress.String(),
+		Refund:   refund,
+	}, nil
+}
+
+func (*CloseOrder) ComputeUnits(chain.Rules) uint64 {
+	return CloseOrderComputeUnits
+}
+
+func (*CloseOrder) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalCloseOrder(p *codec.Packer) (chain.Action, error) {
+	var closeOrder CloseOrder
+	p.UnpackAddress(&closeOrder.OrderAddress)
+	p.UnpackAddress(&closeOrder.InAssetAddress)
+	unpackAddressOrEmpty(p, &closeOrder.InCollectionAddress)
+	return &closeOrder, p.Err()
+}
+
+var _ codec.Typed = (*CloseOrderResult)(nil)
+
+type CloseOrderResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Refund   uint64 `serialize:"true" json:"refund"`
+}
+
+func (*CloseOrderResult) GetTypeID() uint8 {
+	return nconsts.CloseOrderID
+}
+
+func UnmarshalCloseOrderResult(p *codec.Packer) (codec.Typed, error) {
+	var result CloseOrderResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Refund = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestCloseOrderAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), owner)
+	orderAddress := storage.OrderAddress(owner, assetAddress, 10, storage.NAIAddress, 3)
+
+	// newStore sets up an order of [owner] with 70 left and 930 of the asset
+	// still held by [owner]
+	newStore := func() state.Mutable {
+		store := newOrderStore(t, owner, assetAddress)
+		_, err := storage.SetOrder(context.Background(), store, owner, assetAddress, 10, storage.NAIAddress, 3)
+		require.NoError(t, err)
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, owner, 930))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, orderAddress, 70))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "OrderNotFound",
+			Actor: owner,
+			Action: &CloseOrder{
+				OrderAddress:   orderAddress,
+				InAssetAddress: assetAddress,
+			},
+			State:       newOrderStore(t, owner, assetAddress),
+			ExpectedErr: ErrOrderNotFound,
+		},
+		{
+			Name:  "NotOrderOwner",
+			Actor: codectest.NewRandomAddress(),
+			Action: &CloseOrder{
+				OrderAddress:   orderAddress,
+				InAssetAddress: assetAddress,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrNotOrderOwner,
+		},
+		{
+			Name:  "AssetMismatch",
+			Actor: owner,
+			Action: &CloseOrder{
+				OrderAddress:   orderAddress,
+				InAssetAddress: storage.NAIAddress,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrOrderMismatch,
+		},
+		{
+			Name:  "ValidClose",
+			Actor: owner,
+			Action: &CloseOrder{
+				OrderAddress:   orderAddress,
+				InAssetAddress: assetAddress,
+			},
+			State: newStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, _, err := storage.GetOrderNoController(ctx, store, orderAddress)
+				require.NoError(t, err)
+				require.False(t, exists)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, owner)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1000), balance)
+			},
+			ExpectedOutputs: &CloseOrderResult{
+				Actor:    owner.String(),
+				Receiver: orderAddress.String(),
+				Refund:   70,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestCloseOrderMarshal(t *testing.T) {
+	require := require.New(t)
+
+	closeOrder := &CloseOrder{
+		OrderAddress:   codectest.NewRandomAddress(),
+		InAssetAddress: codectest.NewRandomAddress(),
+	}
+	b, err := chain.Marshal(closeOrder)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalCloseOrder(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(closeOrder, unmarshalled)
+
+	closeOrder.InCollectionAddress = codectest.NewRandomAddress()
+	b, err = chain.Marshal(closeOrder)
+	require.NoError(err)
+	unmarshalled, err = UnmarshalCloseOrder(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(closeOrder, unmarshalled)
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	CloseUsageChannelComputeUnits = 5
+)
+
+var (
+	ErrUsageChannelNotExpired              = errors.New("usage channel has not expired")
+	_                         chain.Action = (*CloseUsageChannel)(nil)
+)
+
+type CloseUsageChannel struct {
+	// Address of the usage channel to close
+	UsageChannelAddress codec.Address `serialize:"true" json:"usage_channel_address"`
+
+	// Asset the usage channel was funded with
+	PaymentAssetAddress codec.Address `serialize:"true" json:"payment_asset_address"`
+}
+
+func (*CloseUsageChannel) GetTypeID() uint8 {
+	return nconsts.CloseUsageChannelID
+}
+
+func (c *CloseUsageChannel) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.UsageChannelKey(c.UsageChannelAddress)):                               state.Read | state.Write,
+		string(storage.AssetInfoKey(c.PaymentAssetAddress)):                                  state.Read,
+		string(storage.AssetAccountBalanceKey(c.PaymentAssetAddress, c.UsageChannelAddress)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(c.PaymentAssetAddress, actor)):                 state.All,
+	}
+}
+
+func (c *CloseUsageChannel) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, marketplaceAsset, paymentAsset, buyer, deposit, settled, expirationBlock, closed, err := storage.GetUsageChannelNoController(ctx, mu, c.UsageChannelAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUsageChannelNotFound
+	}
+	if closed {
+		return nil, ErrUsageChannelClosed
+	}
+	if paymentAsset != c.PaymentAssetAddress {
+		return nil, ErrUsageChannelMismatch
+	}
+	// Only the buyer closes the channel, once the owner of the listing had
+	// until the expiration block to settle the usage
+	if buyer != actor {
+		return nil, ErrWrongOwner
+	}
+	if emission.GetEmission().GetLastAcceptedBlockHeight() <= expirationBlock {
+		return nil, ErrUsageChannelNotExpired
+	}
+
+	refund := deposit - settled
+	if refund > 0 {
+		if _, _, err := storage.TransferAsset(ctx, mu, c.PaymentAssetAddress, c.UsageChannelAddress, actor, refund); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.SetUsageChannel(ctx, mu, c.UsageChannelAddress, marketplaceAsset, paymentAsset, buyer, deposit, settled, expirationBlock, true); err != nil {
+		return nil, err
+	}
+
+	return &CloseUsageChannelResult{
+		Actor:               actor.String(),
+		Receiver:            actor.String(),
+		UsageChannelAddress: c.UsageChannelAddress.String(),
+		Refund:              refund,
+	}, nil
+}
+
+func (*CloseUsageChannel) ComputeUnits(chain.Rules) uint64 {
+	return CloseUsageChannelComputeUnits
+}
+
+func (*CloseUsageChannel) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalCloseUsageChannel(p *codec.Packer) (chain.Action, error) {
+	var closeChannel CloseUsageChannel
+	p.UnpackAddress(&closeChannel.UsageChannelAddress)
+	p.UnpackAddress(&closeChannel.PaymentAssetAddress)
+	return &closeChannel, p.Err()
+}
+
+var _ codec.Typed = (*CloseUsageChannelResult)(nil)
+
+type CloseUsageChannelResult struct {
+	Actor               string `serialize:"true" json:"actor"`
+	Receiver            string `serialize:"true" json:"receiver"`
+	UsageChannelAddress string `serialize:"true" json:"usage_channel_address"`
+	Refund              uint64 `serialize:"true" json:"refund"`
+}
+
+func (*CloseUsageChannelResult) GetTypeID() uint8 {
+	return nconsts.CloseUsageChannelID
+}
+
+func UnmarshalCloseUsageChannelResult(p *codec.Packer) (codec.Typed, error) {
+	var result CloseUsageChannelResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.UsageChannelAddress = p.UnpackString(true)
+	result.Refund = p.UnpackUint64(false)
+	return &result, p.Err()
+}
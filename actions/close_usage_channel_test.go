@@ -0,0 +1,115 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestCloseUsageChannelAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	buyer := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	channelAddress := storage.UsageChannelAddress(marketplaceAssetAddress, storage.NAIAddress, buyer, 0)
+
+	mockEmission := emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 200})
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "ChannelNotFound",
+			Actor: buyer,
+			Action: &CloseUsageChannel{
+				UsageChannelAddress: channelAddress,
+				PaymentAssetAddress: storage.NAIAddress,
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 0),
+			ExpectedErr: ErrUsageChannelNotFound,
+		},
+		{
+			Name:  "WrongOwner",
+			Actor: owner, // Only the buyer reclaims the deposit
+			Action: &CloseUsageChannel{
+				UsageChannelAddress: channelAddress,
+				PaymentAssetAddress: storage.NAIAddress,
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 100),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "CloseUsageChannel",
+			Actor: buyer,
+			Action: &CloseUsageChannel{
+				UsageChannelAddress: channelAddress,
+				PaymentAssetAddress: storage.NAIAddress,
+			},
+			State: newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, buyer)
+				require.NoError(t, err)
+				require.Equal(t, uint64(400), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, channelAddress)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+
+				_, _, _, _, _, _, _, closed, err := storage.GetUsageChannelNoController(ctx, store, channelAddress)
+				require.NoError(t, err)
+				require.True(t, closed)
+			},
+			ExpectedOutputs: &CloseUsageChannelResult{
+				Actor:               buyer.String(),
+				Receiver:            buyer.String(),
+				UsageChannelAddress: channelAddress.String(),
+				Refund:              400,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+
+	// The owner of the listing has until the expiration block to settle
+	mockEmission.LastAcceptedBlockHeight = 110
+	test := chaintest.ActionTest{
+		Name:  "ChannelNotExpired",
+		Actor: buyer,
+		Action: &CloseUsageChannel{
+			UsageChannelAddress: channelAddress,
+			PaymentAssetAddress: storage.NAIAddress,
+		},
+		State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 100),
+		ExpectedErr: ErrUsageChannelNotExpired,
+	}
+	test.Run(context.Background(), t)
+}
+
+func TestCloseUsageChannelResultMarshal(t *testing.T) {
+	require := require.New(t)
+
+	// The receiver is optional like in every other result
+	result := &CloseUsageChannelResult{
+		Actor:               codectest.NewRandomAddress().String(),
+		UsageChannelAddress: codectest.NewRandomAddress().String(),
+		Refund:              400,
+	}
+	b, err := chain.Marshal(result)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalCloseUsageChannelResult(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(result, unmarshalled)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/nuklai/nuklaivm/utils"
 
@@ -55,6 +56,8 @@ func (d *CompleteContributeDataset) StateKeys(_ codec.Address) state.Keys {
 
 		string(storage.DatasetInfoKey(d.DatasetAddress)):                                                                                   state.Read,
 		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                                                  state.Read | state.Write,
+		string(storage.DataCommitmentKey(datasetContributionID)):                                                                           state.Read,
+		string(storage.DataCollateralKey(datasetContributionID)):                                                                           state.Allocate | state.Write,
 		string(storage.AssetAccountBalanceKey(dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution, d.DatasetContributor)): state.Read | state.Write,
 		string(storage.AssetAccountBalanceKey(d.DatasetAddress, d.DatasetContributor)):                                                     state.Allocate | state.Write,
 		string(storage.AssetAccountBalanceKey(nftAddress, d.DatasetContributor)):                                                           state.All,
@@ -88,11 +91,13 @@ func (d *CompleteContributeDataset) Execute(
 	}
 
 	// Check if the dataset contribution exists
-	datasetAddress, dataLocation, dataIdentifier, contributor, active, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	datasetAddress, dataLocation, dataIdentifier, contributor, active, initiatedAt, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
 	if err != nil {
 		return nil, err
 	}
-	if active {
+	// A contribution deactivated after failing a data availability challenge
+	// keeps the NFT minted when it was completed
+	if active || storage.AssetExists(ctx, mu, codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID)) {
 		return nil, ErrDatasetContributionAlreadyComplete
 	}
 	if datasetAddress != d.DatasetAddress {
@@ -130,29 +135,49 @@ func (d *CompleteContributeDataset) Execute(
 	}
 
 	// Update the dataset contribution
-	if err := storage.SetDatasetContributionInfo(ctx, mu, datasetContributionID, datasetAddress, dataLocation, dataIdentifier, contributor, true); err != nil {
+	if err := storage.SetDatasetContributionInfo(ctx, mu, datasetContributionID, datasetAddress, dataLocation, dataIdentifier, contributor, true, initiatedAt); err != nil {
 		return nil, err
 	}
 
-	// Refund the collateral back to the contributor
+	// A contribution that committed to its data can be challenged for
+	// ChallengeWindowBlocks so its collateral stays locked until then and is
+	// slashed if a challenge succeeds. Otherwise it is refunded back to the
+	// contributor.
 	dataConfig := dataset.GetDatasetConfig()
-	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, d.DatasetContributor)
+	exists, _, _, err := storage.GetDataCommitmentNoController(ctx, mu, datasetContributionID)
 	if err != nil {
 		return nil, err
 	}
-	newBalance, err := smath.Add(balance, dataConfig.CollateralAmountForDataContribution)
-	if err != nil {
-		return nil, err
-	}
-	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, d.DatasetContributor, newBalance); err != nil {
-		return nil, err
+	var refunded, releaseHeight uint64
+	if exists {
+		releaseHeight, err = smath.Add(emission.GetEmission().GetLastAcceptedBlockHeight(), dataConfig.ChallengeWindowBlocks)
+		if err != nil {
+			return nil, err
+		}
+		if err := storage.SetDataCollateral(ctx, mu, datasetContributionID, dataConfig.CollateralAmountForDataContribution, releaseHeight); err != nil {
+			return nil, err
+		}
+	} else {
+		balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, d.DatasetContributor)
+		if err != nil {
+			return nil, err
+		}
+		newBalance, err := smath.Add(balance, dataConfig.CollateralAmountForDataContribution)
+		if err != nil {
+			return nil, err
+		}
+		if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, d.DatasetContributor, newBalance); err != nil {
+			return nil, err
+		}
+		refunded = dataConfig.CollateralAmountForDataContribution
 	}
 
 	return &CompleteContributeDatasetResult{
 		Actor:                    actor.String(),
 		Receiver:                 d.DatasetContributor.String(),
 		CollateralAssetAddress:   dataConfig.CollateralAssetAddressForDataContribution.String(),
-		CollateralAmountRefunded: dataConfig.CollateralAmountForDataContribution,
+		CollateralAmountRefunded: refunded,
+		CollateralReleaseHeight:  releaseHeight,
 		DatasetChildNftAddress:   nftAddress.String(),
 		To:                       d.DatasetContributor.String(),
 		DataLocation:             string(dataLocation),
@@ -184,6 +209,7 @@ type CompleteContributeDatasetResult struct {
 	Receiver                 string `serialize:"true" json:"receiver"`
 	CollateralAssetAddress   string `serialize:"true" json:"collateral_asset_address"`
 	CollateralAmountRefunded uint64 `serialize:"true" json:"collateral_amount_refunded"`
+	CollateralReleaseHeight  uint64 `serialize:"true" json:"collateral_release_height"`
 	DatasetChildNftAddress   string `serialize:"true" json:"dataset_child_nft_address"`
 	To                       string `serialize:"true" json:"to"`
 	DataLocation             string `serialize:"true" json:"data_location"`
@@ -200,6 +226,7 @@ func UnmarshalCompleteContributeDatasetResult(p *codec.Packer) (codec.Typed, err
 	result.Receiver = p.UnpackString(false)
 	result.CollateralAssetAddress = p.UnpackString(true)
 	result.CollateralAmountRefunded = p.UnpackUint64(false)
+	result.CollateralReleaseHeight = p.UnpackUint64(false)
 	result.DatasetChildNftAddress = p.UnpackString(true)
 	result.To = p.UnpackString(true)
 	result.DataLocation = p.UnpackString(true)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/nuklai/nuklaivm/utils"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,8 @@ func TestCompleteContributeDatasetAction(t *testing.T) {
 	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor)
 	nftAddress := codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID)
 
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
 	tests := []chaintest.ActionTest{
 		{
 			Name:  "WrongOwner",
@@ -76,7 +79,7 @@ func TestCompleteContributeDatasetAction(t *testing.T) {
 			State: func() state.Mutable {
 				store := chaintest.NewInMemoryStore()
 				// Set valid contribution
-				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, true))
+				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, true, 0))
 				// Set valid dataset
 				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
 				return store
@@ -94,7 +97,7 @@ func TestCompleteContributeDatasetAction(t *testing.T) {
 			State: func() state.Mutable {
 				store := chaintest.NewInMemoryStore()
 				// Set valid contribution
-				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, codectest.NewRandomAddress(), []byte(dataLocation), []byte(dataIdentifier), actor, false))
+				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, codectest.NewRandomAddress(), []byte(dataLocation), []byte(dataIdentifier), actor, false, 0))
 				// Set valid dataset
 				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
 				return store
@@ -112,7 +115,7 @@ func TestCompleteContributeDatasetAction(t *testing.T) {
 			State: func() state.Mutable {
 				store := chaintest.NewInMemoryStore()
 				// Set valid contribution
-				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, false))
+				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, false, 0))
 				// Set valid dataset
 				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
 
@@ -132,7 +135,7 @@ func TestCompleteContributeDatasetAction(t *testing.T) {
 			State: func() state.Mutable {
 				store := chaintest.NewInMemoryStore()
 				// Set valid contribution
-				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, false))
+				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, false, 0))
 				// Set valid dataset
 				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
 				// Create existing NFT
@@ -191,6 +194,50 @@ func TestCompleteContributeDatasetAction(t *testing.T) {
 				DataIdentifier:           dataIdentifier,
 			},
 		},
+		{
+			Name:     "CompletionWithDataCommitment",
+			ActionID: ids.GenerateTestID(),
+			Actor:    actor,
+			Action: &CompleteContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    actor,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), actor, false, 0))
+				require.NoError(t, storage.SetDataCommitment(context.Background(), store, datasetContributionID, make([]byte, storage.DataRootLen), 4))
+				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, datasetAddress, nconsts.AssetFractionalTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), []byte(datasetAddress.String()), 1, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				config := dataset.GetDatasetConfig()
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, config.CollateralAssetAddressForDataContribution, actor, 0))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				config := dataset.GetDatasetConfig()
+
+				// The collateral stays locked while the contribution can be
+				// challenged
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, actor)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+				exists, amount, releaseHeight, err := storage.GetDataCollateralNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, config.CollateralAmountForDataContribution, amount)
+				require.Equal(t, 100+config.ChallengeWindowBlocks, releaseHeight)
+			},
+			ExpectedOutputs: &CompleteContributeDatasetResult{
+				Actor:                   actor.String(),
+				Receiver:                actor.String(),
+				CollateralAssetAddress:  dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution.String(),
+				CollateralReleaseHeight: 100 + dataset.GetDatasetConfig().ChallengeWindowBlocks,
+				DatasetChildNftAddress:  nftAddress.String(),
+				To:                      actor.String(),
+				DataLocation:            dataLocation,
+				DataIdentifier:          dataIdentifier,
+			},
+		},
 	}
 
 	for _, tt := range tests {
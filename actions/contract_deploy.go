@@ -26,7 +26,10 @@ const MAXCREATIONSIZE = units.MiB
 type ContractDeploy struct {
 	ContractID   runtime.ContractID `serialize:"true" json:"contractID"`
 	CreationInfo []byte             `serialize:"true" json:"creationInfo"`
-	address      codec.Address
+	// Address allowed to upgrade the deployed contract. Leave it empty to make
+	// the contract immutable.
+	UpgradeAuthority codec.Address `serialize:"true" json:"upgradeAuthority"`
+	address          codec.Address
 }
 
 func (*ContractDeploy) GetTypeID() uint8 {
@@ -40,6 +43,7 @@ func (d *ContractDeploy) StateKeys(_ codec.Address) state.Keys {
 	stateKey, _ := keys.Encode(storage.AccountContractKey(d.address), 36)
 	return state.Keys{
 		string(stateKey): state.All,
+		string(storage.ContractUpgradeAuthorityKey(d.address)): state.All,
 	}
 }
 
@@ -53,7 +57,15 @@ func (d *ContractDeploy) Execute(
 ) (codec.Typed, error) {
 	result, err := (&storage.ContractStateManager{Mutable: mu}).
 		NewAccountWithContract(ctx, d.ContractID, d.CreationInfo)
-	return &ContractDeployResult{Actor: actor.String(), Receiver: "", Address: result}, err
+	if err != nil {
+		return nil, err
+	}
+	if d.UpgradeAuthority != codec.EmptyAddress {
+		if err := storage.SetContractUpgradeAuthority(ctx, mu, result, d.UpgradeAuthority, 0); err != nil {
+			return nil, err
+		}
+	}
+	return &ContractDeployResult{Actor: actor.String(), Receiver: "", Address: result, UpgradeAuthority: d.UpgradeAuthority}, nil
 }
 
 func (*ContractDeploy) ComputeUnits(chain.Rules) uint64 {
@@ -68,18 +80,20 @@ func (*ContractDeploy) ValidRange(chain.Rules) (int64, int64) {
 var _ chain.Marshaler = (*ContractDeploy)(nil)
 
 func (d *ContractDeploy) Size() int {
-	return codec.BytesLen(d.CreationInfo) + codec.BytesLen(d.ContractID)
+	return codec.BytesLen(d.CreationInfo) + codec.BytesLen(d.ContractID) + codec.AddressLen
 }
 
 func (d *ContractDeploy) Marshal(p *codec.Packer) {
 	p.PackBytes(d.ContractID)
 	p.PackBytes(d.CreationInfo)
+	p.PackAddress(d.UpgradeAuthority)
 }
 
 func UnmarshalDeployContract(p *codec.Packer) (chain.Action, error) {
 	var deployContract ContractDeploy
 	p.UnpackBytes(40, true, (*[]byte)(&deployContract.ContractID))
 	p.UnpackBytes(MAXCREATIONSIZE, false, &deployContract.CreationInfo)
+	unpackAddressOrEmpty(p, &deployContract.UpgradeAuthority)
 	deployContract.address = storage.GetAddressForDeploy(0, deployContract.CreationInfo)
 	if err := p.Err(); err != nil {
 		return nil, err
@@ -94,6 +108,8 @@ type ContractDeployResult struct {
 	Actor    string        `serialize:"true" json:"actor"`
 	Receiver string        `serialize:"true" json:"receiver"`
 	Address  codec.Address `serialize:"true" json:"address"`
+	// Empty when the contract was deployed without an upgrade authority
+	UpgradeAuthority codec.Address `serialize:"true" json:"upgradeAuthority"`
 }
 
 func (*ContractDeployResult) GetTypeID() uint8 {
@@ -105,5 +121,6 @@ func UnmarshalContractDeployResult(p *codec.Packer) (codec.Typed, error) {
 	result.Actor = p.UnpackString(true)
 	result.Receiver = p.UnpackString(false)
 	p.UnpackAddress(&result.Address)
+	unpackAddressOrEmpty(p, &result.UpgradeAuthority)
 	return &result, p.Err()
 }
@@ -6,9 +6,11 @@ package actions
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/nuklai/nuklaivm/abi"
 	"github.com/nuklai/nuklaivm/storage"
 
 	"github.com/ava-labs/hypersdk/chain"
@@ -20,13 +22,18 @@ import (
 	mconsts "github.com/nuklai/nuklaivm/consts"
 )
 
-var _ chain.Action = (*ContractPublish)(nil)
+var (
+	ErrContractABIInvalid              = errors.New("contract ABI is invalid")
+	_                     chain.Action = (*ContractPublish)(nil)
+)
 
 const MAXCONTRACTSIZE = 2 * units.MiB
 
 type ContractPublish struct {
 	ContractBytes []byte `serialize:"true" json:"contractBytes"`
-	id            runtime.ContractID
+	// Optional JSON encoded ABI describing the functions of the contract
+	ABI []byte `serialize:"true" json:"abi"`
+	id  runtime.ContractID
 }
 
 func (*ContractPublish) GetTypeID() uint8 {
@@ -38,9 +45,13 @@ func (t *ContractPublish) StateKeys(_ codec.Address) state.Keys {
 		hashedID := sha256.Sum256(t.ContractBytes)
 		t.id, _ = keys.Encode(storage.ContractsKey(hashedID[:]), len(t.ContractBytes))
 	}
-	return state.Keys{
+	stateKeys := state.Keys{
 		string(t.id): state.Write | state.Allocate,
 	}
+	if len(t.ABI) > 0 {
+		stateKeys[string(storage.ContractABIKey(t.id))] = state.Write | state.Allocate
+	}
+	return stateKeys
 }
 
 func (t *ContractPublish) Execute(
@@ -51,10 +62,21 @@ func (t *ContractPublish) Execute(
 	actor codec.Address,
 	_ ids.ID,
 ) (codec.Typed, error) {
+	if len(t.ABI) > 0 {
+		if _, err := abi.Parse(t.ABI); err != nil {
+			return nil, ErrContractABIInvalid
+		}
+	}
 	resultBytes, err := storage.StoreContract(ctx, mu, t.ContractBytes)
 	if err != nil {
 		return nil, err
 	}
+	// The ABI is stored next to the contract bytes so clients can encode calls
+	if len(t.ABI) > 0 {
+		if err := storage.StoreContractABI(ctx, mu, resultBytes, t.ABI); err != nil {
+			return nil, err
+		}
+	}
 	return &ContractPublishResult{Actor: actor.String(), Receiver: "", Value: resultBytes}, nil
 }
 
@@ -70,16 +92,18 @@ func (*ContractPublish) ValidRange(chain.Rules) (int64, int64) {
 var _ chain.Marshaler = (*ContractPublish)(nil)
 
 func (t *ContractPublish) Size() int {
-	return codec.BytesLen(t.ContractBytes)
+	return codec.BytesLen(t.ContractBytes) + codec.BytesLen(t.ABI)
 }
 
 func (t *ContractPublish) Marshal(p *codec.Packer) {
 	p.PackBytes(t.ContractBytes)
+	p.PackBytes(t.ABI)
 }
 
 func UnmarshalPublishContract(p *codec.Packer) (chain.Action, error) {
 	var publishContract ContractPublish
 	p.UnpackBytes(MAXCONTRACTSIZE, true, &publishContract.ContractBytes)
+	p.UnpackBytes(storage.MaxContractABISize, false, &publishContract.ABI)
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
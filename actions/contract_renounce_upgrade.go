@@ -0,0 +1,105 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	mconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ContractRenounceUpgradeComputeUnits = 1
+)
+
+var _ chain.Action = (*ContractRenounceUpgrade)(nil)
+
+type ContractRenounceUpgrade struct {
+	// ContractAddress is the address of the deployed contract that should
+	// become immutable
+	ContractAddress codec.Address `serialize:"true" json:"contractAddress"`
+}
+
+func (*ContractRenounceUpgrade) GetTypeID() uint8 {
+	return mconsts.ContractRenounceUpgradeID
+}
+
+func (r *ContractRenounceUpgrade) StateKeys(_ codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.ContractUpgradeAuthorityKey(r.ContractAddress)): state.Read | state.Write,
+	}
+}
+
+func (r *ContractRenounceUpgrade) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	authority, version, err := storage.GetContractUpgradeAuthorityNoController(ctx, mu, r.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if authority == codec.EmptyAddress {
+		return nil, ErrContractNotUpgradeable
+	}
+	if authority != actor {
+		return nil, ErrWrongUpgradeAuthority
+	}
+
+	// Keep the version around so the upgrade history remains discoverable
+	if err := storage.SetContractUpgradeAuthority(ctx, mu, r.ContractAddress, codec.EmptyAddress, version); err != nil {
+		return nil, err
+	}
+
+	return &ContractRenounceUpgradeResult{
+		Actor:    actor.String(),
+		Receiver: "",
+		Version:  version,
+	}, nil
+}
+
+func (*ContractRenounceUpgrade) ComputeUnits(chain.Rules) uint64 {
+	return ContractRenounceUpgradeComputeUnits
+}
+
+func (*ContractRenounceUpgrade) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalContractRenounceUpgrade(p *codec.Packer) (chain.Action, error) {
+	var renounce ContractRenounceUpgrade
+	p.UnpackAddress(&renounce.ContractAddress)
+	return &renounce, p.Err()
+}
+
+var _ codec.Typed = (*ContractRenounceUpgradeResult)(nil)
+
+type ContractRenounceUpgradeResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Version  uint64 `serialize:"true" json:"version"`
+}
+
+func (*ContractRenounceUpgradeResult) GetTypeID() uint8 {
+	return mconsts.ContractRenounceUpgradeID
+}
+
+func UnmarshalContractRenounceUpgradeResult(p *codec.Packer) (codec.Typed, error) {
+	var result ContractRenounceUpgradeResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Version = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+func TestContractRenounceUpgradeAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	addr := codectest.NewRandomAddress()
+	contractAddress := storage.GetAddressForDeploy(0, []byte("creation info"))
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NoUpgradeAuthority",
+			Actor: actor,
+			Action: &ContractRenounceUpgrade{
+				ContractAddress: contractAddress,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrContractNotUpgradeable,
+		},
+		{
+			Name:  "WrongUpgradeAuthority",
+			Actor: actor,
+			Action: &ContractRenounceUpgrade{
+				ContractAddress: contractAddress,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetContractUpgradeAuthority(context.Background(), store, contractAddress, addr, 0))
+				return store
+			}(),
+			ExpectedErr: ErrWrongUpgradeAuthority,
+		},
+		{
+			Name:  "ValidContractRenounceUpgrade",
+			Actor: actor,
+			Action: &ContractRenounceUpgrade{
+				ContractAddress: contractAddress,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetContractUpgradeAuthority(context.Background(), store, contractAddress, actor, 3))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// Check that the authority is removed while the version is kept
+				authority, version, err := storage.GetContractUpgradeAuthorityNoController(ctx, store, contractAddress)
+				require.NoError(t, err)
+				require.Equal(t, codec.EmptyAddress, authority)
+				require.Equal(t, uint64(3), version)
+			},
+			ExpectedOutputs: &ContractRenounceUpgradeResult{
+				Actor:    actor.String(),
+				Receiver: "",
+				Version:  3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
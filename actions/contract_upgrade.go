@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+
+	mconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ContractUpgradeComputeUnits = 5
+)
+
+var (
+	ErrContractNotUpgradeable              = errors.New("contract is not upgradeable")
+	ErrWrongUpgradeAuthority               = errors.New("upgrade authority is not correct")
+	ErrContractIDInvalid                   = errors.New("contract ID is invalid")
+	ErrContractIDUnchanged                 = errors.New("contract ID is unchanged")
+	ErrContractVersionInvalid              = errors.New("contract version is invalid")
+	_                         chain.Action = (*ContractUpgrade)(nil)
+)
+
+type ContractUpgrade struct {
+	// ContractAddress is the address of the deployed contract to upgrade
+	ContractAddress codec.Address `serialize:"true" json:"contractAddress"`
+
+	// ContractID of the newly published contract the account will point to
+	ContractID runtime.ContractID `serialize:"true" json:"contractID"`
+
+	// Version is the sequence number of this upgrade and must be exactly one
+	// more than the number of upgrades already applied to the contract
+	Version uint64 `serialize:"true" json:"version"`
+}
+
+func (*ContractUpgrade) GetTypeID() uint8 {
+	return mconsts.ContractUpgradeID
+}
+
+func (u *ContractUpgrade) StateKeys(_ codec.Address) state.Keys {
+	stateKey, _ := keys.Encode(storage.AccountContractKey(u.ContractAddress), 36)
+	return state.Keys{
+		string(stateKey): state.Read | state.Write,
+		string(storage.ContractUpgradeAuthorityKey(u.ContractAddress)):   state.Read | state.Write,
+		string(storage.ContractUpgradeKey(u.ContractAddress, u.Version)): state.All,
+		string(u.ContractID): state.Read,
+	}
+}
+
+func (u *ContractUpgrade) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) (codec.Typed, error) {
+	if !storage.ValidContractID(u.ContractID) {
+		return nil, ErrContractIDInvalid
+	}
+
+	authority, version, err := storage.GetContractUpgradeAuthorityNoController(ctx, mu, u.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if authority == codec.EmptyAddress {
+		return nil, ErrContractNotUpgradeable
+	}
+	if authority != actor {
+		return nil, ErrWrongUpgradeAuthority
+	}
+	if u.Version != version+1 {
+		return nil, ErrContractVersionInvalid
+	}
+
+	contractStateManager := &storage.ContractStateManager{Mutable: mu}
+	previousContractID, err := contractStateManager.GetAccountContract(ctx, u.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(previousContractID, u.ContractID) {
+		return nil, ErrContractIDUnchanged
+	}
+	// Ensure the new contract has been published
+	if _, err := contractStateManager.GetContractBytes(ctx, u.ContractID); err != nil {
+		return nil, err
+	}
+
+	// The contract state lives under a prefix derived from the account address
+	// so pointing the account to the new contract ID keeps it intact
+	if err := contractStateManager.SetAccountContract(ctx, u.ContractAddress, u.ContractID); err != nil {
+		return nil, err
+	}
+	if err := storage.SetContractUpgradeAuthority(ctx, mu, u.ContractAddress, authority, u.Version); err != nil {
+		return nil, err
+	}
+	if err := storage.SetContractUpgrade(ctx, mu, u.ContractAddress, u.Version, previousContractID, u.ContractID, actor, timestamp, actionID); err != nil {
+		return nil, err
+	}
+
+	return &ContractUpgradeResult{
+		Actor:              actor.String(),
+		Receiver:           "",
+		PreviousContractID: previousContractID,
+		ContractID:         u.ContractID,
+		Version:            u.Version,
+	}, nil
+}
+
+func (*ContractUpgrade) ComputeUnits(chain.Rules) uint64 {
+	return ContractUpgradeComputeUnits
+}
+
+func (*ContractUpgrade) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalContractUpgrade(p *codec.Packer) (chain.Action, error) {
+	var upgrade ContractUpgrade
+	p.UnpackAddress(&upgrade.ContractAddress)
+	p.UnpackBytes(storage.MaxContractIDSize, true, (*[]byte)(&upgrade.ContractID))
+	upgrade.Version = p.UnpackUint64(true)
+	return &upgrade, p.Err()
+}
+
+var _ codec.Typed = (*ContractUpgradeResult)(nil)
+
+type ContractUpgradeResult struct {
+	Actor              string             `serialize:"true" json:"actor"`
+	Receiver           string             `serialize:"true" json:"receiver"`
+	PreviousContractID runtime.ContractID `serialize:"true" json:"previousContractID"`
+	ContractID         runtime.ContractID `serialize:"true" json:"contractID"`
+	Version            uint64             `serialize:"true" json:"version"`
+}
+
+func (*ContractUpgradeResult) GetTypeID() uint8 {
+	return mconsts.ContractUpgradeID
+}
+
+func UnmarshalContractUpgradeResult(p *codec.Packer) (codec.Typed, error) {
+	var result ContractUpgradeResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	p.UnpackBytes(storage.MaxContractIDSize, true, (*[]byte)(&result.PreviousContractID))
+	p.UnpackBytes(storage.MaxContractIDSize, true, (*[]byte)(&result.ContractID))
+	result.Version = p.UnpackUint64(true)
+	return &result, p.Err()
+}
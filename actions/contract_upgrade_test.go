@@ -0,0 +1,208 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+)
+
+func TestContractUpgradeAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	addr := codectest.NewRandomAddress()
+	contractAddress := storage.GetAddressForDeploy(0, []byte("creation info"))
+	actionID := ids.GenerateTestID()
+
+	// Publishes both contract versions and deploys the first one with [authority]
+	setupState := func(authority codec.Address, version uint64) (state.Mutable, runtime.ContractID, runtime.ContractID) {
+		store := chaintest.NewInMemoryStore()
+		oldContractID, err := storage.StoreContract(context.Background(), store, []byte("old contract bytes"))
+		require.NoError(t, err)
+		newContractID, err := storage.StoreContract(context.Background(), store, []byte("new contract bytes"))
+		require.NoError(t, err)
+		require.NoError(t, (&storage.ContractStateManager{Mutable: store}).SetAccountContract(context.Background(), contractAddress, oldContractID))
+		if authority != codec.EmptyAddress || version > 0 {
+			require.NoError(t, storage.SetContractUpgradeAuthority(context.Background(), store, contractAddress, authority, version))
+		}
+		return store, oldContractID, newContractID
+	}
+
+	notUpgradeableStore, _, newContractID := setupState(codec.EmptyAddress, 0)
+	renouncedStore, _, _ := setupState(codec.EmptyAddress, 1)
+	wrongAuthorityStore, _, _ := setupState(addr, 0)
+	wrongVersionStore, _, _ := setupState(actor, 0)
+	unchangedStore, oldContractID, _ := setupState(actor, 0)
+	validStore, _, _ := setupState(actor, 0)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "InvalidContractID",
+			Actor: actor,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      []byte("invalid"),
+				Version:         1,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrContractIDInvalid,
+		},
+		{
+			Name:  "NoUpgradeAuthority",
+			Actor: actor,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      newContractID,
+				Version:         1,
+			},
+			State:       notUpgradeableStore,
+			ExpectedErr: ErrContractNotUpgradeable,
+		},
+		{
+			Name:  "UpgradeAuthorityRenounced",
+			Actor: actor,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      newContractID,
+				Version:         2,
+			},
+			State:       renouncedStore,
+			ExpectedErr: ErrContractNotUpgradeable,
+		},
+		{
+			Name:  "WrongUpgradeAuthority",
+			Actor: actor,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      newContractID,
+				Version:         1,
+			},
+			State:       wrongAuthorityStore,
+			ExpectedErr: ErrWrongUpgradeAuthority,
+		},
+		{
+			Name:  "WrongVersion",
+			Actor: actor,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      newContractID,
+				Version:         2,
+			},
+			State:       wrongVersionStore,
+			ExpectedErr: ErrContractVersionInvalid,
+		},
+		{
+			Name:  "ContractIDUnchanged",
+			Actor: actor,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      oldContractID,
+				Version:         1,
+			},
+			State:       unchangedStore,
+			ExpectedErr: ErrContractIDUnchanged,
+		},
+		{
+			Name:     "ValidContractUpgrade",
+			Actor:    actor,
+			ActionID: actionID,
+			Action: &ContractUpgrade{
+				ContractAddress: contractAddress,
+				ContractID:      newContractID,
+				Version:         1,
+			},
+			State:     validStore,
+			Timestamp: 100,
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// Check that the account now points to the new contract
+				contractID, err := (&storage.ContractStateManager{Mutable: store}).GetAccountContract(ctx, contractAddress)
+				require.NoError(t, err)
+				require.Equal(t, newContractID, contractID)
+
+				// Check that the authority is kept and the version is bumped
+				authority, version, err := storage.GetContractUpgradeAuthorityNoController(ctx, store, contractAddress)
+				require.NoError(t, err)
+				require.Equal(t, actor, authority)
+				require.Equal(t, uint64(1), version)
+
+				// Check that the upgrade was recorded
+				previousContractID, upgradedContractID, upgradedBy, timestamp, upgradeActionID, err := storage.GetContractUpgradeNoController(ctx, store, contractAddress, 1)
+				require.NoError(t, err)
+				require.Equal(t, oldContractID, previousContractID)
+				require.Equal(t, newContractID, upgradedContractID)
+				require.Equal(t, actor, upgradedBy)
+				require.Equal(t, int64(100), timestamp)
+				require.Equal(t, actionID, upgradeActionID)
+			},
+			ExpectedOutputs: &ContractUpgradeResult{
+				Actor:              actor.String(),
+				Receiver:           "",
+				PreviousContractID: oldContractID,
+				ContractID:         newContractID,
+				Version:            1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func BenchmarkContractUpgrade(b *testing.B) {
+	require := require.New(b)
+	actor := codectest.NewRandomAddress()
+	contractAddress := storage.GetAddressForDeploy(0, []byte("creation info"))
+	oldContractBytes := []byte("old contract bytes")
+	newContractBytes := []byte("new contract bytes")
+	oldContractID, err := storage.StoreContract(context.Background(), chaintest.NewInMemoryStore(), oldContractBytes)
+	require.NoError(err)
+	newContractID, err := storage.StoreContract(context.Background(), chaintest.NewInMemoryStore(), newContractBytes)
+	require.NoError(err)
+
+	contractUpgradeBenchmark := &chaintest.ActionBenchmark{
+		Name:  "ContractUpgradeBenchmark",
+		Actor: actor,
+		Action: &ContractUpgrade{
+			ContractAddress: contractAddress,
+			ContractID:      newContractID,
+			Version:         1,
+		},
+		ExpectedOutput: &ContractUpgradeResult{
+			Actor:              actor.String(),
+			Receiver:           "",
+			PreviousContractID: oldContractID,
+			ContractID:         newContractID,
+			Version:            1,
+		},
+		CreateState: func() state.Mutable {
+			store := chaintest.NewInMemoryStore()
+			_, err := storage.StoreContract(context.Background(), store, oldContractBytes)
+			require.NoError(err)
+			_, err = storage.StoreContract(context.Background(), store, newContractBytes)
+			require.NoError(err)
+			require.NoError((&storage.ContractStateManager{Mutable: store}).SetAccountContract(context.Background(), contractAddress, oldContractID))
+			require.NoError(storage.SetContractUpgradeAuthority(context.Background(), store, contractAddress, actor, 0))
+			return store
+		},
+		Assertion: func(ctx context.Context, b *testing.B, store state.Mutable) {
+			// Check that the account now points to the new contract
+			contractID, err := (&storage.ContractStateManager{Mutable: store}).GetAccountContract(ctx, contractAddress)
+			require.NoError(err)
+			require.Equal(runtime.ContractID(newContractID), contractID)
+		},
+	}
+
+	ctx := context.Background()
+	contractUpgradeBenchmark.Run(ctx, b)
+}
@@ -30,6 +30,7 @@ var (
 	ErrDecimalsInvalid               = errors.New("decimals is invalid")
 	ErrMetadataInvalid               = errors.New("metadata is invalid")
 	ErrURIInvalid                    = errors.New("uri is invalid")
+	ErrRoyaltyInvalid                = errors.New("royalty is invalid")
 	_                   chain.Action = (*CreateAsset)(nil)
 )
 
@@ -63,6 +64,16 @@ type CreateAsset struct {
 
 	// The wallet address that can enable/disable KYC account flag
 	EnableDisableKYCAccountAdmin codec.Address `serialize:"true" json:"enable_disable_kyc_account_admin"`
+
+	// Royalty paid to the creator of a non-fungible or dataset collection,
+	// in basis points of every sale of its tokens. Zero disables royalties.
+	RoyaltyBasisPoints uint64 `serialize:"true" json:"royalty_basis_points"`
+
+	// The wallet address that receives the royalties
+	RoyaltyRecipient codec.Address `serialize:"true" json:"royalty_recipient"`
+
+	// Sales of the collection must be paid in this fungible asset
+	RoyaltyAssetAddress codec.Address `serialize:"true" json:"royalty_asset_address"`
 }
 
 func (*CreateAsset) GetTypeID() uint8 {
@@ -75,6 +86,10 @@ func (c *CreateAsset) StateKeys(actor codec.Address) state.Keys {
 		string(storage.AssetInfoKey(assetAddress)):                  state.All,
 		string(storage.AssetAccountBalanceKey(assetAddress, actor)): state.Allocate | state.Write,
 	}
+	if c.RoyaltyBasisPoints > 0 {
+		stateKeys[string(storage.RoyaltyKey(assetAddress))] = state.Allocate | state.Write
+		stateKeys[string(storage.AssetInfoKey(c.RoyaltyAssetAddress))] = state.Read
+	}
 
 	// Check if c.AssetType is a dataset type so we
 	// can create the NFT ID
@@ -115,6 +130,20 @@ func (c *CreateAsset) Execute(
 		return nil, ErrMetadataInvalid
 	}
 
+	if c.RoyaltyBasisPoints > 0 {
+		if c.AssetType == nconsts.AssetFungibleTokenID || c.RoyaltyBasisPoints > storage.MaxRoyaltyBasisPoints || c.RoyaltyRecipient == codec.EmptyAddress {
+			return nil, ErrRoyaltyInvalid
+		}
+		// Royalties are paid in any amount so the payment asset must be fungible
+		paymentAssetType, _, _, _, _, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, c.RoyaltyAssetAddress)
+		if err != nil {
+			return nil, ErrAssetDoesNotExist
+		}
+		if paymentAssetType == nconsts.AssetNonFungibleTokenID {
+			return nil, ErrRoyaltyInvalid
+		}
+	}
+
 	mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin := codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress
 	if c.MintAdmin != mintAdmin {
 		mintAdmin = c.MintAdmin
@@ -145,6 +174,11 @@ func (c *CreateAsset) Execute(
 	if err := storage.SetAssetInfo(ctx, mu, assetAddress, c.AssetType, []byte(c.Name), []byte(c.Symbol), c.Decimals, []byte(c.Metadata), []byte(assetAddress.String()), 0, c.MaxSupply, actor, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin); err != nil {
 		return nil, err
 	}
+	if c.RoyaltyBasisPoints > 0 {
+		if err := storage.SetRoyalty(ctx, mu, assetAddress, c.RoyaltyBasisPoints, c.RoyaltyRecipient, c.RoyaltyAssetAddress); err != nil {
+			return nil, err
+		}
+	}
 
 	if c.AssetType == nconsts.AssetFractionalTokenID {
 		amountOfToken := uint64(1)
@@ -179,6 +213,14 @@ func (*CreateAsset) ValidRange(chain.Rules) (int64, int64) {
 	return -1, -1
 }
 
+// unpackAddressOrEmpty reads an address like [codec.Packer.UnpackAddress]
+// but also accepts the empty address. The address is always packed in full.
+func unpackAddressOrEmpty(p *codec.Packer, dest *codec.Address) {
+	b := make([]byte, codec.AddressLen)
+	p.UnpackFixedBytes(codec.AddressLen, &b)
+	copy(dest[:], b)
+}
+
 func UnmarshalCreateAsset(p *codec.Packer) (chain.Action, error) {
 	var create CreateAsset
 	create.AssetType = p.UnpackByte()
@@ -191,6 +233,9 @@ func UnmarshalCreateAsset(p *codec.Packer) (chain.Action, error) {
 	p.UnpackAddress(&create.PauseUnpauseAdmin)
 	p.UnpackAddress(&create.FreezeUnfreezeAdmin)
 	p.UnpackAddress(&create.EnableDisableKYCAccountAdmin)
+	create.RoyaltyBasisPoints = p.UnpackUint64(false)
+	unpackAddressOrEmpty(p, &create.RoyaltyRecipient)
+	unpackAddressOrEmpty(p, &create.RoyaltyAssetAddress)
 	return &create, p.Err()
 }
 
@@ -179,6 +179,70 @@ func TestCreateAssetAction(t *testing.T) {
 				AssetBalance: 0,
 			},
 		},
+		{
+			Name:  "RoyaltyOnFungibleToken",
+			Actor: actor,
+			Action: &CreateAsset{
+				AssetType:           nconsts.AssetFungibleTokenID,
+				Name:                "name",
+				Symbol:              "SYM",
+				Decimals:            9,
+				Metadata:            "metadata",
+				RoyaltyBasisPoints:  250,
+				RoyaltyRecipient:    actor,
+				RoyaltyAssetAddress: storage.NAIAddress,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrRoyaltyInvalid,
+		},
+		{
+			Name:  "RoyaltyAboveMax",
+			Actor: actor,
+			Action: &CreateAsset{
+				AssetType:           nconsts.AssetNonFungibleTokenID,
+				Name:                "name",
+				Symbol:              "SYM",
+				Metadata:            "metadata",
+				RoyaltyBasisPoints:  storage.MaxRoyaltyBasisPoints + 1,
+				RoyaltyRecipient:    actor,
+				RoyaltyAssetAddress: storage.NAIAddress,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrRoyaltyInvalid,
+		},
+		{
+			Name:  "ValidNonFungibleTokenWithRoyalty",
+			Actor: actor,
+			Action: &CreateAsset{
+				AssetType:           nconsts.AssetNonFungibleTokenID,
+				Name:                "name",
+				Symbol:              "SYM",
+				Metadata:            "metadata",
+				MaxSupply:           10,
+				RoyaltyBasisPoints:  250,
+				RoyaltyRecipient:    actor,
+				RoyaltyAssetAddress: storage.NAIAddress,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, storage.NAIAddress, nconsts.AssetFungibleTokenID, []byte(nconsts.Name), []byte(nconsts.Symbol), nconsts.Decimals, []byte(nconsts.Metadata), nil, 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, basisPoints, recipient, paymentAsset, err := storage.GetRoyaltyNoController(ctx, store, assetNFT)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, uint64(250), basisPoints)
+				require.Equal(t, actor, recipient)
+				require.Equal(t, storage.NAIAddress, paymentAsset)
+			},
+			ExpectedOutputs: &CreateAssetResult{
+				Actor:        actor.String(),
+				Receiver:     "",
+				AssetAddress: assetNFT.String(),
+				AssetBalance: 0,
+			},
+		},
 		{
 			Name:  "ValidFractionalTokenCreation",
 			Actor: actor,
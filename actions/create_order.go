@@ -0,0 +1,206 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	CreateOrderComputeUnits = 5
+)
+
+var (
+	ErrOrderAssetsInvalid                = errors.New("order assets are invalid")
+	ErrOrderTickZero                     = errors.New("order tick is zero")
+	ErrOrderSupplyInvalid                = errors.New("order supply must be a non zero multiple of the in tick")
+	ErrRoyaltyAssetMismatch              = errors.New("order must be paid in the royalty asset of the collection")
+	_                       chain.Action = (*CreateOrder)(nil)
+)
+
+type CreateOrder struct {
+	// InAssetAddress is the asset the actor locks and sells
+	InAssetAddress codec.Address `serialize:"true" json:"in_asset_address"`
+
+	// InCollectionAddress is the collection of [InAssetAddress] when an NFT
+	// is sold and is empty otherwise. It is needed to know the state keys of
+	// the order.
+	InCollectionAddress codec.Address `serialize:"true" json:"in_collection_address"`
+
+	// InTick is the amount of [InAssetAddress] sold for every [OutTick]
+	InTick uint64 `serialize:"true" json:"in_tick"`
+
+	// OutAssetAddress is the asset the actor receives from takers
+	OutAssetAddress codec.Address `serialize:"true" json:"out_asset_address"`
+
+	// OutTick is the amount of [OutAssetAddress] a taker pays for every
+	// [InTick]
+	OutTick uint64 `serialize:"true" json:"out_tick"`
+
+	// Supply of [InAssetAddress] to lock. It must be a multiple of [InTick].
+	// Creating an order that is already open adds [Supply] to it.
+	Supply uint64 `serialize:"true" json:"supply"`
+}
+
+func (*CreateOrder) GetTypeID() uint8 {
+	return nconsts.CreateOrderID
+}
+
+func (c *CreateOrder) StateKeys(actor codec.Address) state.Keys {
+	orderAddress := storage.OrderAddress(actor, c.InAssetAddress, c.InTick, c.OutAssetAddress, c.OutTick)
+	stateKeys := state.Keys{
+		string(storage.OrderKey(orderAddress)):                                                      state.All,
+		string(storage.AssetInfoKey(c.InAssetAddress)):                                              state.Read,
+		string(storage.AssetInfoKey(c.OutAssetAddress)):                                             state.Read,
+		string(storage.AssetAccountBalanceKey(c.InAssetAddress, actor)):                             state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(c.InAssetAddress, orderAddress)):                      state.All,
+		string(storage.RoyaltyKey(orderRoyaltyCollection(c.InAssetAddress, c.InCollectionAddress))): state.Read,
+	}
+	if c.InCollectionAddress != codec.EmptyAddress {
+		storage.AddNFTTransferStateKeys(stateKeys, c.InAssetAddress, c.InCollectionAddress, actor, orderAddress)
+	}
+	return stateKeys
+}
+
+func (c *CreateOrder) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if c.InAssetAddress == c.OutAssetAddress {
+		return nil, ErrOrderAssetsInvalid
+	}
+	if c.InTick == 0 || c.OutTick == 0 {
+		return nil, ErrOrderTickZero
+	}
+	if c.Supply == 0 || c.Supply%c.InTick != 0 {
+		return nil, ErrOrderSupplyInvalid
+	}
+	if _, err := checkOrderAsset(ctx, mu, c.InAssetAddress, c.InCollectionAddress); err != nil {
+		return nil, err
+	}
+	outAssetType, err := checkOrderAsset(ctx, mu, c.OutAssetAddress, codec.EmptyAddress)
+	if err != nil {
+		return nil, err
+	}
+	if outAssetType == nconsts.AssetNonFungibleTokenID {
+		return nil, ErrOrderAssetsInvalid
+	}
+
+	// Sales of a collection with royalties must be paid in its royalty asset
+	royaltyExists, _, _, royaltyAsset, err := storage.GetRoyaltyNoController(ctx, mu, orderRoyaltyCollection(c.InAssetAddress, c.InCollectionAddress))
+	if err != nil {
+		return nil, err
+	}
+	if royaltyExists && royaltyAsset != c.OutAssetAddress {
+		return nil, ErrRoyaltyAssetMismatch
+	}
+
+	// Check that balance is sufficient
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, c.InAssetAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < c.Supply {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	orderAddress, err := storage.SetOrder(ctx, mu, actor, c.InAssetAddress, c.InTick, c.OutAssetAddress, c.OutTick)
+	if err != nil {
+		return nil, err
+	}
+	_, remaining, err := storage.TransferAsset(ctx, mu, c.InAssetAddress, actor, orderAddress, c.Supply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateOrderResult{
+		Actor:     actor.String(),
+		Receiver:  orderAddress.String(),
+		Remaining: remaining,
+	}, nil
+}
+
+// checkOrderAsset checks that [assetAddress] exists and that
+// [collectionAddress] is its collection if it is an NFT or empty otherwise. It
+// returns the type of the asset.
+func checkOrderAsset(ctx context.Context, im state.Immutable, assetAddress codec.Address, collectionAddress codec.Address) (uint8, error) {
+	assetType, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, im, assetAddress)
+	if err != nil {
+		return 0, ErrAssetDoesNotExist
+	}
+	if assetType != nconsts.AssetNonFungibleTokenID {
+		if collectionAddress != codec.EmptyAddress {
+			return 0, ErrOrderAssetsInvalid
+		}
+		return assetType, nil
+	}
+	if collectionAddress == codec.EmptyAddress || string(uri) != collectionAddress.String() {
+		return 0, ErrOrderAssetsInvalid
+	}
+	return assetType, nil
+}
+
+// orderRoyaltyCollection returns the collection whose royalty applies to the
+// sales of [in]. NFTs belong to [inCollection] while dataset tokens carry
+// their own royalty.
+func orderRoyaltyCollection(in codec.Address, inCollection codec.Address) codec.Address {
+	if inCollection != codec.EmptyAddress {
+		return inCollection
+	}
+	return in
+}
+
+func (*CreateOrder) ComputeUnits(chain.Rules) uint64 {
+	return CreateOrderComputeUnits
+}
+
+func (*CreateOrder) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalCreateOrder(p *codec.Packer) (chain.Action, error) {
+	var create CreateOrder
+	p.UnpackAddress(&create.InAssetAddress)
+	unpackAddressOrEmpty(p, &create.InCollectionAddress)
+	create.InTick = p.UnpackUint64(true)
+	p.UnpackAddress(&create.OutAssetAddress)
+	create.OutTick = p.UnpackUint64(true)
+	create.Supply = p.UnpackUint64(true)
+	return &create, p.Err()
+}
+
+var _ codec.Typed = (*CreateOrderResult)(nil)
+
+type CreateOrderResult struct {
+	Actor     string `serialize:"true" json:"actor"`
+	Receiver  string `serialize:"true" json:"receiver"`
+	Remaining uint64 `serialize:"true" json:"remaining"`
+}
+
+func (*CreateOrderResult) GetTypeID() uint8 {
+	return nconsts.CreateOrderID
+}
+
+func UnmarshalCreateOrderResult(p *codec.Packer) (codec.Typed, error) {
+	var result CreateOrderResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Remaining = p.UnpackUint64(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newOrderStore sets up [assetAddress] and NAI with a balance of 1000 of
+// [assetAddress] for [actor]
+func newOrderStore(t *testing.T, actor codec.Address, assetAddress codec.Address) state.Mutable {
+	store := chaintest.NewInMemoryStore()
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, storage.NAIAddress, nconsts.AssetFungibleTokenID, []byte(nconsts.Name), []byte(nconsts.Symbol), nconsts.Decimals, []byte(nconsts.Metadata), nil, 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 1000, 0, actor, actor, actor, actor, actor))
+	require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, actor, 1000))
+	return store
+}
+
+func TestCreateOrderAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+	nftAddress := storage.AssetAddress(nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT"), 0, []byte("metadata"), actor)
+	orderAddress := storage.OrderAddress(actor, assetAddress, 10, storage.NAIAddress, 3)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "SameAssets",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: assetAddress,
+				OutTick:         3,
+				Supply:          100,
+			},
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: ErrOrderAssetsInvalid,
+		},
+		{
+			Name:  "TickZero",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				OutAssetAddress: storage.NAIAddress,
+				OutTick:         3,
+				Supply:          100,
+			},
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: ErrOrderTickZero,
+		},
+		{
+			Name:  "SupplyNotMultipleOfTick",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: storage.NAIAddress,
+				OutTick:         3,
+				Supply:          105,
+			},
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: ErrOrderSupplyInvalid,
+		},
+		{
+			Name:  "NFTNotAllowed",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: nftAddress,
+				OutTick:         1,
+				Supply:          100,
+			},
+			State: func() state.Mutable {
+				store := newOrderStore(t, actor, assetAddress)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT"), 0, []byte("metadata"), []byte("uri"), 0, 0, actor, actor, actor, actor, actor))
+				return store
+			}(),
+			ExpectedErr: ErrOrderAssetsInvalid,
+		},
+		{
+			Name:  "InsufficientBalance",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: storage.NAIAddress,
+				OutTick:         3,
+				Supply:          2000,
+			},
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ValidOrder",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: storage.NAIAddress,
+				OutTick:         3,
+				Supply:          100,
+			},
+			State: newOrderStore(t, actor, assetAddress),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, in, inTick, out, outTick, owner, err := storage.GetOrderNoController(ctx, store, orderAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, assetAddress, in)
+				require.Equal(t, uint64(10), inTick)
+				require.Equal(t, storage.NAIAddress, out)
+				require.Equal(t, uint64(3), outTick)
+				require.Equal(t, actor, owner)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(900), balance)
+			},
+			ExpectedOutputs: &CreateOrderResult{
+				Actor:     actor.String(),
+				Receiver:  orderAddress.String(),
+				Remaining: 100,
+			},
+		},
+		{
+			Name:  "TopUpOrder",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: storage.NAIAddress,
+				OutTick:         3,
+				Supply:          100,
+			},
+			State: func() state.Mutable {
+				store := newOrderStore(t, actor, assetAddress)
+				_, err := storage.SetOrder(context.Background(), store, actor, assetAddress, 10, storage.NAIAddress, 3)
+				require.NoError(t, err)
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, orderAddress, 50))
+				return store
+			}(),
+			ExpectedOutputs: &CreateOrderResult{
+				Actor:     actor.String(),
+				Receiver:  orderAddress.String(),
+				Remaining: 150,
+			},
+		},
+		{
+			Name:  "RoyaltyAssetMismatch",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:  assetAddress,
+				InTick:          10,
+				OutAssetAddress: storage.NAIAddress,
+				OutTick:         3,
+				Supply:          100,
+			},
+			State: func() state.Mutable {
+				store := newOrderStore(t, actor, assetAddress)
+				require.NoError(t, storage.SetRoyalty(context.Background(), store, assetAddress, 100, actor, nftAddress))
+				return store
+			}(),
+			ExpectedErr: ErrRoyaltyAssetMismatch,
+		},
+		{
+			Name:  "NFTCollectionMismatch",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:      nftAddress,
+				InCollectionAddress: assetAddress,
+				InTick:              1,
+				OutAssetAddress:     storage.NAIAddress,
+				OutTick:             50,
+				Supply:              1,
+			},
+			State: func() state.Mutable {
+				store := newOrderStore(t, actor, assetAddress)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT"), 0, []byte("metadata"), []byte("uri"), 0, 0, actor, actor, actor, actor, actor))
+				return store
+			}(),
+			ExpectedErr: ErrOrderAssetsInvalid,
+		},
+		{
+			Name:  "ValidNFTOrder",
+			Actor: actor,
+			Action: &CreateOrder{
+				InAssetAddress:      nftAddress,
+				InCollectionAddress: assetAddress,
+				InTick:              1,
+				OutAssetAddress:     storage.NAIAddress,
+				OutTick:             50,
+				Supply:              1,
+			},
+			State: func() state.Mutable {
+				store := newOrderStore(t, actor, assetAddress)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT"), 0, []byte("metadata"), []byte(assetAddress.String()), 1, 1, actor, actor, actor, actor, actor))
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, actor, 1))
+				require.NoError(t, storage.SetRoyalty(context.Background(), store, assetAddress, 100, actor, storage.NAIAddress))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The NFT is moved to the order along with its collection balance
+				nftOrderAddress := storage.OrderAddress(actor, nftAddress, 1, storage.NAIAddress, 50)
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, nftOrderAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, nftOrderAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(999), balance)
+			},
+			ExpectedOutputs: &CreateOrderResult{
+				Actor:     actor.String(),
+				Receiver:  storage.OrderAddress(actor, nftAddress, 1, storage.NAIAddress, 50).String(),
+				Remaining: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestCreateOrderMarshal(t *testing.T) {
+	require := require.New(t)
+
+	// Orders of fungible assets leave the collection empty
+	create := &CreateOrder{
+		InAssetAddress:  codectest.NewRandomAddress(),
+		InTick:          1,
+		OutAssetAddress: codectest.NewRandomAddress(),
+		OutTick:         2,
+		Supply:          10,
+	}
+	b, err := chain.Marshal(create)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalCreateOrder(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(create, unmarshalled)
+
+	create.InCollectionAddress = codectest.NewRandomAddress()
+	b, err = chain.Marshal(create)
+	require.NoError(err)
+	unmarshalled, err = UnmarshalCreateOrder(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(create, unmarshalled)
+}
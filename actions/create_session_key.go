@@ -0,0 +1,166 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	CreateSessionKeyComputeUnits = 5
+)
+
+var (
+	ErrSessionKeyInvalid                         = errors.New("session key is invalid")
+	ErrSessionKeyActionTypesInvalid              = errors.New("session key action types are invalid")
+	ErrSessionKeySpendLimitsInvalid              = errors.New("session key spend limits are invalid")
+	ErrSessionKeyExpiryInvalid                   = errors.New("session key expiry block is invalid")
+	_                               chain.Action = (*CreateSessionKey)(nil)
+)
+
+// SessionKeySpendLimit is the maximum amount of [AssetAddress] a session key
+// can move out of the account
+type SessionKeySpendLimit struct {
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+	Limit        uint64        `serialize:"true" json:"limit"`
+}
+
+type CreateSessionKey struct {
+	// SessionKey is the address of the secondary key allowed to act for the
+	// actor. Creating a session key that already exists replaces it.
+	SessionKey codec.Address `serialize:"true" json:"session_key"`
+
+	// Action type IDs the session key is allowed to use
+	ActionTypeIDs []byte `serialize:"true" json:"action_type_ids"`
+
+	// Assets the session key can spend. Assets that are not listed can't be
+	// spent by the session key.
+	SpendLimits []SessionKeySpendLimit `serialize:"true" json:"spend_limits"`
+
+	// Last block at which the session key can be used
+	ExpiryBlock uint64 `serialize:"true" json:"expiry_block"`
+}
+
+func (*CreateSessionKey) GetTypeID() uint8 {
+	return nconsts.CreateSessionKeyID
+}
+
+func (c *CreateSessionKey) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.SessionKeyKey(actor, c.SessionKey)): state.All,
+	}
+}
+
+func (c *CreateSessionKey) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if c.SessionKey == codec.EmptyAddress || c.SessionKey == actor {
+		return nil, ErrSessionKeyInvalid
+	}
+	if len(c.ActionTypeIDs) == 0 || len(c.ActionTypeIDs) > storage.MaxSessionKeyActionTypes {
+		return nil, ErrSessionKeyActionTypesInvalid
+	}
+	seenActionTypeIDs := make(map[uint8]struct{}, len(c.ActionTypeIDs))
+	for _, actionTypeID := range c.ActionTypeIDs {
+		// Session keys must never be able to manage session keys themselves
+		switch actionTypeID {
+		case nconsts.CreateSessionKeyID, nconsts.RevokeSessionKeyID, nconsts.SessionKeyBeginID, nconsts.SessionKeyEndID:
+			return nil, ErrSessionKeyActionTypesInvalid
+		}
+		if _, ok := seenActionTypeIDs[actionTypeID]; ok {
+			return nil, ErrSessionKeyActionTypesInvalid
+		}
+		seenActionTypeIDs[actionTypeID] = struct{}{}
+	}
+	if len(c.SpendLimits) > storage.MaxSessionKeySpendLimits {
+		return nil, ErrSessionKeySpendLimitsInvalid
+	}
+	spendLimits := make([]storage.SessionKeySpendLimit, len(c.SpendLimits))
+	seenAssets := make(map[codec.Address]struct{}, len(c.SpendLimits))
+	for i, spendLimit := range c.SpendLimits {
+		if _, ok := seenAssets[spendLimit.AssetAddress]; ok {
+			return nil, ErrSessionKeySpendLimitsInvalid
+		}
+		seenAssets[spendLimit.AssetAddress] = struct{}{}
+		spendLimits[i] = storage.SessionKeySpendLimit{
+			AssetAddress: spendLimit.AssetAddress,
+			Limit:        spendLimit.Limit,
+		}
+	}
+	if c.ExpiryBlock <= emission.GetEmission().GetLastAcceptedBlockHeight() {
+		return nil, ErrSessionKeyExpiryInvalid
+	}
+
+	if err := storage.SetSessionKey(ctx, mu, actor, c.SessionKey, c.ExpiryBlock, c.ActionTypeIDs, spendLimits); err != nil {
+		return nil, err
+	}
+
+	return &CreateSessionKeyResult{
+		Actor:       actor.String(),
+		Receiver:    c.SessionKey.String(),
+		ExpiryBlock: c.ExpiryBlock,
+	}, nil
+}
+
+func (*CreateSessionKey) ComputeUnits(chain.Rules) uint64 {
+	return CreateSessionKeyComputeUnits
+}
+
+func (*CreateSessionKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalCreateSessionKey(p *codec.Packer) (chain.Action, error) {
+	var create CreateSessionKey
+	p.UnpackAddress(&create.SessionKey)
+	p.UnpackBytes(storage.MaxSessionKeyActionTypes, true, &create.ActionTypeIDs)
+	numSpendLimits := int(p.UnpackInt(false))
+	if numSpendLimits > storage.MaxSessionKeySpendLimits {
+		return nil, ErrSessionKeySpendLimitsInvalid
+	}
+	create.SpendLimits = make([]SessionKeySpendLimit, numSpendLimits)
+	for i := range create.SpendLimits {
+		p.UnpackAddress(&create.SpendLimits[i].AssetAddress)
+		create.SpendLimits[i].Limit = p.UnpackUint64(false)
+	}
+	create.ExpiryBlock = p.UnpackUint64(true)
+	return &create, p.Err()
+}
+
+var _ codec.Typed = (*CreateSessionKeyResult)(nil)
+
+type CreateSessionKeyResult struct {
+	Actor       string `serialize:"true" json:"actor"`
+	Receiver    string `serialize:"true" json:"receiver"`
+	ExpiryBlock uint64 `serialize:"true" json:"expiry_block"`
+}
+
+func (*CreateSessionKeyResult) GetTypeID() uint8 {
+	return nconsts.CreateSessionKeyID
+}
+
+func UnmarshalCreateSessionKeyResult(p *codec.Packer) (codec.Typed, error) {
+	var result CreateSessionKeyResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.ExpiryBlock = p.UnpackUint64(true)
+	return &result, p.Err()
+}
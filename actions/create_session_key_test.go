@@ -0,0 +1,145 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestCreateSessionKeyAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	actor := codectest.NewRandomAddress()
+	sessionKey := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "EmptySessionKey",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    codec.EmptyAddress,
+				ActionTypeIDs: []byte{nconsts.TransferID},
+				ExpiryBlock:   200,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyInvalid,
+		},
+		{
+			Name:  "SessionKeyIsActor",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    actor,
+				ActionTypeIDs: []byte{nconsts.TransferID},
+				ExpiryBlock:   200,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyInvalid,
+		},
+		{
+			Name:  "NoActionTypes",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:  sessionKey,
+				ExpiryBlock: 200,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyActionTypesInvalid,
+		},
+		{
+			Name:  "DuplicateActionType",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    sessionKey,
+				ActionTypeIDs: []byte{nconsts.TransferID, nconsts.TransferID},
+				ExpiryBlock:   200,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyActionTypesInvalid,
+		},
+		{
+			Name:  "SessionKeyActionType",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    sessionKey,
+				ActionTypeIDs: []byte{nconsts.TransferID, nconsts.CreateSessionKeyID},
+				ExpiryBlock:   200,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyActionTypesInvalid,
+		},
+		{
+			Name:  "DuplicateSpendLimit",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    sessionKey,
+				ActionTypeIDs: []byte{nconsts.TransferID},
+				SpendLimits: []SessionKeySpendLimit{
+					{AssetAddress: assetAddress, Limit: 10},
+					{AssetAddress: assetAddress, Limit: 20},
+				},
+				ExpiryBlock: 200,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeySpendLimitsInvalid,
+		},
+		{
+			Name:  "ExpiryInPast",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    sessionKey,
+				ActionTypeIDs: []byte{nconsts.TransferID},
+				ExpiryBlock:   100,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyExpiryInvalid,
+		},
+		{
+			Name:  "ValidSessionKey",
+			Actor: actor,
+			Action: &CreateSessionKey{
+				SessionKey:    sessionKey,
+				ActionTypeIDs: []byte{nconsts.TransferID, nconsts.MintAssetFTID},
+				SpendLimits: []SessionKeySpendLimit{
+					{AssetAddress: storage.NAIAddress, Limit: 1000},
+					{AssetAddress: assetAddress, Limit: 10},
+				},
+				ExpiryBlock: 200,
+			},
+			State: chaintest.NewInMemoryStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, expiryBlock, actionTypeIDs, spendLimits, err := storage.GetSessionKeyNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, uint64(200), expiryBlock)
+				require.Equal(t, []byte{nconsts.TransferID, nconsts.MintAssetFTID}, actionTypeIDs)
+				require.Equal(t, []storage.SessionKeySpendLimit{
+					{AssetAddress: storage.NAIAddress, Limit: 1000},
+					{AssetAddress: assetAddress, Limit: 10},
+				}, spendLimits)
+			},
+			ExpectedOutputs: &CreateSessionKeyResult{
+				Actor:       actor.String(),
+				Receiver:    sessionKey.String(),
+				ExpiryBlock: 200,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
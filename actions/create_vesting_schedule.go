@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	CreateVestingScheduleComputeUnits = 5
+)
+
+var (
+	ErrVestingScheduleInvalid              = errors.New("vesting schedule is invalid")
+	ErrVestingScheduleExists               = errors.New("vesting schedule already exists")
+	_                         chain.Action = (*CreateVestingSchedule)(nil)
+)
+
+type CreateVestingSchedule struct {
+	// AssetAddress to lock
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Beneficiary can claim the amount as it unlocks
+	Beneficiary codec.Address `serialize:"true" json:"beneficiary"`
+
+	// Amount locked by the actor
+	Amount uint64 `serialize:"true" json:"amount"`
+
+	// StartBlock is the block from which the amount is released linearly
+	StartBlock uint64 `serialize:"true" json:"start_block"`
+
+	// Nothing can be claimed before CliffBlock. A cliff at [EndBlock] locks
+	// the whole amount until then.
+	CliffBlock uint64 `serialize:"true" json:"cliff_block"`
+
+	// EndBlock is the block at which the whole amount is unlocked
+	EndBlock uint64 `serialize:"true" json:"end_block"`
+
+	// Optional account that can revoke the schedule and take back what has not
+	// vested yet. The empty address makes the schedule irrevocable.
+	RevocationAuthority codec.Address `serialize:"true" json:"revocation_authority"`
+}
+
+func (*CreateVestingSchedule) GetTypeID() uint8 {
+	return nconsts.CreateVestingScheduleID
+}
+
+func (c *CreateVestingSchedule) StateKeys(actor codec.Address) state.Keys {
+	vestingAddress := c.vestingAddress(actor)
+	return state.Keys{
+		string(storage.VestingScheduleKey(vestingAddress)):                     state.All,
+		string(storage.AssetInfoKey(c.AssetAddress)):                           state.Read,
+		string(storage.AssetAccountBalanceKey(c.AssetAddress, actor)):          state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(c.AssetAddress, vestingAddress)): state.All,
+	}
+}
+
+func (c *CreateVestingSchedule) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if c.Beneficiary == codec.EmptyAddress {
+		return nil, ErrVestingScheduleInvalid
+	}
+	if c.Amount == 0 {
+		return nil, ErrValueZero
+	}
+	if c.StartBlock >= c.EndBlock || c.CliffBlock < c.StartBlock || c.CliffBlock > c.EndBlock {
+		return nil, ErrVestingScheduleInvalid
+	}
+	// Check that asset exists and can be released in parts
+	assetType, _, _, _, _, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, c.AssetAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	if assetType == nconsts.AssetNonFungibleTokenID {
+		return nil, ErrVestingScheduleInvalid
+	}
+
+	vestingAddress := c.vestingAddress(actor)
+	exists, _, _, _, _, _, _, _, _, _, err := storage.GetVestingScheduleNoController(ctx, mu, vestingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrVestingScheduleExists
+	}
+
+	// Check that balance is sufficient
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, c.AssetAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < c.Amount {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	if err := storage.SetVestingSchedule(ctx, mu, vestingAddress, c.AssetAddress, c.Beneficiary, actor, c.RevocationAuthority, c.Amount, 0, c.StartBlock, c.CliffBlock, c.EndBlock); err != nil {
+		return nil, err
+	}
+	if _, _, err := storage.TransferAsset(ctx, mu, c.AssetAddress, actor, vestingAddress, c.Amount); err != nil {
+		return nil, err
+	}
+
+	return &CreateVestingScheduleResult{
+		Actor:          actor.String(),
+		Receiver:       c.Beneficiary.String(),
+		VestingAddress: vestingAddress.String(),
+		Amount:         c.Amount,
+	}, nil
+}
+
+func (c *CreateVestingSchedule) vestingAddress(actor codec.Address) codec.Address {
+	return storage.VestingAddress(actor, c.AssetAddress, c.Beneficiary, c.RevocationAuthority, c.Amount, c.StartBlock, c.CliffBlock, c.EndBlock)
+}
+
+func (*CreateVestingSchedule) ComputeUnits(chain.Rules) uint64 {
+	return CreateVestingScheduleComputeUnits
+}
+
+func (*CreateVestingSchedule) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalCreateVestingSchedule(p *codec.Packer) (chain.Action, error) {
+	var create CreateVestingSchedule
+	p.UnpackAddress(&create.AssetAddress)
+	p.UnpackAddress(&create.Beneficiary)
+	create.Amount = p.UnpackUint64(true)
+	create.StartBlock = p.UnpackUint64(false)
+	create.CliffBlock = p.UnpackUint64(false)
+	create.EndBlock = p.UnpackUint64(true)
+	unpackAddressOrEmpty(p, &create.RevocationAuthority)
+	return &create, p.Err()
+}
+
+var _ codec.Typed = (*CreateVestingScheduleResult)(nil)
+
+type CreateVestingScheduleResult struct {
+	Actor          string `serialize:"true" json:"actor"`
+	Receiver       string `serialize:"true" json:"receiver"`
+	VestingAddress string `serialize:"true" json:"vesting_address"`
+	Amount         uint64 `serialize:"true" json:"amount"`
+}
+
+func (*CreateVestingScheduleResult) GetTypeID() uint8 {
+	return nconsts.CreateVestingScheduleID
+}
+
+func UnmarshalCreateVestingScheduleResult(p *codec.Packer) (codec.Typed, error) {
+	var result CreateVestingScheduleResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.VestingAddress = p.UnpackString(true)
+	result.Amount = p.UnpackUint64(true)
+	return &result, p.Err()
+}
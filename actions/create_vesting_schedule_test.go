@@ -0,0 +1,138 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestCreateVestingScheduleAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	beneficiary := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+	schedule := func(amount uint64, startBlock uint64, cliffBlock uint64, endBlock uint64) *CreateVestingSchedule {
+		return &CreateVestingSchedule{
+			AssetAddress: assetAddress,
+			Beneficiary:  beneficiary,
+			Amount:       amount,
+			StartBlock:   startBlock,
+			CliffBlock:   cliffBlock,
+			EndBlock:     endBlock,
+		}
+	}
+	vestingAddress := storage.VestingAddress(actor, assetAddress, beneficiary, codec.EmptyAddress, 100, 100, 150, 200)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:        "ZeroAmount",
+			Actor:       actor,
+			Action:      schedule(0, 100, 150, 200),
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: ErrValueZero,
+		},
+		{
+			Name:        "EndBeforeStart",
+			Actor:       actor,
+			Action:      schedule(100, 200, 200, 200),
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: ErrVestingScheduleInvalid,
+		},
+		{
+			Name:        "CliffAfterEnd",
+			Actor:       actor,
+			Action:      schedule(100, 100, 250, 200),
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: ErrVestingScheduleInvalid,
+		},
+		{
+			Name:        "AssetDoesNotExist",
+			Actor:       actor,
+			Action:      schedule(100, 100, 150, 200),
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrAssetDoesNotExist,
+		},
+		{
+			Name:        "InsufficientBalance",
+			Actor:       actor,
+			Action:      schedule(2000, 100, 150, 200),
+			State:       newOrderStore(t, actor, assetAddress),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:   "ScheduleExists",
+			Actor:  actor,
+			Action: schedule(100, 100, 150, 200),
+			State: func() state.Mutable {
+				store := newOrderStore(t, actor, assetAddress)
+				require.NoError(t, storage.SetVestingSchedule(context.Background(), store, vestingAddress, assetAddress, beneficiary, actor, codec.EmptyAddress, 100, 0, 100, 150, 200))
+				return store
+			}(),
+			ExpectedErr: ErrVestingScheduleExists,
+		},
+		{
+			Name:   "ValidSchedule",
+			Actor:  actor,
+			Action: schedule(100, 100, 150, 200),
+			State:  newOrderStore(t, actor, assetAddress),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, asset, scheduleBeneficiary, funder, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock, err := storage.GetVestingScheduleNoController(ctx, store, vestingAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, assetAddress, asset)
+				require.Equal(t, beneficiary, scheduleBeneficiary)
+				require.Equal(t, actor, funder)
+				require.Equal(t, codec.EmptyAddress, revocationAuthority)
+				require.Equal(t, uint64(100), amount)
+				require.Zero(t, claimed)
+				require.Equal(t, uint64(100), startBlock)
+				require.Equal(t, uint64(150), cliffBlock)
+				require.Equal(t, uint64(200), endBlock)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, vestingAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(900), balance)
+			},
+			ExpectedOutputs: &CreateVestingScheduleResult{
+				Actor:          actor.String(),
+				Receiver:       beneficiary.String(),
+				VestingAddress: vestingAddress.String(),
+				Amount:         100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestVestedAmount(t *testing.T) {
+	require := require.New(t)
+
+	require.Zero(storage.VestedAmount(1000, 100, 150, 200, 149))
+	require.Equal(uint64(500), storage.VestedAmount(1000, 100, 150, 200, 150))
+	require.Equal(uint64(990), storage.VestedAmount(1000, 100, 150, 200, 199))
+	require.Equal(uint64(1000), storage.VestedAmount(1000, 100, 150, 200, 300))
+
+	// A cliff at the end is a time lock
+	require.Zero(storage.VestedAmount(1000, 100, 200, 200, 199))
+	require.Equal(uint64(1000), storage.VestedAmount(1000, 100, 200, 200, 200))
+
+	// Large amounts do not overflow
+	require.Equal(uint64(1<<63), storage.VestedAmount(1<<64-1, 0, 0, 2, 1)+1)
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	DeliverDatasetKeyComputeUnits = 5
+)
+
+var (
+	ErrSubscriptionNotFound                           = errors.New("subscription not found")
+	ErrEncryptionPublicKeyMissing                     = errors.New("subscription has no encryption public key")
+	ErrEncryptedKeyInvalid                            = errors.New("encrypted key is invalid")
+	ErrEncryptionPublicKeyHolderMismatch              = errors.New("encryption public key was registered by another account")
+	ErrSubscriptionNotHeld                            = errors.New("subscription is not held by the account")
+	_                                    chain.Action = (*DeliverDatasetKey)(nil)
+)
+
+type DeliverDatasetKey struct {
+	// Marketplace asset address of the listing that was subscribed to
+	MarketplaceAssetAddress codec.Address `serialize:"true" json:"marketplace_asset_address"`
+
+	// NFT address of the subscription the key is delivered to
+	SubscriptionNftAddress codec.Address `serialize:"true" json:"subscription_nft_address"`
+
+	// Account that registered the encryption public key of the subscription.
+	// It must still hold the subscription NFT.
+	Subscriber codec.Address `serialize:"true" json:"subscriber"`
+
+	// Data key of the dataset encrypted to the encryption public key of the
+	// subscription. Delivering again replaces it, for instance when the data
+	// key is rotated.
+	EncryptedKey []byte `serialize:"true" json:"encrypted_key"`
+}
+
+func (*DeliverDatasetKey) GetTypeID() uint8 {
+	return nconsts.DeliverDatasetKeyID
+}
+
+func (d *DeliverDatasetKey) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(d.MarketplaceAssetAddress)):                        state.Read,
+		string(storage.DatasetKeyDelegateKey(d.MarketplaceAssetAddress)):               state.Read,
+		string(storage.AssetInfoKey(d.SubscriptionNftAddress)):                         state.Read,
+		string(storage.AssetAccountBalanceKey(d.SubscriptionNftAddress, d.Subscriber)): state.Read,
+		string(storage.DatasetKeyKey(d.SubscriptionNftAddress)):                        state.Read | state.Write,
+	}
+}
+
+func (d *DeliverDatasetKey) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if len(d.EncryptedKey) == 0 || len(d.EncryptedKey) > storage.MaxEncryptedDatasetKeySize {
+		return nil, ErrEncryptedKeyInvalid
+	}
+
+	// Only the owner of the listing or its delegate delivers the data key
+	assetType, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, d.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if assetType != nconsts.AssetMarketplaceTokenID {
+		return nil, ErrAssetTypeInvalid
+	}
+	if owner != actor {
+		// Delegates set by a previous owner are not valid anymore
+		delegator, delegate, err := storage.GetDatasetKeyDelegateNoController(ctx, mu, d.MarketplaceAssetAddress)
+		if err != nil {
+			return nil, err
+		}
+		if delegator != owner || delegate != actor {
+			return nil, ErrWrongOwner
+		}
+	}
+
+	// Ensure the NFT is a subscription to the listing
+	if !storage.AssetExists(ctx, mu, d.SubscriptionNftAddress) {
+		return nil, ErrSubscriptionNotFound
+	}
+	nftType, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, d.SubscriptionNftAddress)
+	if err != nil {
+		return nil, err
+	}
+	if nftType != nconsts.AssetNonFungibleTokenID || string(uri) != d.MarketplaceAssetAddress.String() {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	exists, holder, encryptionPublicKey, _, err := storage.GetDatasetKeyNoController(ctx, mu, d.SubscriptionNftAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrEncryptionPublicKeyMissing
+	}
+	if holder != d.Subscriber {
+		return nil, ErrEncryptionPublicKeyHolderMismatch
+	}
+	// The key is only delivered to the account that holds the subscription.
+	// A new holder has to register its own key first.
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, d.SubscriptionNftAddress, holder)
+	if err != nil {
+		return nil, err
+	}
+	if balance != 1 {
+		return nil, ErrSubscriptionNotHeld
+	}
+	if err := storage.SetDatasetKey(ctx, mu, d.SubscriptionNftAddress, holder, encryptionPublicKey, d.EncryptedKey); err != nil {
+		return nil, err
+	}
+
+	return &DeliverDatasetKeyResult{
+		Actor:                   actor.String(),
+		Receiver:                holder.String(),
+		MarketplaceAssetAddress: d.MarketplaceAssetAddress.String(),
+		SubscriptionNftAddress:  d.SubscriptionNftAddress.String(),
+	}, nil
+}
+
+func (*DeliverDatasetKey) ComputeUnits(chain.Rules) uint64 {
+	return DeliverDatasetKeyComputeUnits
+}
+
+func (*DeliverDatasetKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalDeliverDatasetKey(p *codec.Packer) (chain.Action, error) {
+	var deliver DeliverDatasetKey
+	p.UnpackAddress(&deliver.MarketplaceAssetAddress)
+	p.UnpackAddress(&deliver.SubscriptionNftAddress)
+	p.UnpackAddress(&deliver.Subscriber)
+	p.UnpackBytes(storage.MaxEncryptedDatasetKeySize, true, &deliver.EncryptedKey)
+	return &deliver, p.Err()
+}
+
+var _ codec.Typed = (*DeliverDatasetKeyResult)(nil)
+
+type DeliverDatasetKeyResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	MarketplaceAssetAddress string `serialize:"true" json:"marketplace_asset_address"`
+	SubscriptionNftAddress  string `serialize:"true" json:"subscription_nft_address"`
+}
+
+func (*DeliverDatasetKeyResult) GetTypeID() uint8 {
+	return nconsts.DeliverDatasetKeyID
+}
+
+func UnmarshalDeliverDatasetKeyResult(p *codec.Packer) (codec.Typed, error) {
+	var result DeliverDatasetKeyResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(true)
+	result.MarketplaceAssetAddress = p.UnpackString(true)
+	result.SubscriptionNftAddress = p.UnpackString(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newSubscriptionStore returns the store of a listing [subscriber] subscribed
+// to with [encryptionPublicKey]
+func newSubscriptionStore(t *testing.T, datasetAddress codec.Address, owner codec.Address, subscriber codec.Address, encryptionPublicKey []byte) state.Mutable {
+	store := newListingStore(t, datasetAddress, owner, 0)
+	require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, subscriber, 1000))
+	_, err := (&SubscribeDatasetMarketplace{
+		MarketplaceAssetAddress: storage.AssetAddressFractional(datasetAddress),
+		PaymentAssetAddress:     storage.NAIAddress,
+		NumBlocksToSubscribe:    10,
+		EncryptionPublicKey:     encryptionPublicKey,
+	}).Execute(context.Background(), nil, store, 0, subscriber, ids.Empty)
+	require.NoError(t, err)
+	return store
+}
+
+func TestDeliverDatasetKeyAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	subscriber := codectest.NewRandomAddress()
+	delegate := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	nftAddress := storage.AssetAddressNFT(marketplaceAssetAddress, nil, subscriber)
+	encryptionPublicKey := []byte("encryption public key")
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 1})
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "EncryptedKeyInvalid",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+			},
+			State:       newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey),
+			ExpectedErr: ErrEncryptedKeyInvalid,
+		},
+		{
+			Name:  "WrongOwner",
+			Actor: subscriber, // Not the owner of the listing
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State:       newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "SubscriptionNotFound",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  storage.AssetAddressNFT(marketplaceAssetAddress, nil, owner),
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State:       newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey),
+			ExpectedErr: ErrSubscriptionNotFound,
+		},
+		{
+			Name:  "NotSubscriptionNFT",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  marketplaceAssetAddress, // Not a subscription NFT
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State:       newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey),
+			ExpectedErr: ErrSubscriptionNotFound,
+		},
+		{
+			Name:  "EncryptionPublicKeyMissing",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State:       newSubscriptionStore(t, datasetAddress, owner, subscriber, nil),
+			ExpectedErr: ErrEncryptionPublicKeyMissing,
+		},
+		{
+			Name:  "EncryptionPublicKeyHolderMismatch",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              owner, // Did not register the key
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State:       newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey),
+			ExpectedErr: ErrEncryptionPublicKeyHolderMismatch,
+		},
+		{
+			Name:  "SubscriptionNotHeld",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State: func() state.Mutable {
+				// The subscription was sold after the key was registered
+				store := newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey)
+				_, _, err := storage.TransferAsset(context.Background(), store, nftAddress, subscriber, codectest.NewRandomAddress(), 1)
+				require.NoError(t, err)
+				return store
+			}(),
+			ExpectedErr: ErrSubscriptionNotHeld,
+		},
+		{
+			Name:  "DeliverDatasetKey",
+			Actor: owner,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State: newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, holder, storedPublicKey, encryptedKey, err := storage.GetDatasetKeyNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, subscriber, holder)
+				require.Equal(t, encryptionPublicKey, storedPublicKey)
+				require.Equal(t, []byte("encrypted key"), encryptedKey)
+
+				// Delivering again replaces the key
+				_, err = (&DeliverDatasetKey{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					SubscriptionNftAddress:  nftAddress,
+					Subscriber:              subscriber,
+					EncryptedKey:            []byte("rotated key"),
+				}).Execute(ctx, nil, store, 0, owner, ids.Empty)
+				require.NoError(t, err)
+				_, _, _, encryptedKey, err = storage.GetDatasetKeyNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.Equal(t, []byte("rotated key"), encryptedKey)
+			},
+			ExpectedOutputs: &DeliverDatasetKeyResult{
+				Actor:                   owner.String(),
+				Receiver:                subscriber.String(),
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				SubscriptionNftAddress:  nftAddress.String(),
+			},
+		}, {
+			Name:  "StaleDelegate",
+			Actor: delegate,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State: func() state.Mutable {
+				store := newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey)
+				// Set by a previous owner of the listing
+				require.NoError(t, storage.SetDatasetKeyDelegate(context.Background(), store, marketplaceAssetAddress, codectest.NewRandomAddress(), delegate))
+				return store
+			}(),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "DeliverByDelegate",
+			Actor: delegate,
+			Action: &DeliverDatasetKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				Subscriber:              subscriber,
+				EncryptedKey:            []byte("encrypted key"),
+			},
+			State: func() state.Mutable {
+				store := newSubscriptionStore(t, datasetAddress, owner, subscriber, encryptionPublicKey)
+				require.NoError(t, storage.SetDatasetKeyDelegate(context.Background(), store, marketplaceAssetAddress, owner, delegate))
+				return store
+			}(),
+			ExpectedOutputs: &DeliverDatasetKeyResult{
+				Actor:                   delegate.String(),
+				Receiver:                subscriber.String(),
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				SubscriptionNftAddress:  nftAddress.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
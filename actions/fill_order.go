@@ -0,0 +1,220 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	FillOrderComputeUnits = 5
+)
+
+var (
+	ErrOrderNotFound              = errors.New("order not found")
+	ErrOrderMismatch              = errors.New("order does not match")
+	ErrOrderNoFill                = errors.New("value is too small to fill the order")
+	_                chain.Action = (*FillOrder)(nil)
+)
+
+type FillOrder struct {
+	// OrderAddress of the order to fill
+	OrderAddress codec.Address `serialize:"true" json:"order_address"`
+
+	// Owner, InAssetAddress and OutAssetAddress of the order. They are needed
+	// to know the state keys of the fill.
+	Owner           codec.Address `serialize:"true" json:"owner"`
+	InAssetAddress  codec.Address `serialize:"true" json:"in_asset_address"`
+	OutAssetAddress codec.Address `serialize:"true" json:"out_asset_address"`
+
+	// InCollectionAddress is the collection of [InAssetAddress] when the order
+	// sells an NFT and is empty otherwise
+	InCollectionAddress codec.Address `serialize:"true" json:"in_collection_address"`
+
+	// RoyaltyRecipient of the collection sold by the order or empty if it has
+	// no royalty
+	RoyaltyRecipient codec.Address `serialize:"true" json:"royalty_recipient"`
+
+	// Value is the maximum amount of [OutAssetAddress] the actor pays. Only
+	// whole ticks are filled and any remainder stays with the actor.
+	Value uint64 `serialize:"true" json:"value"`
+}
+
+func (*FillOrder) GetTypeID() uint8 {
+	return nconsts.FillOrderID
+}
+
+func (f *FillOrder) StateKeys(actor codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.OrderKey(f.OrderAddress)):                                                    state.Read | state.Write,
+		string(storage.AssetInfoKey(f.InAssetAddress)):                                              state.Read,
+		string(storage.AssetInfoKey(f.OutAssetAddress)):                                             state.Read,
+		string(storage.AssetAccountBalanceKey(f.InAssetAddress, f.OrderAddress)):                    state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(f.InAssetAddress, actor)):                             state.All,
+		string(storage.AssetAccountBalanceKey(f.OutAssetAddress, actor)):                            state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(f.OutAssetAddress, f.Owner)):                          state.All,
+		string(storage.RoyaltyKey(orderRoyaltyCollection(f.InAssetAddress, f.InCollectionAddress))): state.Read,
+	}
+	if f.InCollectionAddress != codec.EmptyAddress {
+		storage.AddNFTTransferStateKeys(stateKeys, f.InAssetAddress, f.InCollectionAddress, f.OrderAddress, actor)
+	}
+	if f.RoyaltyRecipient != codec.EmptyAddress {
+		stateKeys[string(storage.AssetAccountBalanceKey(f.OutAssetAddress, f.RoyaltyRecipient))] = state.All
+	}
+	return stateKeys
+}
+
+func (f *FillOrder) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, in, inTick, out, outTick, owner, err := storage.GetOrderNoController(ctx, mu, f.OrderAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOrderNotFound
+	}
+	if owner != f.Owner || in != f.InAssetAddress || out != f.OutAssetAddress {
+		return nil, ErrOrderMismatch
+	}
+	if actor == owner {
+		return nil, ErrTransferToSelf
+	}
+	if _, err := checkOrderAsset(ctx, mu, in, f.InCollectionAddress); err != nil {
+		return nil, err
+	}
+	royaltyExists, royaltyBasisPoints, royaltyRecipient, _, err := storage.GetRoyaltyNoController(ctx, mu, orderRoyaltyCollection(in, f.InCollectionAddress))
+	if err != nil {
+		return nil, err
+	}
+	if royaltyRecipient != f.RoyaltyRecipient {
+		return nil, ErrOrderMismatch
+	}
+
+	// Fill as many whole ticks as the value and the order allow
+	available, err := storage.GetAssetAccountBalanceNoController(ctx, mu, in, f.OrderAddress)
+	if err != nil {
+		return nil, err
+	}
+	ticks := min(f.Value/outTick, available/inTick)
+	if ticks == 0 {
+		return nil, ErrOrderNoFill
+	}
+	outAmount, err := smath.Mul(ticks, outTick)
+	if err != nil {
+		return nil, err
+	}
+	inAmount, err := smath.Mul(ticks, inTick)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that balance is sufficient
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, out, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < outAmount {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	// The royalty of the collection is taken out of the payment to the owner
+	var royalty uint64
+	if royaltyExists {
+		royalty = storage.RoyaltyAmount(outAmount, royaltyBasisPoints)
+	}
+	if royalty > 0 && royaltyRecipient != actor {
+		if _, _, err := storage.TransferAsset(ctx, mu, out, actor, royaltyRecipient, royalty); err != nil {
+			return nil, err
+		}
+	}
+	if outAmount > royalty {
+		if _, _, err := storage.TransferAsset(ctx, mu, out, actor, owner, outAmount-royalty); err != nil {
+			return nil, err
+		}
+	}
+	remaining, _, err := storage.TransferAsset(ctx, mu, in, f.OrderAddress, actor, inAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Supply is a multiple of the in tick so the order is closed once empty
+	if remaining == 0 {
+		if err := storage.DeleteOrder(ctx, mu, f.OrderAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FillOrderResult{
+		Actor:     actor.String(),
+		Receiver:  owner.String(),
+		In:        inAmount,
+		Out:       outAmount,
+		Royalty:   royalty,
+		Remaining: remaining,
+	}, nil
+}
+
+func (*FillOrder) ComputeUnits(chain.Rules) uint64 {
+	return FillOrderComputeUnits
+}
+
+func (*FillOrder) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalFillOrder(p *codec.Packer) (chain.Action, error) {
+	var fill FillOrder
+	p.UnpackAddress(&fill.OrderAddress)
+	p.UnpackAddress(&fill.Owner)
+	p.UnpackAddress(&fill.InAssetAddress)
+	p.UnpackAddress(&fill.OutAssetAddress)
+	unpackAddressOrEmpty(p, &fill.InCollectionAddress)
+	unpackAddressOrEmpty(p, &fill.RoyaltyRecipient)
+	fill.Value = p.UnpackUint64(true)
+	return &fill, p.Err()
+}
+
+var _ codec.Typed = (*FillOrderResult)(nil)
+
+type FillOrderResult struct {
+	Actor     string `serialize:"true" json:"actor"`
+	Receiver  string `serialize:"true" json:"receiver"`
+	In        uint64 `serialize:"true" json:"in"`        // Amount of in asset received by the actor
+	Out       uint64 `serialize:"true" json:"out"`       // Amount of out asset paid by the actor
+	Royalty   uint64 `serialize:"true" json:"royalty"`   // Part of [Out] paid to the royalty recipient
+	Remaining uint64 `serialize:"true" json:"remaining"` // Amount of in asset left in the order
+}
+
+func (*FillOrderResult) GetTypeID() uint8 {
+	return nconsts.FillOrderID
+}
+
+func UnmarshalFillOrderResult(p *codec.Packer) (codec.Typed, error) {
+	var result FillOrderResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.In = p.UnpackUint64(true)
+	result.Out = p.UnpackUint64(true)
+	result.Royalty = p.UnpackUint64(false)
+	result.Remaining = p.UnpackUint64(false)
+	return &result, p.Err()
+}
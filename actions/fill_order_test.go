@@ -0,0 +1,239 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestFillOrderAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	taker := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), owner)
+	orderAddress := storage.OrderAddress(owner, assetAddress, 10, storage.NAIAddress, 3)
+
+	// newStore sets up an order of [owner] selling 10 of the asset for every 3
+	// NAI with [remaining] left. The taker holds 100 NAI.
+	newStore := func(remaining uint64) state.Mutable {
+		store := newOrderStore(t, owner, assetAddress)
+		_, err := storage.SetOrder(context.Background(), store, owner, assetAddress, 10, storage.NAIAddress, 3)
+		require.NoError(t, err)
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, orderAddress, remaining))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, taker, 100))
+		return store
+	}
+	fill := func(value uint64) *FillOrder {
+		return &FillOrder{
+			OrderAddress:    orderAddress,
+			Owner:           owner,
+			InAssetAddress:  assetAddress,
+			OutAssetAddress: storage.NAIAddress,
+			Value:           value,
+		}
+	}
+
+	// An order of [owner] selling an NFT of a collection paying a royalty of
+	// 2.5% in NAI to the creator
+	creator := codectest.NewRandomAddress()
+	collectionAddress := storage.AssetAddress(nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT"), 0, []byte("metadata"), creator)
+	nftAddress := storage.AssetAddressNFT(collectionAddress, []byte("metadata"), owner)
+	nftOrderAddress := storage.OrderAddress(owner, nftAddress, 1, storage.NAIAddress, 50)
+	newNFTOrderStore := func() state.Mutable {
+		store := newOrderStore(t, owner, assetAddress)
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, collectionAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT"), 0, []byte("metadata"), []byte(collectionAddress.String()), 1, 0, creator, creator, creator, creator, creator))
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("NFT-0"), 0, []byte("metadata"), []byte(collectionAddress.String()), 1, 1, owner, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+		require.NoError(t, storage.SetRoyalty(context.Background(), store, collectionAddress, 250, creator, storage.NAIAddress))
+		_, err := storage.SetOrder(context.Background(), store, owner, nftAddress, 1, storage.NAIAddress, 50)
+		require.NoError(t, err)
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, nftOrderAddress, 1))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, collectionAddress, nftOrderAddress, 1))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, taker, 100))
+		return store
+	}
+	fillNFT := func(royaltyRecipient codec.Address) *FillOrder {
+		return &FillOrder{
+			OrderAddress:        nftOrderAddress,
+			Owner:               owner,
+			InAssetAddress:      nftAddress,
+			OutAssetAddress:     storage.NAIAddress,
+			InCollectionAddress: collectionAddress,
+			RoyaltyRecipient:    royaltyRecipient,
+			Value:               100,
+		}
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:        "OrderNotFound",
+			Actor:       taker,
+			Action:      fill(9),
+			State:       newOrderStore(t, owner, assetAddress),
+			ExpectedErr: ErrOrderNotFound,
+		},
+		{
+			Name:  "OrderMismatch",
+			Actor: taker,
+			Action: &FillOrder{
+				OrderAddress:    orderAddress,
+				Owner:           taker,
+				InAssetAddress:  assetAddress,
+				OutAssetAddress: storage.NAIAddress,
+				Value:           9,
+			},
+			State:       newStore(100),
+			ExpectedErr: ErrOrderMismatch,
+		},
+		{
+			Name:        "FillOwnOrder",
+			Actor:       owner,
+			Action:      fill(9),
+			State:       newStore(100),
+			ExpectedErr: ErrTransferToSelf,
+		},
+		{
+			Name:        "ValueBelowTick",
+			Actor:       taker,
+			Action:      fill(2),
+			State:       newStore(100),
+			ExpectedErr: ErrOrderNoFill,
+		},
+		{
+			Name:        "InsufficientBalance",
+			Actor:       taker,
+			Action:      fill(300),
+			State:       newStore(1000),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:   "PartialFill",
+			Actor:  taker,
+			Action: fill(10),
+			State:  newStore(100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// 3 ticks are filled and the remainder of the value is kept
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, taker)
+				require.NoError(t, err)
+				require.Equal(t, uint64(91), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, owner)
+				require.NoError(t, err)
+				require.Equal(t, uint64(9), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, taker)
+				require.NoError(t, err)
+				require.Equal(t, uint64(30), balance)
+
+				exists, _, _, _, _, _, err := storage.GetOrderNoController(ctx, store, orderAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+			},
+			ExpectedOutputs: &FillOrderResult{
+				Actor:     taker.String(),
+				Receiver:  owner.String(),
+				In:        30,
+				Out:       9,
+				Remaining: 70,
+			},
+		},
+		{
+			Name:   "FillEmptiesOrder",
+			Actor:  taker,
+			Action: fill(100),
+			State:  newStore(20),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, _, err := storage.GetOrderNoController(ctx, store, orderAddress)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+			ExpectedOutputs: &FillOrderResult{
+				Actor:    taker.String(),
+				Receiver: owner.String(),
+				In:       20,
+				Out:      6,
+			},
+		},
+		{
+			Name:        "RoyaltyRecipientMismatch",
+			Actor:       taker,
+			Action:      fillNFT(taker),
+			State:       newNFTOrderStore(),
+			ExpectedErr: ErrOrderMismatch,
+		},
+		{
+			Name:   "FillPaysRoyalty",
+			Actor:  taker,
+			Action: fillNFT(creator),
+			State:  newNFTOrderStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The royalty is rounded down and taken out of the payment
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, taker)
+				require.NoError(t, err)
+				require.Equal(t, uint64(50), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, creator)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, owner)
+				require.NoError(t, err)
+				require.Equal(t, uint64(49), balance)
+
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, taker)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, collectionAddress, taker)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, collectionAddress, nftOrderAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(0), balance)
+			},
+			ExpectedOutputs: &FillOrderResult{
+				Actor:    taker.String(),
+				Receiver: owner.String(),
+				In:       1,
+				Out:      50,
+				Royalty:  1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestFillOrderMarshal(t *testing.T) {
+	require := require.New(t)
+
+	// Orders of fungible assets without royalties leave both addresses empty
+	fill := &FillOrder{
+		OrderAddress:    codectest.NewRandomAddress(),
+		Owner:           codectest.NewRandomAddress(),
+		InAssetAddress:  codectest.NewRandomAddress(),
+		OutAssetAddress: codectest.NewRandomAddress(),
+		Value:           4,
+	}
+	b, err := chain.Marshal(fill)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalFillOrder(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(fill, unmarshalled)
+
+	fill.InCollectionAddress = codectest.NewRandomAddress()
+	fill.RoyaltyRecipient = codectest.NewRandomAddress()
+	b, err = chain.Marshal(fill)
+	require.NoError(err)
+	unmarshalled, err = UnmarshalFillOrder(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(fill, unmarshalled)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	FractionalizeNFTComputeUnits = 5
+)
+
+var (
+	ErrNFTAlreadyFractionalized              = errors.New("NFT is already fractionalized")
+	_                           chain.Action = (*FractionalizeNFT)(nil)
+)
+
+type FractionalizeNFT struct {
+	// AssetAddress is the NFT collection address
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// AssetNftAddress of the NFT to lock
+	AssetNftAddress codec.Address `serialize:"true" json:"asset_nft_address"`
+
+	// Shares of the fungible token minted to the actor. Holding all of them is
+	// required to redeem the NFT.
+	Shares uint64 `serialize:"true" json:"shares"`
+}
+
+func (*FractionalizeNFT) GetTypeID() uint8 {
+	return nconsts.FractionalizeNFTID
+}
+
+func (f *FractionalizeNFT) StateKeys(actor codec.Address) state.Keys {
+	sharesAddress := storage.AssetAddressFractional(f.AssetNftAddress)
+	stateKeys := state.Keys{
+		string(storage.AssetAccountBalanceKey(f.AssetNftAddress, actor)):         state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(f.AssetNftAddress, sharesAddress)): state.All,
+		string(storage.AssetInfoKey(sharesAddress)):                              state.All,
+		string(storage.AssetAccountBalanceKey(sharesAddress, actor)):             state.All,
+	}
+	storage.AddNFTTransferStateKeys(stateKeys, f.AssetNftAddress, f.AssetAddress, actor, sharesAddress)
+	return stateKeys
+}
+
+func (f *FractionalizeNFT) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if f.Shares == 0 {
+		return nil, ErrValueZero
+	}
+	// Retrieve nft info
+	assetType, name, symbol, _, metadata, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, f.AssetNftAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	if assetType != nconsts.AssetNonFungibleTokenID {
+		return nil, ErrAssetTypeInvalid
+	}
+	// Ensure that the NFT was minted in f.AssetAddress
+	if f.AssetAddress.String() != string(uri) || f.AssetAddress == f.AssetNftAddress {
+		return nil, ErrNFTDoesNotBelongToTheCollection
+	}
+
+	// Check that the actor owns the NFT
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, f.AssetNftAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance == 0 {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	// The NFT is locked in the account of the shares until it is redeemed
+	sharesAddress := storage.AssetAddressFractional(f.AssetNftAddress)
+	if storage.AssetExists(ctx, mu, sharesAddress) {
+		return nil, ErrNFTAlreadyFractionalized
+	}
+	if _, _, err := storage.TransferAsset(ctx, mu, f.AssetNftAddress, actor, sharesAddress, 1); err != nil {
+		return nil, err
+	}
+	// Nobody can mint more shares or administer them
+	if err := storage.SetAssetInfo(ctx, mu, sharesAddress, nconsts.AssetFungibleTokenID, name, symbol, 0, metadata, []byte(f.AssetNftAddress.String()), 0, f.Shares, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress); err != nil {
+		return nil, err
+	}
+	if _, err := storage.MintAsset(ctx, mu, sharesAddress, actor, f.Shares); err != nil {
+		return nil, err
+	}
+
+	return &FractionalizeNFTResult{
+		Actor:              actor.String(),
+		Receiver:           actor.String(),
+		SharesAssetAddress: sharesAddress.String(),
+		Shares:             f.Shares,
+	}, nil
+}
+
+func (*FractionalizeNFT) ComputeUnits(chain.Rules) uint64 {
+	return FractionalizeNFTComputeUnits
+}
+
+func (*FractionalizeNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalFractionalizeNFT(p *codec.Packer) (chain.Action, error) {
+	var fractionalize FractionalizeNFT
+	p.UnpackAddress(&fractionalize.AssetAddress)
+	p.UnpackAddress(&fractionalize.AssetNftAddress)
+	fractionalize.Shares = p.UnpackUint64(true)
+	return &fractionalize, p.Err()
+}
+
+var _ codec.Typed = (*FractionalizeNFTResult)(nil)
+
+type FractionalizeNFTResult struct {
+	Actor              string `serialize:"true" json:"actor"`
+	Receiver           string `serialize:"true" json:"receiver"`
+	SharesAssetAddress string `serialize:"true" json:"shares_asset_address"`
+	Shares             uint64 `serialize:"true" json:"shares"`
+}
+
+func (*FractionalizeNFTResult) GetTypeID() uint8 {
+	return nconsts.FractionalizeNFTID
+}
+
+func UnmarshalFractionalizeNFTResult(p *codec.Packer) (codec.Typed, error) {
+	var result FractionalizeNFTResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.SharesAssetAddress = p.UnpackString(true)
+	result.Shares = p.UnpackUint64(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newNFTStore sets up an NFT collection with a single NFT owned by [owner]
+func newNFTStore(t *testing.T, owner codec.Address, assetAddress codec.Address, nftAddress codec.Address) state.Mutable {
+	store := chaintest.NewInMemoryStore()
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), []byte(assetAddress.String()), 1, 10, owner, owner, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM-0"), 0, []byte("metadata"), []byte(assetAddress.String()), 1, 1, owner, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, owner, 1))
+	require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, owner, 1))
+	return store
+}
+
+func TestFractionalizeNFTAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), actor)
+	nftAddress := storage.AssetAddressNFT(assetAddress, []byte("metadata"), actor)
+	sharesAddress := storage.AssetAddressFractional(nftAddress)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "ZeroShares",
+			Actor: actor,
+			Action: &FractionalizeNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+			},
+			State:       newNFTStore(t, actor, assetAddress, nftAddress),
+			ExpectedErr: ErrValueZero,
+		},
+		{
+			Name:  "CantFractionalizeNFTCollection",
+			Actor: actor,
+			Action: &FractionalizeNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: assetAddress,
+				Shares:          100,
+			},
+			State:       newNFTStore(t, actor, assetAddress, nftAddress),
+			ExpectedErr: ErrNFTDoesNotBelongToTheCollection,
+		},
+		{
+			Name:  "NotNFTOwner",
+			Actor: codectest.NewRandomAddress(),
+			Action: &FractionalizeNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+				Shares:          100,
+			},
+			State:       newNFTStore(t, actor, assetAddress, nftAddress),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ValidFractionalize",
+			Actor: actor,
+			Action: &FractionalizeNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+				Shares:          100,
+			},
+			State: newNFTStore(t, actor, assetAddress, nftAddress),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The NFT is locked in the account of the shares
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, actor)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, sharesAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+
+				assetType, _, _, decimals, _, uri, totalSupply, maxSupply, owner, mintAdmin, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, sharesAddress)
+				require.NoError(t, err)
+				require.Equal(t, nconsts.AssetFungibleTokenID, assetType)
+				require.Zero(t, decimals)
+				require.Equal(t, nftAddress.String(), string(uri))
+				require.Equal(t, uint64(100), totalSupply)
+				require.Equal(t, uint64(100), maxSupply)
+				require.Equal(t, codec.EmptyAddress, owner)
+				require.Equal(t, codec.EmptyAddress, mintAdmin)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, sharesAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), balance)
+			},
+			ExpectedOutputs: &FractionalizeNFTResult{
+				Actor:              actor.String(),
+				Receiver:           actor.String(),
+				SharesAssetAddress: sharesAddress.String(),
+				Shares:             100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	GrantMintRoleComputeUnits = 1
+)
+
+var (
+	ErrMintRoleInvalid                = errors.New("mint role is invalid")
+	ErrMintQuotaExceeded              = errors.New("mint quota exceeded")
+	_                    chain.Action = (*GrantMintRole)(nil)
+)
+
+// GrantMintRole grants the mint role of an asset to an account on top of
+// the mint admin set in the asset info. Granting the role again updates its
+// mint quota.
+type GrantMintRole struct {
+	// AssetAddress the role is granted on
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Account the role is granted to
+	Account codec.Address `serialize:"true" json:"account"`
+
+	// MintQuota is the most a minter can mint every [MintPeriod] blocks. Zero
+	// is unlimited.
+	MintQuota uint64 `serialize:"true" json:"mint_quota"`
+
+	// MintPeriod is the number of blocks after which the quota is available
+	// again. Zero makes the quota a lifetime cap.
+	MintPeriod uint64 `serialize:"true" json:"mint_period"`
+}
+
+func (*GrantMintRole) GetTypeID() uint8 {
+	return nconsts.GrantMintRoleID
+}
+
+func (g *GrantMintRole) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(g.AssetAddress)):           state.Read,
+		string(storage.MintRoleKey(g.AssetAddress, g.Account)): state.All,
+	}
+}
+
+func (g *GrantMintRole) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if g.Account == codec.EmptyAddress {
+		return nil, ErrMintRoleInvalid
+	}
+
+	// Only the owner of the asset can grant the role
+	_, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, g.AssetAddress)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+	if owner != actor {
+		return nil, ErrWrongOwner
+	}
+
+	// Minters granted again keep what they minted during the current period
+	exists, _, _, periodStart, minted, err := storage.GetMintRoleNoController(ctx, mu, g.AssetAddress, g.Account)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		periodStart = emission.GetEmission().GetLastAcceptedBlockHeight()
+	}
+	if err := storage.SetMintRole(ctx, mu, g.AssetAddress, g.Account, g.MintQuota, g.MintPeriod, periodStart, minted); err != nil {
+		return nil, err
+	}
+
+	return &GrantMintRoleResult{
+		Actor:    actor.String(),
+		Receiver: g.Account.String(),
+	}, nil
+}
+
+func (*GrantMintRole) ComputeUnits(chain.Rules) uint64 {
+	return GrantMintRoleComputeUnits
+}
+
+func (*GrantMintRole) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalGrantMintRole(p *codec.Packer) (chain.Action, error) {
+	var grant GrantMintRole
+	p.UnpackAddress(&grant.AssetAddress)
+	p.UnpackAddress(&grant.Account)
+	grant.MintQuota = p.UnpackUint64(false)
+	grant.MintPeriod = p.UnpackUint64(false)
+	return &grant, p.Err()
+}
+
+// checkMinter checks that [actor] can mint [amount] of [asset] whose mint
+// admin is [mintAdmin] and counts it against the quota of granted minters
+func checkMinter(ctx context.Context, mu state.Mutable, asset codec.Address, mintAdmin codec.Address, actor codec.Address, amount uint64) error {
+	if mintAdmin == actor {
+		return nil
+	}
+	exists, quota, period, periodStart, minted, err := storage.GetMintRoleNoController(ctx, mu, asset, actor)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrWrongMintAdmin
+	}
+
+	// Start over when a new period began
+	currentPeriodStart := storage.MintPeriodStart(periodStart, period, emission.GetEmission().GetLastAcceptedBlockHeight())
+	if currentPeriodStart != periodStart {
+		periodStart = currentPeriodStart
+		minted = 0
+	}
+	minted, err = smath.Add(minted, amount)
+	if err != nil {
+		return err
+	}
+	if quota != 0 && minted > quota {
+		return ErrMintQuotaExceeded
+	}
+	return storage.SetMintRole(ctx, mu, asset, actor, quota, period, periodStart, minted)
+}
+
+var _ codec.Typed = (*GrantMintRoleResult)(nil)
+
+type GrantMintRoleResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+}
+
+func (*GrantMintRoleResult) GetTypeID() uint8 {
+	return nconsts.GrantMintRoleID
+}
+
+func UnmarshalGrantMintRoleResult(p *codec.Packer) (codec.Typed, error) {
+	var result GrantMintRoleResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestGrantMintRoleAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	minter := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), owner)
+
+	newStore := func() state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte(assetAddress.String()), 0, 0, owner, owner, owner, owner, owner))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "EmptyAccount",
+			Actor: owner,
+			Action: &GrantMintRole{
+				AssetAddress: assetAddress,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrMintRoleInvalid,
+		},
+		{
+			Name:  "WrongOwner",
+			Actor: minter,
+			Action: &GrantMintRole{
+				AssetAddress: assetAddress,
+				Account:      minter,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "ValidGrant",
+			Actor: owner,
+			Action: &GrantMintRole{
+				AssetAddress: assetAddress,
+				Account:      minter,
+				MintQuota:    1000,
+				MintPeriod:   50,
+			},
+			State: newStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, quota, period, periodStart, minted, err := storage.GetMintRoleNoController(ctx, store, assetAddress, minter)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, uint64(1000), quota)
+				require.Equal(t, uint64(50), period)
+				require.Equal(t, uint64(100), periodStart)
+				require.Zero(t, minted)
+			},
+			ExpectedOutputs: &GrantMintRoleResult{
+				Actor:    owner.String(),
+				Receiver: minter.String(),
+			},
+		},
+		{
+			Name:  "RegrantKeepsMinted",
+			Actor: owner,
+			Action: &GrantMintRole{
+				AssetAddress: assetAddress,
+				Account:      minter,
+				MintQuota:    2000,
+			},
+			State: func() state.Mutable {
+				store := newStore()
+				require.NoError(t, storage.SetMintRole(context.Background(), store, assetAddress, minter, 1000, 50, 80, 700))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, quota, period, periodStart, minted, err := storage.GetMintRoleNoController(ctx, store, assetAddress, minter)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, uint64(2000), quota)
+				require.Zero(t, period)
+				require.Equal(t, uint64(80), periodStart)
+				require.Equal(t, uint64(700), minted)
+			},
+			ExpectedOutputs: &GrantMintRoleResult{
+				Actor:    owner.String(),
+				Receiver: minter.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestRevokeMintRoleAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	minter := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), owner)
+
+	newStore := func(granted bool) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte(assetAddress.String()), 0, 0, owner, owner, owner, owner, owner))
+		if granted {
+			require.NoError(t, storage.SetMintRole(context.Background(), store, assetAddress, minter, 1000, 0, 0, 0))
+		}
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: minter,
+			Action: &RevokeMintRole{
+				AssetAddress: assetAddress,
+				Account:      minter,
+			},
+			State:       newStore(true),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "RoleNotFound",
+			Actor: owner,
+			Action: &RevokeMintRole{
+				AssetAddress: assetAddress,
+				Account:      minter,
+			},
+			State:       newStore(false),
+			ExpectedErr: ErrMintRoleNotFound,
+		},
+		{
+			Name:  "ValidRevoke",
+			Actor: owner,
+			Action: &RevokeMintRole{
+				AssetAddress: assetAddress,
+				Account:      minter,
+			},
+			State: newStore(true),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, err := storage.GetMintRoleNoController(ctx, store, assetAddress, minter)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+			ExpectedOutputs: &RevokeMintRoleResult{
+				Actor:    owner.String(),
+				Receiver: minter.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestGrantMintRoleMarshal(t *testing.T) {
+	require := require.New(t)
+
+	grant := &GrantMintRole{
+		AssetAddress: codectest.NewRandomAddress(),
+		Account:      codectest.NewRandomAddress(),
+		MintQuota:    1000,
+		MintPeriod:   50,
+	}
+	b, err := chain.Marshal(grant)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalGrantMintRole(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(grant, unmarshalled)
+}
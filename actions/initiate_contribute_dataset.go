@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/storage"
 
 	"github.com/ava-labs/hypersdk/chain"
@@ -29,6 +30,7 @@ var (
 	ErrDataLocationInvalid                           = errors.New("data location is invalid")
 	ErrDataIdentifierInvalid                         = errors.New("data identifier is invalid")
 	ErrDatasetContributionAlreadyExists              = errors.New("dataset contribution already exists")
+	ErrDataRootInvalid                               = errors.New("data root is invalid")
 	_                                   chain.Action = (*InitiateContributeDataset)(nil)
 )
 
@@ -41,6 +43,14 @@ type InitiateContributeDataset struct {
 
 	// Data Identifier(id/hash/URL)
 	DataIdentifier string `serialize:"true" json:"data_identifier"`
+
+	// Merkle root of the data split in dataset.DataChunkSize chunks(optional).
+	// Only contributions committing to a root can be challenged to prove the
+	// data is available
+	DataRoot []byte `serialize:"true" json:"data_root"`
+
+	// Number of chunks the data root commits to
+	DataChunks uint64 `serialize:"true" json:"data_chunks"`
 }
 
 func (*InitiateContributeDataset) GetTypeID() uint8 {
@@ -51,6 +61,7 @@ func (d *InitiateContributeDataset) StateKeys(actor codec.Address) state.Keys {
 	datasetContributionID := storage.DatasetContributionID(d.DatasetAddress, []byte(d.DataLocation), []byte(d.DataIdentifier), actor)
 	return state.Keys{
 		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                                   state.All,
+		string(storage.DataCommitmentKey(datasetContributionID)):                                                            state.Allocate | state.Write,
 		string(storage.DatasetInfoKey(d.DatasetAddress)):                                                                    state.Read,
 		string(storage.AssetAccountBalanceKey(dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution, actor)): state.Read | state.Write,
 	}
@@ -97,10 +108,24 @@ func (d *InitiateContributeDataset) Execute(
 		return nil, ErrDataIdentifierInvalid
 	}
 
+	// Check if the data root is valid
+	if len(d.DataRoot) > 0 {
+		if len(d.DataRoot) != storage.DataRootLen || d.DataChunks == 0 {
+			return nil, ErrDataRootInvalid
+		}
+	} else if d.DataChunks > 0 {
+		return nil, ErrDataRootInvalid
+	}
+
 	// Set the dataset contribution info to storage
-	if err := storage.SetDatasetContributionInfo(ctx, mu, datasetContributionID, d.DatasetAddress, []byte(d.DataLocation), []byte(d.DataIdentifier), actor, false); err != nil {
+	if err := storage.SetDatasetContributionInfo(ctx, mu, datasetContributionID, d.DatasetAddress, []byte(d.DataLocation), []byte(d.DataIdentifier), actor, false, emission.GetEmission().GetLastAcceptedBlockHeight()); err != nil {
 		return nil, err
 	}
+	if len(d.DataRoot) > 0 {
+		if err := storage.SetDataCommitment(ctx, mu, datasetContributionID, d.DataRoot, d.DataChunks); err != nil {
+			return nil, err
+		}
+	}
 
 	// Reduce the balance of the contributor with the collateral needed to contribute to the dataset
 	// This will be refunded if the contribution is successful
@@ -146,6 +171,8 @@ func UnmarshalInitiateContributeDataset(p *codec.Packer) (chain.Action, error) {
 	p.UnpackAddress(&initiate.DatasetAddress)
 	initiate.DataLocation = p.UnpackString(false)
 	initiate.DataIdentifier = p.UnpackString(true)
+	p.UnpackBytes(storage.DataRootLen, false, &initiate.DataRoot)
+	initiate.DataChunks = p.UnpackUint64(false)
 	return &initiate, p.Err()
 }
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/stretchr/testify/require"
 
@@ -21,6 +22,8 @@ import (
 )
 
 func TestInitiateContributeDatasetAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
 	dataLocation := "default"
 	dataIdentifier := "data_id_1234"
 
@@ -93,6 +96,24 @@ func TestInitiateContributeDatasetAction(t *testing.T) {
 			}(),
 			ExpectedErr: ErrDataIdentifierInvalid,
 		},
+		{
+			Name:  "InvalidDataRoot",
+			Actor: actor,
+			Action: &InitiateContributeDataset{
+				DatasetAddress: datasetAddress,
+				DataLocation:   dataLocation,
+				DataIdentifier: dataIdentifier,
+				DataRoot:       make([]byte, storage.DataRootLen),
+				DataChunks:     0, // A data root must commit to at least one chunk
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				// Set valid dataset open for contributions
+				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
+				return store
+			}(),
+			ExpectedErr: ErrDataRootInvalid,
+		},
 		{
 			Name:  "ValidContribution",
 			Actor: actor,
@@ -119,13 +140,14 @@ func TestInitiateContributeDatasetAction(t *testing.T) {
 				require.Equal(t, uint64(0), balance) // Initial collateral balance should be zero after deduction
 
 				// Verify that the contribution is initiated correctly
-				datasetAddress, dataLocation, dataIdentifier, contributor, active, err := storage.GetDatasetContributionInfoNoController(ctx, store, datasetContributionID)
+				datasetAddress, dataLocation, dataIdentifier, contributor, active, initiatedAt, err := storage.GetDatasetContributionInfoNoController(ctx, store, datasetContributionID)
 				require.NoError(t, err)
 				require.Equal(t, datasetAddress, datasetAddress)
 				require.Equal(t, "default", string(dataLocation))
 				require.Equal(t, "data_id_1234", string(dataIdentifier))
 				require.Equal(t, actor, contributor)
 				require.False(t, active)
+				require.Equal(t, uint64(100), initiatedAt)
 			},
 			ExpectedOutputs: &InitiateContributeDatasetResult{
 				Actor:                  actor.String(),
@@ -144,6 +166,7 @@ func TestInitiateContributeDatasetAction(t *testing.T) {
 
 func BenchmarkInitiateContributeDataset(b *testing.B) {
 	require := require.New(b)
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
 	dataLocation := "default"
 	dataIdentifier := "data_id_1234"
 
@@ -177,7 +200,7 @@ func BenchmarkInitiateContributeDataset(b *testing.B) {
 			require.Equal(uint64(0), balance) // Initial collateral balance should be zero after deduction
 
 			// Verify that the contribution is initiated correctly
-			datasetAddress, dataLocation, dataIdentifier, contributor, active, err := storage.GetDatasetContributionInfoNoController(ctx, store, datasetContributionID)
+			datasetAddress, dataLocation, dataIdentifier, contributor, active, _, err := storage.GetDatasetContributionInfoNoController(ctx, store, datasetContributionID)
 			require.NoError(err)
 			require.Equal(datasetAddress, datasetAddress)
 			require.Equal("default", string(dataLocation))
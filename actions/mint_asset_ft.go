@@ -43,10 +43,11 @@ func (*MintAssetFT) GetTypeID() uint8 {
 	return nconsts.MintAssetFTID
 }
 
-func (m *MintAssetFT) StateKeys(codec.Address) state.Keys {
+func (m *MintAssetFT) StateKeys(actor codec.Address) state.Keys {
 	return state.Keys{
 		string(storage.AssetInfoKey(m.AssetAddress)):                 state.Read | state.Write,
 		string(storage.AssetAccountBalanceKey(m.AssetAddress, m.To)): state.All,
+		string(storage.MintRoleKey(m.AssetAddress, actor)):           state.Read | state.Write,
 	}
 }
 
@@ -73,8 +74,8 @@ func (m *MintAssetFT) Execute(
 	if assetType != nconsts.AssetFungibleTokenID {
 		return nil, ErrAssetTypeInvalid
 	}
-	if mintAdmin != actor {
-		return nil, ErrWrongMintAdmin
+	if err := checkMinter(ctx, mu, m.AssetAddress, mintAdmin, actor, m.Value); err != nil {
+		return nil, err
 	}
 
 	// Minting logic for fungible tokens
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/stretchr/testify/require"
 
@@ -20,7 +21,10 @@ import (
 )
 
 func TestMintAssetFTAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
 	actor := codectest.NewRandomAddress()
+	minter := codectest.NewRandomAddress()
 	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
 
 	tests := []chaintest.ActionTest{
@@ -76,6 +80,56 @@ func TestMintAssetFTAction(t *testing.T) {
 			}(),
 			ExpectedErr: ErrWrongMintAdmin,
 		},
+		{
+			Name:  "MintQuotaExceeded",
+			Actor: minter,
+			Action: &MintAssetFT{
+				AssetAddress: assetAddress,
+				Value:        400,
+				To:           minter,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 0, 1000000, actor, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				// 700 of the 1000 quota already minted in the period starting at block 80
+				require.NoError(t, storage.SetMintRole(context.Background(), store, assetAddress, minter, 1000, 50, 80, 700))
+				return store
+			}(),
+			ExpectedErr: ErrMintQuotaExceeded,
+		},
+		{
+			Name:  "MintQuotaNewPeriod",
+			Actor: minter,
+			Action: &MintAssetFT{
+				AssetAddress: assetAddress,
+				Value:        400,
+				To:           minter,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 0, 1000000, actor, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				// The period starting at block 30 ended at block 80
+				require.NoError(t, storage.SetMintRole(context.Background(), store, assetAddress, minter, 1000, 50, 30, 700))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, periodStart, minted, err := storage.GetMintRoleNoController(ctx, store, assetAddress, minter)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, uint64(80), periodStart)
+				require.Equal(t, uint64(400), minted)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, minter)
+				require.NoError(t, err)
+				require.Equal(t, uint64(400), balance)
+			},
+			ExpectedOutputs: &MintAssetFTResult{
+				Actor:      minter.String(),
+				Receiver:   minter.String(),
+				OldBalance: 0,
+				NewBalance: 400,
+			},
+		},
 		{
 			Name:  "ExceedMaxSupply",
 			Actor: actor,
@@ -43,13 +43,14 @@ func (*MintAssetNFT) GetTypeID() uint8 {
 	return nconsts.MintAssetNFTID
 }
 
-func (m *MintAssetNFT) StateKeys(codec.Address) state.Keys {
+func (m *MintAssetNFT) StateKeys(actor codec.Address) state.Keys {
 	nftAddress := storage.AssetAddressNFT(m.AssetAddress, []byte(m.Metadata), m.To)
 	return state.Keys{
 		string(storage.AssetInfoKey(m.AssetAddress)):                 state.Read | state.Write,
 		string(storage.AssetInfoKey(nftAddress)):                     state.All,
 		string(storage.AssetAccountBalanceKey(m.AssetAddress, m.To)): state.All,
 		string(storage.AssetAccountBalanceKey(nftAddress, m.To)):     state.All,
+		string(storage.MintRoleKey(m.AssetAddress, actor)):           state.Read | state.Write,
 	}
 }
 
@@ -73,8 +74,8 @@ func (m *MintAssetNFT) Execute(
 	if assetType != nconsts.AssetNonFungibleTokenID {
 		return nil, ErrAssetTypeInvalid
 	}
-	if mintAdmin != actor {
-		return nil, ErrWrongMintAdmin
+	if err := checkMinter(ctx, mu, m.AssetAddress, mintAdmin, actor, 1); err != nil {
+		return nil, err
 	}
 	// Ensure that m.AssetAddress is not the same as uri
 	if m.AssetAddress.String() != string(uri) {
@@ -0,0 +1,190 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	OpenUsageChannelComputeUnits = 5
+)
+
+var (
+	ErrUsageChannelExists               = errors.New("usage channel already exists")
+	ErrUsageDepositInvalid              = errors.New("usage channel deposit is invalid")
+	ErrUsageBuyerInvalid                = errors.New("usage channel buyer can't sign receipts")
+	_                      chain.Action = (*OpenUsageChannel)(nil)
+)
+
+type OpenUsageChannel struct {
+	// Marketplace asset address of the listing the usage is paid for
+	MarketplaceAssetAddress codec.Address `serialize:"true" json:"marketplace_asset_address"`
+
+	// Asset the usage is paid with. It must be accepted by the listing.
+	PaymentAssetAddress codec.Address `serialize:"true" json:"payment_asset_address"`
+
+	// Distinguishes the channels of a buyer for the same listing and payment
+	// asset. A channel can never be opened twice.
+	Nonce uint64 `serialize:"true" json:"nonce"`
+
+	// Amount locked in the channel to pay for the usage
+	Deposit uint64 `serialize:"true" json:"deposit"`
+
+	// Number of blocks after which the buyer can close the channel and get
+	// back what was not settled
+	NumBlocks uint64 `serialize:"true" json:"num_blocks"`
+}
+
+func (*OpenUsageChannel) GetTypeID() uint8 {
+	return nconsts.OpenUsageChannelID
+}
+
+func (o *OpenUsageChannel) StateKeys(actor codec.Address) state.Keys {
+	channelAddress := storage.UsageChannelAddress(o.MarketplaceAssetAddress, o.PaymentAssetAddress, actor, o.Nonce)
+	return state.Keys{
+		string(storage.UsageChannelKey(channelAddress)):                                         state.All,
+		string(storage.AssetInfoKey(o.MarketplaceAssetAddress)):                                 state.Read | state.Write,
+		string(storage.MarketplacePaymentAssetsKey(o.MarketplaceAssetAddress)):                  state.All,
+		string(storage.MarketplacePaymentKey(o.MarketplaceAssetAddress, o.PaymentAssetAddress)): state.All,
+		string(storage.AssetInfoKey(o.PaymentAssetAddress)):                                     state.Read,
+		string(storage.AssetAccountBalanceKey(o.PaymentAssetAddress, actor)):                    state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(o.PaymentAssetAddress, channelAddress)):           state.All,
+	}
+}
+
+func (o *OpenUsageChannel) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if o.Deposit == 0 {
+		return nil, ErrUsageDepositInvalid
+	}
+	if o.NumBlocks < dataset.GetDatasetConfig().MinBlocksToSubscribe {
+		return nil, ErrOutputNumBlocksToSubscribeInvalid
+	}
+	// Usage can only be settled with receipts signed by the key of the buyer
+	if !UsageReceiptSigner(actor) {
+		return nil, ErrUsageBuyerInvalid
+	}
+	channelAddress := storage.UsageChannelAddress(o.MarketplaceAssetAddress, o.PaymentAssetAddress, actor, o.Nonce)
+	exists, _, _, _, _, _, _, _, err := storage.GetUsageChannelNoController(ctx, mu, channelAddress)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrUsageChannelExists
+	}
+
+	if err := migrateMarketplacePayment(ctx, mu, o.MarketplaceAssetAddress, o.PaymentAssetAddress); err != nil {
+		return nil, err
+	}
+
+	// Ensure the listing is open and accepts the payment asset
+	assetType, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, o.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if assetType != nconsts.AssetMarketplaceTokenID {
+		return nil, ErrAssetTypeInvalid
+	}
+	metadataMap, err := utils.BytesToMap(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if status, ok := metadataMap["status"]; ok && status != storage.MarketplaceStatusActive {
+		return nil, ErrMarketplaceListingNotActive
+	}
+	paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, mu, o.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if paymentAsset, ok := storage.MarketplacePaymentAssetOf(paymentAssets, o.PaymentAssetAddress); !ok || !paymentAsset.Accepted {
+		return nil, ErrPaymentAssetNotSupported
+	}
+
+	// Lock the deposit in the channel
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, o.PaymentAssetAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < o.Deposit {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+	if _, _, err := storage.TransferAsset(ctx, mu, o.PaymentAssetAddress, actor, channelAddress, o.Deposit); err != nil {
+		return nil, err
+	}
+	expirationBlock := emission.GetEmission().GetLastAcceptedBlockHeight() + o.NumBlocks
+	if err := storage.SetUsageChannel(ctx, mu, channelAddress, o.MarketplaceAssetAddress, o.PaymentAssetAddress, actor, o.Deposit, 0, expirationBlock, false); err != nil {
+		return nil, err
+	}
+
+	return &OpenUsageChannelResult{
+		Actor:               actor.String(),
+		Receiver:            "",
+		UsageChannelAddress: channelAddress.String(),
+		Deposit:             o.Deposit,
+		ExpirationBlock:     expirationBlock,
+	}, nil
+}
+
+func (*OpenUsageChannel) ComputeUnits(chain.Rules) uint64 {
+	return OpenUsageChannelComputeUnits
+}
+
+func (*OpenUsageChannel) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalOpenUsageChannel(p *codec.Packer) (chain.Action, error) {
+	var open OpenUsageChannel
+	p.UnpackAddress(&open.MarketplaceAssetAddress)
+	p.UnpackAddress(&open.PaymentAssetAddress)
+	open.Nonce = p.UnpackUint64(false)
+	open.Deposit = p.UnpackUint64(true)
+	open.NumBlocks = p.UnpackUint64(true)
+	return &open, p.Err()
+}
+
+var _ codec.Typed = (*OpenUsageChannelResult)(nil)
+
+type OpenUsageChannelResult struct {
+	Actor               string `serialize:"true" json:"actor"`
+	Receiver            string `serialize:"true" json:"receiver"`
+	UsageChannelAddress string `serialize:"true" json:"usage_channel_address"`
+	Deposit             uint64 `serialize:"true" json:"deposit"`
+	ExpirationBlock     uint64 `serialize:"true" json:"expiration_block"`
+}
+
+func (*OpenUsageChannelResult) GetTypeID() uint8 {
+	return nconsts.OpenUsageChannelID
+}
+
+func UnmarshalOpenUsageChannelResult(p *codec.Packer) (codec.Typed, error) {
+	var result OpenUsageChannelResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.UsageChannelAddress = p.UnpackString(true)
+	result.Deposit = p.UnpackUint64(true)
+	result.ExpirationBlock = p.UnpackUint64(true)
+	return &result, p.Err()
+}
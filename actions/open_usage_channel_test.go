@@ -0,0 +1,186 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newUsageListingStore returns the store of a listing paid in NAI along with
+// the balance of [buyer]
+func newUsageListingStore(t *testing.T, datasetAddress codec.Address, owner codec.Address, buyer codec.Address, balance uint64) state.Mutable {
+	store := newListingStore(t, datasetAddress, owner, 0)
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, storage.NAIAddress, nconsts.AssetFungibleTokenID, []byte(nconsts.Name), []byte(nconsts.Symbol), nconsts.Decimals, []byte(nconsts.Metadata), nil, 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, buyer, balance))
+	return store
+}
+
+func TestOpenUsageChannelAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	buyer := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	channelAddress := storage.UsageChannelAddress(marketplaceAssetAddress, storage.NAIAddress, buyer, 0)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "DepositInvalid",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 0,
+				NumBlocks:               10,
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 1000),
+			ExpectedErr: ErrUsageDepositInvalid,
+		},
+		{
+			Name:  "NumBlocksInvalid",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 500,
+				NumBlocks:               1, // Below the minimum to subscribe
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 1000),
+			ExpectedErr: ErrOutputNumBlocksToSubscribeInvalid,
+		},
+		{
+			Name:  "BuyerInvalid",
+			Actor: codec.CreateAddress(nconsts.SponsoredAddressID, ids.GenerateTestID()), // Can't sign receipts
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 500,
+				NumBlocks:               10,
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 1000),
+			ExpectedErr: ErrUsageBuyerInvalid,
+		},
+		{
+			Name:  "PaymentAssetNotSupported",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     codectest.NewRandomAddress(),
+				Deposit:                 500,
+				NumBlocks:               10,
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 1000),
+			ExpectedErr: ErrPaymentAssetNotSupported,
+		},
+		{
+			Name:  "ListingPaused",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 500,
+				NumBlocks:               10,
+			},
+			State: func() state.Mutable {
+				store := newUsageListingStore(t, datasetAddress, owner, buyer, 1000)
+				_, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, _, _, _, _, _, err := storage.GetAssetInfoNoController(context.Background(), store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				metadataMap, err := utils.BytesToMap(metadata)
+				require.NoError(t, err)
+				metadataMap["status"] = storage.MarketplaceStatusPaused
+				metadata, err = utils.MapToBytes(metadataMap)
+				require.NoError(t, err)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				return store
+			}(),
+			ExpectedErr: ErrMarketplaceListingNotActive,
+		},
+		{
+			Name:  "InsufficientBalance",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 5000,
+				NumBlocks:               10,
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 1000),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ChannelExists",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 500,
+				NumBlocks:               10,
+			},
+			State: func() state.Mutable {
+				store := newUsageListingStore(t, datasetAddress, owner, buyer, 1000)
+				// A closed channel can't be reopened either
+				require.NoError(t, storage.SetUsageChannel(context.Background(), store, channelAddress, marketplaceAssetAddress, storage.NAIAddress, buyer, 500, 500, 110, true))
+				return store
+			}(),
+			ExpectedErr: ErrUsageChannelExists,
+		},
+		{
+			Name:  "OpenUsageChannel",
+			Actor: buyer,
+			Action: &OpenUsageChannel{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Deposit:                 500,
+				NumBlocks:               10,
+			},
+			State: newUsageListingStore(t, datasetAddress, owner, buyer, 1000),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The deposit is locked in the channel
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, buyer)
+				require.NoError(t, err)
+				require.Equal(t, uint64(500), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, channelAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(500), balance)
+
+				exists, marketplaceAsset, paymentAsset, channelBuyer, deposit, settled, expirationBlock, closed, err := storage.GetUsageChannelNoController(ctx, store, channelAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, marketplaceAssetAddress, marketplaceAsset)
+				require.Equal(t, storage.NAIAddress, paymentAsset)
+				require.Equal(t, buyer, channelBuyer)
+				require.Equal(t, uint64(500), deposit)
+				require.Zero(t, settled)
+				require.Equal(t, uint64(110), expirationBlock)
+				require.False(t, closed)
+			},
+			ExpectedOutputs: &OpenUsageChannelResult{
+				Actor:               buyer.String(),
+				Receiver:            "",
+				UsageChannelAddress: channelAddress.String(),
+				Deposit:             500,
+				ExpirationBlock:     110,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ProposeOwnershipTransferComputeUnits = 1
+)
+
+var (
+	ErrOwnershipKindInvalid                   = errors.New("ownership kind is invalid")
+	ErrOwnershipRoleInvalid                   = errors.New("ownership role is invalid")
+	ErrOwnershipTransferToSelf                = errors.New("role is already held by the new owner")
+	ErrOwnershipTransferNotFound              = errors.New("ownership transfer not found")
+	_                            chain.Action = (*ProposeOwnershipTransfer)(nil)
+)
+
+// ProposeOwnershipTransfer proposes to hand a role of an asset, a dataset or
+// a marketplace listing to another account. Nothing changes until the account
+// accepts it with [AcceptOwnershipTransfer]. A dataset and its listing have
+// owners of their own that are handed off separately.
+type ProposeOwnershipTransfer struct {
+	// Kind of what [Address] is. One of the nconsts.OwnershipKind values.
+	Kind uint8 `serialize:"true" json:"kind"`
+
+	// Address of the asset, dataset or marketplace asset of the listing
+	Address codec.Address `serialize:"true" json:"address"`
+
+	// Role to hand off. One of the nconsts.OwnershipRole values.
+	Role uint8 `serialize:"true" json:"role"`
+
+	// NewOwner the role is proposed to. An empty address cancels the pending
+	// proposal.
+	NewOwner codec.Address `serialize:"true" json:"new_owner"`
+}
+
+func (*ProposeOwnershipTransfer) GetTypeID() uint8 {
+	return nconsts.ProposeOwnershipTransferID
+}
+
+func (p *ProposeOwnershipTransfer) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(ownershipInfoKey(p.Kind, p.Address)):                     state.Read,
+		string(storage.OwnershipTransferKey(p.Kind, p.Address, p.Role)): state.All,
+	}
+}
+
+func (p *ProposeOwnershipTransfer) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	owner, holder, err := getOwnershipHolder(ctx, mu, p.Kind, p.Address, p.Role)
+	if err != nil {
+		return nil, err
+	}
+	// Only the owner can hand off any of the roles
+	if owner != actor {
+		return nil, ErrWrongOwner
+	}
+
+	if p.NewOwner == codec.EmptyAddress {
+		exists, _, _, err := storage.GetOwnershipTransferNoController(ctx, mu, p.Kind, p.Address, p.Role)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrOwnershipTransferNotFound
+		}
+		if err := storage.DeleteOwnershipTransfer(ctx, mu, p.Kind, p.Address, p.Role); err != nil {
+			return nil, err
+		}
+		return &ProposeOwnershipTransferResult{
+			Actor: actor.String(),
+		}, nil
+	}
+	if p.NewOwner == holder {
+		return nil, ErrOwnershipTransferToSelf
+	}
+	if err := storage.SetOwnershipTransfer(ctx, mu, p.Kind, p.Address, p.Role, p.NewOwner, actor); err != nil {
+		return nil, err
+	}
+
+	return &ProposeOwnershipTransferResult{
+		Actor:    actor.String(),
+		Receiver: p.NewOwner.String(),
+	}, nil
+}
+
+func (*ProposeOwnershipTransfer) ComputeUnits(chain.Rules) uint64 {
+	return ProposeOwnershipTransferComputeUnits
+}
+
+func (*ProposeOwnershipTransfer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalProposeOwnershipTransfer(p *codec.Packer) (chain.Action, error) {
+	var propose ProposeOwnershipTransfer
+	propose.Kind = p.UnpackByte()
+	p.UnpackAddress(&propose.Address)
+	propose.Role = p.UnpackByte()
+	unpackAddressOrEmpty(p, &propose.NewOwner)
+	return &propose, p.Err()
+}
+
+// ownershipInfoKey returns the key of the info holding the roles of the
+// [kind] at [address]
+func ownershipInfoKey(kind uint8, address codec.Address) []byte {
+	if kind == nconsts.OwnershipKindDataset {
+		return storage.DatasetInfoKey(address)
+	}
+	return storage.AssetInfoKey(address)
+}
+
+// getOwnershipHolder returns the owner of the [kind] at [address] and the
+// account that holds [role]
+func getOwnershipHolder(ctx context.Context, im state.Immutable, kind uint8, address codec.Address, role uint8) (codec.Address, codec.Address, error) {
+	switch kind {
+	case nconsts.OwnershipKindAsset, nconsts.OwnershipKindListing:
+		assetType, _, _, _, _, _, _, _, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, im, address)
+		if err != nil {
+			return codec.EmptyAddress, codec.EmptyAddress, ErrAssetNotFound
+		}
+		// Listings are the marketplace assets of datasets and are handed off
+		// on their own
+		if (kind == nconsts.OwnershipKindListing) != (assetType == nconsts.AssetMarketplaceTokenID) {
+			return codec.EmptyAddress, codec.EmptyAddress, ErrOwnershipKindInvalid
+		}
+		if kind == nconsts.OwnershipKindListing && role != nconsts.OwnershipRoleOwner {
+			return codec.EmptyAddress, codec.EmptyAddress, ErrOwnershipRoleInvalid
+		}
+		switch role {
+		case nconsts.OwnershipRoleOwner:
+			return owner, owner, nil
+		case nconsts.OwnershipRoleMintAdmin:
+			return owner, mintAdmin, nil
+		case nconsts.OwnershipRolePauseUnpauseAdmin:
+			return owner, pauseUnpauseAdmin, nil
+		case nconsts.OwnershipRoleFreezeUnfreezeAdmin:
+			return owner, freezeUnfreezeAdmin, nil
+		case nconsts.OwnershipRoleEnableDisableKYCAccountAdmin:
+			return owner, enableDisableKYCAccountAdmin, nil
+		default:
+			return codec.EmptyAddress, codec.EmptyAddress, ErrOwnershipRoleInvalid
+		}
+	case nconsts.OwnershipKindDataset:
+		_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, owner, err := storage.GetDatasetInfoNoController(ctx, im, address)
+		if err != nil {
+			return codec.EmptyAddress, codec.EmptyAddress, ErrDatasetNotFound
+		}
+		if role != nconsts.OwnershipRoleOwner {
+			return codec.EmptyAddress, codec.EmptyAddress, ErrOwnershipRoleInvalid
+		}
+		return owner, owner, nil
+	default:
+		return codec.EmptyAddress, codec.EmptyAddress, ErrOwnershipKindInvalid
+	}
+}
+
+// setOwnershipHolder hands [role] of the [kind] at [address] to [holder]
+func setOwnershipHolder(ctx context.Context, mu state.Mutable, kind uint8, address codec.Address, role uint8, holder codec.Address) error {
+	if kind == nconsts.OwnershipKindDataset {
+		name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, baseAssetAddress, basePrice, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, _, err := storage.GetDatasetInfoNoController(ctx, mu, address)
+		if err != nil {
+			return err
+		}
+		return storage.SetDatasetInfo(ctx, mu, address, name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, baseAssetAddress, basePrice, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, holder)
+	}
+
+	assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, mu, address)
+	if err != nil {
+		return err
+	}
+	switch role {
+	case nconsts.OwnershipRoleOwner:
+		owner = holder
+	case nconsts.OwnershipRoleMintAdmin:
+		mintAdmin = holder
+	case nconsts.OwnershipRolePauseUnpauseAdmin:
+		pauseUnpauseAdmin = holder
+	case nconsts.OwnershipRoleFreezeUnfreezeAdmin:
+		freezeUnfreezeAdmin = holder
+	case nconsts.OwnershipRoleEnableDisableKYCAccountAdmin:
+		enableDisableKYCAccountAdmin = holder
+	default:
+		return ErrOwnershipRoleInvalid
+	}
+	return storage.SetAssetInfo(ctx, mu, address, assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin)
+}
+
+var _ codec.Typed = (*ProposeOwnershipTransferResult)(nil)
+
+type ProposeOwnershipTransferResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"` // Empty when the proposal is cancelled
+}
+
+func (*ProposeOwnershipTransferResult) GetTypeID() uint8 {
+	return nconsts.ProposeOwnershipTransferID
+}
+
+func UnmarshalProposeOwnershipTransferResult(p *codec.Packer) (codec.Typed, error) {
+	var result ProposeOwnershipTransferResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newOwnershipStore sets up an asset and a dataset at [address] and a
+// marketplace listing at [listingAddress] all owned by [owner]
+func newOwnershipStore(t *testing.T, owner codec.Address, address codec.Address, listingAddress codec.Address) state.Mutable {
+	store := chaintest.NewInMemoryStore()
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, address, nconsts.AssetFractionalTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), []byte(address.String()), 0, 1000, owner, owner, owner, owner, owner))
+	require.NoError(t, storage.SetDatasetInfo(context.Background(), store, address, []byte("name"), []byte("description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("metadata"), false, listingAddress, storage.NAIAddress, 1, 100, 0, 100, 0, owner))
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, listingAddress, nconsts.AssetMarketplaceTokenID, []byte(storage.MarketplaceAssetName), []byte(storage.MarketplaceAssetSymbol), 0, []byte("metadata"), []byte(listingAddress.String()), 0, 0, owner, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	return store
+}
+
+func TestProposeOwnershipTransferAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	newOwner := codectest.NewRandomAddress()
+	address := codectest.NewRandomAddress()
+	listingAddress := codectest.NewRandomAddress()
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: newOwner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:     nconsts.OwnershipKindAsset,
+				Address:  address,
+				Role:     nconsts.OwnershipRoleOwner,
+				NewOwner: newOwner,
+			},
+			State:       newOwnershipStore(t, owner, address, listingAddress),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "ListingAsAsset",
+			Actor: owner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:     nconsts.OwnershipKindAsset,
+				Address:  listingAddress,
+				Role:     nconsts.OwnershipRoleOwner,
+				NewOwner: newOwner,
+			},
+			State:       newOwnershipStore(t, owner, address, listingAddress),
+			ExpectedErr: ErrOwnershipKindInvalid,
+		},
+		{
+			Name:  "DatasetAdminRole",
+			Actor: owner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:     nconsts.OwnershipKindDataset,
+				Address:  address,
+				Role:     nconsts.OwnershipRoleMintAdmin,
+				NewOwner: newOwner,
+			},
+			State:       newOwnershipStore(t, owner, address, listingAddress),
+			ExpectedErr: ErrOwnershipRoleInvalid,
+		},
+		{
+			Name:  "AlreadyHeld",
+			Actor: owner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:     nconsts.OwnershipKindAsset,
+				Address:  address,
+				Role:     nconsts.OwnershipRoleMintAdmin,
+				NewOwner: owner,
+			},
+			State:       newOwnershipStore(t, owner, address, listingAddress),
+			ExpectedErr: ErrOwnershipTransferToSelf,
+		},
+		{
+			Name:  "CancelWithoutProposal",
+			Actor: owner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindDataset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleOwner,
+			},
+			State:       newOwnershipStore(t, owner, address, listingAddress),
+			ExpectedErr: ErrOwnershipTransferNotFound,
+		},
+		{
+			Name:  "ValidProposal",
+			Actor: owner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:     nconsts.OwnershipKindListing,
+				Address:  listingAddress,
+				Role:     nconsts.OwnershipRoleOwner,
+				NewOwner: newOwner,
+			},
+			State: newOwnershipStore(t, owner, address, listingAddress),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, proposed, proposer, err := storage.GetOwnershipTransferNoController(ctx, store, nconsts.OwnershipKindListing, listingAddress, nconsts.OwnershipRoleOwner)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, newOwner, proposed)
+				require.Equal(t, owner, proposer)
+				// Nothing changes until the proposal is accepted
+				_, _, _, _, _, _, _, _, listingOwner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, listingAddress)
+				require.NoError(t, err)
+				require.Equal(t, owner, listingOwner)
+			},
+			ExpectedOutputs: &ProposeOwnershipTransferResult{
+				Actor:    owner.String(),
+				Receiver: newOwner.String(),
+			},
+		},
+		{
+			Name:  "ValidCancel",
+			Actor: owner,
+			Action: &ProposeOwnershipTransfer{
+				Kind:    nconsts.OwnershipKindAsset,
+				Address: address,
+				Role:    nconsts.OwnershipRoleOwner,
+			},
+			State: func() state.Mutable {
+				store := newOwnershipStore(t, owner, address, listingAddress)
+				require.NoError(t, storage.SetOwnershipTransfer(context.Background(), store, nconsts.OwnershipKindAsset, address, nconsts.OwnershipRoleOwner, newOwner, owner))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, err := storage.GetOwnershipTransferNoController(ctx, store, nconsts.OwnershipKindAsset, address, nconsts.OwnershipRoleOwner)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+			ExpectedOutputs: &ProposeOwnershipTransferResult{
+				Actor: owner.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
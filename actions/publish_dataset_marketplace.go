@@ -5,7 +5,9 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/storage"
@@ -22,7 +24,11 @@ const (
 	PublishDatasetMarketplaceComputeUnits = 5
 )
 
-var _ chain.Action = (*PublishDatasetMarketplace)(nil)
+var (
+	ErrMarketplacePaymentPending                  = errors.New("marketplace payment must be claimed first")
+	ErrMarketplacePaymentNotMigrated              = errors.New("marketplace payments must first be migrated with the primary payment asset of the listing")
+	_                                chain.Action = (*PublishDatasetMarketplace)(nil)
+)
 
 type PublishDatasetMarketplace struct {
 	// DatasetAddress
@@ -45,8 +51,10 @@ func (*PublishDatasetMarketplace) GetTypeID() uint8 {
 func (d *PublishDatasetMarketplace) StateKeys(_ codec.Address) state.Keys {
 	marketplaceAssetAddress := storage.AssetAddressFractional(d.DatasetAddress)
 	return state.Keys{
-		string(storage.AssetInfoKey(marketplaceAssetAddress)): state.All,
-		string(storage.DatasetInfoKey(d.DatasetAddress)):      state.Read | state.Write,
+		string(storage.AssetInfoKey(marketplaceAssetAddress)):                                 state.All,
+		string(storage.DatasetInfoKey(d.DatasetAddress)):                                      state.Read | state.Write,
+		string(storage.MarketplacePaymentAssetsKey(marketplaceAssetAddress)):                  state.All,
+		string(storage.MarketplacePaymentKey(marketplaceAssetAddress, d.PaymentAssetAddress)): state.All,
 	}
 }
 
@@ -60,8 +68,10 @@ func (d *PublishDatasetMarketplace) Execute(
 ) (codec.Typed, error) {
 	marketplaceAssetAddress := storage.AssetAddressFractional(d.DatasetAddress)
 
-	// Check if the marketplace asset already exists
-	if storage.AssetExists(ctx, mu, marketplaceAssetAddress) {
+	// Check if the marketplace asset already exists. Only an unpublished
+	// listing can be published again.
+	republish := storage.AssetExists(ctx, mu, marketplaceAssetAddress)
+	if republish && !listingUnpublished(ctx, mu, marketplaceAssetAddress) {
 		return nil, ErrAssetExists
 	}
 
@@ -75,6 +85,12 @@ func (d *PublishDatasetMarketplace) Execute(
 		return nil, ErrWrongOwner
 	}
 
+	// An unpublished listing is published again with its marketplace asset,
+	// which keeps the subscriptions and payments of the listing
+	if republish {
+		return d.republish(ctx, mu, actor, marketplaceAssetAddress, name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner)
+	}
+
 	// Update the dataset
 	if err := storage.SetDatasetInfo(ctx, mu, d.DatasetAddress, name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, d.PaymentAssetAddress, d.DatasetPricePerBlock, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner); err != nil {
 		return nil, err
@@ -88,10 +104,8 @@ func (d *PublishDatasetMarketplace) Execute(
 	metadataMap["datasetPricePerBlock"] = fmt.Sprint(d.DatasetPricePerBlock)
 	metadataMap["paymentAssetAddress"] = d.PaymentAssetAddress.String()
 	metadataMap["publisher"] = actor.String()
-	metadataMap["lastClaimedBlock"] = "0"
 	metadataMap["subscriptions"] = "0"
-	metadataMap["paymentRemaining"] = "0"
-	metadataMap["paymentClaimed"] = "0"
+	metadataMap["status"] = storage.MarketplaceStatusActive
 	// Convert the map to a JSON string
 	metadata, err = utils.MapToBytes(metadataMap)
 	if err != nil {
@@ -101,6 +115,80 @@ func (d *PublishDatasetMarketplace) Execute(
 	if err := storage.SetAssetInfo(ctx, mu, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte(storage.MarketplaceAssetName), []byte(storage.MarketplaceAssetSymbol), 0, metadata, []byte(marketplaceAssetAddress.String()), 0, 0, actor, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress); err != nil {
 		return nil, err
 	}
+	// The price and the payments of every payment asset are kept apart from
+	// the metadata
+	if err := storage.AcceptMarketplacePayment(ctx, mu, marketplaceAssetAddress, d.PaymentAssetAddress, d.DatasetPricePerBlock); err != nil {
+		return nil, err
+	}
+
+	return &PublishDatasetMarketplaceResult{
+		Actor:                   actor.String(),
+		Receiver:                "",
+		MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+		PaymentAssetAddress:     d.PaymentAssetAddress.String(),
+		DatasetPricePerBlock:    d.DatasetPricePerBlock,
+		Publisher:               actor.String(),
+	}, nil
+}
+
+func (d *PublishDatasetMarketplace) republish(
+	ctx context.Context,
+	mu state.Mutable,
+	actor codec.Address,
+	marketplaceAssetAddress codec.Address,
+	name, description, categories, licenseName, licenseSymbol, licenseURL, metadata []byte,
+	isCommunityDataset bool,
+	revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut uint8,
+	owner codec.Address,
+) (codec.Typed, error) {
+	if err := migrateMarketplacePayment(ctx, mu, marketplaceAssetAddress, d.PaymentAssetAddress); err != nil {
+		return nil, err
+	}
+	assetType, assetName, symbol, decimals, assetMetadata, uri, totalSupply, maxSupply, listingOwner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	metadataMap, err := utils.BytesToMap(assetMetadata)
+	if err != nil {
+		return nil, err
+	}
+	// Payments left to claim stay with the owner of the listing
+	pending, err := storage.MarketplacePaymentPending(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if listingOwner != actor && pending {
+		return nil, ErrMarketplacePaymentPending
+	}
+
+	// The listing is published again with only the payment asset given, while
+	// payments left in any other asset can still be claimed
+	paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	for _, paymentAsset := range paymentAssets {
+		if err := storage.RejectMarketplacePayment(ctx, mu, marketplaceAssetAddress, paymentAsset.Address); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.AcceptMarketplacePayment(ctx, mu, marketplaceAssetAddress, d.PaymentAssetAddress, d.DatasetPricePerBlock); err != nil {
+		return nil, err
+	}
+	metadataMap["datasetPricePerBlock"] = fmt.Sprint(d.DatasetPricePerBlock)
+	metadataMap["paymentAssetAddress"] = d.PaymentAssetAddress.String()
+	metadataMap["publisher"] = actor.String()
+	metadataMap["status"] = storage.MarketplaceStatusActive
+	assetMetadata, err = utils.MapToBytes(metadataMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAssetInfo(ctx, mu, marketplaceAssetAddress, assetType, assetName, symbol, decimals, assetMetadata, uri, totalSupply, maxSupply, actor, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin); err != nil {
+		return nil, err
+	}
+	if err := storage.SetDatasetInfo(ctx, mu, d.DatasetAddress, name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, d.PaymentAssetAddress, d.DatasetPricePerBlock, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner); err != nil {
+		return nil, err
+	}
 
 	return &PublishDatasetMarketplaceResult{
 		Actor:                   actor.String(),
@@ -112,6 +200,75 @@ func (d *PublishDatasetMarketplace) Execute(
 	}, nil
 }
 
+// listingUnpublished returns whether the listing of [marketplaceAssetAddress]
+// was unpublished
+func listingUnpublished(ctx context.Context, im state.Immutable, marketplaceAssetAddress codec.Address) bool {
+	_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, im, marketplaceAssetAddress)
+	if err != nil {
+		return false
+	}
+	metadataMap, err := utils.BytesToMap(metadata)
+	return err == nil && metadataMap["status"] == storage.MarketplaceStatusUnpublished
+}
+
+// migrateMarketplacePayment moves the price and the payments of
+// [paymentAssetAddress] that listings published before payment assets were
+// kept apart track in the metadata of [marketplaceAssetAddress] to
+// [storage.MarketplacePaymentKey]. Other listings are left as they are. Only
+// the primary payment asset of the listing can be migrated, as the actions
+// only declare the payment key of the asset they are given.
+func migrateMarketplacePayment(ctx context.Context, mu state.Mutable, marketplaceAssetAddress codec.Address, paymentAssetAddress codec.Address) error {
+	if !storage.AssetExists(ctx, mu, marketplaceAssetAddress) {
+		return nil
+	}
+	assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return err
+	}
+	if assetType != nconsts.AssetMarketplaceTokenID {
+		return nil
+	}
+	metadataMap, err := utils.BytesToMap(metadata)
+	if err != nil {
+		return err
+	}
+	if _, ok := metadataMap["paymentRemaining"]; !ok {
+		return nil
+	}
+	if metadataMap["paymentAssetAddress"] != paymentAssetAddress.String() {
+		return ErrMarketplacePaymentNotMigrated
+	}
+
+	// Missing fields were never set and are zero
+	values := make(map[string]uint64, 4)
+	for _, field := range []string{"datasetPricePerBlock", "paymentRemaining", "paymentClaimed", "lastClaimedBlock"} {
+		if metadataMap[field] == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(metadataMap[field], 10, 64)
+		if err != nil {
+			return err
+		}
+		values[field] = value
+	}
+	if err := storage.AcceptMarketplacePayment(ctx, mu, marketplaceAssetAddress, paymentAssetAddress, values["datasetPricePerBlock"]); err != nil {
+		return err
+	}
+	if err := storage.UpdateMarketplacePayment(ctx, mu, marketplaceAssetAddress, paymentAssetAddress, values["paymentRemaining"], values["paymentClaimed"], values["lastClaimedBlock"]); err != nil {
+		return err
+	}
+
+	// The payments are only tracked by the payment keys from now on
+	delete(metadataMap, "paymentRemaining")
+	delete(metadataMap, "paymentClaimed")
+	delete(metadataMap, "lastClaimedBlock")
+	metadata, err = utils.MapToBytes(metadataMap)
+	if err != nil {
+		return err
+	}
+	return storage.SetAssetInfo(ctx, mu, marketplaceAssetAddress, assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin)
+}
+
 func (*PublishDatasetMarketplace) ComputeUnits(chain.Rules) uint64 {
 	return PublishDatasetMarketplaceComputeUnits
 }
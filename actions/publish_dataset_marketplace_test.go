@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/nuklai/nuklaivm/utils"
 	"github.com/stretchr/testify/require"
@@ -57,6 +58,26 @@ func TestPublishDatasetMarketplaceAction(t *testing.T) {
 			}(),
 			ExpectedErr: ErrWrongOwner,
 		},
+		{
+			Name:  "RepublishPaymentPending",
+			Actor: actor,
+			Action: &PublishDatasetMarketplace{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  baseAssetAddress,
+				DatasetPricePerBlock: 100,
+			},
+			State: func() state.Mutable {
+				// The listing was unpublished by its previous owner with payments
+				// left to claim
+				previousOwner := codectest.NewRandomAddress()
+				store := newListingStore(t, datasetAddress, previousOwner, 1000)
+				_, err := (&UnpublishDatasetMarketplace{DatasetAddress: datasetAddress}).Execute(context.Background(), nil, store, 0, previousOwner, ids.Empty)
+				require.NoError(t, err)
+				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), false, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, actor))
+				return store
+			}(),
+			ExpectedErr: ErrMarketplacePaymentPending,
+		},
 		{
 			Name:  "ValidPublishDataset",
 			Actor: actor,
@@ -96,10 +117,19 @@ func TestPublishDatasetMarketplaceAction(t *testing.T) {
 				require.Equal(t, "100", metadataMap["datasetPricePerBlock"])
 				require.Equal(t, baseAssetAddress.String(), metadataMap["paymentAssetAddress"])
 				require.Equal(t, actor.String(), metadataMap["publisher"])
-				require.Equal(t, "0", metadataMap["lastClaimedBlock"])
 				require.Equal(t, "0", metadataMap["subscriptions"])
-				require.Equal(t, "0", metadataMap["paymentRemaining"])
-				require.Equal(t, "0", metadataMap["paymentClaimed"])
+				require.Equal(t, storage.MarketplaceStatusActive, metadataMap["status"])
+
+				// Check the payment accounting of the listing
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, []storage.MarketplacePaymentAsset{{Address: baseAssetAddress, Accepted: true}}, paymentAssets)
+				price, remaining, claimed, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), price)
+				require.Zero(t, remaining)
+				require.Zero(t, claimed)
+				require.Zero(t, lastClaimedBlock)
 
 				// Check if the dataset was updated correctly
 				_, _, _, _, _, _, _, _, mAddr, baseAsset, basePrice, _, _, _, _, _, err := storage.GetDatasetInfoNoController(ctx, store, datasetAddress)
@@ -169,10 +199,7 @@ func BenchmarkPublishDatasetMarketplace(b *testing.B) {
 			require.Equal("100", metadataMap["datasetPricePerBlock"])
 			require.Equal(baseAssetAddress.String(), metadataMap["paymentAssetAddress"])
 			require.Equal(actor.String(), metadataMap["publisher"])
-			require.Equal("0", metadataMap["lastClaimedBlock"])
 			require.Equal("0", metadataMap["subscriptions"])
-			require.Equal("0", metadataMap["paymentRemaining"])
-			require.Equal("0", metadataMap["paymentClaimed"])
 
 			// Check if the dataset was updated correctly
 			_, _, _, _, _, _, _, _, mAddr, baseAsset, basePrice, _, _, _, _, _, err := storage.GetDatasetInfoNoController(ctx, store, datasetAddress)
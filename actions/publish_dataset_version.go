@@ -0,0 +1,147 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	PublishDatasetVersionComputeUnits = 5
+)
+
+var (
+	ErrDatasetContentHashInvalid              = errors.New("dataset content hash is invalid")
+	ErrDatasetChangelogInvalid                = errors.New("dataset changelog is invalid")
+	ErrDatasetVersionInvalid                  = errors.New("dataset version is not the next version")
+	_                            chain.Action = (*PublishDatasetVersion)(nil)
+)
+
+// PublishDatasetVersion commits to a new version of the data of a dataset so
+// that anyone can check the data they got against the version that was
+// current when they got it
+type PublishDatasetVersion struct {
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+
+	// Version to publish. Must be the next version of the dataset, starting
+	// at 1, so that the key of the version is known ahead of execution.
+	Version uint64 `serialize:"true" json:"version"`
+
+	// Content hash or Merkle root of the data of the version
+	ContentHash string `serialize:"true" json:"content_hash"`
+
+	// Size of the data of the version in bytes
+	Size uint64 `serialize:"true" json:"size"`
+
+	// Changes since the previous version
+	Changelog string `serialize:"true" json:"changelog"`
+}
+
+func (*PublishDatasetVersion) GetTypeID() uint8 {
+	return nconsts.PublishDatasetVersionID
+}
+
+func (p *PublishDatasetVersion) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.DatasetInfoKey(p.DatasetAddress)):               state.Read,
+		string(storage.DatasetVersionsKey(p.DatasetAddress)):           state.All,
+		string(storage.DatasetVersionKey(p.DatasetAddress, p.Version)): state.Allocate | state.Write,
+	}
+}
+
+func (p *PublishDatasetVersion) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Check if the dataset exists
+	_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, owner, err := storage.GetDatasetInfoNoController(ctx, mu, p.DatasetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if actor != owner {
+		return nil, ErrWrongOwner
+	}
+
+	if len(p.ContentHash) == 0 || len(p.ContentHash) > storage.MaxDatasetContentHashSize {
+		return nil, ErrDatasetContentHashInvalid
+	}
+	if p.Size == 0 {
+		return nil, ErrValueZero
+	}
+	if len(p.Changelog) > storage.MaxTextSize {
+		return nil, ErrDatasetChangelogInvalid
+	}
+
+	versions, err := storage.GetDatasetVersionsNoController(ctx, mu, p.DatasetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if p.Version != versions+1 {
+		return nil, ErrDatasetVersionInvalid
+	}
+	if err := storage.SetDatasetVersion(ctx, mu, p.DatasetAddress, p.Version, []byte(p.ContentHash), p.Size, timestamp, emission.GetEmission().GetLastAcceptedBlockHeight(), []byte(p.Changelog)); err != nil {
+		return nil, err
+	}
+
+	return &PublishDatasetVersionResult{
+		Actor:    actor.String(),
+		Receiver: "",
+		Version:  p.Version,
+	}, nil
+}
+
+func (*PublishDatasetVersion) ComputeUnits(chain.Rules) uint64 {
+	return PublishDatasetVersionComputeUnits
+}
+
+func (*PublishDatasetVersion) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalPublishDatasetVersion(p *codec.Packer) (chain.Action, error) {
+	var publish PublishDatasetVersion
+	p.UnpackAddress(&publish.DatasetAddress)
+	publish.Version = p.UnpackUint64(true)
+	publish.ContentHash = p.UnpackString(true)
+	publish.Size = p.UnpackUint64(true)
+	publish.Changelog = p.UnpackString(false)
+	return &publish, p.Err()
+}
+
+var _ codec.Typed = (*PublishDatasetVersionResult)(nil)
+
+type PublishDatasetVersionResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Version  uint64 `serialize:"true" json:"version"`
+}
+
+func (*PublishDatasetVersionResult) GetTypeID() uint8 {
+	return nconsts.PublishDatasetVersionID
+}
+
+func UnmarshalPublishDatasetVersionResult(p *codec.Packer) (codec.Typed, error) {
+	var result PublishDatasetVersionResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Version = p.UnpackUint64(true)
+	return &result, p.Err()
+}
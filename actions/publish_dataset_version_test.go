@@ -0,0 +1,115 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestPublishDatasetVersionAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+
+	newStore := func(versions uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), false, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, owner))
+		for version := uint64(1); version <= versions; version++ {
+			require.NoError(t, storage.SetDatasetVersion(context.Background(), store, datasetAddress, version, []byte("hash"), 10, 0, version*10, nil))
+		}
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: codectest.NewRandomAddress(), // Not the owner of the dataset
+			Action: &PublishDatasetVersion{
+				DatasetAddress: datasetAddress,
+				Version:        1,
+				ContentHash:    "hash",
+				Size:           10,
+			},
+			State:       newStore(0),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "ContentHashTooLong",
+			Actor: owner,
+			Action: &PublishDatasetVersion{
+				DatasetAddress: datasetAddress,
+				Version:        1,
+				ContentHash:    strings.Repeat("a", storage.MaxDatasetContentHashSize+1),
+				Size:           10,
+			},
+			State:       newStore(0),
+			ExpectedErr: ErrDatasetContentHashInvalid,
+		},
+		{
+			Name:  "NotNextVersion",
+			Actor: owner,
+			Action: &PublishDatasetVersion{
+				DatasetAddress: datasetAddress,
+				Version:        1, // Version 1 already exists
+				ContentHash:    "hash",
+				Size:           10,
+			},
+			State:       newStore(1),
+			ExpectedErr: ErrDatasetVersionInvalid,
+		},
+		{
+			Name:  "ValidPublish",
+			Actor: owner,
+			Action: &PublishDatasetVersion{
+				DatasetAddress: datasetAddress,
+				Version:        3,
+				ContentHash:    "0x1234",
+				Size:           2048,
+				Changelog:      "Added 2023 records",
+			},
+			State: newStore(2),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				versions, err := storage.GetDatasetVersionsNoController(ctx, store, datasetAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(3), versions)
+
+				contentHash, size, _, height, changelog, err := storage.GetDatasetVersionNoController(ctx, store, datasetAddress, 3)
+				require.NoError(t, err)
+				require.Equal(t, "0x1234", string(contentHash))
+				require.Equal(t, uint64(2048), size)
+				require.Equal(t, uint64(100), height)
+				require.Equal(t, "Added 2023 records", string(changelog))
+
+				// Earlier versions are kept
+				contentHash, _, _, height, _, err = storage.GetDatasetVersionNoController(ctx, store, datasetAddress, 1)
+				require.NoError(t, err)
+				require.Equal(t, "hash", string(contentHash))
+				require.Equal(t, uint64(10), height)
+			},
+			ExpectedOutputs: &PublishDatasetVersionResult{
+				Actor:    owner.String(),
+				Receiver: "",
+				Version:  3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	RedeemNFTComputeUnits = 5
+)
+
+var (
+	ErrNFTNotFractionalized              = errors.New("NFT is not fractionalized")
+	ErrNotAllShares                      = errors.New("all the shares are required to redeem the NFT")
+	_                       chain.Action = (*RedeemNFT)(nil)
+)
+
+type RedeemNFT struct {
+	// AssetAddress is the NFT collection address
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// AssetNftAddress of the fractionalized NFT
+	AssetNftAddress codec.Address `serialize:"true" json:"asset_nft_address"`
+}
+
+func (*RedeemNFT) GetTypeID() uint8 {
+	return nconsts.RedeemNFTID
+}
+
+func (r *RedeemNFT) StateKeys(actor codec.Address) state.Keys {
+	sharesAddress := storage.AssetAddressFractional(r.AssetNftAddress)
+	stateKeys := state.Keys{
+		string(storage.AssetInfoKey(sharesAddress)):                              state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(sharesAddress, actor)):             state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(r.AssetNftAddress, sharesAddress)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(r.AssetNftAddress, actor)):         state.All,
+	}
+	storage.AddNFTTransferStateKeys(stateKeys, r.AssetNftAddress, r.AssetAddress, sharesAddress, actor)
+	return stateKeys
+}
+
+func (r *RedeemNFT) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Retrieve nft info
+	_, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, r.AssetNftAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	if r.AssetAddress.String() != string(uri) || r.AssetAddress == r.AssetNftAddress {
+		return nil, ErrNFTDoesNotBelongToTheCollection
+	}
+
+	// Retrieve shares info
+	sharesAddress := storage.AssetAddressFractional(r.AssetNftAddress)
+	if !storage.AssetExists(ctx, mu, sharesAddress) {
+		return nil, ErrNFTNotFractionalized
+	}
+	_, _, _, _, _, _, totalSupply, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, sharesAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that the actor holds every share left
+	shares, err := storage.GetAssetAccountBalanceNoController(ctx, mu, sharesAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if shares == 0 || shares != totalSupply {
+		return nil, ErrNotAllShares
+	}
+
+	if _, err := storage.BurnAsset(ctx, mu, sharesAddress, actor, shares); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteAsset(ctx, mu, sharesAddress); err != nil {
+		return nil, err
+	}
+	if _, _, err := storage.TransferAsset(ctx, mu, r.AssetNftAddress, sharesAddress, actor, 1); err != nil {
+		return nil, err
+	}
+
+	return &RedeemNFTResult{
+		Actor:    actor.String(),
+		Receiver: actor.String(),
+		Shares:   shares,
+	}, nil
+}
+
+func (*RedeemNFT) ComputeUnits(chain.Rules) uint64 {
+	return RedeemNFTComputeUnits
+}
+
+func (*RedeemNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalRedeemNFT(p *codec.Packer) (chain.Action, error) {
+	var redeem RedeemNFT
+	p.UnpackAddress(&redeem.AssetAddress)
+	p.UnpackAddress(&redeem.AssetNftAddress)
+	return &redeem, p.Err()
+}
+
+var _ codec.Typed = (*RedeemNFTResult)(nil)
+
+type RedeemNFTResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Shares   uint64 `serialize:"true" json:"shares"` // Burned to redeem the NFT
+}
+
+func (*RedeemNFTResult) GetTypeID() uint8 {
+	return nconsts.RedeemNFTID
+}
+
+func UnmarshalRedeemNFTResult(p *codec.Packer) (codec.Typed, error) {
+	var result RedeemNFTResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Shares = p.UnpackUint64(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestRedeemNFTAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	holder := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), owner)
+	nftAddress := storage.AssetAddressNFT(assetAddress, []byte("metadata"), owner)
+	sharesAddress := storage.AssetAddressFractional(nftAddress)
+
+	// newStore sets up the NFT fractionalized into 100 shares of which
+	// [holderShares] are held by [holder] and the rest by [owner]
+	newStore := func(holderShares uint64) state.Mutable {
+		store := newNFTStore(t, owner, assetAddress, nftAddress)
+		_, err := (&FractionalizeNFT{
+			AssetAddress:    assetAddress,
+			AssetNftAddress: nftAddress,
+			Shares:          100,
+		}).Execute(context.Background(), nil, store, 0, owner, ids.Empty)
+		require.NoError(t, err)
+		_, _, err = storage.TransferAsset(context.Background(), store, sharesAddress, owner, holder, holderShares)
+		require.NoError(t, err)
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NotFractionalized",
+			Actor: owner,
+			Action: &RedeemNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+			},
+			State:       newNFTStore(t, owner, assetAddress, nftAddress),
+			ExpectedErr: ErrNFTNotFractionalized,
+		},
+		{
+			Name:  "NotAllShares",
+			Actor: holder,
+			Action: &RedeemNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+			},
+			State:       newStore(99),
+			ExpectedErr: ErrNotAllShares,
+		},
+		{
+			Name:  "ValidRedeem",
+			Actor: holder,
+			Action: &RedeemNFT{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+			},
+			State: newStore(100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				require.False(t, storage.AssetExists(ctx, store, sharesAddress))
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, sharesAddress, holder)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+
+				// The holder of every share gets the NFT
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, holder)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, sharesAddress)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+			},
+			ExpectedOutputs: &RedeemNFTResult{
+				Actor:    holder.String(),
+				Receiver: holder.String(),
+				Shares:   100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
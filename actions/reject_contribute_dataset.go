@@ -0,0 +1,176 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	RejectContributeDatasetComputeUnits = 5
+)
+
+var _ chain.Action = (*RejectContributeDataset)(nil)
+
+type RejectContributeDataset struct {
+	// Contribution ID
+	DatasetContributionID string `serialize:"true" json:"dataset_contribution_id"`
+
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+
+	// DatasetContributor
+	DatasetContributor codec.Address `serialize:"true" json:"dataset_contributor"`
+
+	// Whether the collateral is slashed to the dataset owner instead of being
+	// refunded to the contributor
+	SlashCollateral bool `serialize:"true" json:"slash_collateral"`
+}
+
+func (*RejectContributeDataset) GetTypeID() uint8 {
+	return nconsts.RejectContributeDatasetID
+}
+
+func (d *RejectContributeDataset) StateKeys(actor codec.Address) state.Keys {
+	datasetContributionID, _ := ids.FromString(d.DatasetContributionID)
+	collateralAssetAddress := dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution
+	return state.Keys{
+		string(storage.DatasetInfoKey(d.DatasetAddress)):                                                         state.Read,
+		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                        state.Read | state.Write,
+		string(storage.DataCommitmentKey(datasetContributionID)):                                                 state.Write,
+		string(storage.AssetInfoKey(codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID))): state.Read,
+		string(storage.AssetAccountBalanceKey(collateralAssetAddress, d.DatasetContributor)):                     state.All,
+		string(storage.AssetAccountBalanceKey(collateralAssetAddress, actor)):                                    state.All,
+	}
+}
+
+func (d *RejectContributeDataset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	datasetContributionID, err := ids.FromString(d.DatasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the dataset exists
+	_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, owner, err := storage.GetDatasetInfoNoController(ctx, mu, d.DatasetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if actor != owner {
+		return nil, ErrWrongOwner
+	}
+
+	// Check if the dataset contribution is pending
+	datasetAddress, _, _, contributor, active, _, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	// A contribution deactivated after failing a data availability challenge
+	// keeps the NFT minted when it was completed
+	if active || storage.AssetExists(ctx, mu, codec.CreateAddress(nconsts.AssetFractionalTokenID, datasetContributionID)) {
+		return nil, ErrDatasetContributionAlreadyComplete
+	}
+	if datasetAddress != d.DatasetAddress {
+		return nil, ErrDatasetAddressMismatch
+	}
+	if contributor != d.DatasetContributor {
+		return nil, ErrDatasetContributorMismatch
+	}
+
+	if err := storage.DeleteDatasetContributionInfo(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteDataCommitment(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+
+	// Hand the collateral to the dataset owner or back to the contributor
+	dataConfig := dataset.GetDatasetConfig()
+	receiver := d.DatasetContributor
+	if d.SlashCollateral {
+		receiver = actor
+	}
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, receiver)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := smath.Add(balance, dataConfig.CollateralAmountForDataContribution)
+	if err != nil {
+		return nil, err
+	}
+	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, receiver, newBalance); err != nil {
+		return nil, err
+	}
+
+	result := &RejectContributeDatasetResult{
+		Actor:                  actor.String(),
+		Receiver:               d.DatasetContributor.String(),
+		CollateralAssetAddress: dataConfig.CollateralAssetAddressForDataContribution.String(),
+	}
+	if d.SlashCollateral {
+		result.CollateralAmountSlashed = dataConfig.CollateralAmountForDataContribution
+	} else {
+		result.CollateralAmountRefunded = dataConfig.CollateralAmountForDataContribution
+	}
+	return result, nil
+}
+
+func (*RejectContributeDataset) ComputeUnits(chain.Rules) uint64 {
+	return RejectContributeDatasetComputeUnits
+}
+
+func (*RejectContributeDataset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalRejectContributeDataset(p *codec.Packer) (chain.Action, error) {
+	var reject RejectContributeDataset
+	reject.DatasetContributionID = p.UnpackString(true)
+	p.UnpackAddress(&reject.DatasetAddress)
+	p.UnpackAddress(&reject.DatasetContributor)
+	reject.SlashCollateral = p.UnpackBool()
+	return &reject, p.Err()
+}
+
+var _ codec.Typed = (*RejectContributeDatasetResult)(nil)
+
+type RejectContributeDatasetResult struct {
+	Actor                    string `serialize:"true" json:"actor"`
+	Receiver                 string `serialize:"true" json:"receiver"`
+	CollateralAssetAddress   string `serialize:"true" json:"collateral_asset_address"`
+	CollateralAmountRefunded uint64 `serialize:"true" json:"collateral_amount_refunded"`
+	CollateralAmountSlashed  uint64 `serialize:"true" json:"collateral_amount_slashed"`
+}
+
+func (*RejectContributeDatasetResult) GetTypeID() uint8 {
+	return nconsts.RejectContributeDatasetID
+}
+
+func UnmarshalRejectContributeDatasetResult(p *codec.Packer) (codec.Typed, error) {
+	var result RejectContributeDatasetResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.CollateralAssetAddress = p.UnpackString(true)
+	result.CollateralAmountRefunded = p.UnpackUint64(false)
+	result.CollateralAmountSlashed = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestRejectContributeDatasetAction(t *testing.T) {
+	const (
+		dataLocation   = "default"
+		dataIdentifier = "data_id_1234"
+	)
+
+	owner := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor)
+	config := dataset.GetDatasetConfig()
+
+	newStore := func(active bool) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), true, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, owner))
+		require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor, active, 0))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: contributor, // Not the owner of the dataset
+			Action: &RejectContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+			},
+			State:       newStore(false),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "ContributionAlreadyCompleted",
+			Actor: owner,
+			Action: &RejectContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+			},
+			State:       newStore(true),
+			ExpectedErr: ErrDatasetContributionAlreadyComplete,
+		},
+		{
+			Name:  "DatasetContributorMismatch",
+			Actor: owner,
+			Action: &RejectContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    codectest.NewRandomAddress(),
+			},
+			State:       newStore(false),
+			ExpectedErr: ErrDatasetContributorMismatch,
+		},
+		{
+			Name:  "ValidRejectionRefund",
+			Actor: owner,
+			Action: &RejectContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+			},
+			State: newStore(false),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				require.False(t, storage.DatasetContributionExists(ctx, store, datasetContributionID))
+
+				// Collateral is refunded to the contributor
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, contributor)
+				require.NoError(t, err)
+				require.Equal(t, config.CollateralAmountForDataContribution, balance)
+			},
+			ExpectedOutputs: &RejectContributeDatasetResult{
+				Actor:                    owner.String(),
+				Receiver:                 contributor.String(),
+				CollateralAssetAddress:   config.CollateralAssetAddressForDataContribution.String(),
+				CollateralAmountRefunded: config.CollateralAmountForDataContribution,
+			},
+		},
+		{
+			Name:  "ValidRejectionSlash",
+			Actor: owner,
+			Action: &RejectContributeDataset{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				DatasetContributor:    contributor,
+				SlashCollateral:       true,
+			},
+			State: newStore(false),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				require.False(t, storage.DatasetContributionExists(ctx, store, datasetContributionID))
+
+				// Collateral goes to the dataset owner
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, owner)
+				require.NoError(t, err)
+				require.Equal(t, config.CollateralAmountForDataContribution, balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, contributor)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+			},
+			ExpectedOutputs: &RejectContributeDatasetResult{
+				Actor:                   owner.String(),
+				Receiver:                contributor.String(),
+				CollateralAssetAddress:  config.CollateralAssetAddressForDataContribution.String(),
+				CollateralAmountSlashed: config.CollateralAmountForDataContribution,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	ReleaseContributionCollateralComputeUnits = 5
+)
+
+var (
+	ErrDataCollateralNotFound              = errors.New("dataset contribution has no locked collateral")
+	ErrDataCollateralLocked                = errors.New("dataset contribution can still be challenged")
+	_                         chain.Action = (*ReleaseContributionCollateral)(nil)
+)
+
+type ReleaseContributionCollateral struct {
+	// Contribution ID
+	DatasetContributionID string `serialize:"true" json:"dataset_contribution_id"`
+
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+}
+
+func (*ReleaseContributionCollateral) GetTypeID() uint8 {
+	return nconsts.ReleaseContributionCollateralID
+}
+
+func (d *ReleaseContributionCollateral) StateKeys(actor codec.Address) state.Keys {
+	datasetContributionID, _ := ids.FromString(d.DatasetContributionID)
+	return state.Keys{
+		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                                   state.Read,
+		string(storage.DataChallengeKey(datasetContributionID)):                                                             state.Read,
+		string(storage.DataCollateralKey(datasetContributionID)):                                                            state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution, actor)): state.All,
+	}
+}
+
+func (d *ReleaseContributionCollateral) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	datasetContributionID, err := ids.FromString(d.DatasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+
+	datasetAddress, _, _, contributor, _, _, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if datasetAddress != d.DatasetAddress {
+		return nil, ErrDatasetAddressMismatch
+	}
+	if contributor != actor {
+		return nil, ErrDatasetContributorMismatch
+	}
+
+	// The collateral is released once the challenge window of the
+	// contribution has ended and no challenge is left to be resolved
+	exists, amount, releaseHeight, err := storage.GetDataCollateralNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrDataCollateralNotFound
+	}
+	if emission.GetEmission().GetLastAcceptedBlockHeight() <= releaseHeight {
+		return nil, ErrDataCollateralLocked
+	}
+	exists, _, _, _, _, err = storage.GetDataChallengeNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDataChallengeAlreadyOpen
+	}
+	if err := storage.DeleteDataCollateral(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+
+	// Refund the collateral back to the contributor
+	dataConfig := dataset.GetDatasetConfig()
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := smath.Add(balance, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor, newBalance); err != nil {
+		return nil, err
+	}
+
+	return &ReleaseContributionCollateralResult{
+		Actor:                    actor.String(),
+		Receiver:                 actor.String(),
+		CollateralAssetAddress:   dataConfig.CollateralAssetAddressForDataContribution.String(),
+		CollateralAmountRefunded: amount,
+	}, nil
+}
+
+func (*ReleaseContributionCollateral) ComputeUnits(chain.Rules) uint64 {
+	return ReleaseContributionCollateralComputeUnits
+}
+
+func (*ReleaseContributionCollateral) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalReleaseContributionCollateral(p *codec.Packer) (chain.Action, error) {
+	var release ReleaseContributionCollateral
+	release.DatasetContributionID = p.UnpackString(true)
+	p.UnpackAddress(&release.DatasetAddress)
+	return &release, p.Err()
+}
+
+var _ codec.Typed = (*ReleaseContributionCollateralResult)(nil)
+
+type ReleaseContributionCollateralResult struct {
+	Actor                    string `serialize:"true" json:"actor"`
+	Receiver                 string `serialize:"true" json:"receiver"`
+	CollateralAssetAddress   string `serialize:"true" json:"collateral_asset_address"`
+	CollateralAmountRefunded uint64 `serialize:"true" json:"collateral_amount_refunded"`
+}
+
+func (*ReleaseContributionCollateralResult) GetTypeID() uint8 {
+	return nconsts.ReleaseContributionCollateralID
+}
+
+func UnmarshalReleaseContributionCollateralResult(p *codec.Packer) (codec.Typed, error) {
+	var result ReleaseContributionCollateralResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.CollateralAssetAddress = p.UnpackString(true)
+	result.CollateralAmountRefunded = p.UnpackUint64(false)
+	return &result, p.Err()
+}
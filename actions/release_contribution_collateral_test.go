@@ -0,0 +1,128 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestReleaseContributionCollateralAction(t *testing.T) {
+	const (
+		dataLocation   = "default"
+		dataIdentifier = "data_id_1234"
+	)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor)
+	config := dataset.GetDatasetConfig()
+
+	newStore := func(releaseHeight uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor, true, 0))
+		require.NoError(t, storage.SetDataCommitment(context.Background(), store, datasetContributionID, make([]byte, storage.DataRootLen), 4))
+		require.NoError(t, storage.SetDataCollateral(context.Background(), store, datasetContributionID, config.CollateralAmountForDataContribution, releaseHeight))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NotContributor",
+			Actor: codectest.NewRandomAddress(),
+			Action: &ReleaseContributionCollateral{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(90),
+			ExpectedErr: ErrDatasetContributorMismatch,
+		},
+		{
+			Name:  "NoLockedCollateral",
+			Actor: contributor,
+			Action: &ReleaseContributionCollateral{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State: func() state.Mutable {
+				store := newStore(90)
+				require.NoError(t, storage.DeleteDataCollateral(context.Background(), store, datasetContributionID))
+				return store
+			}(),
+			ExpectedErr: ErrDataCollateralNotFound,
+		},
+		{
+			Name:  "ChallengeWindowOpen",
+			Actor: contributor,
+			Action: &ReleaseContributionCollateral{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State:       newStore(100),
+			ExpectedErr: ErrDataCollateralLocked,
+		},
+		{
+			Name:  "ChallengeOpen",
+			Actor: contributor,
+			Action: &ReleaseContributionCollateral{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State: func() state.Mutable {
+				store := newStore(90)
+				require.NoError(t, storage.SetDataChallenge(context.Background(), store, datasetContributionID, codectest.NewRandomAddress(), config.ChallengeBondAmount, 1, 95))
+				return store
+			}(),
+			ExpectedErr: ErrDataChallengeAlreadyOpen,
+		},
+		{
+			Name:  "ValidRelease",
+			Actor: contributor,
+			Action: &ReleaseContributionCollateral{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+			},
+			State: newStore(90),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, err := storage.GetDataCollateralNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.False(t, exists)
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, contributor)
+				require.NoError(t, err)
+				require.Equal(t, config.CollateralAmountForDataContribution, balance)
+
+				// The contribution can no longer be challenged
+				_, err = (&ChallengeDatasetContribution{
+					DatasetContributionID: datasetContributionID.String(),
+					DatasetAddress:        datasetAddress,
+				}).Execute(ctx, nil, store, 0, owner, datasetContributionID)
+				require.ErrorIs(t, err, ErrDataChallengeWindowClosed)
+			},
+			ExpectedOutputs: &ReleaseContributionCollateralResult{
+				Actor:                    contributor.String(),
+				Receiver:                 contributor.String(),
+				CollateralAssetAddress:   config.CollateralAssetAddressForDataContribution.String(),
+				CollateralAmountRefunded: config.CollateralAmountForDataContribution,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
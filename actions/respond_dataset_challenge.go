@@ -0,0 +1,177 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	RespondDatasetChallengeComputeUnits = 10
+
+	// Enough sibling hashes for a data root over any number of chunks
+	MaxDataProofSize = 64 * dataset.HashLen
+)
+
+var (
+	ErrDataChallengeNotFound              = errors.New("data challenge not found")
+	ErrDataChallengeExpired               = errors.New("data challenge expired")
+	ErrDataChunkInvalid                   = errors.New("data chunk is invalid")
+	ErrDataProofInvalid                   = errors.New("data proof is invalid")
+	_                        chain.Action = (*RespondDatasetChallenge)(nil)
+)
+
+type RespondDatasetChallenge struct {
+	// Contribution ID
+	DatasetContributionID string `serialize:"true" json:"dataset_contribution_id"`
+
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+
+	// Challenged chunk of the contributed data
+	Chunk []byte `serialize:"true" json:"chunk"`
+
+	// Merkle proof of the chunk against the data root of the contribution
+	Proof []byte `serialize:"true" json:"proof"`
+}
+
+func (*RespondDatasetChallenge) GetTypeID() uint8 {
+	return nconsts.RespondDatasetChallengeID
+}
+
+func (d *RespondDatasetChallenge) StateKeys(actor codec.Address) state.Keys {
+	datasetContributionID, _ := ids.FromString(d.DatasetContributionID)
+	return state.Keys{
+		string(storage.DatasetContributionInfoKey(datasetContributionID)):                                                   state.Read,
+		string(storage.DataCommitmentKey(datasetContributionID)):                                                            state.Read,
+		string(storage.DataChallengeKey(datasetContributionID)):                                                             state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(dataset.GetDatasetConfig().CollateralAssetAddressForDataContribution, actor)): state.All,
+	}
+}
+
+func (d *RespondDatasetChallenge) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	datasetContributionID, err := ids.FromString(d.DatasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+
+	datasetAddress, _, _, contributor, _, _, err := storage.GetDatasetContributionInfoNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if datasetAddress != d.DatasetAddress {
+		return nil, ErrDatasetAddressMismatch
+	}
+	if contributor != actor {
+		return nil, ErrDatasetContributorMismatch
+	}
+
+	exists, challenger, bond, chunkIndex, deadline, err := storage.GetDataChallengeNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrDataChallengeNotFound
+	}
+	if emission.GetEmission().GetLastAcceptedBlockHeight() > deadline {
+		return nil, ErrDataChallengeExpired
+	}
+
+	// Check the chunk against the data root the contributor committed to
+	if len(d.Chunk) == 0 || len(d.Chunk) > dataset.DataChunkSize {
+		return nil, ErrDataChunkInvalid
+	}
+	_, root, chunks, err := storage.GetDataCommitmentNoController(ctx, mu, datasetContributionID)
+	if err != nil {
+		return nil, err
+	}
+	if !dataset.VerifyMerkleProof(root, chunks, chunkIndex, d.Chunk, d.Proof) {
+		return nil, ErrDataProofInvalid
+	}
+
+	if err := storage.DeleteDataChallenge(ctx, mu, datasetContributionID); err != nil {
+		return nil, err
+	}
+
+	// The bond of the challenger goes to the contributor
+	dataConfig := dataset.GetDatasetConfig()
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := smath.Add(balance, bond)
+	if err != nil {
+		return nil, err
+	}
+	if err = storage.SetAssetAccountBalance(ctx, mu, dataConfig.CollateralAssetAddressForDataContribution, actor, newBalance); err != nil {
+		return nil, err
+	}
+
+	return &RespondDatasetChallengeResult{
+		Actor:              actor.String(),
+		Receiver:           challenger.String(),
+		BondAssetAddress:   dataConfig.CollateralAssetAddressForDataContribution.String(),
+		BondAmountReceived: bond,
+	}, nil
+}
+
+func (*RespondDatasetChallenge) ComputeUnits(chain.Rules) uint64 {
+	return RespondDatasetChallengeComputeUnits
+}
+
+func (*RespondDatasetChallenge) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalRespondDatasetChallenge(p *codec.Packer) (chain.Action, error) {
+	var respond RespondDatasetChallenge
+	respond.DatasetContributionID = p.UnpackString(true)
+	p.UnpackAddress(&respond.DatasetAddress)
+	p.UnpackBytes(dataset.DataChunkSize, true, &respond.Chunk)
+	p.UnpackBytes(MaxDataProofSize, false, &respond.Proof)
+	return &respond, p.Err()
+}
+
+var _ codec.Typed = (*RespondDatasetChallengeResult)(nil)
+
+type RespondDatasetChallengeResult struct {
+	Actor              string `serialize:"true" json:"actor"`
+	Receiver           string `serialize:"true" json:"receiver"`
+	BondAssetAddress   string `serialize:"true" json:"bond_asset_address"`
+	BondAmountReceived uint64 `serialize:"true" json:"bond_amount_received"`
+}
+
+func (*RespondDatasetChallengeResult) GetTypeID() uint8 {
+	return nconsts.RespondDatasetChallengeID
+}
+
+func UnmarshalRespondDatasetChallengeResult(p *codec.Packer) (codec.Typed, error) {
+	var result RespondDatasetChallengeResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.BondAssetAddress = p.UnpackString(true)
+	result.BondAmountReceived = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestRespondDatasetChallengeAction(t *testing.T) {
+	const (
+		dataLocation   = "default"
+		dataIdentifier = "data_id_1234"
+		challenged     = 3
+	)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	owner := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	challenger := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	datasetContributionID := storage.DatasetContributionID(datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor)
+	config := dataset.GetDatasetConfig()
+	data := make([]byte, 4800)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	chunks := dataset.SplitChunks(data)
+
+	newStore := func(deadline uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetDatasetContributionInfo(context.Background(), store, datasetContributionID, datasetAddress, []byte(dataLocation), []byte(dataIdentifier), contributor, true, 0))
+		require.NoError(t, storage.SetDataCommitment(context.Background(), store, datasetContributionID, dataset.MerkleRoot(chunks), uint64(len(chunks))))
+		require.NoError(t, storage.SetDataChallenge(context.Background(), store, datasetContributionID, challenger, config.ChallengeBondAmount, challenged, deadline))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NotContributor",
+			Actor: challenger,
+			Action: &RespondDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				Chunk:                 chunks[challenged],
+				Proof:                 dataset.MerkleProof(chunks, challenged),
+			},
+			State:       newStore(110),
+			ExpectedErr: ErrDatasetContributorMismatch,
+		},
+		{
+			Name:  "NoOpenChallenge",
+			Actor: contributor,
+			Action: &RespondDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				Chunk:                 chunks[challenged],
+				Proof:                 dataset.MerkleProof(chunks, challenged),
+			},
+			State: func() state.Mutable {
+				store := newStore(110)
+				require.NoError(t, storage.DeleteDataChallenge(context.Background(), store, datasetContributionID))
+				return store
+			}(),
+			ExpectedErr: ErrDataChallengeNotFound,
+		},
+		{
+			Name:  "ChallengeExpired",
+			Actor: contributor,
+			Action: &RespondDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				Chunk:                 chunks[challenged],
+				Proof:                 dataset.MerkleProof(chunks, challenged),
+			},
+			State:       newStore(99),
+			ExpectedErr: ErrDataChallengeExpired,
+		},
+		{
+			Name:  "WrongChunk",
+			Actor: contributor,
+			Action: &RespondDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				Chunk:                 chunks[challenged-1],
+				Proof:                 dataset.MerkleProof(chunks, challenged-1),
+			},
+			State:       newStore(110),
+			ExpectedErr: ErrDataProofInvalid,
+		},
+		{
+			Name:  "ValidResponse",
+			Actor: contributor,
+			Action: &RespondDatasetChallenge{
+				DatasetContributionID: datasetContributionID.String(),
+				DatasetAddress:        datasetAddress,
+				Chunk:                 chunks[challenged],
+				Proof:                 dataset.MerkleProof(chunks, challenged),
+			},
+			State: newStore(100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, err := storage.GetDataChallengeNoController(ctx, store, datasetContributionID)
+				require.NoError(t, err)
+				require.False(t, exists)
+
+				// The contributor gets the bond
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, config.CollateralAssetAddressForDataContribution, contributor)
+				require.NoError(t, err)
+				require.Equal(t, config.ChallengeBondAmount, balance)
+			},
+			ExpectedOutputs: &RespondDatasetChallengeResult{
+				Actor:              contributor.String(),
+				Receiver:           challenger.String(),
+				BondAssetAddress:   config.CollateralAssetAddressForDataContribution.String(),
+				BondAmountReceived: config.ChallengeBondAmount,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	RevokeMintRoleComputeUnits = 1
+)
+
+var (
+	ErrMintRoleNotFound              = errors.New("mint role not found")
+	_                   chain.Action = (*RevokeMintRole)(nil)
+)
+
+// RevokeMintRole revokes the mint role of an asset from an account
+type RevokeMintRole struct {
+	// AssetAddress the role was granted on
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Account the role was granted to
+	Account codec.Address `serialize:"true" json:"account"`
+}
+
+func (*RevokeMintRole) GetTypeID() uint8 {
+	return nconsts.RevokeMintRoleID
+}
+
+func (r *RevokeMintRole) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(r.AssetAddress)):           state.Read,
+		string(storage.MintRoleKey(r.AssetAddress, r.Account)): state.Read | state.Write,
+	}
+}
+
+func (r *RevokeMintRole) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Only the owner of the asset can revoke the role
+	_, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, r.AssetAddress)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+	if owner != actor {
+		return nil, ErrWrongOwner
+	}
+
+	exists, _, _, _, _, err := storage.GetMintRoleNoController(ctx, mu, r.AssetAddress, r.Account)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrMintRoleNotFound
+	}
+	if err := storage.DeleteMintRole(ctx, mu, r.AssetAddress, r.Account); err != nil {
+		return nil, err
+	}
+
+	return &RevokeMintRoleResult{
+		Actor:    actor.String(),
+		Receiver: r.Account.String(),
+	}, nil
+}
+
+func (*RevokeMintRole) ComputeUnits(chain.Rules) uint64 {
+	return RevokeMintRoleComputeUnits
+}
+
+func (*RevokeMintRole) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalRevokeMintRole(p *codec.Packer) (chain.Action, error) {
+	var revoke RevokeMintRole
+	p.UnpackAddress(&revoke.AssetAddress)
+	p.UnpackAddress(&revoke.Account)
+	return &revoke, p.Err()
+}
+
+var _ codec.Typed = (*RevokeMintRoleResult)(nil)
+
+type RevokeMintRoleResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+}
+
+func (*RevokeMintRoleResult) GetTypeID() uint8 {
+	return nconsts.RevokeMintRoleID
+}
+
+func UnmarshalRevokeMintRoleResult(p *codec.Packer) (codec.Typed, error) {
+	var result RevokeMintRoleResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	return &result, p.Err()
+}
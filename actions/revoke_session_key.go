@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	RevokeSessionKeyComputeUnits = 1
+)
+
+var (
+	ErrSessionKeyNotFound              = errors.New("session key not found")
+	_                     chain.Action = (*RevokeSessionKey)(nil)
+)
+
+type RevokeSessionKey struct {
+	// SessionKey is the address of the session key to revoke
+	SessionKey codec.Address `serialize:"true" json:"session_key"`
+}
+
+func (*RevokeSessionKey) GetTypeID() uint8 {
+	return nconsts.RevokeSessionKeyID
+}
+
+func (r *RevokeSessionKey) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.SessionKeyKey(actor, r.SessionKey)): state.Read | state.Write,
+	}
+}
+
+func (r *RevokeSessionKey) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, _, _, _, err := storage.GetSessionKeyNoController(ctx, mu, actor, r.SessionKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrSessionKeyNotFound
+	}
+
+	if err := storage.DeleteSessionKey(ctx, mu, actor, r.SessionKey); err != nil {
+		return nil, err
+	}
+
+	return &RevokeSessionKeyResult{
+		Actor:    actor.String(),
+		Receiver: r.SessionKey.String(),
+	}, nil
+}
+
+func (*RevokeSessionKey) ComputeUnits(chain.Rules) uint64 {
+	return RevokeSessionKeyComputeUnits
+}
+
+func (*RevokeSessionKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalRevokeSessionKey(p *codec.Packer) (chain.Action, error) {
+	var revoke RevokeSessionKey
+	p.UnpackAddress(&revoke.SessionKey)
+	return &revoke, p.Err()
+}
+
+var _ codec.Typed = (*RevokeSessionKeyResult)(nil)
+
+type RevokeSessionKeyResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+}
+
+func (*RevokeSessionKeyResult) GetTypeID() uint8 {
+	return nconsts.RevokeSessionKeyID
+}
+
+func UnmarshalRevokeSessionKeyResult(p *codec.Packer) (codec.Typed, error) {
+	var result RevokeSessionKeyResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestRevokeSessionKeyAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	sessionKey := codectest.NewRandomAddress()
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "SessionKeyNotFound",
+			Actor: actor,
+			Action: &RevokeSessionKey{
+				SessionKey: sessionKey,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyNotFound,
+		},
+		{
+			Name:  "ValidRevoke",
+			Actor: actor,
+			Action: &RevokeSessionKey{
+				SessionKey: sessionKey,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetSessionKey(context.Background(), store, actor, sessionKey, 200, []byte{nconsts.TransferID}, nil))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, err := storage.GetSessionKeyNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+			ExpectedOutputs: &RevokeSessionKeyResult{
+				Actor:    actor.String(),
+				Receiver: sessionKey.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	RevokeVestingScheduleComputeUnits = 5
+)
+
+var (
+	ErrNotRevocationAuthority              = errors.New("not the revocation authority of the vesting schedule")
+	_                         chain.Action = (*RevokeVestingSchedule)(nil)
+)
+
+type RevokeVestingSchedule struct {
+	// VestingAddress of the schedule to revoke
+	VestingAddress codec.Address `serialize:"true" json:"vesting_address"`
+
+	// AssetAddress locked by the schedule
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Beneficiary of the schedule that receives what has already vested
+	Beneficiary codec.Address `serialize:"true" json:"beneficiary"`
+}
+
+func (*RevokeVestingSchedule) GetTypeID() uint8 {
+	return nconsts.RevokeVestingScheduleID
+}
+
+func (r *RevokeVestingSchedule) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.VestingScheduleKey(r.VestingAddress)):                     state.Read | state.Write,
+		string(storage.AssetInfoKey(r.AssetAddress)):                             state.Read,
+		string(storage.AssetAccountBalanceKey(r.AssetAddress, r.VestingAddress)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(r.AssetAddress, r.Beneficiary)):    state.All,
+		string(storage.AssetAccountBalanceKey(r.AssetAddress, actor)):            state.All,
+	}
+}
+
+func (r *RevokeVestingSchedule) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, asset, beneficiary, _, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock, err := storage.GetVestingScheduleNoController(ctx, mu, r.VestingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrVestingScheduleNotFound
+	}
+	if revocationAuthority == codec.EmptyAddress || revocationAuthority != actor {
+		return nil, ErrNotRevocationAuthority
+	}
+	if asset != r.AssetAddress || beneficiary != r.Beneficiary {
+		return nil, ErrVestingScheduleInvalid
+	}
+
+	// What has vested is paid out to the beneficiary and the rest goes back to
+	// the revocation authority
+	vested := storage.VestedAmount(amount, startBlock, cliffBlock, endBlock, emission.GetEmission().GetLastAcceptedBlockHeight())
+	vested -= claimed
+	refund := amount - claimed - vested
+
+	if err := storage.DeleteVestingSchedule(ctx, mu, r.VestingAddress); err != nil {
+		return nil, err
+	}
+	if vested > 0 {
+		if _, _, err := storage.TransferAsset(ctx, mu, asset, r.VestingAddress, beneficiary, vested); err != nil {
+			return nil, err
+		}
+	}
+	if refund > 0 {
+		if _, _, err := storage.TransferAsset(ctx, mu, asset, r.VestingAddress, actor, refund); err != nil {
+			return nil, err
+		}
+	}
+
+	return &RevokeVestingScheduleResult{
+		Actor:    actor.String(),
+		Receiver: beneficiary.String(),
+		Vested:   vested,
+		Refund:   refund,
+	}, nil
+}
+
+func (*RevokeVestingSchedule) ComputeUnits(chain.Rules) uint64 {
+	return RevokeVestingScheduleComputeUnits
+}
+
+func (*RevokeVestingSchedule) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalRevokeVestingSchedule(p *codec.Packer) (chain.Action, error) {
+	var revoke RevokeVestingSchedule
+	p.UnpackAddress(&revoke.VestingAddress)
+	p.UnpackAddress(&revoke.AssetAddress)
+	p.UnpackAddress(&revoke.Beneficiary)
+	return &revoke, p.Err()
+}
+
+var _ codec.Typed = (*RevokeVestingScheduleResult)(nil)
+
+type RevokeVestingScheduleResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Vested   uint64 `serialize:"true" json:"vested"` // Paid out to the beneficiary
+	Refund   uint64 `serialize:"true" json:"refund"` // Returned to the actor
+}
+
+func (*RevokeVestingScheduleResult) GetTypeID() uint8 {
+	return nconsts.RevokeVestingScheduleID
+}
+
+func UnmarshalRevokeVestingScheduleResult(p *codec.Packer) (codec.Typed, error) {
+	var result RevokeVestingScheduleResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.Vested = p.UnpackUint64(false)
+	result.Refund = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestRevokeVestingScheduleAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	funder := codectest.NewRandomAddress()
+	authority := codectest.NewRandomAddress()
+	beneficiary := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), funder)
+
+	// newStore sets up a schedule releasing 1000 from block 50 to block 150 of
+	// which 200 has already been claimed
+	newStore := func(revocationAuthority codec.Address) (codec.Address, state.Mutable) {
+		vestingAddress := storage.VestingAddress(funder, assetAddress, beneficiary, revocationAuthority, 1000, 50, 50, 150)
+		store := newOrderStore(t, funder, assetAddress)
+		require.NoError(t, storage.SetVestingSchedule(context.Background(), store, vestingAddress, assetAddress, beneficiary, funder, revocationAuthority, 1000, 200, 50, 50, 150))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, vestingAddress, 800))
+		return vestingAddress, store
+	}
+	irrevocableAddress, irrevocableStore := newStore(codec.EmptyAddress)
+	vestingAddress, store := newStore(authority)
+	_, otherStore := newStore(authority)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "ScheduleNotFound",
+			Actor: authority,
+			Action: &RevokeVestingSchedule{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+				Beneficiary:    beneficiary,
+			},
+			State:       newOrderStore(t, funder, assetAddress),
+			ExpectedErr: ErrVestingScheduleNotFound,
+		},
+		{
+			Name:  "Irrevocable",
+			Actor: authority,
+			Action: &RevokeVestingSchedule{
+				VestingAddress: irrevocableAddress,
+				AssetAddress:   assetAddress,
+				Beneficiary:    beneficiary,
+			},
+			State:       irrevocableStore,
+			ExpectedErr: ErrNotRevocationAuthority,
+		},
+		{
+			Name:  "NotRevocationAuthority",
+			Actor: funder,
+			Action: &RevokeVestingSchedule{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+				Beneficiary:    beneficiary,
+			},
+			State:       otherStore,
+			ExpectedErr: ErrNotRevocationAuthority,
+		},
+		{
+			Name:  "ValidRevoke",
+			Actor: authority,
+			Action: &RevokeVestingSchedule{
+				VestingAddress: vestingAddress,
+				AssetAddress:   assetAddress,
+				Beneficiary:    beneficiary,
+			},
+			State: store,
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, _, _, _, _, _, err := storage.GetVestingScheduleNoController(ctx, store, vestingAddress)
+				require.NoError(t, err)
+				require.False(t, exists)
+
+				// Half of the amount has vested at block 100
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, beneficiary)
+				require.NoError(t, err)
+				require.Equal(t, uint64(300), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, authority)
+				require.NoError(t, err)
+				require.Equal(t, uint64(500), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, vestingAddress)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+			},
+			ExpectedOutputs: &RevokeVestingScheduleResult{
+				Actor:    authority.String(),
+				Receiver: beneficiary.String(),
+				Vested:   300,
+				Refund:   500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SessionKeyBeginComputeUnits = 1
+)
+
+var (
+	ErrSessionKeyExpired                       = errors.New("session key has expired")
+	ErrSessionKeyActionNotAllowed              = errors.New("action is not allowed for the session key")
+	ErrSessionKeyAssetsMismatch                = errors.New("assets do not match the session key spend limits")
+	_                             chain.Action = (*SessionKeyBegin)(nil)
+)
+
+// SessionKeyBegin is the first action of every transaction signed with a
+// session key. The session key auth guarantees that [ActionTypeIDs] are the
+// types of the actions that follow it and that the transaction ends with a
+// matching [SessionKeyEnd].
+type SessionKeyBegin struct {
+	// SessionKey is the address of the session key signing the transaction
+	SessionKey codec.Address `serialize:"true" json:"session_key"`
+
+	// Type IDs of the actions between this action and [SessionKeyEnd]
+	ActionTypeIDs []byte `serialize:"true" json:"action_type_ids"`
+
+	// Assets the wrapped actions use. It must include every asset the
+	// session key has a spend limit for. The balances of the actor are
+	// recorded so [SessionKeyEnd] can compute how much was spent. Assets
+	// without a spend limit can't be spent at all.
+	AssetAddresses []codec.Address `serialize:"true" json:"asset_addresses"`
+}
+
+func (*SessionKeyBegin) GetTypeID() uint8 {
+	return nconsts.SessionKeyBeginID
+}
+
+func (s *SessionKeyBegin) StateKeys(actor codec.Address) state.Keys {
+	return sessionKeyStateKeys(actor, s.SessionKey, s.AssetAddresses)
+}
+
+func (s *SessionKeyBegin) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, expiryBlock, actionTypeIDs, spendLimits, err := storage.GetSessionKeyNoController(ctx, mu, actor, s.SessionKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrSessionKeyNotFound
+	}
+	if emission.GetEmission().GetLastAcceptedBlockHeight() > expiryBlock {
+		return nil, ErrSessionKeyExpired
+	}
+	for _, actionTypeID := range s.ActionTypeIDs {
+		if !slices.Contains(actionTypeIDs, actionTypeID) {
+			return nil, ErrSessionKeyActionNotAllowed
+		}
+	}
+
+	for _, spendLimit := range spendLimits {
+		if !slices.Contains(s.AssetAddresses, spendLimit.AssetAddress) {
+			return nil, ErrSessionKeyAssetsMismatch
+		}
+	}
+	if hasDuplicates(s.AssetAddresses) {
+		return nil, ErrSessionKeyAssetsMismatch
+	}
+
+	// Record the balance of every asset the wrapped actions use
+	balances := make([]storage.SessionKeyBalance, len(s.AssetAddresses))
+	for i, assetAddress := range s.AssetAddresses {
+		balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, assetAddress, actor)
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = storage.SessionKeyBalance{AssetAddress: assetAddress, Balance: balance}
+	}
+	if err := storage.SetSessionKeySnapshot(ctx, mu, actor, s.SessionKey, balances); err != nil {
+		return nil, err
+	}
+
+	return &SessionKeyBeginResult{
+		Actor:       actor.String(),
+		Receiver:    s.SessionKey.String(),
+		ExpiryBlock: expiryBlock,
+	}, nil
+}
+
+func (*SessionKeyBegin) ComputeUnits(chain.Rules) uint64 {
+	return SessionKeyBeginComputeUnits
+}
+
+func (*SessionKeyBegin) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSessionKeyBegin(p *codec.Packer) (chain.Action, error) {
+	var begin SessionKeyBegin
+	p.UnpackAddress(&begin.SessionKey)
+	p.UnpackBytes(storage.MaxSessionKeyActionTypes, false, &begin.ActionTypeIDs)
+	assetAddresses, err := unpackAddresses(p, storage.MaxSessionKeySpendLimits, ErrSessionKeySpendLimitsInvalid)
+	if err != nil {
+		return nil, err
+	}
+	begin.AssetAddresses = assetAddresses
+	return &begin, p.Err()
+}
+
+// sessionKeyStateKeys are the keys touched by both [SessionKeyBegin] and
+// [SessionKeyEnd]
+func sessionKeyStateKeys(actor codec.Address, sessionKey codec.Address, assetAddresses []codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.SessionKeyKey(actor, sessionKey)):         state.Read | state.Write,
+		string(storage.SessionKeySnapshotKey(actor, sessionKey)): state.All,
+	}
+	for _, assetAddress := range assetAddresses {
+		stateKeys.Add(string(storage.AssetAccountBalanceKey(assetAddress, actor)), state.Read)
+	}
+	return stateKeys
+}
+
+var _ codec.Typed = (*SessionKeyBeginResult)(nil)
+
+type SessionKeyBeginResult struct {
+	Actor       string `serialize:"true" json:"actor"`
+	Receiver    string `serialize:"true" json:"receiver"`
+	ExpiryBlock uint64 `serialize:"true" json:"expiry_block"`
+}
+
+func (*SessionKeyBeginResult) GetTypeID() uint8 {
+	return nconsts.SessionKeyBeginID
+}
+
+func UnmarshalSessionKeyBeginResult(p *codec.Packer) (codec.Typed, error) {
+	var result SessionKeyBeginResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.ExpiryBlock = p.UnpackUint64(true)
+	return &result, p.Err()
+}
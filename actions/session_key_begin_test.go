@@ -0,0 +1,145 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSessionKeyBeginAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	actor := codectest.NewRandomAddress()
+	sessionKey := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+
+	// Registers [sessionKey] for transfers with a NAI spend limit
+	setupState := func(expiryBlock uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetSessionKey(context.Background(), store, actor, sessionKey, expiryBlock, []byte{nconsts.TransferID}, []storage.SessionKeySpendLimit{
+			{AssetAddress: storage.NAIAddress, Limit: 500, Spent: 100},
+		}))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, actor, 1000))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "SessionKeyNotFound",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:     sessionKey,
+				ActionTypeIDs:  []byte{nconsts.TransferID},
+				AssetAddresses: []codec.Address{storage.NAIAddress},
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyNotFound,
+		},
+		{
+			Name:  "SessionKeyExpired",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:     sessionKey,
+				ActionTypeIDs:  []byte{nconsts.TransferID},
+				AssetAddresses: []codec.Address{storage.NAIAddress},
+			},
+			State:       setupState(99),
+			ExpectedErr: ErrSessionKeyExpired,
+		},
+		{
+			Name:  "ActionNotAllowed",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:     sessionKey,
+				ActionTypeIDs:  []byte{nconsts.TransferID, nconsts.MintAssetFTID},
+				AssetAddresses: []codec.Address{storage.NAIAddress},
+			},
+			State:       setupState(200),
+			ExpectedErr: ErrSessionKeyActionNotAllowed,
+		},
+		{
+			Name:  "MissingSpendLimitAsset",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:    sessionKey,
+				ActionTypeIDs: []byte{nconsts.TransferID},
+			},
+			State:       setupState(200),
+			ExpectedErr: ErrSessionKeyAssetsMismatch,
+		},
+		{
+			Name:  "DuplicateAsset",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:     sessionKey,
+				ActionTypeIDs:  []byte{nconsts.TransferID},
+				AssetAddresses: []codec.Address{storage.NAIAddress, storage.NAIAddress},
+			},
+			State:       setupState(200),
+			ExpectedErr: ErrSessionKeyAssetsMismatch,
+		},
+		{
+			Name:  "UncappedAssetRecorded",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:     sessionKey,
+				ActionTypeIDs:  []byte{nconsts.TransferID},
+				AssetAddresses: []codec.Address{storage.NAIAddress, assetAddress},
+			},
+			State: setupState(200),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, balances, err := storage.GetSessionKeySnapshotNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.Equal(t, []storage.SessionKeyBalance{
+					{AssetAddress: storage.NAIAddress, Balance: 1000},
+					{AssetAddress: assetAddress, Balance: 0},
+				}, balances)
+			},
+			ExpectedOutputs: &SessionKeyBeginResult{
+				Actor:       actor.String(),
+				Receiver:    sessionKey.String(),
+				ExpiryBlock: 200,
+			},
+		},
+		{
+			Name:  "ValidBegin",
+			Actor: actor,
+			Action: &SessionKeyBegin{
+				SessionKey:     sessionKey,
+				ActionTypeIDs:  []byte{nconsts.TransferID},
+				AssetAddresses: []codec.Address{storage.NAIAddress},
+			},
+			State: setupState(100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, balances, err := storage.GetSessionKeySnapshotNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, []storage.SessionKeyBalance{
+					{AssetAddress: storage.NAIAddress, Balance: 1000},
+				}, balances)
+			},
+			ExpectedOutputs: &SessionKeyBeginResult{
+				Actor:       actor.String(),
+				Receiver:    sessionKey.String(),
+				ExpiryBlock: 100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SessionKeyEndComputeUnits = 1
+)
+
+var (
+	ErrSessionKeySpendLimitExceeded              = errors.New("session key spend limit exceeded")
+	_                               chain.Action = (*SessionKeyEnd)(nil)
+)
+
+// SessionKeyEnd is the last action of every transaction signed with a session
+// key. It charges whatever left the account since [SessionKeyBegin] against
+// the spend limits of the session key. Assets without a spend limit have a
+// limit of zero.
+type SessionKeyEnd struct {
+	// SessionKey is the address of the session key signing the transaction
+	SessionKey codec.Address `serialize:"true" json:"session_key"`
+
+	// Assets the wrapped actions use, the same as in [SessionKeyBegin]
+	AssetAddresses []codec.Address `serialize:"true" json:"asset_addresses"`
+}
+
+func (*SessionKeyEnd) GetTypeID() uint8 {
+	return nconsts.SessionKeyEndID
+}
+
+func (s *SessionKeyEnd) StateKeys(actor codec.Address) state.Keys {
+	return sessionKeyStateKeys(actor, s.SessionKey, s.AssetAddresses)
+}
+
+func (s *SessionKeyEnd) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, expiryBlock, actionTypeIDs, spendLimits, err := storage.GetSessionKeyNoController(ctx, mu, actor, s.SessionKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrSessionKeyNotFound
+	}
+
+	exists, balances, err := storage.GetSessionKeySnapshotNoController(ctx, mu, actor, s.SessionKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || len(balances) != len(s.AssetAddresses) {
+		return nil, ErrSessionKeyAssetsMismatch
+	}
+	for _, snapshot := range balances {
+		if !slices.Contains(s.AssetAddresses, snapshot.AssetAddress) {
+			return nil, ErrSessionKeyAssetsMismatch
+		}
+		balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, snapshot.AssetAddress, actor)
+		if err != nil {
+			return nil, err
+		}
+		// Funds received during the transaction do not offset the limit
+		if snapshot.Balance <= balance {
+			continue
+		}
+		i := slices.IndexFunc(spendLimits, func(spendLimit storage.SessionKeySpendLimit) bool {
+			return spendLimit.AssetAddress == snapshot.AssetAddress
+		})
+		if i < 0 {
+			return nil, ErrSessionKeySpendLimitExceeded
+		}
+		spent, err := smath.Add(spendLimits[i].Spent, snapshot.Balance-balance)
+		if err != nil {
+			return nil, err
+		}
+		if spent > spendLimits[i].Limit {
+			return nil, ErrSessionKeySpendLimitExceeded
+		}
+		spendLimits[i].Spent = spent
+	}
+	if err := storage.SetSessionKey(ctx, mu, actor, s.SessionKey, expiryBlock, actionTypeIDs, spendLimits); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteSessionKeySnapshot(ctx, mu, actor, s.SessionKey); err != nil {
+		return nil, err
+	}
+
+	return &SessionKeyEndResult{
+		Actor:    actor.String(),
+		Receiver: s.SessionKey.String(),
+	}, nil
+}
+
+func (*SessionKeyEnd) ComputeUnits(chain.Rules) uint64 {
+	return SessionKeyEndComputeUnits
+}
+
+func (*SessionKeyEnd) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSessionKeyEnd(p *codec.Packer) (chain.Action, error) {
+	var end SessionKeyEnd
+	p.UnpackAddress(&end.SessionKey)
+	assetAddresses, err := unpackAddresses(p, storage.MaxSessionKeySpendLimits, ErrSessionKeySpendLimitsInvalid)
+	if err != nil {
+		return nil, err
+	}
+	end.AssetAddresses = assetAddresses
+	return &end, p.Err()
+}
+
+var _ codec.Typed = (*SessionKeyEndResult)(nil)
+
+type SessionKeyEndResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+}
+
+func (*SessionKeyEndResult) GetTypeID() uint8 {
+	return nconsts.SessionKeyEndID
+}
+
+func UnmarshalSessionKeyEndResult(p *codec.Packer) (codec.Typed, error) {
+	var result SessionKeyEndResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	return &result, p.Err()
+}
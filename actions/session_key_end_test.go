@@ -0,0 +1,132 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSessionKeyEndAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	sessionKey := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), actor)
+
+	// Registers [sessionKey] as if [SessionKeyBegin] recorded a NAI balance of
+	// 1000 and an uncapped asset balance of 1000, and sets the current
+	// balances to [balance] and [assetBalance]
+	setupState := func(balance uint64, assetBalance uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetSessionKey(context.Background(), store, actor, sessionKey, 200, []byte{nconsts.TransferID}, []storage.SessionKeySpendLimit{
+			{AssetAddress: storage.NAIAddress, Limit: 500, Spent: 100},
+		}))
+		require.NoError(t, storage.SetSessionKeySnapshot(context.Background(), store, actor, sessionKey, []storage.SessionKeyBalance{
+			{AssetAddress: storage.NAIAddress, Balance: 1000},
+			{AssetAddress: assetAddress, Balance: 1000},
+		}))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, actor, balance))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, actor, assetBalance))
+		return store
+	}
+	assetAddresses := []codec.Address{storage.NAIAddress, assetAddress}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "SessionKeyNotFound",
+			Actor: actor,
+			Action: &SessionKeyEnd{
+				SessionKey:     sessionKey,
+				AssetAddresses: assetAddresses,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSessionKeyNotFound,
+		},
+		{
+			Name:  "MissingSpendLimitAsset",
+			Actor: actor,
+			Action: &SessionKeyEnd{
+				SessionKey: sessionKey,
+			},
+			State:       setupState(1000, 1000),
+			ExpectedErr: ErrSessionKeyAssetsMismatch,
+		},
+		{
+			Name:  "SpendLimitExceeded",
+			Actor: actor,
+			Action: &SessionKeyEnd{
+				SessionKey:     sessionKey,
+				AssetAddresses: assetAddresses,
+			},
+			State:       setupState(599, 1000),
+			ExpectedErr: ErrSessionKeySpendLimitExceeded,
+		},
+		{
+			Name:  "UncappedAssetSpent",
+			Actor: actor,
+			Action: &SessionKeyEnd{
+				SessionKey:     sessionKey,
+				AssetAddresses: assetAddresses,
+			},
+			State:       setupState(1000, 999),
+			ExpectedErr: ErrSessionKeySpendLimitExceeded,
+		},
+		{
+			Name:  "FundsReceived",
+			Actor: actor,
+			Action: &SessionKeyEnd{
+				SessionKey:     sessionKey,
+				AssetAddresses: assetAddresses,
+			},
+			State: setupState(2000, 2000),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, spendLimits, err := storage.GetSessionKeyNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.Equal(t, []storage.SessionKeySpendLimit{
+					{AssetAddress: storage.NAIAddress, Limit: 500, Spent: 100},
+				}, spendLimits)
+			},
+			ExpectedOutputs: &SessionKeyEndResult{
+				Actor:    actor.String(),
+				Receiver: sessionKey.String(),
+			},
+		},
+		{
+			Name:  "ValidEnd",
+			Actor: actor,
+			Action: &SessionKeyEnd{
+				SessionKey:     sessionKey,
+				AssetAddresses: assetAddresses,
+			},
+			State: setupState(600, 1000),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, spendLimits, err := storage.GetSessionKeyNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.Equal(t, []storage.SessionKeySpendLimit{
+					{AssetAddress: storage.NAIAddress, Limit: 500, Spent: 500},
+				}, spendLimits)
+				exists, _, err := storage.GetSessionKeySnapshotNoController(ctx, store, actor, sessionKey)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+			ExpectedOutputs: &SessionKeyEndResult{
+				Actor:    actor.String(),
+				Receiver: sessionKey.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SetDatasetKeyDelegateComputeUnits = 1
+)
+
+var _ chain.Action = (*SetDatasetKeyDelegate)(nil)
+
+// SetDatasetKeyDelegate lets an account, such as a key service, deliver the
+// data keys of a listing on behalf of its owner. The delegate stops working
+// once the listing is transferred to another owner.
+type SetDatasetKeyDelegate struct {
+	// Marketplace asset address of the listing
+	MarketplaceAssetAddress codec.Address `serialize:"true" json:"marketplace_asset_address"`
+
+	// Account delivering the data keys. The empty address removes the
+	// delegate.
+	Delegate codec.Address `serialize:"true" json:"delegate"`
+}
+
+func (*SetDatasetKeyDelegate) GetTypeID() uint8 {
+	return nconsts.SetDatasetKeyDelegateID
+}
+
+func (s *SetDatasetKeyDelegate) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(s.MarketplaceAssetAddress)):          state.Read,
+		string(storage.DatasetKeyDelegateKey(s.MarketplaceAssetAddress)): state.All,
+	}
+}
+
+func (s *SetDatasetKeyDelegate) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Only the owner of the listing chooses who delivers its data keys
+	assetType, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, s.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if assetType != nconsts.AssetMarketplaceTokenID {
+		return nil, ErrAssetTypeInvalid
+	}
+	if owner != actor {
+		return nil, ErrWrongOwner
+	}
+
+	receiver := ""
+	if s.Delegate == codec.EmptyAddress {
+		if err := storage.DeleteDatasetKeyDelegate(ctx, mu, s.MarketplaceAssetAddress); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := storage.SetDatasetKeyDelegate(ctx, mu, s.MarketplaceAssetAddress, owner, s.Delegate); err != nil {
+			return nil, err
+		}
+		receiver = s.Delegate.String()
+	}
+
+	return &SetDatasetKeyDelegateResult{
+		Actor:                   actor.String(),
+		Receiver:                receiver,
+		MarketplaceAssetAddress: s.MarketplaceAssetAddress.String(),
+	}, nil
+}
+
+func (*SetDatasetKeyDelegate) ComputeUnits(chain.Rules) uint64 {
+	return SetDatasetKeyDelegateComputeUnits
+}
+
+func (*SetDatasetKeyDelegate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSetDatasetKeyDelegate(p *codec.Packer) (chain.Action, error) {
+	var set SetDatasetKeyDelegate
+	p.UnpackAddress(&set.MarketplaceAssetAddress)
+	unpackAddressOrEmpty(p, &set.Delegate)
+	return &set, p.Err()
+}
+
+var _ codec.Typed = (*SetDatasetKeyDelegateResult)(nil)
+
+type SetDatasetKeyDelegateResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	MarketplaceAssetAddress string `serialize:"true" json:"marketplace_asset_address"`
+}
+
+func (*SetDatasetKeyDelegateResult) GetTypeID() uint8 {
+	return nconsts.SetDatasetKeyDelegateID
+}
+
+func UnmarshalSetDatasetKeyDelegateResult(p *codec.Packer) (codec.Typed, error) {
+	var result SetDatasetKeyDelegateResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.MarketplaceAssetAddress = p.UnpackString(true)
+	return &result, p.Err()
+}
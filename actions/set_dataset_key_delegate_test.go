@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSetDatasetKeyDelegateAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	delegate := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 1})
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: delegate, // Not the owner of the listing
+			Action: &SetDatasetKeyDelegate{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				Delegate:                delegate,
+			},
+			State:       newListingStore(t, datasetAddress, owner, 0),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "SetDelegate",
+			Actor: owner,
+			Action: &SetDatasetKeyDelegate{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				Delegate:                delegate,
+			},
+			State: newListingStore(t, datasetAddress, owner, 0),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				delegator, storedDelegate, err := storage.GetDatasetKeyDelegateNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, owner, delegator)
+				require.Equal(t, delegate, storedDelegate)
+			},
+			ExpectedOutputs: &SetDatasetKeyDelegateResult{
+				Actor:                   owner.String(),
+				Receiver:                delegate.String(),
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+			},
+		},
+		{
+			Name:  "RemoveDelegate",
+			Actor: owner,
+			Action: &SetDatasetKeyDelegate{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+			},
+			State: func() state.Mutable {
+				store := newListingStore(t, datasetAddress, owner, 0)
+				require.NoError(t, storage.SetDatasetKeyDelegate(context.Background(), store, marketplaceAssetAddress, owner, delegate))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, storedDelegate, err := storage.GetDatasetKeyDelegateNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, codec.EmptyAddress, storedDelegate)
+			},
+			ExpectedOutputs: &SetDatasetKeyDelegateResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestSetDatasetKeyDelegateMarshal(t *testing.T) {
+	require := require.New(t)
+
+	// The empty delegate removes the delegate and must round trip
+	set := &SetDatasetKeyDelegate{
+		MarketplaceAssetAddress: codectest.NewRandomAddress(),
+	}
+	b, err := chain.Marshal(set)
+	require.NoError(err)
+	unmarshalled, err := UnmarshalSetDatasetKeyDelegate(codec.NewReader(b, len(b)))
+	require.NoError(err)
+	require.Equal(set, unmarshalled)
+}
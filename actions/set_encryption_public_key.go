@@ -0,0 +1,147 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SetEncryptionPublicKeyComputeUnits = 5
+)
+
+var (
+	ErrEncryptionPublicKeyInvalid              = errors.New("encryption public key is invalid")
+	_                             chain.Action = (*SetEncryptionPublicKey)(nil)
+)
+
+// SetEncryptionPublicKey registers the encryption public key of the holder of
+// a subscription, replacing the one registered before. The data key delivered
+// to the previous key is cleared and has to be delivered again.
+type SetEncryptionPublicKey struct {
+	// Marketplace asset address of the listing that was subscribed to
+	MarketplaceAssetAddress codec.Address `serialize:"true" json:"marketplace_asset_address"`
+
+	// NFT address of the subscription held by the actor
+	SubscriptionNftAddress codec.Address `serialize:"true" json:"subscription_nft_address"`
+
+	// Public key the data key of the dataset is encrypted to
+	EncryptionPublicKey []byte `serialize:"true" json:"encryption_public_key"`
+}
+
+func (*SetEncryptionPublicKey) GetTypeID() uint8 {
+	return nconsts.SetEncryptionPublicKeyID
+}
+
+func (s *SetEncryptionPublicKey) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(s.MarketplaceAssetAddress)):                 state.Read,
+		string(storage.AssetInfoKey(s.SubscriptionNftAddress)):                  state.Read,
+		string(storage.AssetAccountBalanceKey(s.SubscriptionNftAddress, actor)): state.Read,
+		string(storage.DatasetKeyKey(s.SubscriptionNftAddress)):                 state.All,
+	}
+}
+
+func (s *SetEncryptionPublicKey) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if len(s.EncryptionPublicKey) == 0 || len(s.EncryptionPublicKey) > storage.MaxEncryptionPublicKeySize {
+		return nil, ErrEncryptionPublicKeyInvalid
+	}
+
+	// Check for the listing
+	assetType, _, _, _, _, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, s.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if assetType != nconsts.AssetMarketplaceTokenID {
+		return nil, ErrAssetTypeInvalid
+	}
+
+	// Ensure the NFT is a subscription to the listing
+	if !storage.AssetExists(ctx, mu, s.SubscriptionNftAddress) {
+		return nil, ErrSubscriptionNotFound
+	}
+	nftType, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, s.SubscriptionNftAddress)
+	if err != nil {
+		return nil, err
+	}
+	if nftType != nconsts.AssetNonFungibleTokenID || string(uri) != s.MarketplaceAssetAddress.String() {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	// Only the holder of the subscription registers its key
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, s.SubscriptionNftAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance != 1 {
+		return nil, ErrSubscriptionNotHeld
+	}
+
+	if err := storage.SetDatasetKey(ctx, mu, s.SubscriptionNftAddress, actor, s.EncryptionPublicKey, nil); err != nil {
+		return nil, err
+	}
+
+	return &SetEncryptionPublicKeyResult{
+		Actor:                   actor.String(),
+		Receiver:                "",
+		MarketplaceAssetAddress: s.MarketplaceAssetAddress.String(),
+		SubscriptionNftAddress:  s.SubscriptionNftAddress.String(),
+	}, nil
+}
+
+func (*SetEncryptionPublicKey) ComputeUnits(chain.Rules) uint64 {
+	return SetEncryptionPublicKeyComputeUnits
+}
+
+func (*SetEncryptionPublicKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSetEncryptionPublicKey(p *codec.Packer) (chain.Action, error) {
+	var set SetEncryptionPublicKey
+	p.UnpackAddress(&set.MarketplaceAssetAddress)
+	p.UnpackAddress(&set.SubscriptionNftAddress)
+	p.UnpackBytes(storage.MaxEncryptionPublicKeySize, true, &set.EncryptionPublicKey)
+	return &set, p.Err()
+}
+
+var _ codec.Typed = (*SetEncryptionPublicKeyResult)(nil)
+
+type SetEncryptionPublicKeyResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	MarketplaceAssetAddress string `serialize:"true" json:"marketplace_asset_address"`
+	SubscriptionNftAddress  string `serialize:"true" json:"subscription_nft_address"`
+}
+
+func (*SetEncryptionPublicKeyResult) GetTypeID() uint8 {
+	return nconsts.SetEncryptionPublicKeyID
+}
+
+func UnmarshalSetEncryptionPublicKeyResult(p *codec.Packer) (codec.Typed, error) {
+	var result SetEncryptionPublicKeyResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.MarketplaceAssetAddress = p.UnpackString(true)
+	result.SubscriptionNftAddress = p.UnpackString(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSetEncryptionPublicKeyAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	subscriber := codectest.NewRandomAddress()
+	buyer := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	nftAddress := storage.AssetAddressNFT(marketplaceAssetAddress, nil, subscriber)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 1})
+
+	// newDeliveredStore returns a subscription the data key was delivered to
+	newDeliveredStore := func() state.Mutable {
+		store := newSubscriptionStore(t, datasetAddress, owner, subscriber, []byte("encryption public key"))
+		_, err := (&DeliverDatasetKey{
+			MarketplaceAssetAddress: marketplaceAssetAddress,
+			SubscriptionNftAddress:  nftAddress,
+			Subscriber:              subscriber,
+			EncryptedKey:            []byte("encrypted key"),
+		}).Execute(context.Background(), nil, store, 0, owner, ids.Empty)
+		require.NoError(t, err)
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "EncryptionPublicKeyInvalid",
+			Actor: subscriber,
+			Action: &SetEncryptionPublicKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+			},
+			State:       newDeliveredStore(),
+			ExpectedErr: ErrEncryptionPublicKeyInvalid,
+		},
+		{
+			Name:  "SubscriptionNotFound",
+			Actor: subscriber,
+			Action: &SetEncryptionPublicKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  storage.AssetAddressNFT(marketplaceAssetAddress, nil, owner),
+				EncryptionPublicKey:     []byte("new public key"),
+			},
+			State:       newDeliveredStore(),
+			ExpectedErr: ErrSubscriptionNotFound,
+		},
+		{
+			Name:  "SubscriptionNotHeld",
+			Actor: owner, // Does not hold the subscription
+			Action: &SetEncryptionPublicKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				EncryptionPublicKey:     []byte("new public key"),
+			},
+			State:       newDeliveredStore(),
+			ExpectedErr: ErrSubscriptionNotHeld,
+		},
+		{
+			Name:  "ValidReplaceKey",
+			Actor: subscriber,
+			Action: &SetEncryptionPublicKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				EncryptionPublicKey:     []byte("new public key"),
+			},
+			State: newDeliveredStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The data key has to be delivered again
+				exists, holder, encryptionPublicKey, encryptedKey, err := storage.GetDatasetKeyNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, subscriber, holder)
+				require.Equal(t, []byte("new public key"), encryptionPublicKey)
+				require.Empty(t, encryptedKey)
+			},
+			ExpectedOutputs: &SetEncryptionPublicKeyResult{
+				Actor:                   subscriber.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				SubscriptionNftAddress:  nftAddress.String(),
+			},
+		},
+		{
+			Name:  "NewHolderRegistersKey",
+			Actor: buyer,
+			Action: &SetEncryptionPublicKey{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				SubscriptionNftAddress:  nftAddress,
+				EncryptionPublicKey:     []byte("buyer public key"),
+			},
+			State: func() state.Mutable {
+				store := newDeliveredStore()
+				_, _, err := storage.TransferAsset(context.Background(), store, nftAddress, subscriber, buyer, 1)
+				require.NoError(t, err)
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The previous holder no longer receives the data key
+				_, err := (&DeliverDatasetKey{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					SubscriptionNftAddress:  nftAddress,
+					Subscriber:              subscriber,
+					EncryptedKey:            []byte("encrypted key"),
+				}).Execute(ctx, nil, store, 0, owner, ids.Empty)
+				require.ErrorIs(t, err, ErrEncryptionPublicKeyHolderMismatch)
+				result, err := (&DeliverDatasetKey{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					SubscriptionNftAddress:  nftAddress,
+					Subscriber:              buyer,
+					EncryptedKey:            []byte("buyer encrypted key"),
+				}).Execute(ctx, nil, store, 0, owner, ids.Empty)
+				require.NoError(t, err)
+				require.Equal(t, buyer.String(), result.(*DeliverDatasetKeyResult).Receiver)
+			},
+			ExpectedOutputs: &SetEncryptionPublicKeyResult{
+				Actor:                   buyer.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				SubscriptionNftAddress:  nftAddress.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
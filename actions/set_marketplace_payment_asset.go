@@ -0,0 +1,168 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SetMarketplacePaymentAssetComputeUnits = 5
+)
+
+var (
+	ErrPrimaryPaymentAsset              = errors.New("primary payment asset is set with the listing")
+	_                      chain.Action = (*SetMarketplacePaymentAsset)(nil)
+)
+
+type SetMarketplacePaymentAsset struct {
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+
+	// Asset accepted by the listing besides its primary payment asset
+	PaymentAssetAddress codec.Address `serialize:"true" json:"payment_asset_address"`
+
+	// Price per block of new subscriptions paid with the asset. Existing
+	// subscriptions keep the price recorded in their NFT.
+	DatasetPricePerBlock uint64 `serialize:"true" json:"dataset_price_per_block"`
+
+	// Whether new subscriptions can pay with the asset. Payments already made
+	// with an asset that is no longer accepted can still be claimed.
+	Accepted bool `serialize:"true" json:"accepted"`
+}
+
+func (*SetMarketplacePaymentAsset) GetTypeID() uint8 {
+	return nconsts.SetMarketplacePaymentAssetID
+}
+
+func (s *SetMarketplacePaymentAsset) StateKeys(_ codec.Address) state.Keys {
+	marketplaceAssetAddress := storage.AssetAddressFractional(s.DatasetAddress)
+	return state.Keys{
+		string(storage.AssetInfoKey(marketplaceAssetAddress)):                                 state.Read | state.Write,
+		string(storage.AssetInfoKey(s.PaymentAssetAddress)):                                   state.Read,
+		string(storage.DatasetInfoKey(s.DatasetAddress)):                                      state.Read,
+		string(storage.MarketplacePaymentAssetsKey(marketplaceAssetAddress)):                  state.All,
+		string(storage.MarketplacePaymentKey(marketplaceAssetAddress, s.PaymentAssetAddress)): state.All,
+	}
+}
+
+func (s *SetMarketplacePaymentAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Check if the dataset is on the marketplace
+	_, _, _, _, _, _, _, _, marketplaceAssetAddress, paymentAssetAddress, _, _, _, _, _, _, err := storage.GetDatasetInfoNoController(ctx, mu, s.DatasetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if marketplaceAssetAddress == codec.EmptyAddress {
+		return nil, ErrDatasetNotOnSale
+	}
+
+	if err := migrateMarketplacePayment(ctx, mu, marketplaceAssetAddress, s.PaymentAssetAddress); err != nil {
+		return nil, err
+	}
+
+	// Only the owner of the listing can change the assets it accepts
+	_, _, _, _, _, _, _, _, listingOwner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if listingOwner != actor {
+		return nil, ErrWrongOwner
+	}
+	if paymentAssetAddress == s.PaymentAssetAddress {
+		return nil, ErrPrimaryPaymentAsset
+	}
+
+	// The price and the payments of every payment asset are kept apart from
+	// the metadata of the listing
+	if s.Accepted {
+		// Check that the payment asset exists
+		if !storage.AssetExists(ctx, mu, s.PaymentAssetAddress) {
+			return nil, ErrAssetNotFound
+		}
+		if err := storage.AcceptMarketplacePayment(ctx, mu, marketplaceAssetAddress, s.PaymentAssetAddress, s.DatasetPricePerBlock); err != nil {
+			return nil, err
+		}
+	} else {
+		paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, mu, marketplaceAssetAddress)
+		if err != nil {
+			return nil, err
+		}
+		if paymentAsset, ok := storage.MarketplacePaymentAssetOf(paymentAssets, s.PaymentAssetAddress); !ok || !paymentAsset.Accepted {
+			return nil, ErrPaymentAssetNotSupported
+		}
+		if err := storage.RejectMarketplacePayment(ctx, mu, marketplaceAssetAddress, s.PaymentAssetAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SetMarketplacePaymentAssetResult{
+		Actor:                   actor.String(),
+		Receiver:                "",
+		MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+		PaymentAssetAddress:     s.PaymentAssetAddress.String(),
+		DatasetPricePerBlock:    s.DatasetPricePerBlock,
+		Accepted:                s.Accepted,
+	}, nil
+}
+
+func (*SetMarketplacePaymentAsset) ComputeUnits(chain.Rules) uint64 {
+	return SetMarketplacePaymentAssetComputeUnits
+}
+
+func (*SetMarketplacePaymentAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSetMarketplacePaymentAsset(p *codec.Packer) (chain.Action, error) {
+	var set SetMarketplacePaymentAsset
+	p.UnpackAddress(&set.DatasetAddress)
+	p.UnpackAddress(&set.PaymentAssetAddress)
+	set.DatasetPricePerBlock = p.UnpackUint64(false)
+	set.Accepted = p.UnpackBool()
+	return &set, p.Err()
+}
+
+var _ codec.Typed = (*SetMarketplacePaymentAssetResult)(nil)
+
+type SetMarketplacePaymentAssetResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	MarketplaceAssetAddress string `serialize:"true" json:"marketplace_asset_address"`
+	PaymentAssetAddress     string `serialize:"true" json:"payment_asset_address"`
+	DatasetPricePerBlock    uint64 `serialize:"true" json:"dataset_price_per_block"`
+	Accepted                bool   `serialize:"true" json:"accepted"`
+}
+
+func (*SetMarketplacePaymentAssetResult) GetTypeID() uint8 {
+	return nconsts.SetMarketplacePaymentAssetID
+}
+
+func UnmarshalSetMarketplacePaymentAssetResult(p *codec.Packer) (codec.Typed, error) {
+	var result SetMarketplacePaymentAssetResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.MarketplaceAssetAddress = p.UnpackString(true)
+	result.PaymentAssetAddress = p.UnpackString(true)
+	result.DatasetPricePerBlock = p.UnpackUint64(false)
+	result.Accepted = p.UnpackBool()
+	return &result, p.Err()
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSetMarketplacePaymentAssetAction(t *testing.T) {
+	mockEmission := emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 10})
+
+	owner := codectest.NewRandomAddress()
+	subscriber := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	usdAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("USD"), []byte("USD"), 0, []byte("metadata"), owner)
+
+	// newStore returns a listing accepting NAI and the assets of [accepted]
+	newStore := func(accepted ...codec.Address) state.Mutable {
+		store := newListingStore(t, datasetAddress, owner, 0)
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, usdAddress, nconsts.AssetFungibleTokenID, []byte("USD"), []byte("USD"), 0, []byte("metadata"), []byte("uri"), 0, 0, owner, owner, owner, owner, owner))
+		for _, paymentAsset := range accepted {
+			_, err := (&SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  paymentAsset,
+				DatasetPricePerBlock: 1,
+				Accepted:             true,
+			}).Execute(context.Background(), nil, store, 0, owner, ids.Empty)
+			require.NoError(t, err)
+		}
+		return store
+	}
+	// newAssets returns [n] payment assets created in [store]
+	newAssets := func(store state.Mutable, n int) []codec.Address {
+		assets := make([]codec.Address, n)
+		for i := range assets {
+			assets[i] = storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte(fmt.Sprint(i)), []byte("SYM"), 0, []byte("metadata"), owner)
+			require.NoError(t, storage.SetAssetInfo(context.Background(), store, assets[i], nconsts.AssetFungibleTokenID, []byte(fmt.Sprint(i)), []byte("SYM"), 0, []byte("metadata"), []byte("uri"), 0, 0, owner, owner, owner, owner, owner))
+		}
+		return assets
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: codectest.NewRandomAddress(), // Not the owner of the listing
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  usdAddress,
+				DatasetPricePerBlock: 5,
+				Accepted:             true,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "PrimaryPaymentAsset",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  storage.NAIAddress,
+				DatasetPricePerBlock: 5,
+				Accepted:             true,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrPrimaryPaymentAsset,
+		},
+		{
+			Name:  "PaymentAssetNotFound",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  codectest.NewRandomAddress(),
+				DatasetPricePerBlock: 5,
+				Accepted:             true,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrAssetNotFound,
+		},
+		{
+			Name:  "RemovePaymentAssetNotAccepted",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:      datasetAddress,
+				PaymentAssetAddress: usdAddress,
+			},
+			State:       newStore(),
+			ExpectedErr: ErrPaymentAssetNotSupported,
+		},
+		{
+			Name:  "TooManyPaymentAssets",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  usdAddress,
+				DatasetPricePerBlock: 5,
+				Accepted:             true,
+			},
+			State: func() state.Mutable {
+				store := newStore()
+				for _, paymentAsset := range newAssets(store, storage.MaxMarketplacePaymentAssets) {
+					_, err := (&SetMarketplacePaymentAsset{
+						DatasetAddress:       datasetAddress,
+						PaymentAssetAddress:  paymentAsset,
+						DatasetPricePerBlock: 1,
+						Accepted:             true,
+					}).Execute(context.Background(), nil, store, 0, owner, ids.Empty)
+					require.NoError(t, err)
+				}
+				return store
+			}(),
+			ExpectedErr: storage.ErrTooManyPaymentAssets,
+		},
+		{
+			Name:  "ValidAcceptLastPaymentAsset",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  usdAddress,
+				DatasetPricePerBlock: 5,
+				Accepted:             true,
+			},
+			State: func() state.Mutable {
+				store := newStore()
+				for _, paymentAsset := range newAssets(store, storage.MaxMarketplacePaymentAssets-1) {
+					_, err := (&SetMarketplacePaymentAsset{
+						DatasetAddress:       datasetAddress,
+						PaymentAssetAddress:  paymentAsset,
+						DatasetPricePerBlock: 1,
+						Accepted:             true,
+					}).Execute(context.Background(), nil, store, 0, owner, ids.Empty)
+					require.NoError(t, err)
+				}
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Len(t, paymentAssets, storage.MaxMarketplacePaymentAssets+1)
+
+				// Every value written fits in the chunks of its key
+				for _, key := range [][]byte{
+					storage.AssetInfoKey(marketplaceAssetAddress),
+					storage.MarketplacePaymentAssetsKey(marketplaceAssetAddress),
+					storage.MarketplacePaymentKey(marketplaceAssetAddress, usdAddress),
+				} {
+					value, err := store.GetValue(ctx, key)
+					require.NoError(t, err)
+					require.True(t, keys.VerifyValue(key, value))
+				}
+			},
+			ExpectedOutputs: &SetMarketplacePaymentAssetResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				PaymentAssetAddress:     usdAddress.String(),
+				DatasetPricePerBlock:    5,
+				Accepted:                true,
+			},
+		},
+		{
+			Name:  "ValidAcceptPaymentAsset",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  usdAddress,
+				DatasetPricePerBlock: 5,
+				Accepted:             true,
+			},
+			State: newStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				paymentAsset, ok := storage.MarketplacePaymentAssetOf(paymentAssets, usdAddress)
+				require.True(t, ok)
+				require.True(t, paymentAsset.Accepted)
+				price, _, _, _, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, usdAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(5), price)
+
+				// Subscriptions can be paid with the asset at its own price
+				require.NoError(t, storage.SetAssetAccountBalance(ctx, store, usdAddress, subscriber, 1000))
+				mockEmission.LastAcceptedBlockHeight = 10
+				result, err := (&SubscribeDatasetMarketplace{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					PaymentAssetAddress:     usdAddress,
+					NumBlocksToSubscribe:    100,
+				}).Execute(ctx, nil, store, 0, subscriber, ids.Empty)
+				require.NoError(t, err)
+				require.Equal(t, uint64(500), result.(*SubscribeDatasetMarketplaceResult).TotalCost)
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, usdAddress, subscriber)
+				require.NoError(t, err)
+				require.Equal(t, uint64(500), balance)
+
+				// Payments are accounted for and claimed per asset
+				_, remaining, _, _, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, storage.NAIAddress)
+				require.NoError(t, err)
+				require.Zero(t, remaining)
+				_, remaining, _, _, err = storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, usdAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(500), remaining)
+				_, err = (&ClaimMarketplacePayment{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					PaymentAssetAddress:     storage.NAIAddress,
+				}).Execute(ctx, nil, store, 0, owner, ids.Empty)
+				require.ErrorIs(t, err, ErrNoPaymentRemaining)
+				mockEmission.LastAcceptedBlockHeight = 30
+				result, err = (&ClaimMarketplacePayment{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					PaymentAssetAddress:     usdAddress,
+				}).Execute(ctx, nil, store, 0, owner, ids.Empty)
+				require.NoError(t, err)
+				require.Equal(t, uint64(20), result.(*ClaimMarketplacePaymentResult).DistributedReward)
+				require.Equal(t, uint64(480), result.(*ClaimMarketplacePaymentResult).PaymentRemaining)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, usdAddress, owner)
+				require.NoError(t, err)
+				require.Equal(t, uint64(20), balance)
+			},
+			ExpectedOutputs: &SetMarketplacePaymentAssetResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				PaymentAssetAddress:     usdAddress.String(),
+				DatasetPricePerBlock:    5,
+				Accepted:                true,
+			},
+		},
+		{
+			Name:  "ValidRemovePaymentAsset",
+			Actor: owner,
+			Action: &SetMarketplacePaymentAsset{
+				DatasetAddress:      datasetAddress,
+				PaymentAssetAddress: usdAddress,
+			},
+			State: newStore(usdAddress),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, []storage.MarketplacePaymentAsset{{Address: storage.NAIAddress, Accepted: true}}, paymentAssets)
+
+				// New subscriptions can no longer pay with the asset
+				_, err = (&SubscribeDatasetMarketplace{
+					MarketplaceAssetAddress: marketplaceAssetAddress,
+					PaymentAssetAddress:     usdAddress,
+					NumBlocksToSubscribe:    100,
+				}).Execute(ctx, nil, store, 0, subscriber, ids.Empty)
+				require.ErrorIs(t, err, ErrPaymentAssetNotSupported)
+			},
+			ExpectedOutputs: &SetMarketplacePaymentAssetResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				PaymentAssetAddress:     usdAddress.String(),
+				DatasetPricePerBlock:    0,
+				Accepted:                false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SetSponsorPolicyComputeUnits = 5
+)
+
+var (
+	ErrSponsorPolicyActionTypesInvalid              = errors.New("sponsor policy action types are invalid")
+	ErrSponsorPolicyAssetsInvalid                   = errors.New("sponsor policy assets are invalid")
+	ErrSponsorPolicyDatasetsInvalid                 = errors.New("sponsor policy datasets are invalid")
+	_                                  chain.Action = (*SetSponsorPolicy)(nil)
+)
+
+// SetSponsorPolicy lets the actor pay the fees of other accounts for the
+// transactions allowed by the policy, see [storage.SponsorPolicyAddress].
+// Fees of every policy of the actor are paid from its sponsor account, see
+// [storage.SponsorAccountAddress], which receives [Deposit]. Setting the same
+// policy again only tops up the sponsor account.
+type SetSponsorPolicy struct {
+	// Action type IDs the sponsor pays for
+	ActionTypeIDs []byte `serialize:"true" json:"action_type_ids"`
+
+	// Assets the sponsored actions may use. Any asset if empty.
+	AssetAddresses []codec.Address `serialize:"true" json:"asset_addresses"`
+
+	// Datasets the sponsored actions may use. Any dataset if empty.
+	DatasetAddresses []codec.Address `serialize:"true" json:"dataset_addresses"`
+
+	// Amount of NAI moved from the actor to its sponsor account
+	Deposit uint64 `serialize:"true" json:"deposit"`
+}
+
+func (*SetSponsorPolicy) GetTypeID() uint8 {
+	return nconsts.SetSponsorPolicyID
+}
+
+func (s *SetSponsorPolicy) StateKeys(actor codec.Address) state.Keys {
+	policyAddress := storage.SponsorPolicyAddress(actor, s.ActionTypeIDs, s.AssetAddresses, s.DatasetAddresses)
+	return state.Keys{
+		string(storage.SponsorPolicyKey(policyAddress)):                                                  state.All,
+		string(storage.AssetInfoKey(storage.NAIAddress)):                                                 state.Read,
+		string(storage.AssetAccountBalanceKey(storage.NAIAddress, actor)):                                state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(storage.NAIAddress, storage.SponsorAccountAddress(actor))): state.All,
+	}
+}
+
+func (s *SetSponsorPolicy) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if len(s.ActionTypeIDs) == 0 || len(s.ActionTypeIDs) > storage.MaxSponsorPolicyActionTypes || hasDuplicates(s.ActionTypeIDs) {
+		return nil, ErrSponsorPolicyActionTypesInvalid
+	}
+	if len(s.AssetAddresses) > storage.MaxSponsorPolicyAssets || hasDuplicates(s.AssetAddresses) {
+		return nil, ErrSponsorPolicyAssetsInvalid
+	}
+	if len(s.DatasetAddresses) > storage.MaxSponsorPolicyDatasets || hasDuplicates(s.DatasetAddresses) {
+		return nil, ErrSponsorPolicyDatasetsInvalid
+	}
+
+	policyAddress, err := storage.SetSponsorPolicy(ctx, mu, actor, s.ActionTypeIDs, s.AssetAddresses, s.DatasetAddresses)
+	if err != nil {
+		return nil, err
+	}
+	sponsorAccount := storage.SponsorAccountAddress(actor)
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, storage.NAIAddress, sponsorAccount)
+	if err != nil {
+		return nil, err
+	}
+	if s.Deposit > 0 {
+		actorBalance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, storage.NAIAddress, actor)
+		if err != nil {
+			return nil, err
+		}
+		if actorBalance < s.Deposit {
+			return nil, storage.ErrInsufficientAssetBalance
+		}
+		if _, balance, err = storage.TransferAsset(ctx, mu, storage.NAIAddress, actor, sponsorAccount, s.Deposit); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SetSponsorPolicyResult{
+		Actor:    actor.String(),
+		Receiver: policyAddress.String(),
+		Balance:  balance,
+	}, nil
+}
+
+func (*SetSponsorPolicy) ComputeUnits(chain.Rules) uint64 {
+	return SetSponsorPolicyComputeUnits
+}
+
+func (*SetSponsorPolicy) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSetSponsorPolicy(p *codec.Packer) (chain.Action, error) {
+	var set SetSponsorPolicy
+	p.UnpackBytes(storage.MaxSponsorPolicyActionTypes, true, &set.ActionTypeIDs)
+	assetAddresses, err := unpackAddresses(p, storage.MaxSponsorPolicyAssets, ErrSponsorPolicyAssetsInvalid)
+	if err != nil {
+		return nil, err
+	}
+	set.AssetAddresses = assetAddresses
+	datasetAddresses, err := unpackAddresses(p, storage.MaxSponsorPolicyDatasets, ErrSponsorPolicyDatasetsInvalid)
+	if err != nil {
+		return nil, err
+	}
+	set.DatasetAddresses = datasetAddresses
+	set.Deposit = p.UnpackUint64(false)
+	return &set, p.Err()
+}
+
+// unpackAddresses reads a list of at most [limit] addresses and returns
+// [errLimit] if it is longer
+func unpackAddresses(p *codec.Packer, limit int, errLimit error) ([]codec.Address, error) {
+	numAddresses := int(p.UnpackInt(false))
+	if numAddresses > limit {
+		return nil, errLimit
+	}
+	addresses := make([]codec.Address, numAddresses)
+	for i := range addresses {
+		p.UnpackAddress(&addresses[i])
+	}
+	return addresses, nil
+}
+
+func hasDuplicates[T comparable](values []T) bool {
+	seen := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			return true
+		}
+		seen[v] = struct{}{}
+	}
+	return false
+}
+
+var _ codec.Typed = (*SetSponsorPolicyResult)(nil)
+
+type SetSponsorPolicyResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Balance  uint64 `serialize:"true" json:"balance"`
+}
+
+func (*SetSponsorPolicyResult) GetTypeID() uint8 {
+	return nconsts.SetSponsorPolicyID
+}
+
+func UnmarshalSetSponsorPolicyResult(p *codec.Packer) (codec.Typed, error) {
+	var result SetSponsorPolicyResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(true)
+	result.Balance = p.UnpackUint64(false)
+	return &result, p.Err()
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSetSponsorPolicyAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	datasetAddress := codectest.NewRandomAddress()
+	actionTypeIDs := []byte{nconsts.SubscribeDatasetMarketplaceID}
+	policyAddress := storage.SponsorPolicyAddress(actor, actionTypeIDs, nil, []codec.Address{datasetAddress})
+	sponsorAccount := storage.SponsorAccountAddress(actor)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NoActionTypes",
+			Actor: actor,
+			Action: &SetSponsorPolicy{
+				Deposit: 100,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSponsorPolicyActionTypesInvalid,
+		},
+		{
+			Name:  "DuplicateAsset",
+			Actor: actor,
+			Action: &SetSponsorPolicy{
+				ActionTypeIDs:  []byte{nconsts.TransferID},
+				AssetAddresses: []codec.Address{storage.NAIAddress, storage.NAIAddress},
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSponsorPolicyAssetsInvalid,
+		},
+		{
+			Name:  "DuplicateDataset",
+			Actor: actor,
+			Action: &SetSponsorPolicy{
+				ActionTypeIDs:    actionTypeIDs,
+				DatasetAddresses: []codec.Address{datasetAddress, datasetAddress},
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSponsorPolicyDatasetsInvalid,
+		},
+		{
+			Name:  "InsufficientBalance",
+			Actor: actor,
+			Action: &SetSponsorPolicy{
+				ActionTypeIDs:    actionTypeIDs,
+				DatasetAddresses: []codec.Address{datasetAddress},
+				Deposit:          100,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ValidPolicy",
+			Actor: actor,
+			Action: &SetSponsorPolicy{
+				ActionTypeIDs:    actionTypeIDs,
+				DatasetAddresses: []codec.Address{datasetAddress},
+				Deposit:          100,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, storage.NAIAddress, nconsts.AssetFungibleTokenID, []byte(nconsts.Name), []byte(nconsts.Symbol), nconsts.Decimals, []byte(nconsts.Metadata), nil, 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, actor, 1000))
+				// The sponsor account was already funded by another policy
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, sponsorAccount, 50))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, sponsor, storedActionTypeIDs, assetAddresses, datasetAddresses, err := storage.GetSponsorPolicyNoController(ctx, store, policyAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, actor, sponsor)
+				require.Equal(t, actionTypeIDs, storedActionTypeIDs)
+				require.Empty(t, assetAddresses)
+				require.Equal(t, []codec.Address{datasetAddress}, datasetAddresses)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(900), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, sponsorAccount)
+				require.NoError(t, err)
+				require.Equal(t, uint64(150), balance)
+			},
+			ExpectedOutputs: &SetSponsorPolicyResult{
+				Actor:    actor.String(),
+				Receiver: policyAddress.String(),
+				Balance:  150,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SettleUsageComputeUnits = 5
+
+	// MaxUsageReceiptSize is the size of the largest receipt, one signed
+	// with a BLS key
+	MaxUsageReceiptSize = consts.ByteLen + auth.BLSSize
+)
+
+var (
+	ErrUsageChannelNotFound              = errors.New("usage channel not found")
+	ErrUsageChannelClosed                = errors.New("usage channel is closed")
+	ErrUsageChannelMismatch              = errors.New("usage channel does not match")
+	ErrUsageAmountInvalid                = errors.New("usage amount is invalid")
+	ErrUsageReceiptInvalid               = errors.New("usage receipt is invalid")
+	_                       chain.Action = (*SettleUsage)(nil)
+)
+
+// usageReceiptPrefix keeps receipts from being valid signatures of anything
+// else
+var usageReceiptPrefix = []byte("nuklai-usage-receipt")
+
+type SettleUsage struct {
+	// Address of the usage channel to settle
+	UsageChannelAddress codec.Address `serialize:"true" json:"usage_channel_address"`
+
+	// Marketplace asset, payment asset and buyer of the channel
+	MarketplaceAssetAddress codec.Address `serialize:"true" json:"marketplace_asset_address"`
+	PaymentAssetAddress     codec.Address `serialize:"true" json:"payment_asset_address"`
+	Buyer                   codec.Address `serialize:"true" json:"buyer"`
+
+	// Cumulative usage of the channel the buyer agreed to pay
+	Amount uint64 `serialize:"true" json:"amount"`
+
+	// Receipt signed by the buyer over the channel address and the amount. It
+	// can be left empty when nothing new is settled.
+	Receipt []byte `serialize:"true" json:"receipt"`
+
+	// Whether to close the channel and refund the rest of the deposit to the
+	// buyer
+	Close bool `serialize:"true" json:"close"`
+}
+
+func (*SettleUsage) GetTypeID() uint8 {
+	return nconsts.SettleUsageID
+}
+
+func (s *SettleUsage) StateKeys(actor codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.UsageChannelKey(s.UsageChannelAddress)):                               state.Read | state.Write,
+		string(storage.AssetInfoKey(s.MarketplaceAssetAddress)):                              state.Read,
+		string(storage.AssetInfoKey(s.PaymentAssetAddress)):                                  state.Read,
+		string(storage.AssetAccountBalanceKey(s.PaymentAssetAddress, s.UsageChannelAddress)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(s.PaymentAssetAddress, actor)):                 state.All,
+		string(storage.AssetAccountBalanceKey(s.PaymentAssetAddress, s.Buyer)):               state.All,
+	}
+	// The treasury is credited with the protocol fee
+	if treasury := emission.GetEmission().GetTreasury(); treasury.MarketplaceFeeBasisPoints > 0 {
+		stateKeys.Add(string(storage.AssetAccountBalanceKey(s.PaymentAssetAddress, treasury.Address)), state.All)
+	}
+	return stateKeys
+}
+
+func (s *SettleUsage) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, marketplaceAsset, paymentAsset, buyer, deposit, settled, expirationBlock, closed, err := storage.GetUsageChannelNoController(ctx, mu, s.UsageChannelAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUsageChannelNotFound
+	}
+	if closed {
+		return nil, ErrUsageChannelClosed
+	}
+	if marketplaceAsset != s.MarketplaceAssetAddress || paymentAsset != s.PaymentAssetAddress || buyer != s.Buyer {
+		return nil, ErrUsageChannelMismatch
+	}
+
+	// Only the owner of the listing settles the usage and is paid for it, like
+	// it is for subscriptions, even when someone else owns the dataset
+	_, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, s.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if owner != actor {
+		return nil, ErrWrongOwner
+	}
+
+	// Receipts are cumulative so only the latest one needs to be settled
+	if s.Amount < settled || s.Amount > deposit {
+		return nil, ErrUsageAmountInvalid
+	}
+	if s.Amount > settled {
+		if err := VerifyUsageReceipt(ctx, s.UsageChannelAddress, s.Amount, buyer, s.Receipt); err != nil {
+			return nil, err
+		}
+	}
+	payout := s.Amount - settled
+
+	// The treasury takes the protocol fee out of the payout
+	treasury := emission.GetEmission().GetTreasury()
+	protocolFee := storage.MarketplaceFeeAmount(payout, treasury.MarketplaceFeeBasisPoints)
+	treasuryAddress := ""
+	if protocolFee > 0 {
+		treasuryAddress = treasury.Address.String()
+		if _, _, err := storage.TransferAsset(ctx, mu, s.PaymentAssetAddress, s.UsageChannelAddress, treasury.Address, protocolFee); err != nil {
+			return nil, err
+		}
+	}
+	distributedReward := payout - protocolFee
+	if distributedReward > 0 {
+		if _, _, err := storage.TransferAsset(ctx, mu, s.PaymentAssetAddress, s.UsageChannelAddress, actor, distributedReward); err != nil {
+			return nil, err
+		}
+	}
+
+	// Refund the rest of the deposit when the channel closes
+	refund := uint64(0)
+	if s.Close {
+		refund, err = smath.Sub(deposit, s.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if refund > 0 {
+			if _, _, err := storage.TransferAsset(ctx, mu, s.PaymentAssetAddress, s.UsageChannelAddress, buyer, refund); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := storage.SetUsageChannel(ctx, mu, s.UsageChannelAddress, marketplaceAsset, paymentAsset, buyer, deposit, s.Amount, expirationBlock, s.Close); err != nil {
+		return nil, err
+	}
+
+	return &SettleUsageResult{
+		Actor:               actor.String(),
+		Receiver:            actor.String(),
+		UsageChannelAddress: s.UsageChannelAddress.String(),
+		Settled:             s.Amount,
+		DistributedReward:   distributedReward,
+		ProtocolFee:         protocolFee,
+		TreasuryAddress:     treasuryAddress,
+		Refund:              refund,
+		Closed:              s.Close,
+	}, nil
+}
+
+func (*SettleUsage) ComputeUnits(chain.Rules) uint64 {
+	return SettleUsageComputeUnits
+}
+
+func (*SettleUsage) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSettleUsage(p *codec.Packer) (chain.Action, error) {
+	var settle SettleUsage
+	p.UnpackAddress(&settle.UsageChannelAddress)
+	p.UnpackAddress(&settle.MarketplaceAssetAddress)
+	p.UnpackAddress(&settle.PaymentAssetAddress)
+	p.UnpackAddress(&settle.Buyer)
+	settle.Amount = p.UnpackUint64(false)
+	p.UnpackBytes(MaxUsageReceiptSize, false, &settle.Receipt)
+	settle.Close = p.UnpackBool()
+	return &settle, p.Err()
+}
+
+// UsageReceiptMessage returns the message the buyer signs to agree to pay
+// [amount] in total for the usage of [channelAddress]
+func UsageReceiptMessage(channelAddress codec.Address, amount uint64) []byte {
+	p := codec.NewWriter(len(usageReceiptPrefix)+codec.AddressLen+consts.Uint64Len, len(usageReceiptPrefix)+codec.AddressLen+consts.Uint64Len)
+	p.PackFixedBytes(usageReceiptPrefix)
+	p.PackAddress(channelAddress)
+	p.PackUint64(amount)
+	return p.Bytes()
+}
+
+// MarshalUsageReceipt encodes the signature of a usage receipt as the type of
+// the key that signed it followed by the signature
+func MarshalUsageReceipt(signature chain.Auth) []byte {
+	size := consts.ByteLen + signature.Size()
+	p := codec.NewWriter(size, size)
+	p.PackByte(signature.GetTypeID())
+	signature.Marshal(p)
+	return p.Bytes()
+}
+
+// UsageReceiptSigner returns whether [address] belongs to a single key that
+// can sign usage receipts. Multisig, session and sponsored accounts have no key
+// of their own so their channels could never be settled.
+func UsageReceiptSigner(address codec.Address) bool {
+	switch address[0] {
+	case auth.ED25519ID, auth.SECP256R1ID, auth.BLSID:
+		return true
+	default:
+		return false
+	}
+}
+
+// VerifyUsageReceipt checks that [receipt] was signed by [buyer] for [amount]
+// on [channelAddress]
+func VerifyUsageReceipt(ctx context.Context, channelAddress codec.Address, amount uint64, buyer codec.Address, receipt []byte) error {
+	p := codec.NewReader(receipt, MaxUsageReceiptSize)
+	var (
+		signature chain.Auth
+		err       error
+	)
+	switch p.UnpackByte() {
+	case auth.ED25519ID:
+		signature, err = auth.UnmarshalED25519(p)
+	case auth.SECP256R1ID:
+		signature, err = auth.UnmarshalSECP256R1(p)
+	case auth.BLSID:
+		signature, err = auth.UnmarshalBLS(p)
+	default:
+		return ErrUsageReceiptInvalid
+	}
+	if err != nil || !p.Empty() || p.Err() != nil {
+		return ErrUsageReceiptInvalid
+	}
+	if signature.Actor() != buyer {
+		return ErrUsageReceiptInvalid
+	}
+	if err := signature.Verify(ctx, UsageReceiptMessage(channelAddress, amount)); err != nil {
+		return ErrUsageReceiptInvalid
+	}
+	return nil
+}
+
+var _ codec.Typed = (*SettleUsageResult)(nil)
+
+type SettleUsageResult struct {
+	Actor               string `serialize:"true" json:"actor"`
+	Receiver            string `serialize:"true" json:"receiver"`
+	UsageChannelAddress string `serialize:"true" json:"usage_channel_address"`
+	Settled             uint64 `serialize:"true" json:"settled"`
+	DistributedReward   uint64 `serialize:"true" json:"distributed_reward"`
+	ProtocolFee         uint64 `serialize:"true" json:"protocol_fee"`
+	TreasuryAddress     string `serialize:"true" json:"treasury_address"`
+	Refund              uint64 `serialize:"true" json:"refund"`
+	Closed              bool   `serialize:"true" json:"closed"`
+}
+
+func (*SettleUsageResult) GetTypeID() uint8 {
+	return nconsts.SettleUsageID
+}
+
+func UnmarshalSettleUsageResult(p *codec.Packer) (codec.Typed, error) {
+	var result SettleUsageResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.UsageChannelAddress = p.UnpackString(true)
+	result.Settled = p.UnpackUint64(false)
+	result.DistributedReward = p.UnpackUint64(false)
+	result.ProtocolFee = p.UnpackUint64(false)
+	result.TreasuryAddress = p.UnpackString(false)
+	result.Refund = p.UnpackUint64(false)
+	result.Closed = p.UnpackBool()
+	return &result, p.Err()
+}
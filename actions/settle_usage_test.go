@@ -0,0 +1,323 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newUsageChannelStore returns the store of an open usage channel of [buyer]
+// with [deposit] locked in it and [settled] already paid out
+func newUsageChannelStore(t *testing.T, datasetAddress codec.Address, owner codec.Address, buyer codec.Address, deposit uint64, settled uint64) state.Mutable {
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	channelAddress := storage.UsageChannelAddress(marketplaceAssetAddress, storage.NAIAddress, buyer, 0)
+	store := newUsageListingStore(t, datasetAddress, owner, buyer, 0)
+	require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, channelAddress, deposit-settled))
+	require.NoError(t, storage.SetUsageChannel(context.Background(), store, channelAddress, marketplaceAssetAddress, storage.NAIAddress, buyer, deposit, settled, 110, false))
+	return store
+}
+
+func TestSettleUsageAction(t *testing.T) {
+	require := require.New(t)
+
+	owner := codectest.NewRandomAddress()
+	buyerKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	buyerFactory := auth.NewED25519Factory(buyerKey)
+	buyer := auth.NewED25519Address(buyerKey.PublicKey())
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	channelAddress := storage.UsageChannelAddress(marketplaceAssetAddress, storage.NAIAddress, buyer, 0)
+
+	signReceipt := func(channelAddress codec.Address, amount uint64) []byte {
+		signature, err := buyerFactory.Sign(UsageReceiptMessage(channelAddress, amount))
+		require.NoError(err)
+		return MarshalUsageReceipt(signature)
+	}
+	otherKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	otherSignature, err := auth.NewED25519Factory(otherKey).Sign(UsageReceiptMessage(channelAddress, 300))
+	require.NoError(err)
+
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 105})
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "ChannelNotFound",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+				Receipt:                 signReceipt(channelAddress, 300),
+			},
+			State:       newUsageListingStore(t, datasetAddress, owner, buyer, 0),
+			ExpectedErr: ErrUsageChannelNotFound,
+		},
+		{
+			Name:  "ChannelMismatch",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   owner, // Not the buyer of the channel
+				Amount:                  300,
+				Receipt:                 signReceipt(channelAddress, 300),
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			ExpectedErr: ErrUsageChannelMismatch,
+		},
+		{
+			Name:  "WrongOwner",
+			Actor: buyer, // Not the owner of the listing
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+				Receipt:                 signReceipt(channelAddress, 300),
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "AmountAboveDeposit",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  600,
+				Receipt:                 signReceipt(channelAddress, 600),
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			ExpectedErr: ErrUsageAmountInvalid,
+		},
+		{
+			Name:  "AmountBelowSettled",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  100, // An older receipt
+				Receipt:                 signReceipt(channelAddress, 100),
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 200),
+			ExpectedErr: ErrUsageAmountInvalid,
+		},
+		{
+			Name:  "ReceiptForOtherAmount",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  400,
+				Receipt:                 signReceipt(channelAddress, 300),
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			ExpectedErr: ErrUsageReceiptInvalid,
+		},
+		{
+			Name:  "ReceiptNotSignedByBuyer",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+				Receipt:                 MarshalUsageReceipt(otherSignature),
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			ExpectedErr: ErrUsageReceiptInvalid,
+		},
+		{
+			Name:  "ReceiptMissing",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+			},
+			State:       newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			ExpectedErr: ErrUsageReceiptInvalid,
+		},
+		{
+			Name:  "SettleUsage",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+				Receipt:                 signReceipt(channelAddress, 300),
+			},
+			State: newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// Only the usage since the last settlement is paid out
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, owner)
+				require.NoError(err)
+				require.Equal(uint64(200), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, channelAddress)
+				require.NoError(err)
+				require.Equal(uint64(200), balance)
+
+				_, _, _, _, _, settled, _, closed, err := storage.GetUsageChannelNoController(ctx, store, channelAddress)
+				require.NoError(err)
+				require.Equal(uint64(300), settled)
+				require.False(closed)
+			},
+			ExpectedOutputs: &SettleUsageResult{
+				Actor:               owner.String(),
+				Receiver:            owner.String(),
+				UsageChannelAddress: channelAddress.String(),
+				Settled:             300,
+				DistributedReward:   200,
+				Refund:              0,
+				Closed:              false,
+			},
+		},
+		{
+			Name:  "SettleAndClose",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+				Receipt:                 signReceipt(channelAddress, 300),
+				Close:                   true,
+			},
+			State: newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, owner)
+				require.NoError(err)
+				require.Equal(uint64(300), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, buyer)
+				require.NoError(err)
+				require.Equal(uint64(200), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, channelAddress)
+				require.NoError(err)
+				require.Zero(balance)
+
+				// The channel is kept so that its receipts can't be settled again
+				exists, _, _, _, _, _, _, closed, err := storage.GetUsageChannelNoController(ctx, store, channelAddress)
+				require.NoError(err)
+				require.True(exists)
+				require.True(closed)
+			},
+			ExpectedOutputs: &SettleUsageResult{
+				Actor:               owner.String(),
+				Receiver:            owner.String(),
+				UsageChannelAddress: channelAddress.String(),
+				Settled:             300,
+				DistributedReward:   300,
+				Refund:              200,
+				Closed:              true,
+			},
+		},
+		{
+			Name:  "ChannelClosed",
+			Actor: owner,
+			Action: &SettleUsage{
+				UsageChannelAddress:     channelAddress,
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     storage.NAIAddress,
+				Buyer:                   buyer,
+				Amount:                  300,
+				Receipt:                 signReceipt(channelAddress, 300),
+			},
+			State: func() state.Mutable {
+				store := newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0)
+				require.NoError(storage.SetUsageChannel(context.Background(), store, channelAddress, marketplaceAssetAddress, storage.NAIAddress, buyer, 500, 0, 110, true))
+				return store
+			}(),
+			ExpectedErr: ErrUsageChannelClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestSettleUsageProtocolFee(t *testing.T) {
+	require := require.New(t)
+
+	owner := codectest.NewRandomAddress()
+	treasury := codectest.NewRandomAddress()
+	buyerKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	buyer := auth.NewED25519Address(buyerKey.PublicKey())
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	channelAddress := storage.UsageChannelAddress(marketplaceAssetAddress, storage.NAIAddress, buyer, 0)
+	signature, err := auth.NewED25519Factory(buyerKey).Sign(UsageReceiptMessage(channelAddress, 400))
+	require.NoError(err)
+
+	emission.MockNewEmission(&emission.MockEmission{
+		LastAcceptedBlockHeight: 105,
+		Treasury:                emission.Treasury{Address: treasury, MarketplaceFeeBasisPoints: 250},
+	})
+	defer emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 105})
+
+	test := chaintest.ActionTest{
+		Name:  "ProtocolFee",
+		Actor: owner,
+		Action: &SettleUsage{
+			UsageChannelAddress:     channelAddress,
+			MarketplaceAssetAddress: marketplaceAssetAddress,
+			PaymentAssetAddress:     storage.NAIAddress,
+			Buyer:                   buyer,
+			Amount:                  400,
+			Receipt:                 MarshalUsageReceipt(signature),
+		},
+		State: newUsageChannelStore(t, datasetAddress, owner, buyer, 500, 0),
+		Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+			balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, treasury)
+			require.NoError(err)
+			require.Equal(uint64(10), balance)
+			balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, owner)
+			require.NoError(err)
+			require.Equal(uint64(390), balance)
+		},
+		ExpectedOutputs: &SettleUsageResult{
+			Actor:               owner.String(),
+			Receiver:            owner.String(),
+			UsageChannelAddress: channelAddress.String(),
+			Settled:             400,
+			DistributedReward:   390,
+			ProtocolFee:         10,
+			TreasuryAddress:     treasury.String(),
+		},
+	}
+	test.Run(context.Background(), t)
+}
@@ -33,6 +33,7 @@ var (
 	ErrPaymentAssetNotSupported                       = errors.New("base asset is not supported")
 	ErrOutputNumBlocksToSubscribeInvalid              = errors.New("num blocks to subscribe is invalid")
 	ErrUserAlreadySubscribed                          = errors.New("user is already subscribed")
+	ErrMarketplaceListingNotActive                    = errors.New("marketplace listing is not active")
 	_                                    chain.Action = (*SubscribeDatasetMarketplace)(nil)
 )
 
@@ -46,6 +47,10 @@ type SubscribeDatasetMarketplace struct {
 
 	// Total amount of blocks to subscribe to
 	NumBlocksToSubscribe uint64 `serialize:"true" json:"num_blocks_to_subscribe"`
+
+	// Public key the owner of the dataset encrypts the data key to. It can be
+	// left empty if the subscriber does not need the data key.
+	EncryptionPublicKey []byte `serialize:"true" json:"encryption_public_key"`
 }
 
 func (*SubscribeDatasetMarketplace) GetTypeID() uint8 {
@@ -54,14 +59,20 @@ func (*SubscribeDatasetMarketplace) GetTypeID() uint8 {
 
 func (d *SubscribeDatasetMarketplace) StateKeys(actor codec.Address) state.Keys {
 	nftAddress := storage.AssetAddressNFT(d.MarketplaceAssetAddress, nil, actor)
-	return state.Keys{
-		string(storage.AssetInfoKey(d.MarketplaceAssetAddress)):                  state.Read | state.Write,
-		string(storage.AssetInfoKey(d.PaymentAssetAddress)):                      state.Read | state.Write,
-		string(storage.AssetInfoKey(nftAddress)):                                 state.All,
-		string(storage.AssetAccountBalanceKey(d.MarketplaceAssetAddress, actor)): state.Allocate | state.Write,
-		string(storage.AssetAccountBalanceKey(d.PaymentAssetAddress, actor)):     state.Read | state.Write,
-		string(storage.AssetAccountBalanceKey(nftAddress, actor)):                state.All,
+	stateKeys := state.Keys{
+		string(storage.AssetInfoKey(d.MarketplaceAssetAddress)):                                 state.Read | state.Write,
+		string(storage.AssetInfoKey(d.PaymentAssetAddress)):                                     state.Read | state.Write,
+		string(storage.AssetInfoKey(nftAddress)):                                                state.All,
+		string(storage.AssetAccountBalanceKey(d.MarketplaceAssetAddress, actor)):                state.Allocate | state.Write,
+		string(storage.AssetAccountBalanceKey(d.PaymentAssetAddress, actor)):                    state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(nftAddress, actor)):                               state.All,
+		string(storage.MarketplacePaymentAssetsKey(d.MarketplaceAssetAddress)):                  state.All,
+		string(storage.MarketplacePaymentKey(d.MarketplaceAssetAddress, d.PaymentAssetAddress)): state.All,
+	}
+	if len(d.EncryptionPublicKey) > 0 {
+		stateKeys.Add(string(storage.DatasetKeyKey(nftAddress)), state.All)
 	}
+	return stateKeys
 }
 
 func (d *SubscribeDatasetMarketplace) Execute(
@@ -84,6 +95,10 @@ func (d *SubscribeDatasetMarketplace) Execute(
 		return nil, ErrOutputNumBlocksToSubscribeInvalid
 	}
 
+	if err := migrateMarketplacePayment(ctx, mu, d.MarketplaceAssetAddress, d.PaymentAssetAddress); err != nil {
+		return nil, err
+	}
+
 	// Check for the asset
 	assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, mu, d.MarketplaceAssetAddress)
 	if err != nil {
@@ -99,13 +114,22 @@ func (d *SubscribeDatasetMarketplace) Execute(
 	if err != nil {
 		return nil, err
 	}
-	// Ensure paymentAssetAddress is supported
-	if metadataMap["paymentAssetAddress"] != d.PaymentAssetAddress.String() {
+	// Ensure the listing is open for new subscriptions
+	if status, ok := metadataMap["status"]; ok && status != storage.MarketplaceStatusActive {
+		return nil, ErrMarketplaceListingNotActive
+	}
+	// Ensure paymentAssetAddress is supported. Besides its primary payment
+	// asset, a listing accepts every asset its owner set a price for.
+	paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, mu, d.MarketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if paymentAsset, ok := storage.MarketplacePaymentAssetOf(paymentAssets, d.PaymentAssetAddress); !ok || !paymentAsset.Accepted {
 		return nil, ErrPaymentAssetNotSupported
 	}
 
 	// Calculate the total cost of the subscription
-	datasetPricePerBlock, err := strconv.ParseUint(metadataMap["datasetPricePerBlock"], 10, 64)
+	datasetPricePerBlock, paymentRemaining, paymentClaimed, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, mu, d.MarketplaceAssetAddress, d.PaymentAssetAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -133,24 +157,23 @@ func (d *SubscribeDatasetMarketplace) Execute(
 	emissionInstance := emission.GetEmission()
 	currentBlock := emissionInstance.GetLastAcceptedBlockHeight()
 
-	// Update the paymentRemaining, subscriptions and lastClaimedBlock fields
-	prevPaymentRemaining, err := strconv.ParseUint(metadataMap["paymentRemaining"], 10, 64)
+	// Update the paymentRemaining, subscriptions and lastClaimedBlock fields.
+	// Payments are accounted for separately in every payment asset.
+	paymentRemaining, err = smath.Add(paymentRemaining, totalCost)
 	if err != nil {
 		return nil, err
 	}
-	metadataMap["paymentRemaining"] = fmt.Sprint(prevPaymentRemaining + totalCost)
-	prevSubscriptions, err := strconv.ParseUint(metadataMap["subscriptions"], 10, 64)
-	if err != nil {
+	if lastClaimedBlock == 0 {
+		lastClaimedBlock = currentBlock
+	}
+	if err := storage.UpdateMarketplacePayment(ctx, mu, d.MarketplaceAssetAddress, d.PaymentAssetAddress, paymentRemaining, paymentClaimed, lastClaimedBlock); err != nil {
 		return nil, err
 	}
-	metadataMap["subscriptions"] = fmt.Sprint(prevSubscriptions + 1)
-	prevStartSubscriptionBlock, err := strconv.ParseUint(metadataMap["lastClaimedBlock"], 10, 64)
+	prevSubscriptions, err := strconv.ParseUint(metadataMap["subscriptions"], 10, 64)
 	if err != nil {
 		return nil, err
 	}
-	if prevStartSubscriptionBlock == 0 {
-		metadataMap["lastClaimedBlock"] = fmt.Sprint(currentBlock)
-	}
+	metadataMap["subscriptions"] = fmt.Sprint(prevSubscriptions + 1)
 	// Marshal the map back to a JSON byte slice
 	metadata, err = utils.MapToBytes(metadataMap)
 	if err != nil {
@@ -165,7 +188,7 @@ func (d *SubscribeDatasetMarketplace) Execute(
 	metadataNFTMap := make(map[string]string, 0)
 	metadataNFTMap["datasetAddress"] = metadataMap["datasetAddress"]
 	metadataNFTMap["marketplaceAssetAddress"] = d.MarketplaceAssetAddress.String()
-	metadataNFTMap["datasetPricePerBlock"] = metadataMap["datasetPricePerBlock"]
+	metadataNFTMap["datasetPricePerBlock"] = fmt.Sprint(datasetPricePerBlock)
 	metadataNFTMap["paymentAssetAddress"] = d.PaymentAssetAddress.String()
 	metadataNFTMap["totalCost"] = fmt.Sprint(totalCost)
 	metadataNFTMap["issuanceBlock"] = fmt.Sprint(currentBlock)
@@ -188,6 +211,12 @@ func (d *SubscribeDatasetMarketplace) Execute(
 	if _, err := storage.MintAsset(ctx, mu, nftAddress, actor, 1); err != nil {
 		return nil, err
 	}
+	// Register the key the data key is delivered to
+	if len(d.EncryptionPublicKey) > 0 {
+		if err := storage.SetDatasetKey(ctx, mu, nftAddress, actor, d.EncryptionPublicKey, nil); err != nil {
+			return nil, err
+		}
+	}
 
 	return &SubscribeDatasetMarketplaceResult{
 		Actor:                            actor.String(),
@@ -218,6 +247,7 @@ func UnmarshalSubscribeDatasetMarketplace(p *codec.Packer) (chain.Action, error)
 	p.UnpackAddress(&subscribe.MarketplaceAssetAddress)
 	p.UnpackAddress(&subscribe.PaymentAssetAddress)
 	subscribe.NumBlocksToSubscribe = p.UnpackUint64(true)
+	p.UnpackBytes(storage.MaxEncryptionPublicKeySize, false, &subscribe.EncryptionPublicKey)
 	return &subscribe, p.Err()
 }
 
@@ -62,10 +62,7 @@ func TestSubscribeDatasetMarketplaceAction(t *testing.T) {
 					"datasetPricePerBlock":    "100",
 					"paymentAssetAddress":     baseAssetAddress.String(),
 					"publisher":               actor.String(),
-					"lastClaimedBlock":        "0",
 					"subscriptions":           "0",
-					"paymentRemaining":        "0",
-					"paymentClaimed":          "0",
 				}
 				metadataBytes, err := utils.MapToBytes(metadata)
 				require.NoError(t, err)
@@ -74,6 +71,32 @@ func TestSubscribeDatasetMarketplaceAction(t *testing.T) {
 			}(),
 			ExpectedErr: ErrPaymentAssetNotSupported,
 		},
+		{
+			Name:  "ListingPaused",
+			Actor: actor,
+			Action: &SubscribeDatasetMarketplace{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     baseAssetAddress,
+				NumBlocksToSubscribe:    10,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				metadata := map[string]string{
+					"datasetAddress":          datasetAddress.String(),
+					"marketplaceAssetAddress": marketplaceAssetAddress.String(),
+					"datasetPricePerBlock":    "100",
+					"paymentAssetAddress":     baseAssetAddress.String(),
+					"publisher":               actor.String(),
+					"subscriptions":           "0",
+					"status":                  storage.MarketplaceStatusPaused,
+				}
+				metadataBytes, err := utils.MapToBytes(metadata)
+				require.NoError(t, err)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				return store
+			}(),
+			ExpectedErr: ErrMarketplaceListingNotActive,
+		},
 		{
 			Name:  "ValidSubscription",
 			Actor: actor,
@@ -90,14 +113,12 @@ func TestSubscribeDatasetMarketplaceAction(t *testing.T) {
 					"datasetPricePerBlock":    "100",
 					"paymentAssetAddress":     baseAssetAddress.String(),
 					"publisher":               actor.String(),
-					"lastClaimedBlock":        "0",
 					"subscriptions":           "0",
-					"paymentRemaining":        "0",
-					"paymentClaimed":          "0",
 				}
 				metadataBytes, err := utils.MapToBytes(metadata)
 				require.NoError(t, err)
 				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100))
 				// Set base asset balance to sufficient amount
 				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, baseAssetAddress, actor, 5000))
 				return store
@@ -140,9 +161,16 @@ func TestSubscribeDatasetMarketplaceAction(t *testing.T) {
 				require.NoError(t, err)
 				metadataMap, err = utils.BytesToMap(metadata)
 				require.NoError(t, err)
-				require.Equal(t, "1000", metadataMap["paymentRemaining"])
 				require.Equal(t, "1", metadataMap["subscriptions"])
-				require.Equal(t, fmt.Sprint(mockEmission.GetLastAcceptedBlockHeight()), metadataMap["lastClaimedBlock"])
+				_, paymentRemaining, _, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1000), paymentRemaining)
+				require.Equal(t, mockEmission.GetLastAcceptedBlockHeight(), lastClaimedBlock)
+
+				// No encryption public key was registered
+				exists, _, _, _, err := storage.GetDatasetKeyNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.False(t, exists)
 			},
 			ExpectedOutputs: &SubscribeDatasetMarketplaceResult{
 				Actor:                            actor.String(),
@@ -158,6 +186,94 @@ func TestSubscribeDatasetMarketplaceAction(t *testing.T) {
 				ExpirationBlock:                  mockEmission.GetLastAcceptedBlockHeight() + 10,
 			},
 		},
+		{
+			Name:  "LegacyListingSubscription",
+			Actor: actor,
+			Action: &SubscribeDatasetMarketplace{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     baseAssetAddress,
+				NumBlocksToSubscribe:    10,
+			},
+			State: func() state.Mutable {
+				// Listings published before payment assets were kept apart
+				// track their payments in the metadata
+				store := chaintest.NewInMemoryStore()
+				metadata := map[string]string{
+					"datasetAddress":          datasetAddress.String(),
+					"marketplaceAssetAddress": marketplaceAssetAddress.String(),
+					"datasetPricePerBlock":    "100",
+					"paymentAssetAddress":     baseAssetAddress.String(),
+					"publisher":               actor.String(),
+					"subscriptions":           "1",
+					"paymentRemaining":        "500",
+					"paymentClaimed":          "0",
+					"lastClaimedBlock":        "1",
+				}
+				metadataBytes, err := utils.MapToBytes(metadata)
+				require.NoError(t, err)
+				require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 1, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, baseAssetAddress, actor, 5000))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The payment is added to the payments made before
+				pricePerBlock, paymentRemaining, _, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), pricePerBlock)
+				require.Equal(t, uint64(1500), paymentRemaining)
+				require.Equal(t, uint64(1), lastClaimedBlock)
+			},
+			ExpectedOutputs: &SubscribeDatasetMarketplaceResult{
+				Actor:                            actor.String(),
+				Receiver:                         actor.String(),
+				MarketplaceAssetAddress:          marketplaceAssetAddress.String(),
+				MarketplaceAssetNumSubscriptions: 2,
+				SubscriptionNftAddress:           nftAddress.String(),
+				PaymentAssetAddress:              baseAssetAddress.String(),
+				DatasetPricePerBlock:             100,
+				TotalCost:                        1000,
+				NumBlocksToSubscribe:             10,
+				IssuanceBlock:                    mockEmission.GetLastAcceptedBlockHeight(),
+				ExpirationBlock:                  mockEmission.GetLastAcceptedBlockHeight() + 10,
+			},
+		},
+		{
+			Name:  "EncryptionPublicKeyRegistered",
+			Actor: actor,
+			Action: &SubscribeDatasetMarketplace{
+				MarketplaceAssetAddress: marketplaceAssetAddress,
+				PaymentAssetAddress:     baseAssetAddress,
+				NumBlocksToSubscribe:    10,
+				EncryptionPublicKey:     []byte("encryption public key"),
+			},
+			State: func() state.Mutable {
+				store := newListingStore(t, datasetAddress, actor, 0)
+				require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, baseAssetAddress, actor, 5000))
+				return store
+			}(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The data key is not delivered yet
+				exists, holder, encryptionPublicKey, encryptedKey, err := storage.GetDatasetKeyNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, actor, holder)
+				require.Equal(t, []byte("encryption public key"), encryptionPublicKey)
+				require.Empty(t, encryptedKey)
+			},
+			ExpectedOutputs: &SubscribeDatasetMarketplaceResult{
+				Actor:                            actor.String(),
+				Receiver:                         actor.String(),
+				MarketplaceAssetAddress:          marketplaceAssetAddress.String(),
+				MarketplaceAssetNumSubscriptions: 2,
+				SubscriptionNftAddress:           nftAddress.String(),
+				PaymentAssetAddress:              baseAssetAddress.String(),
+				DatasetPricePerBlock:             100,
+				TotalCost:                        1000,
+				NumBlocksToSubscribe:             10,
+				IssuanceBlock:                    mockEmission.GetLastAcceptedBlockHeight(),
+				ExpirationBlock:                  mockEmission.GetLastAcceptedBlockHeight() + 10,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,14 +307,12 @@ func BenchmarkSubscribeDatasetMarketplace(b *testing.B) {
 				"datasetPricePerBlock":    "100",
 				"paymentAssetAddress":     baseAssetAddress.String(),
 				"publisher":               actor.String(),
-				"lastClaimedBlock":        "0",
 				"subscriptions":           "0",
-				"paymentRemaining":        "0",
-				"paymentClaimed":          "0",
 			}
 			metadataBytes, err := utils.MapToBytes(metadata)
 			require.NoError(err)
 			require.NoError(storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte("name"), []byte("SYM"), 0, metadataBytes, []byte(marketplaceAssetAddress.String()), 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+			require.NoError(storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, baseAssetAddress, 100))
 			// Set base asset balance to sufficient amount
 			require.NoError(storage.SetAssetAccountBalance(context.Background(), store, baseAssetAddress, actor, 5000))
 			return store
@@ -241,9 +355,11 @@ func BenchmarkSubscribeDatasetMarketplace(b *testing.B) {
 			require.NoError(err)
 			metadataMap, err = utils.BytesToMap(metadata)
 			require.NoError(err)
-			require.Equal("1000", metadataMap["paymentRemaining"])
 			require.Equal("1", metadataMap["subscriptions"])
-			require.Equal(fmt.Sprint(mockEmission.GetLastAcceptedBlockHeight()), metadataMap["lastClaimedBlock"])
+			_, paymentRemaining, _, lastClaimedBlock, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, baseAssetAddress)
+			require.NoError(err)
+			require.Equal(uint64(1000), paymentRemaining)
+			require.Equal(mockEmission.GetLastAcceptedBlockHeight(), lastClaimedBlock)
 		},
 	}
 
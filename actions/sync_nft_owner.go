@@ -0,0 +1,130 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SyncNFTOwnerComputeUnits = 1
+)
+
+var (
+	ErrNFTNotHeldByOwner              = errors.New("NFT is not held by the owner")
+	ErrNFTOwnerUpToDate               = errors.New("NFT owner is already up to date")
+	_                    chain.Action = (*SyncNFTOwner)(nil)
+)
+
+// SyncNFTOwner sets the owner in the asset info of an NFT to the account that
+// holds it. NFTs transferred before transfers kept the owner up to date still
+// point to their minter and anyone can bring them up to date.
+type SyncNFTOwner struct {
+	// AssetAddress is the NFT collection address
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// AssetNftAddress of the NFT to update
+	AssetNftAddress codec.Address `serialize:"true" json:"asset_nft_address"`
+
+	// Owner is the account that holds the NFT
+	Owner codec.Address `serialize:"true" json:"owner"`
+}
+
+func (*SyncNFTOwner) GetTypeID() uint8 {
+	return nconsts.SyncNFTOwnerID
+}
+
+func (s *SyncNFTOwner) StateKeys(codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(s.AssetNftAddress)):                    state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(s.AssetNftAddress, s.Owner)): state.Read,
+		string(storage.NFTOwnershipKey(s.AssetNftAddress)):                 state.All,
+	}
+}
+
+func (s *SyncNFTOwner) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Retrieve nft info
+	_, _, _, _, _, uri, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, s.AssetNftAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	if s.AssetAddress.String() != string(uri) || s.AssetAddress == s.AssetNftAddress {
+		return nil, ErrNFTDoesNotBelongToTheCollection
+	}
+	if owner == s.Owner {
+		return nil, ErrNFTOwnerUpToDate
+	}
+
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, s.AssetNftAddress, s.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if balance != 1 {
+		return nil, ErrNFTNotHeldByOwner
+	}
+
+	if err := storage.SetNFTOwner(ctx, mu, s.AssetNftAddress, s.Owner); err != nil {
+		return nil, err
+	}
+
+	return &SyncNFTOwnerResult{
+		Actor:         actor.String(),
+		Receiver:      s.Owner.String(),
+		PreviousOwner: owner.String(),
+	}, nil
+}
+
+func (*SyncNFTOwner) ComputeUnits(chain.Rules) uint64 {
+	return SyncNFTOwnerComputeUnits
+}
+
+func (*SyncNFTOwner) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalSyncNFTOwner(p *codec.Packer) (chain.Action, error) {
+	var sync SyncNFTOwner
+	p.UnpackAddress(&sync.AssetAddress)
+	p.UnpackAddress(&sync.AssetNftAddress)
+	p.UnpackAddress(&sync.Owner)
+	return &sync, p.Err()
+}
+
+var _ codec.Typed = (*SyncNFTOwnerResult)(nil)
+
+type SyncNFTOwnerResult struct {
+	Actor         string `serialize:"true" json:"actor"`
+	Receiver      string `serialize:"true" json:"receiver"`
+	PreviousOwner string `serialize:"true" json:"previous_owner"`
+}
+
+func (*SyncNFTOwnerResult) GetTypeID() uint8 {
+	return nconsts.SyncNFTOwnerID
+}
+
+func UnmarshalSyncNFTOwnerResult(p *codec.Packer) (codec.Typed, error) {
+	var result SyncNFTOwnerResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.PreviousOwner = p.UnpackString(false)
+	return &result, p.Err()
+}
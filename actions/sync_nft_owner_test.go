@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestSyncNFTOwnerAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	minter := codectest.NewRandomAddress()
+	holder := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetNonFungibleTokenID, []byte("name"), []byte("SYM"), 0, []byte("metadata"), minter)
+	nftAddress := storage.AssetAddressNFT(assetAddress, []byte("metadata"), minter)
+
+	// newStaleStore sets up an NFT moved to [holder] by a transfer that left
+	// [minter] as its owner
+	newStaleStore := func() state.Mutable {
+		store := newNFTStore(t, minter, assetAddress, nftAddress)
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, minter, 0))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, nftAddress, holder, 1))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NFTCollectionMismatch",
+			Actor: actor,
+			Action: &SyncNFTOwner{
+				AssetAddress:    codectest.NewRandomAddress(),
+				AssetNftAddress: nftAddress,
+				Owner:           holder,
+			},
+			State:       newStaleStore(),
+			ExpectedErr: ErrNFTDoesNotBelongToTheCollection,
+		},
+		{
+			Name:  "OwnerUpToDate",
+			Actor: actor,
+			Action: &SyncNFTOwner{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+				Owner:           minter,
+			},
+			State:       newNFTStore(t, minter, assetAddress, nftAddress),
+			ExpectedErr: ErrNFTOwnerUpToDate,
+		},
+		{
+			Name:  "NotHeldByOwner",
+			Actor: actor,
+			Action: &SyncNFTOwner{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+				Owner:           actor,
+			},
+			State:       newStaleStore(),
+			ExpectedErr: ErrNFTNotHeldByOwner,
+		},
+		{
+			Name:  "ValidSyncNFTOwner",
+			Actor: actor,
+			Action: &SyncNFTOwner{
+				AssetAddress:    assetAddress,
+				AssetNftAddress: nftAddress,
+				Owner:           holder,
+			},
+			State: newStaleStore(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.Equal(t, holder, owner)
+				exists, previousOwner, owner, changes, err := storage.GetNFTOwnershipNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, minter, previousOwner)
+				require.Equal(t, holder, owner)
+				require.Equal(t, uint64(1), changes)
+			},
+			ExpectedOutputs: &SyncNFTOwnerResult{
+				Actor:         actor.String(),
+				Receiver:      holder.String(),
+				PreviousOwner: minter.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -22,12 +22,13 @@ const (
 )
 
 var (
-	ErrAssetDoesNotExist              = errors.New("asset does not exist")
-	ErrValueZero                      = errors.New("value is zero")
-	ErrNFTValueMustBeOne              = errors.New("NFT value must be one")
-	ErrMemoTooLarge                   = errors.New("memo is too large")
-	ErrTransferToSelf                 = errors.New("cannot transfer to self")
-	_                    chain.Action = (*Transfer)(nil)
+	ErrAssetDoesNotExist               = errors.New("asset does not exist")
+	ErrValueZero                       = errors.New("value is zero")
+	ErrNFTValueMustBeOne               = errors.New("NFT value must be one")
+	ErrMemoTooLarge                    = errors.New("memo is too large")
+	ErrTransferToSelf                  = errors.New("cannot transfer to self")
+	ErrNFTTransferInvalid              = errors.New("NFTs are transferred with TransferNFT")
+	_                     chain.Action = (*Transfer)(nil)
 )
 
 type Transfer struct {
@@ -73,11 +74,13 @@ func (t *Transfer) Execute(
 	if err != nil {
 		return nil, ErrAssetDoesNotExist
 	}
+	// The state keys of the collection of an NFT are not known here
+	if assetType == nconsts.AssetNonFungibleTokenID {
+		return nil, ErrNFTTransferInvalid
+	}
 
 	// Check the invariants
-	if assetType == nconsts.AssetNonFungibleTokenID && t.Value != 1 {
-		return nil, ErrNFTValueMustBeOne
-	} else if t.Value == 0 {
+	if t.Value == 0 {
 		return nil, ErrValueZero
 	}
 	if len(t.Memo) > storage.MaxTextSize {
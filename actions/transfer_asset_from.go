@@ -0,0 +1,188 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	TransferAssetFromComputeUnits = 1
+)
+
+var (
+	ErrInsufficientAllowance              = errors.New("insufficient allowance")
+	ErrAllowanceExpired                   = errors.New("allowance expired")
+	_                        chain.Action = (*TransferAssetFrom)(nil)
+)
+
+type TransferAssetFrom struct {
+	// From is the account that approved the actor to move its balance
+	From codec.Address `serialize:"true" json:"from"`
+
+	// To is the recipient of the [Value].
+	To codec.Address `serialize:"true" json:"to"`
+
+	// AssetAddress to transfer.
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// Amount are transferred to [To] and deducted from the allowance.
+	Value uint64 `serialize:"true" json:"value"`
+
+	// Optional message to accompany transaction.
+	Memo string `serialize:"true" json:"memo"`
+
+	// CollectionAddress is the collection of [AssetAddress] when an NFT is
+	// transferred and is empty otherwise. It is needed to know the state keys
+	// of the transfer.
+	CollectionAddress codec.Address `serialize:"true" json:"collection_address"`
+}
+
+func (*TransferAssetFrom) GetTypeID() uint8 {
+	return nconsts.TransferAssetFromID
+}
+
+func (t *TransferAssetFrom) StateKeys(actor codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.AssetInfoKey(t.AssetAddress)):                     state.Read | state.Write,
+		string(storage.AssetAllowanceKey(t.AssetAddress, t.From, actor)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(t.AssetAddress, t.From)):   state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(t.AssetAddress, t.To)):     state.All,
+	}
+	if t.CollectionAddress != codec.EmptyAddress {
+		storage.AddNFTTransferStateKeys(stateKeys, t.AssetAddress, t.CollectionAddress, t.From, t.To)
+	}
+	return stateKeys
+}
+
+func (t *TransferAssetFrom) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Ensure that the owner is not transferring to self
+	if t.From == t.To {
+		return nil, ErrTransferToSelf
+	}
+	// Check that asset exists
+	assetType, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, t.AssetAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	if err := checkTransferCollection(assetType, uri, t.CollectionAddress); err != nil {
+		return nil, err
+	}
+
+	// Check the invariants
+	if assetType == nconsts.AssetNonFungibleTokenID && t.Value != 1 {
+		return nil, ErrNFTValueMustBeOne
+	} else if t.Value == 0 {
+		return nil, ErrValueZero
+	}
+	if len(t.Memo) > storage.MaxTextSize {
+		return nil, ErrMemoTooLarge
+	}
+
+	// Check that the allowance is sufficient
+	allowance, expiryBlock, err := storage.GetAssetAllowanceNoController(ctx, mu, t.AssetAddress, t.From, actor)
+	if err != nil {
+		return nil, err
+	}
+	if allowance < t.Value {
+		return nil, ErrInsufficientAllowance
+	}
+	if expiryBlock != 0 && emission.GetEmission().GetLastAcceptedBlockHeight() > expiryBlock {
+		return nil, ErrAllowanceExpired
+	}
+
+	// Check that balance is sufficient
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, t.AssetAddress, t.From)
+	if err != nil {
+		return nil, err
+	}
+	if balance < t.Value {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	remainingAllowance := allowance - t.Value
+	if remainingAllowance == 0 {
+		err = storage.DeleteAssetAllowance(ctx, mu, t.AssetAddress, t.From, actor)
+	} else {
+		err = storage.SetAssetAllowance(ctx, mu, t.AssetAddress, t.From, actor, remainingAllowance, expiryBlock)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	senderBalance, receiverBalance, err := storage.TransferAsset(ctx, mu, t.AssetAddress, t.From, t.To, t.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferAssetFromResult{
+		Actor:              actor.String(),
+		Receiver:           t.To.String(),
+		SenderBalance:      senderBalance,
+		ReceiverBalance:    receiverBalance,
+		RemainingAllowance: remainingAllowance,
+	}, nil
+}
+
+func (*TransferAssetFrom) ComputeUnits(chain.Rules) uint64 {
+	return TransferAssetFromComputeUnits
+}
+
+func (*TransferAssetFrom) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalTransferAssetFrom(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferAssetFrom
+	p.UnpackAddress(&transfer.From)
+	p.UnpackAddress(&transfer.To)
+	p.UnpackAddress(&transfer.AssetAddress)
+	transfer.Value = p.UnpackUint64(true)
+	transfer.Memo = p.UnpackString(false)
+	unpackAddressOrEmpty(p, &transfer.CollectionAddress)
+	return &transfer, p.Err()
+}
+
+var _ codec.Typed = (*TransferAssetFromResult)(nil)
+
+type TransferAssetFromResult struct {
+	Actor              string `serialize:"true" json:"actor"`
+	Receiver           string `serialize:"true" json:"receiver"`
+	SenderBalance      uint64 `serialize:"true" json:"sender_balance"`
+	ReceiverBalance    uint64 `serialize:"true" json:"receiver_balance"`
+	RemainingAllowance uint64 `serialize:"true" json:"remaining_allowance"`
+}
+
+func (*TransferAssetFromResult) GetTypeID() uint8 {
+	return nconsts.TransferAssetFromID
+}
+
+func UnmarshalTransferAssetFromResult(p *codec.Packer) (codec.Typed, error) {
+	var result TransferAssetFromResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.SenderBalance = p.UnpackUint64(false)
+	result.ReceiverBalance = p.UnpackUint64(false)
+	result.RemainingAllowance = p.UnpackUint64(false)
+	return &result, p.Err()
+}
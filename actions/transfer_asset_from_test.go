@@ -0,0 +1,148 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestTransferAssetFromAction(t *testing.T) {
+	emission.MockNewEmission(&emission.MockEmission{LastAcceptedBlockHeight: 100})
+
+	actor := codectest.NewRandomAddress()
+	owner := codectest.NewRandomAddress()
+	receiver := codectest.NewRandomAddress()
+	assetAddress := storage.AssetAddress(nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), owner)
+
+	// newStore sets up the asset with a balance of 1000 for the owner and an
+	// allowance of [allowance] for the actor
+	newStore := func(allowance uint64, expiryBlock uint64) state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, assetAddress, nconsts.AssetFungibleTokenID, []byte("name"), []byte("SYM"), 9, []byte("metadata"), []byte("uri"), 1000, 0, owner, owner, owner, owner, owner))
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, assetAddress, owner, 1000))
+		if allowance > 0 {
+			require.NoError(t, storage.SetAssetAllowance(context.Background(), store, assetAddress, owner, actor, allowance, expiryBlock))
+		}
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NoAllowance",
+			Actor: actor,
+			Action: &TransferAssetFrom{
+				From:         owner,
+				To:           receiver,
+				AssetAddress: assetAddress,
+				Value:        100,
+			},
+			State:       newStore(0, 0),
+			ExpectedErr: ErrInsufficientAllowance,
+		},
+		{
+			Name:  "InsufficientAllowance",
+			Actor: actor,
+			Action: &TransferAssetFrom{
+				From:         owner,
+				To:           receiver,
+				AssetAddress: assetAddress,
+				Value:        100,
+			},
+			State:       newStore(50, 0),
+			ExpectedErr: ErrInsufficientAllowance,
+		},
+		{
+			Name:  "AllowanceExpired",
+			Actor: actor,
+			Action: &TransferAssetFrom{
+				From:         owner,
+				To:           receiver,
+				AssetAddress: assetAddress,
+				Value:        100,
+			},
+			State:       newStore(100, 99),
+			ExpectedErr: ErrAllowanceExpired,
+		},
+		{
+			Name:  "InsufficientBalance",
+			Actor: actor,
+			Action: &TransferAssetFrom{
+				From:         owner,
+				To:           receiver,
+				AssetAddress: assetAddress,
+				Value:        2000,
+			},
+			State:       newStore(5000, 0),
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ValidTransferFrom",
+			Actor: actor,
+			Action: &TransferAssetFrom{
+				From:         owner,
+				To:           receiver,
+				AssetAddress: assetAddress,
+				Value:        100,
+			},
+			State: newStore(300, 100),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				amount, expiryBlock, err := storage.GetAssetAllowanceNoController(ctx, store, assetAddress, owner, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(200), amount)
+				require.Equal(t, uint64(100), expiryBlock)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, owner)
+				require.NoError(t, err)
+				require.Equal(t, uint64(900), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, receiver)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), balance)
+			},
+			ExpectedOutputs: &TransferAssetFromResult{
+				Actor:              actor.String(),
+				Receiver:           receiver.String(),
+				SenderBalance:      900,
+				ReceiverBalance:    100,
+				RemainingAllowance: 200,
+			},
+		},
+		{
+			Name:  "AllowanceUsedUp",
+			Actor: actor,
+			Action: &TransferAssetFrom{
+				From:         owner,
+				To:           receiver,
+				AssetAddress: assetAddress,
+				Value:        100,
+			},
+			State: newStore(100, 0),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				amount, _, err := storage.GetAssetAllowanceNoController(ctx, store, assetAddress, owner, actor)
+				require.NoError(t, err)
+				require.Zero(t, amount)
+			},
+			ExpectedOutputs: &TransferAssetFromResult{
+				Actor:           actor.String(),
+				Receiver:        receiver.String(),
+				SenderBalance:   900,
+				ReceiverBalance: 100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	TransferNFTComputeUnits = 1
+)
+
+var _ chain.Action = (*TransferNFT)(nil)
+
+// TransferNFT transfers an NFT. Unlike [Transfer] it names the collection of
+// the NFT so that the collection balances can be updated as well.
+type TransferNFT struct {
+	// To is the recipient of the NFT.
+	To codec.Address `serialize:"true" json:"to"`
+
+	// AssetAddress of the NFT to transfer.
+	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
+
+	// CollectionAddress is the collection the NFT belongs to.
+	CollectionAddress codec.Address `serialize:"true" json:"collection_address"`
+
+	// Optional message to accompany transaction.
+	Memo string `serialize:"true" json:"memo"`
+}
+
+func (*TransferNFT) GetTypeID() uint8 {
+	return nconsts.TransferNFTID
+}
+
+func (t *TransferNFT) StateKeys(actor codec.Address) state.Keys {
+	stateKeys := state.Keys{
+		string(storage.AssetInfoKey(t.AssetAddress)):                  state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(t.AssetAddress, actor)): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(t.AssetAddress, t.To)):  state.All,
+	}
+	storage.AddNFTTransferStateKeys(stateKeys, t.AssetAddress, t.CollectionAddress, actor, t.To)
+	return stateKeys
+}
+
+func (t *TransferNFT) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Ensure that the user is not transferring to self
+	if actor == t.To {
+		return nil, ErrTransferToSelf
+	}
+	// Check that the NFT exists and belongs to the collection
+	assetType, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, mu, t.AssetAddress)
+	if err != nil {
+		return nil, ErrAssetDoesNotExist
+	}
+	if assetType != nconsts.AssetNonFungibleTokenID {
+		return nil, ErrAssetTypeInvalid
+	}
+	if err := checkTransferCollection(assetType, uri, t.CollectionAddress); err != nil {
+		return nil, err
+	}
+	if len(t.Memo) > storage.MaxTextSize {
+		return nil, ErrMemoTooLarge
+	}
+
+	// Check that the actor owns the NFT
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, t.AssetAddress, actor)
+	if err != nil {
+		return nil, err
+	}
+	if balance < 1 {
+		return nil, storage.ErrInsufficientAssetBalance
+	}
+
+	if _, _, err := storage.TransferAsset(ctx, mu, t.AssetAddress, actor, t.To, 1); err != nil {
+		return nil, err
+	}
+
+	return &TransferNFTResult{
+		Actor:             actor.String(),
+		Receiver:          t.To.String(),
+		CollectionAddress: t.CollectionAddress.String(),
+	}, nil
+}
+
+func (*TransferNFT) ComputeUnits(chain.Rules) uint64 {
+	return TransferNFTComputeUnits
+}
+
+func (*TransferNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalTransferNFT(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferNFT
+	p.UnpackAddress(&transfer.To)
+	p.UnpackAddress(&transfer.AssetAddress)
+	p.UnpackAddress(&transfer.CollectionAddress)
+	transfer.Memo = p.UnpackString(false)
+	return &transfer, p.Err()
+}
+
+// checkTransferCollection checks that [collectionAddress] is the collection of
+// the asset if it is an NFT and that it is empty otherwise
+func checkTransferCollection(assetType uint8, uri []byte, collectionAddress codec.Address) error {
+	if assetType != nconsts.AssetNonFungibleTokenID {
+		if collectionAddress != codec.EmptyAddress {
+			return ErrNFTDoesNotBelongToTheCollection
+		}
+		return nil
+	}
+	if string(uri) != collectionAddress.String() {
+		return ErrNFTDoesNotBelongToTheCollection
+	}
+	return nil
+}
+
+var _ codec.Typed = (*TransferNFTResult)(nil)
+
+type TransferNFTResult struct {
+	Actor             string `serialize:"true" json:"actor"`
+	Receiver          string `serialize:"true" json:"receiver"`
+	CollectionAddress string `serialize:"true" json:"collection_address"`
+}
+
+func (*TransferNFTResult) GetTypeID() uint8 {
+	return nconsts.TransferNFTID
+}
+
+func UnmarshalTransferNFTResult(p *codec.Packer) (codec.Typed, error) {
+	var result TransferNFTResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(true)
+	result.CollectionAddress = p.UnpackString(true)
+	return &result, p.Err()
+}
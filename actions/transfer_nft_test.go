@@ -0,0 +1,167 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/state/tstate"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestTransferNFTAction(t *testing.T) {
+	req := require.New(t)
+	ts := tstate.New(1)
+
+	actor1 := codectest.NewRandomAddress()
+	actor2 := codectest.NewRandomAddress()
+
+	assetAddress := codectest.NewRandomAddress()
+	nftAddress := codectest.NewRandomAddress()
+	nftCollectionAddress := codectest.NewRandomAddress()
+
+	parentState := ts.NewView(
+		state.Keys{
+			string(storage.AssetInfoKey(assetAddress)):                           state.All,
+			string(storage.AssetInfoKey(nftAddress)):                             state.All,
+			string(storage.AssetAccountBalanceKey(assetAddress, actor1)):         state.All,
+			string(storage.AssetAccountBalanceKey(nftAddress, actor1)):           state.All,
+			string(storage.AssetAccountBalanceKey(nftAddress, actor2)):           state.All,
+			string(storage.AssetAccountBalanceKey(nftCollectionAddress, actor1)): state.All,
+			string(storage.AssetAccountBalanceKey(nftCollectionAddress, actor2)): state.All,
+			string(storage.NFTOwnershipKey(nftAddress)):                          state.All,
+		},
+		chaintest.NewInMemoryStore().Storage,
+	)
+	req.NoError(storage.SetAssetInfo(context.Background(), parentState, assetAddress, nconsts.AssetFungibleTokenID, []byte("My Token"), []byte("MYT"), 9, []byte("Metadata"), []byte("uri"), 1, 0, actor1, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, assetAddress, actor1, 1))
+	req.NoError(storage.SetAssetInfo(context.Background(), parentState, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("My Token"), []byte("MYT"), 0, []byte("Metadata"), []byte(nftCollectionAddress.String()), 1, 0, actor1, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, nftAddress, actor1, 1))
+	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, nftCollectionAddress, actor1, 1))
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "NFTDoesNotExist",
+			Actor: actor1,
+			Action: &TransferNFT{
+				To:                actor2,
+				AssetAddress:      codectest.NewRandomAddress(),
+				CollectionAddress: nftCollectionAddress,
+			},
+			State:       parentState,
+			ExpectedErr: ErrAssetDoesNotExist,
+		},
+		{
+			Name:  "SelfTransferShouldNotBePossible",
+			Actor: actor1,
+			Action: &TransferNFT{
+				To:                actor1,
+				AssetAddress:      nftAddress,
+				CollectionAddress: nftCollectionAddress,
+			},
+			State:       parentState,
+			ExpectedErr: ErrTransferToSelf,
+		},
+		{
+			Name:  "AssetIsNotAnNFT",
+			Actor: actor1,
+			Action: &TransferNFT{
+				To:                actor2,
+				AssetAddress:      assetAddress,
+				CollectionAddress: nftCollectionAddress,
+			},
+			State:       parentState,
+			ExpectedErr: ErrAssetTypeInvalid,
+		},
+		{
+			Name:  "WrongCollection",
+			Actor: actor1,
+			Action: &TransferNFT{
+				To:                actor2,
+				AssetAddress:      nftAddress,
+				CollectionAddress: codectest.NewRandomAddress(),
+			},
+			State:       parentState,
+			ExpectedErr: ErrNFTDoesNotBelongToTheCollection,
+		},
+		{
+			Name:  "MemoSizeExceeded",
+			Actor: actor1,
+			Action: &TransferNFT{
+				To:                actor2,
+				AssetAddress:      nftAddress,
+				CollectionAddress: nftCollectionAddress,
+				Memo:              strings.Repeat("a", storage.MaxTextSize+1),
+			},
+			State:       parentState,
+			ExpectedErr: ErrMemoTooLarge,
+		},
+		{
+			Name:  "NotTheOwner",
+			Actor: actor2,
+			Action: &TransferNFT{
+				To:                actor1,
+				AssetAddress:      nftAddress,
+				CollectionAddress: nftCollectionAddress,
+			},
+			State:       parentState,
+			ExpectedErr: storage.ErrInsufficientAssetBalance,
+		},
+		{
+			Name:  "ValidNFTTransfer",
+			Actor: actor1,
+			Action: &TransferNFT{
+				To:                actor2,
+				AssetAddress:      nftAddress,
+				CollectionAddress: nftCollectionAddress,
+			},
+			State: parentState,
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// Check NFT balances
+				receiverBalance, err := storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, actor2)
+				require.NoError(t, err)
+				require.Equal(t, receiverBalance, uint64(1))
+				senderBalance, err := storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, actor1)
+				require.NoError(t, err)
+				require.Equal(t, senderBalance, uint64(0))
+				// Check collectionAsset balances
+				receiverBalance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftCollectionAddress, actor2)
+				require.NoError(t, err)
+				require.Equal(t, receiverBalance, uint64(1))
+				senderBalance, err = storage.GetAssetAccountBalanceNoController(ctx, store, nftCollectionAddress, actor1)
+				require.NoError(t, err)
+				require.Equal(t, senderBalance, uint64(0))
+				// Check that the NFT changed owner
+				_, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.Equal(t, actor2, owner)
+				exists, previousOwner, owner, changes, err := storage.GetNFTOwnershipNoController(ctx, store, nftAddress)
+				require.NoError(t, err)
+				require.True(t, exists)
+				require.Equal(t, actor1, previousOwner)
+				require.Equal(t, actor2, owner)
+				require.Equal(t, uint64(1), changes)
+			},
+			ExpectedOutputs: &TransferNFTResult{
+				Actor:             actor1.String(),
+				Receiver:          actor2.String(),
+				CollectionAddress: nftCollectionAddress.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
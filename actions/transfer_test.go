@@ -30,18 +30,20 @@ func TestTransferAction(t *testing.T) {
 
 	assetAddress := codectest.NewRandomAddress()
 	nftAddress := codectest.NewRandomAddress()
+	nftCollectionAddress := codectest.NewRandomAddress()
 
 	parentState := ts.NewView(
 		state.Keys{
-			string(storage.AssetInfoKey(storage.NAIAddress)):                   state.All,
-			string(storage.AssetInfoKey(assetAddress)):                         state.All,
-			string(storage.AssetInfoKey(nftAddress)):                           state.All,
-			string(storage.AssetAccountBalanceKey(storage.NAIAddress, actor1)): state.All,
-			string(storage.AssetAccountBalanceKey(assetAddress, actor1)):       state.All,
-			string(storage.AssetAccountBalanceKey(nftAddress, actor1)):         state.All,
-			string(storage.AssetAccountBalanceKey(storage.NAIAddress, actor2)): state.All,
-			string(storage.AssetAccountBalanceKey(assetAddress, actor2)):       state.All,
-			string(storage.AssetAccountBalanceKey(nftAddress, actor2)):         state.All,
+			string(storage.AssetInfoKey(storage.NAIAddress)):                     state.All,
+			string(storage.AssetInfoKey(assetAddress)):                           state.All,
+			string(storage.AssetInfoKey(nftAddress)):                             state.All,
+			string(storage.AssetAccountBalanceKey(storage.NAIAddress, actor1)):   state.All,
+			string(storage.AssetAccountBalanceKey(assetAddress, actor1)):         state.All,
+			string(storage.AssetAccountBalanceKey(nftAddress, actor1)):           state.All,
+			string(storage.AssetAccountBalanceKey(storage.NAIAddress, actor2)):   state.All,
+			string(storage.AssetAccountBalanceKey(assetAddress, actor2)):         state.All,
+			string(storage.AssetAccountBalanceKey(nftAddress, actor2)):           state.All,
+			string(storage.AssetAccountBalanceKey(nftCollectionAddress, actor1)): state.All,
 		},
 		chaintest.NewInMemoryStore().Storage,
 	)
@@ -115,8 +117,9 @@ func TestTransferAction(t *testing.T) {
 	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, storage.NAIAddress, actor1, 1))
 	req.NoError(storage.SetAssetInfo(context.Background(), parentState, assetAddress, nconsts.AssetFungibleTokenID, []byte("My Token"), []byte("MYT"), 9, []byte("Metadata"), []byte("uri"), 1, 0, actor1, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
 	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, assetAddress, actor1, 1))
-	req.NoError(storage.SetAssetInfo(context.Background(), parentState, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("My Token"), []byte("MYT"), 0, []byte("Metadata"), assetAddress[:], 1, 0, actor1, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	req.NoError(storage.SetAssetInfo(context.Background(), parentState, nftAddress, nconsts.AssetNonFungibleTokenID, []byte("My Token"), []byte("MYT"), 0, []byte("Metadata"), []byte(nftCollectionAddress.String()), 1, 0, actor1, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
 	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, nftAddress, actor1, 1))
+	req.NoError(storage.SetAssetAccountBalance(context.Background(), parentState, nftCollectionAddress, actor1, 1))
 
 	tests = []chaintest.ActionTest{
 		{
@@ -190,36 +193,15 @@ func TestTransferAction(t *testing.T) {
 			},
 		},
 		{
-			Name:  "ValidNFTTransfer",
+			Name:  "NFTTransferInvalid",
 			Actor: actor1,
 			Action: &Transfer{
 				To:           actor2,
 				AssetAddress: nftAddress,
 				Value:        1,
 			},
-			State: parentState,
-			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
-				// Check NFT balances
-				receiverBalance, err := storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, actor2)
-				require.NoError(t, err)
-				require.Equal(t, receiverBalance, uint64(1))
-				senderBalance, err := storage.GetAssetAccountBalanceNoController(ctx, store, nftAddress, actor1)
-				require.NoError(t, err)
-				require.Equal(t, senderBalance, uint64(0))
-				// Check collectionAsset balances
-				receiverBalance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor2)
-				require.NoError(t, err)
-				require.Equal(t, receiverBalance, uint64(1))
-				senderBalance, err = storage.GetAssetAccountBalanceNoController(ctx, store, assetAddress, actor1)
-				require.NoError(t, err)
-				require.Equal(t, senderBalance, uint64(0))
-			},
-			ExpectedOutputs: &TransferResult{
-				Actor:           actor1.String(),
-				Receiver:        actor2.String(),
-				SenderBalance:   0,
-				ReceiverBalance: 1,
-			},
+			State:       parentState,
+			ExpectedErr: ErrNFTTransferInvalid,
 		},
 	}
 
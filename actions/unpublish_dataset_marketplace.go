@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	UnpublishDatasetMarketplaceComputeUnits = 5
+)
+
+var _ chain.Action = (*UnpublishDatasetMarketplace)(nil)
+
+type UnpublishDatasetMarketplace struct {
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+}
+
+func (*UnpublishDatasetMarketplace) GetTypeID() uint8 {
+	return nconsts.UnpublishDatasetMarketplaceID
+}
+
+func (u *UnpublishDatasetMarketplace) StateKeys(_ codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.AssetInfoKey(storage.AssetAddressFractional(u.DatasetAddress))): state.Read | state.Write,
+		string(storage.DatasetInfoKey(u.DatasetAddress)):                               state.Read | state.Write,
+	}
+}
+
+func (u *UnpublishDatasetMarketplace) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Check if the dataset is on the marketplace
+	name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, _, _, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner, err := storage.GetDatasetInfoNoController(ctx, mu, u.DatasetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if marketplaceAssetAddress == codec.EmptyAddress {
+		return nil, ErrDatasetNotOnSale
+	}
+
+	// Only the owner of the listing can unpublish it
+	assetType, assetName, symbol, decimals, assetMetadata, uri, totalSupply, maxSupply, listingOwner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if listingOwner != actor {
+		return nil, ErrWrongOwner
+	}
+
+	// The marketplace asset is kept so that existing subscriptions stay valid
+	// and their payments can still be claimed
+	metadataMap, err := utils.BytesToMap(assetMetadata)
+	if err != nil {
+		return nil, err
+	}
+	metadataMap["status"] = storage.MarketplaceStatusUnpublished
+	assetMetadata, err = utils.MapToBytes(metadataMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAssetInfo(ctx, mu, marketplaceAssetAddress, assetType, assetName, symbol, decimals, assetMetadata, uri, totalSupply, maxSupply, listingOwner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin); err != nil {
+		return nil, err
+	}
+	// Take the dataset off the marketplace
+	if err := storage.SetDatasetInfo(ctx, mu, u.DatasetAddress, name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, codec.EmptyAddress, codec.EmptyAddress, 0, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner); err != nil {
+		return nil, err
+	}
+
+	return &UnpublishDatasetMarketplaceResult{
+		Actor:                   actor.String(),
+		Receiver:                "",
+		MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+	}, nil
+}
+
+func (*UnpublishDatasetMarketplace) ComputeUnits(chain.Rules) uint64 {
+	return UnpublishDatasetMarketplaceComputeUnits
+}
+
+func (*UnpublishDatasetMarketplace) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalUnpublishDatasetMarketplace(p *codec.Packer) (chain.Action, error) {
+	var unpublish UnpublishDatasetMarketplace
+	p.UnpackAddress(&unpublish.DatasetAddress)
+	return &unpublish, p.Err()
+}
+
+var _ codec.Typed = (*UnpublishDatasetMarketplaceResult)(nil)
+
+type UnpublishDatasetMarketplaceResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	MarketplaceAssetAddress string `serialize:"true" json:"marketplace_asset_address"`
+}
+
+func (*UnpublishDatasetMarketplaceResult) GetTypeID() uint8 {
+	return nconsts.UnpublishDatasetMarketplaceID
+}
+
+func UnmarshalUnpublishDatasetMarketplaceResult(p *codec.Packer) (codec.Typed, error) {
+	var result UnpublishDatasetMarketplaceResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.MarketplaceAssetAddress = p.UnpackString(true)
+	return &result, p.Err()
+}
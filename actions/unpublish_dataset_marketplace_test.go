@@ -0,0 +1,102 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestUnpublishDatasetMarketplaceAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "WrongOwner",
+			Actor: codectest.NewRandomAddress(), // Not the owner of the listing
+			Action: &UnpublishDatasetMarketplace{
+				DatasetAddress: datasetAddress,
+			},
+			State:       newListingStore(t, datasetAddress, owner, 0),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "ValidUnpublish",
+			Actor: owner,
+			Action: &UnpublishDatasetMarketplace{
+				DatasetAddress: datasetAddress,
+			},
+			State: newListingStore(t, datasetAddress, owner, 1000),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				// The dataset is off the marketplace
+				_, _, _, _, _, _, _, _, mAddr, baseAsset, basePrice, _, _, _, _, _, err := storage.GetDatasetInfoNoController(ctx, store, datasetAddress)
+				require.NoError(t, err)
+				require.Equal(t, codec.EmptyAddress, mAddr)
+				require.Equal(t, codec.EmptyAddress, baseAsset)
+				require.Zero(t, basePrice)
+
+				// The listing keeps its subscriptions and payments
+				_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				metadataMap, err := utils.BytesToMap(metadata)
+				require.NoError(t, err)
+				require.Equal(t, storage.MarketplaceStatusUnpublished, metadataMap["status"])
+				require.Equal(t, "1", metadataMap["subscriptions"])
+				_, remaining, _, _, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, storage.NAIAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1000), remaining)
+
+				// The dataset can be published again in another payment asset
+				otherAsset := codectest.NewRandomAddress()
+				_, err = (&PublishDatasetMarketplace{
+					DatasetAddress:       datasetAddress,
+					PaymentAssetAddress:  otherAsset,
+					DatasetPricePerBlock: 200,
+				}).Execute(ctx, nil, store, 0, owner, ids.Empty)
+				require.NoError(t, err)
+				_, _, _, _, metadata, _, _, _, _, _, _, _, _, err = storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				metadataMap, err = utils.BytesToMap(metadata)
+				require.NoError(t, err)
+				require.Equal(t, storage.MarketplaceStatusActive, metadataMap["status"])
+				require.Equal(t, otherAsset.String(), metadataMap["paymentAssetAddress"])
+				require.Equal(t, "200", metadataMap["datasetPricePerBlock"])
+				require.Equal(t, "1", metadataMap["subscriptions"])
+				// Payments left in the previous payment asset can still be claimed
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, []storage.MarketplacePaymentAsset{
+					{Address: storage.NAIAddress, Pending: true},
+					{Address: otherAsset, Accepted: true},
+				}, paymentAssets)
+				_, remaining, _, _, err = storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, storage.NAIAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1000), remaining)
+			},
+			ExpectedOutputs: &UnpublishDatasetMarketplaceResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
@@ -23,18 +23,15 @@ const (
 )
 
 var (
-	ErrWrongOwner                                             = errors.New("wrong owner")
-	ErrAssetNotFound                                          = errors.New("asset not found")
-	ErrOutputMustUpdateAtLeastOneField                        = errors.New("must update at least one field")
-	ErrOutputMaxSupplyInvalid                                 = errors.New("max supply must be greater than or equal to total supply")
-	ErrOutputOwnerInvalid                                     = errors.New("owner is invalid")
-	ErrOutputMintAdminInvalid                                 = errors.New("mint admin is invalid")
-	ErrOutputPauseUnpauseAdminInvalid                         = errors.New("pause/unpause admin is invalid")
-	ErrOutputFreezeUnfreezeAdminInvalid                       = errors.New("freeze/unfreeze admin is invalid")
-	ErrOutputEnableDisableKYCAccountAdminInvalid              = errors.New("enable/disable KYC account admin is invalid")
-	_                                            chain.Action = (*UpdateAsset)(nil)
+	ErrWrongOwner                                   = errors.New("wrong owner")
+	ErrAssetNotFound                                = errors.New("asset not found")
+	ErrOutputMustUpdateAtLeastOneField              = errors.New("must update at least one field")
+	ErrOutputMaxSupplyInvalid                       = errors.New("max supply must be greater than or equal to total supply")
+	_                                  chain.Action = (*UpdateAsset)(nil)
 )
 
+// UpdateAsset updates the details of an asset. The owner and admins are
+// handed off with [ProposeOwnershipTransfer] instead.
 type UpdateAsset struct {
 	// AssetAddress to update
 	AssetAddress codec.Address `serialize:"true" json:"asset_address"`
@@ -50,21 +47,6 @@ type UpdateAsset struct {
 
 	// The max supply of the asset
 	MaxSupply uint64 `serialize:"true" json:"max_supply"`
-
-	// Owner of the asset
-	Owner string `serialize:"true" json:"owner"`
-
-	// The wallet address that can mint/burn assets
-	MintAdmin string `serialize:"true" json:"mint_admin"`
-
-	// The wallet address that can pause/unpause assets
-	PauseUnpauseAdmin string `serialize:"true" json:"pause_unpause_admin"`
-
-	// The wallet address that can freeze/unfreeze assets
-	FreezeUnfreezeAdmin string `serialize:"true" json:"freeze_unfreeze_admin"`
-
-	// The wallet address that can enable/disable KYC account flag
-	EnableDisableKYCAccountAdmin string `serialize:"true" json:"enable_disable_kyc_account_admin"`
 }
 
 func (*UpdateAsset) GetTypeID() uint8 {
@@ -107,7 +89,7 @@ func (u *UpdateAsset) Execute(
 	}
 
 	// Ensure that at least one field is being updated
-	if (len(u.Name) == 0 || bytes.Equal([]byte(u.Name), name)) && (len(u.Symbol) == 0 || bytes.Equal([]byte(u.Symbol), symbol)) && (len(u.Metadata) == 0 || bytes.Equal([]byte(u.Metadata), metadata)) && (u.MaxSupply == maxSupply) && (len(u.Owner) == 0 || u.Owner != owner.String()) && (len(u.MintAdmin) == 0 || u.MintAdmin != mintAdmin.String()) && (len(u.PauseUnpauseAdmin) == 0 || u.PauseUnpauseAdmin != pauseUnpauseAdmin.String()) && (len(u.FreezeUnfreezeAdmin) == 0 || u.FreezeUnfreezeAdmin != freezeUnfreezeAdmin.String()) && (len(u.EnableDisableKYCAccountAdmin) == 0 || u.EnableDisableKYCAccountAdmin != enableDisableKYCAccountAdmin.String()) {
+	if (len(u.Name) == 0 || bytes.Equal([]byte(u.Name), name)) && (len(u.Symbol) == 0 || bytes.Equal([]byte(u.Symbol), symbol)) && (len(u.Metadata) == 0 || bytes.Equal([]byte(u.Metadata), metadata)) && (u.MaxSupply == maxSupply) {
 		return nil, ErrOutputMustUpdateAtLeastOneField
 	}
 
@@ -146,37 +128,6 @@ func (u *UpdateAsset) Execute(
 		updateAssetResult.MaxSupply = u.MaxSupply
 	}
 
-	if len(u.Owner) > 0 {
-		if owner, err = codec.StringToAddress(u.Owner); err != nil {
-			return nil, ErrOutputOwnerInvalid
-		}
-		updateAssetResult.Owner = u.Owner
-	}
-	if len(u.MintAdmin) > 0 {
-		if mintAdmin, err = codec.StringToAddress(u.MintAdmin); err != nil {
-			return nil, ErrOutputMintAdminInvalid
-		}
-		updateAssetResult.MintAdmin = u.MintAdmin
-	}
-	if len(u.PauseUnpauseAdmin) > 0 {
-		if pauseUnpauseAdmin, err = codec.StringToAddress(u.PauseUnpauseAdmin); err != nil {
-			return nil, ErrOutputPauseUnpauseAdminInvalid
-		}
-		updateAssetResult.PauseUnpauseAdmin = u.PauseUnpauseAdmin
-	}
-	if len(u.FreezeUnfreezeAdmin) > 0 {
-		if freezeUnfreezeAdmin, err = codec.StringToAddress(u.FreezeUnfreezeAdmin); err != nil {
-			return nil, ErrOutputFreezeUnfreezeAdminInvalid
-		}
-		updateAssetResult.FreezeUnfreezeAdmin = u.FreezeUnfreezeAdmin
-	}
-	if len(u.EnableDisableKYCAccountAdmin) > 0 {
-		if enableDisableKYCAccountAdmin, err = codec.StringToAddress(u.EnableDisableKYCAccountAdmin); err != nil {
-			return nil, ErrOutputEnableDisableKYCAccountAdminInvalid
-		}
-		updateAssetResult.EnableDisableKYCAccountAdmin = u.EnableDisableKYCAccountAdmin
-	}
-
 	// Update the asset
 	if err := storage.SetAssetInfo(ctx, mu, u.AssetAddress, assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin); err != nil {
 		return nil, err
@@ -201,28 +152,18 @@ func UnmarshalUpdateAsset(p *codec.Packer) (chain.Action, error) {
 	create.Symbol = p.UnpackString(false)
 	create.Metadata = p.UnpackString(false)
 	create.MaxSupply = p.UnpackUint64(false)
-	create.Owner = p.UnpackString(false)
-	create.MintAdmin = p.UnpackString(false)
-	create.PauseUnpauseAdmin = p.UnpackString(false)
-	create.FreezeUnfreezeAdmin = p.UnpackString(false)
-	create.EnableDisableKYCAccountAdmin = p.UnpackString(false)
 	return &create, p.Err()
 }
 
 var _ codec.Typed = (*UpdateAssetResult)(nil)
 
 type UpdateAssetResult struct {
-	Actor                        string `serialize:"true" json:"actor"`
-	Receiver                     string `serialize:"true" json:"receiver"`
-	Name                         string `serialize:"true" json:"name"`
-	Symbol                       string `serialize:"true" json:"symbol"`
-	Metadata                     string `serialize:"true" json:"metadata"`
-	MaxSupply                    uint64 `serialize:"true" json:"max_supply"`
-	Owner                        string `serialize:"true" json:"owner"`
-	MintAdmin                    string `serialize:"true" json:"mint_admin"`
-	PauseUnpauseAdmin            string `serialize:"true" json:"pause_unpause_admin"`
-	FreezeUnfreezeAdmin          string `serialize:"true" json:"freeze_unfreeze_admin"`
-	EnableDisableKYCAccountAdmin string `serialize:"true" json:"enable_disable_kyc_account_admin"`
+	Actor     string `serialize:"true" json:"actor"`
+	Receiver  string `serialize:"true" json:"receiver"`
+	Name      string `serialize:"true" json:"name"`
+	Symbol    string `serialize:"true" json:"symbol"`
+	Metadata  string `serialize:"true" json:"metadata"`
+	MaxSupply uint64 `serialize:"true" json:"max_supply"`
 }
 
 func (*UpdateAssetResult) GetTypeID() uint8 {
@@ -237,10 +178,5 @@ func UnmarshalUpdateAssetResult(p *codec.Packer) (codec.Typed, error) {
 	result.Symbol = p.UnpackString(false)
 	result.Metadata = p.UnpackString(false)
 	result.MaxSupply = p.UnpackUint64(false)
-	result.Owner = p.UnpackString(false)
-	result.MintAdmin = p.UnpackString(false)
-	result.PauseUnpauseAdmin = p.UnpackString(false)
-	result.FreezeUnfreezeAdmin = p.UnpackString(false)
-	result.EnableDisableKYCAccountAdmin = p.UnpackString(false)
 	return &result, p.Err()
 }
@@ -0,0 +1,169 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	UpdateMarketplaceListingComputeUnits = 5
+)
+
+var _ chain.Action = (*UpdateMarketplaceListing)(nil)
+
+type UpdateMarketplaceListing struct {
+	// DatasetAddress
+	DatasetAddress codec.Address `serialize:"true" json:"dataset_address"`
+
+	// Primary asset new subscriptions pay with
+	PaymentAssetAddress codec.Address `serialize:"true" json:"payment_asset_address"`
+
+	// Price per block of new subscriptions. Existing subscriptions keep the
+	// price recorded in their NFT.
+	DatasetPricePerBlock uint64 `serialize:"true" json:"dataset_price_per_block"`
+
+	// Whether new subscriptions are paused
+	Paused bool `serialize:"true" json:"paused"`
+}
+
+func (*UpdateMarketplaceListing) GetTypeID() uint8 {
+	return nconsts.UpdateMarketplaceListingID
+}
+
+func (u *UpdateMarketplaceListing) StateKeys(_ codec.Address) state.Keys {
+	marketplaceAssetAddress := storage.AssetAddressFractional(u.DatasetAddress)
+	return state.Keys{
+		string(storage.AssetInfoKey(marketplaceAssetAddress)):                                 state.Read | state.Write,
+		string(storage.DatasetInfoKey(u.DatasetAddress)):                                      state.Read | state.Write,
+		string(storage.MarketplacePaymentAssetsKey(marketplaceAssetAddress)):                  state.All,
+		string(storage.MarketplacePaymentKey(marketplaceAssetAddress, u.PaymentAssetAddress)): state.All,
+	}
+}
+
+func (u *UpdateMarketplaceListing) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	// Check if the dataset is on the marketplace
+	name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, previousPaymentAssetAddress, _, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner, err := storage.GetDatasetInfoNoController(ctx, mu, u.DatasetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if marketplaceAssetAddress == codec.EmptyAddress {
+		return nil, ErrDatasetNotOnSale
+	}
+
+	if err := migrateMarketplacePayment(ctx, mu, marketplaceAssetAddress, u.PaymentAssetAddress); err != nil {
+		return nil, err
+	}
+
+	// Only the owner of the listing can update it
+	assetType, assetName, symbol, decimals, assetMetadata, uri, totalSupply, maxSupply, listingOwner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := storage.GetAssetInfoNoController(ctx, mu, marketplaceAssetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if listingOwner != actor {
+		return nil, ErrWrongOwner
+	}
+	metadataMap, err := utils.BytesToMap(assetMetadata)
+	if err != nil {
+		return nil, err
+	}
+	status := storage.MarketplaceStatusActive
+	if u.Paused {
+		status = storage.MarketplaceStatusPaused
+	}
+	// Payments left to claim in the previous payment asset can still be
+	// claimed
+	if previousPaymentAssetAddress != u.PaymentAssetAddress {
+		if err := storage.RejectMarketplacePayment(ctx, mu, marketplaceAssetAddress, previousPaymentAssetAddress); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.AcceptMarketplacePayment(ctx, mu, marketplaceAssetAddress, u.PaymentAssetAddress, u.DatasetPricePerBlock); err != nil {
+		return nil, err
+	}
+	metadataMap["datasetPricePerBlock"] = fmt.Sprint(u.DatasetPricePerBlock)
+	metadataMap["paymentAssetAddress"] = u.PaymentAssetAddress.String()
+	metadataMap["status"] = status
+	assetMetadata, err = utils.MapToBytes(metadataMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAssetInfo(ctx, mu, marketplaceAssetAddress, assetType, assetName, symbol, decimals, assetMetadata, uri, totalSupply, maxSupply, listingOwner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin); err != nil {
+		return nil, err
+	}
+	if err := storage.SetDatasetInfo(ctx, mu, u.DatasetAddress, name, description, categories, licenseName, licenseSymbol, licenseURL, metadata, isCommunityDataset, marketplaceAssetAddress, u.PaymentAssetAddress, u.DatasetPricePerBlock, revenueModelDataShare, revenueModelMetadataShare, revenueModelDataOwnerCut, revenueModelMetadataOwnerCut, owner); err != nil {
+		return nil, err
+	}
+
+	return &UpdateMarketplaceListingResult{
+		Actor:                   actor.String(),
+		Receiver:                "",
+		MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+		PaymentAssetAddress:     u.PaymentAssetAddress.String(),
+		DatasetPricePerBlock:    u.DatasetPricePerBlock,
+		Status:                  status,
+	}, nil
+}
+
+func (*UpdateMarketplaceListing) ComputeUnits(chain.Rules) uint64 {
+	return UpdateMarketplaceListingComputeUnits
+}
+
+func (*UpdateMarketplaceListing) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalUpdateMarketplaceListing(p *codec.Packer) (chain.Action, error) {
+	var update UpdateMarketplaceListing
+	p.UnpackAddress(&update.DatasetAddress)
+	p.UnpackAddress(&update.PaymentAssetAddress)
+	update.DatasetPricePerBlock = p.UnpackUint64(false)
+	update.Paused = p.UnpackBool()
+	return &update, p.Err()
+}
+
+var _ codec.Typed = (*UpdateMarketplaceListingResult)(nil)
+
+type UpdateMarketplaceListingResult struct {
+	Actor                   string `serialize:"true" json:"actor"`
+	Receiver                string `serialize:"true" json:"receiver"`
+	MarketplaceAssetAddress string `serialize:"true" json:"marketplace_asset_address"`
+	PaymentAssetAddress     string `serialize:"true" json:"payment_asset_address"`
+	DatasetPricePerBlock    uint64 `serialize:"true" json:"dataset_price_per_block"`
+	Status                  string `serialize:"true" json:"status"`
+}
+
+func (*UpdateMarketplaceListingResult) GetTypeID() uint8 {
+	return nconsts.UpdateMarketplaceListingID
+}
+
+func UnmarshalUpdateMarketplaceListingResult(p *codec.Packer) (codec.Typed, error) {
+	var result UpdateMarketplaceListingResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(false)
+	result.MarketplaceAssetAddress = p.UnpackString(true)
+	result.PaymentAssetAddress = p.UnpackString(true)
+	result.DatasetPricePerBlock = p.UnpackUint64(false)
+	result.Status = p.UnpackString(true)
+	return &result, p.Err()
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+// newListingStore returns a store with the dataset at [datasetAddress] of
+// [owner] published to the marketplace for 100 NAI per block
+func newListingStore(t *testing.T, datasetAddress codec.Address, owner codec.Address, paymentRemaining uint64) state.Mutable {
+	store := chaintest.NewInMemoryStore()
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), false, marketplaceAssetAddress, storage.NAIAddress, 100, 100, 0, 100, 0, owner))
+	metadata, err := utils.MapToBytes(map[string]string{
+		"datasetAddress":          datasetAddress.String(),
+		"marketplaceAssetAddress": marketplaceAssetAddress.String(),
+		"datasetPricePerBlock":    "100",
+		"paymentAssetAddress":     storage.NAIAddress.String(),
+		"publisher":               owner.String(),
+		"subscriptions":           "1",
+		"status":                  storage.MarketplaceStatusActive,
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.SetAssetInfo(context.Background(), store, marketplaceAssetAddress, nconsts.AssetMarketplaceTokenID, []byte(storage.MarketplaceAssetName), []byte(storage.MarketplaceAssetSymbol), 0, metadata, []byte(marketplaceAssetAddress.String()), 1, 0, owner, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+	require.NoError(t, storage.AcceptMarketplacePayment(context.Background(), store, marketplaceAssetAddress, storage.NAIAddress, 100))
+	if paymentRemaining > 0 {
+		require.NoError(t, storage.UpdateMarketplacePayment(context.Background(), store, marketplaceAssetAddress, storage.NAIAddress, paymentRemaining, 0, 1))
+	}
+	return store
+}
+
+func TestUpdateMarketplaceListingAction(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	datasetAddress := storage.AssetAddress(nconsts.AssetFractionalTokenID, []byte("Valid Name"), []byte("DATASET"), 0, []byte("metadata"), owner)
+	marketplaceAssetAddress := storage.AssetAddressFractional(datasetAddress)
+	otherAsset := codectest.NewRandomAddress()
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "DatasetNotOnSale",
+			Actor: owner,
+			Action: &UpdateMarketplaceListing{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  storage.NAIAddress,
+				DatasetPricePerBlock: 50,
+			},
+			State: func() state.Mutable {
+				store := chaintest.NewInMemoryStore()
+				require.NoError(t, storage.SetDatasetInfo(context.Background(), store, datasetAddress, []byte("Valid Name"), []byte("Valid Description"), []byte("Science"), []byte("MIT"), []byte("MIT"), []byte("http://license-url.com"), []byte("Metadata"), false, codec.EmptyAddress, codec.EmptyAddress, 0, 100, 0, 100, 0, owner))
+				return store
+			}(),
+			ExpectedErr: ErrDatasetNotOnSale,
+		},
+		{
+			Name:  "WrongOwner",
+			Actor: codectest.NewRandomAddress(), // Not the owner of the listing
+			Action: &UpdateMarketplaceListing{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  storage.NAIAddress,
+				DatasetPricePerBlock: 50,
+			},
+			State:       newListingStore(t, datasetAddress, owner, 0),
+			ExpectedErr: ErrWrongOwner,
+		},
+		{
+			Name:  "PaymentAssetChangeKeepsPayments",
+			Actor: owner,
+			Action: &UpdateMarketplaceListing{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  otherAsset,
+				DatasetPricePerBlock: 50,
+			},
+			State: newListingStore(t, datasetAddress, owner, 1000),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				metadataMap, err := utils.BytesToMap(metadata)
+				require.NoError(t, err)
+				require.Equal(t, otherAsset.String(), metadataMap["paymentAssetAddress"])
+				paymentAssets, err := storage.GetMarketplacePaymentAssetsNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				require.Equal(t, []storage.MarketplacePaymentAsset{
+					{Address: storage.NAIAddress, Pending: true},
+					{Address: otherAsset, Accepted: true},
+				}, paymentAssets)
+				// Payments in the new payment asset start from scratch
+				price, remaining, _, _, err := storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, otherAsset)
+				require.NoError(t, err)
+				require.Equal(t, uint64(50), price)
+				require.Zero(t, remaining)
+				// Payments in the previous one can still be claimed
+				_, remaining, _, _, err = storage.GetMarketplacePaymentNoController(ctx, store, marketplaceAssetAddress, storage.NAIAddress)
+				require.NoError(t, err)
+				require.Equal(t, uint64(1000), remaining)
+			},
+			ExpectedOutputs: &UpdateMarketplaceListingResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				PaymentAssetAddress:     otherAsset.String(),
+				DatasetPricePerBlock:    50,
+				Status:                  storage.MarketplaceStatusActive,
+			},
+		},
+		{
+			Name:  "ValidUpdate",
+			Actor: owner,
+			Action: &UpdateMarketplaceListing{
+				DatasetAddress:       datasetAddress,
+				PaymentAssetAddress:  otherAsset,
+				DatasetPricePerBlock: 50,
+				Paused:               true,
+			},
+			State: newListingStore(t, datasetAddress, owner, 0),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoNoController(ctx, store, marketplaceAssetAddress)
+				require.NoError(t, err)
+				metadataMap, err := utils.BytesToMap(metadata)
+				require.NoError(t, err)
+				require.Equal(t, "50", metadataMap["datasetPricePerBlock"])
+				require.Equal(t, otherAsset.String(), metadataMap["paymentAssetAddress"])
+				require.Equal(t, storage.MarketplaceStatusPaused, metadataMap["status"])
+				// Subscriptions are kept
+				require.Equal(t, "1", metadataMap["subscriptions"])
+
+				_, _, _, _, _, _, _, _, mAddr, baseAsset, basePrice, _, _, _, _, _, err := storage.GetDatasetInfoNoController(ctx, store, datasetAddress)
+				require.NoError(t, err)
+				require.Equal(t, marketplaceAssetAddress, mAddr)
+				require.Equal(t, otherAsset, baseAsset)
+				require.Equal(t, uint64(50), basePrice)
+			},
+			ExpectedOutputs: &UpdateMarketplaceListingResult{
+				Actor:                   owner.String(),
+				Receiver:                "",
+				MarketplaceAssetAddress: marketplaceAssetAddress.String(),
+				PaymentAssetAddress:     otherAsset.String(),
+				DatasetPricePerBlock:    50,
+				Status:                  storage.MarketplaceStatusPaused,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
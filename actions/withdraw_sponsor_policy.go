@@ -0,0 +1,130 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	WithdrawSponsorPolicyComputeUnits = 5
+)
+
+var (
+	ErrSponsorPolicyNotFound              = errors.New("sponsor policy not found")
+	ErrNotSponsor                         = errors.New("actor is not the sponsor of the policy")
+	_                        chain.Action = (*WithdrawSponsorPolicy)(nil)
+)
+
+// WithdrawSponsorPolicy deletes a policy created with [SetSponsorPolicy] and
+// returns up to [Amount] NAI from the sponsor account to the sponsor. The
+// sponsor account is shared by all the policies of the sponsor, so the NAI
+// left on it keeps paying for its other policies.
+type WithdrawSponsorPolicy struct {
+	// PolicyAddress is the address returned by [SetSponsorPolicy]
+	PolicyAddress codec.Address `serialize:"true" json:"policy_address"`
+
+	// Maximum amount of NAI returned from the sponsor account to the actor
+	Amount uint64 `serialize:"true" json:"amount"`
+}
+
+func (*WithdrawSponsorPolicy) GetTypeID() uint8 {
+	return nconsts.WithdrawSponsorPolicyID
+}
+
+func (w *WithdrawSponsorPolicy) StateKeys(actor codec.Address) state.Keys {
+	return state.Keys{
+		string(storage.SponsorPolicyKey(w.PolicyAddress)):                                                state.Read | state.Write,
+		string(storage.AssetInfoKey(storage.NAIAddress)):                                                 state.Read,
+		string(storage.AssetAccountBalanceKey(storage.NAIAddress, storage.SponsorAccountAddress(actor))): state.Read | state.Write,
+		string(storage.AssetAccountBalanceKey(storage.NAIAddress, actor)):                                state.All,
+	}
+}
+
+func (w *WithdrawSponsorPolicy) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	exists, sponsor, _, _, _, err := storage.GetSponsorPolicyNoController(ctx, mu, w.PolicyAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrSponsorPolicyNotFound
+	}
+	if sponsor != actor {
+		return nil, ErrNotSponsor
+	}
+
+	sponsorAccount := storage.SponsorAccountAddress(actor)
+	balance, err := storage.GetAssetAccountBalanceNoController(ctx, mu, storage.NAIAddress, sponsorAccount)
+	if err != nil {
+		return nil, err
+	}
+	amount := min(w.Amount, balance)
+	if amount > 0 {
+		if _, _, err := storage.TransferAsset(ctx, mu, storage.NAIAddress, sponsorAccount, actor, amount); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.DeleteSponsorPolicy(ctx, mu, w.PolicyAddress); err != nil {
+		return nil, err
+	}
+
+	return &WithdrawSponsorPolicyResult{
+		Actor:    actor.String(),
+		Receiver: sponsorAccount.String(),
+		Amount:   amount,
+	}, nil
+}
+
+func (*WithdrawSponsorPolicy) ComputeUnits(chain.Rules) uint64 {
+	return WithdrawSponsorPolicyComputeUnits
+}
+
+func (*WithdrawSponsorPolicy) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func UnmarshalWithdrawSponsorPolicy(p *codec.Packer) (chain.Action, error) {
+	var withdraw WithdrawSponsorPolicy
+	p.UnpackAddress(&withdraw.PolicyAddress)
+	withdraw.Amount = p.UnpackUint64(false)
+	return &withdraw, p.Err()
+}
+
+var _ codec.Typed = (*WithdrawSponsorPolicyResult)(nil)
+
+type WithdrawSponsorPolicyResult struct {
+	Actor    string `serialize:"true" json:"actor"`
+	Receiver string `serialize:"true" json:"receiver"`
+	Amount   uint64 `serialize:"true" json:"amount"`
+}
+
+func (*WithdrawSponsorPolicyResult) GetTypeID() uint8 {
+	return nconsts.WithdrawSponsorPolicyID
+}
+
+func UnmarshalWithdrawSponsorPolicyResult(p *codec.Packer) (codec.Typed, error) {
+	var result WithdrawSponsorPolicyResult
+	result.Actor = p.UnpackString(true)
+	result.Receiver = p.UnpackString(true)
+	result.Amount = p.UnpackUint64(false)
+	return &result, p.Err()
+}
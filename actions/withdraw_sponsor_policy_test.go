@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func TestWithdrawSponsorPolicyAction(t *testing.T) {
+	actor := codectest.NewRandomAddress()
+	actionTypeIDs := []byte{nconsts.TransferID}
+	policyAddress := storage.SponsorPolicyAddress(actor, actionTypeIDs, nil, nil)
+	sponsorAccount := storage.SponsorAccountAddress(actor)
+
+	setupState := func() state.Mutable {
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAssetInfo(context.Background(), store, storage.NAIAddress, nconsts.AssetFungibleTokenID, []byte(nconsts.Name), []byte(nconsts.Symbol), nconsts.Decimals, []byte(nconsts.Metadata), nil, 0, 0, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress))
+		_, err := storage.SetSponsorPolicy(context.Background(), store, actor, actionTypeIDs, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, storage.SetAssetAccountBalance(context.Background(), store, storage.NAIAddress, sponsorAccount, 150))
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "PolicyNotFound",
+			Actor: actor,
+			Action: &WithdrawSponsorPolicy{
+				PolicyAddress: policyAddress,
+			},
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrSponsorPolicyNotFound,
+		},
+		{
+			Name:  "NotSponsor",
+			Actor: codectest.NewRandomAddress(),
+			Action: &WithdrawSponsorPolicy{
+				PolicyAddress: policyAddress,
+			},
+			State:       setupState(),
+			ExpectedErr: ErrNotSponsor,
+		},
+		{
+			Name:  "ValidWithdraw",
+			Actor: actor,
+			Action: &WithdrawSponsorPolicy{
+				PolicyAddress: policyAddress,
+				Amount:        100,
+			},
+			State: setupState(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				exists, _, _, _, _, err := storage.GetSponsorPolicyNoController(ctx, store, policyAddress)
+				require.NoError(t, err)
+				require.False(t, exists)
+
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, sponsorAccount)
+				require.NoError(t, err)
+				require.Equal(t, uint64(50), balance)
+			},
+			ExpectedOutputs: &WithdrawSponsorPolicyResult{
+				Actor:    actor.String(),
+				Receiver: sponsorAccount.String(),
+				Amount:   100,
+			},
+		},
+		{
+			Name:  "WithdrawAboveBalance",
+			Actor: actor,
+			Action: &WithdrawSponsorPolicy{
+				PolicyAddress: policyAddress,
+				Amount:        1000,
+			},
+			State: setupState(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				balance, err := storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, actor)
+				require.NoError(t, err)
+				require.Equal(t, uint64(150), balance)
+				balance, err = storage.GetAssetAccountBalanceNoController(ctx, store, storage.NAIAddress, sponsorAccount)
+				require.NoError(t, err)
+				require.Zero(t, balance)
+			},
+			ExpectedOutputs: &WithdrawSponsorPolicyResult{
+				Actor:    actor.String(),
+				Receiver: sponsorAccount.String(),
+				Amount:   150,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
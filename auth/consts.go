@@ -0,0 +1,17 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+// Note: IDs continue after the auth types provided by hypersdk (ED25519,
+// SECP256R1 and BLS)
+const (
+	// Auth TypeIDs
+	MultisigID   uint8 = 3
+	SessionKeyID uint8 = 4
+	SponsoredID  uint8 = 5
+
+	MultisigKey   = "multisig"
+	SessionKeyKey = "session"
+	SponsoredKey  = "sponsored"
+)
@@ -0,0 +1,392 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/bls"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var (
+	ErrMultisigThresholdInvalid  = errors.New("multisig threshold is invalid")
+	ErrMultisigTooManySigners    = errors.New("multisig has too many signers")
+	ErrMultisigDuplicateSigner   = errors.New("multisig signer is duplicated")
+	ErrMultisigPublicKeyInvalid  = errors.New("multisig public key is invalid")
+	ErrMultisigSignaturesInvalid = errors.New("multisig signatures are invalid")
+	ErrMultisigNotASigner        = errors.New("key is not a signer of the multisig")
+
+	_ chain.Auth        = (*Multisig)(nil)
+	_ chain.AuthFactory = (*MultisigFactory)(nil)
+)
+
+const (
+	MultisigComputeUnits = 1
+	MaxMultisigSigners   = 16
+)
+
+// MultisigSigner is a member of a multisig account. Members can use any of
+// the key types supported by the VM.
+type MultisigSigner struct {
+	KeyType   uint8  `json:"keyType"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+// MultisigSignature is the signature of the member at [Index] in the list of
+// signers of the multisig account
+type MultisigSignature struct {
+	Index     uint8  `json:"index"`
+	Signature []byte `json:"signature"`
+}
+
+// Multisig authorizes a transaction on behalf of an M-of-N account. The
+// address of the account commits to [Threshold] and [Signers] so both are
+// provided with every transaction along with exactly [Threshold] signatures.
+type Multisig struct {
+	Threshold  uint8               `json:"threshold"`
+	Signers    []MultisigSigner    `json:"signers"`
+	Signatures []MultisigSignature `json:"signatures"`
+
+	addr codec.Address
+}
+
+func (m *Multisig) address() codec.Address {
+	if m.addr == codec.EmptyAddress {
+		m.addr = NewMultisigAddress(m.Threshold, m.Signers)
+	}
+	return m.addr
+}
+
+func (*Multisig) GetTypeID() uint8 {
+	return MultisigID
+}
+
+func (m *Multisig) ComputeUnits(chain.Rules) uint64 {
+	units := uint64(MultisigComputeUnits)
+	for _, signature := range m.Signatures {
+		if int(signature.Index) < len(m.Signers) {
+			units += keyComputeUnits(m.Signers[signature.Index].KeyType)
+		}
+	}
+	return units
+}
+
+func (*Multisig) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (m *Multisig) Verify(ctx context.Context, msg []byte) error {
+	if err := VerifyMultisigSigners(m.Threshold, m.Signers); err != nil {
+		return err
+	}
+	if len(m.Signatures) != int(m.Threshold) {
+		return ErrMultisigSignaturesInvalid
+	}
+	for i, signature := range m.Signatures {
+		// Strictly increasing indices guarantee that every signer is counted once
+		if int(signature.Index) >= len(m.Signers) || (i > 0 && signature.Index <= m.Signatures[i-1].Index) {
+			return ErrMultisigSignaturesInvalid
+		}
+		signerAuth, err := newSignerAuth(m.Signers[signature.Index], signature.Signature)
+		if err != nil {
+			return err
+		}
+		if err := signerAuth.Verify(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Multisig) Actor() codec.Address {
+	return m.address()
+}
+
+func (m *Multisig) Sponsor() codec.Address {
+	return m.address()
+}
+
+func (m *Multisig) Size() int {
+	size := consts.Uint8Len + consts.Uint8Len + consts.Uint8Len
+	for _, signer := range m.Signers {
+		size += consts.Uint8Len + len(signer.PublicKey)
+	}
+	for _, signature := range m.Signatures {
+		size += consts.Uint8Len + len(signature.Signature)
+	}
+	return size
+}
+
+func (m *Multisig) Marshal(p *codec.Packer) {
+	p.PackByte(m.Threshold)
+	p.PackByte(uint8(len(m.Signers)))
+	for _, signer := range m.Signers {
+		p.PackByte(signer.KeyType)
+		p.PackFixedBytes(signer.PublicKey)
+	}
+	p.PackByte(uint8(len(m.Signatures)))
+	for _, signature := range m.Signatures {
+		p.PackByte(signature.Index)
+		p.PackFixedBytes(signature.Signature)
+	}
+}
+
+func UnmarshalMultisig(p *codec.Packer) (chain.Auth, error) {
+	var m Multisig
+	m.Threshold = p.UnpackByte()
+	numSigners := int(p.UnpackByte())
+	if numSigners > MaxMultisigSigners {
+		return nil, ErrMultisigTooManySigners
+	}
+	m.Signers = make([]MultisigSigner, numSigners)
+	for i := range m.Signers {
+		m.Signers[i].KeyType = p.UnpackByte()
+		publicKeyLen, _, err := keyLens(m.Signers[i].KeyType)
+		if err != nil {
+			return nil, err
+		}
+		m.Signers[i].PublicKey = make([]byte, publicKeyLen)
+		p.UnpackFixedBytes(publicKeyLen, &m.Signers[i].PublicKey)
+	}
+	if err := VerifyMultisigSigners(m.Threshold, m.Signers); err != nil {
+		return nil, err
+	}
+	numSignatures := int(p.UnpackByte())
+	if numSignatures != int(m.Threshold) {
+		return nil, ErrMultisigSignaturesInvalid
+	}
+	m.Signatures = make([]MultisigSignature, numSignatures)
+	for i := range m.Signatures {
+		m.Signatures[i].Index = p.UnpackByte()
+		if int(m.Signatures[i].Index) >= numSigners {
+			return nil, ErrMultisigSignaturesInvalid
+		}
+		_, signatureLen, err := keyLens(m.Signers[m.Signatures[i].Index].KeyType)
+		if err != nil {
+			return nil, err
+		}
+		m.Signatures[i].Signature = make([]byte, signatureLen)
+		p.UnpackFixedBytes(signatureLen, &m.Signatures[i].Signature)
+	}
+	return &m, p.Err()
+}
+
+// MultisigFactory estimates the units of a multisig transaction and produces
+// its auth once enough members have co-signed it.
+type MultisigFactory struct {
+	threshold  uint8
+	signers    []MultisigSigner
+	signatures []MultisigSignature
+}
+
+// NewMultisigFactory returns a factory for the account committing to
+// [threshold] and [signers]. [signatures] may be empty when the factory is
+// only used to estimate fees.
+func NewMultisigFactory(threshold uint8, signers []MultisigSigner, signatures []MultisigSignature) *MultisigFactory {
+	return &MultisigFactory{
+		threshold:  threshold,
+		signers:    signers,
+		signatures: signatures,
+	}
+}
+
+func (m *MultisigFactory) Sign(msg []byte) (chain.Auth, error) {
+	signatures := slices.Clone(m.signatures)
+	slices.SortFunc(signatures, func(a, b MultisigSignature) int {
+		return int(a.Index) - int(b.Index)
+	})
+	if len(signatures) > int(m.threshold) {
+		signatures = signatures[:m.threshold]
+	}
+	multisig := &Multisig{
+		Threshold:  m.threshold,
+		Signers:    m.signers,
+		Signatures: signatures,
+	}
+	if err := multisig.Verify(context.Background(), msg); err != nil {
+		return nil, err
+	}
+	return multisig, nil
+}
+
+// MaxUnits assumes the most expensive members are the ones signing
+func (m *MultisigFactory) MaxUnits() (uint64, uint64) {
+	signatureLens := make([]int, 0, len(m.signers))
+	computeUnits := make([]uint64, 0, len(m.signers))
+	bandwidth := uint64(3 * consts.Uint8Len)
+	for _, signer := range m.signers {
+		_, signatureLen, _ := keyLens(signer.KeyType)
+		signatureLens = append(signatureLens, signatureLen)
+		computeUnits = append(computeUnits, keyComputeUnits(signer.KeyType))
+		bandwidth += uint64(consts.Uint8Len + len(signer.PublicKey))
+	}
+	slices.Sort(signatureLens)
+	slices.Sort(computeUnits)
+	compute := uint64(MultisigComputeUnits)
+	for i := 0; i < int(m.threshold) && i < len(m.signers); i++ {
+		bandwidth += uint64(consts.Uint8Len + signatureLens[len(signatureLens)-1-i])
+		compute += computeUnits[len(computeUnits)-1-i]
+	}
+	return bandwidth, compute
+}
+
+func (m *MultisigFactory) Address() codec.Address {
+	return NewMultisigAddress(m.threshold, m.signers)
+}
+
+// NewMultisigAddress derives the address of the account committing to
+// [threshold] and [signers]. The order of [signers] matters.
+func NewMultisigAddress(threshold uint8, signers []MultisigSigner) codec.Address {
+	size := consts.Uint8Len
+	for _, signer := range signers {
+		size += consts.Uint8Len + len(signer.PublicKey)
+	}
+	v := make([]byte, 0, size)
+	v = append(v, threshold)
+	for _, signer := range signers {
+		v = append(v, signer.KeyType)
+		v = append(v, signer.PublicKey...)
+	}
+	return codec.CreateAddress(MultisigID, utils.ToID(v))
+}
+
+// NewMultisigSignature converts the auth produced by a member's own factory
+// into a signature of the multisig account
+func NewMultisigSignature(signers []MultisigSigner, signerAuth chain.Auth) (MultisigSignature, error) {
+	signer, signature, err := signerFromAuth(signerAuth)
+	if err != nil {
+		return MultisigSignature{}, err
+	}
+	for i, s := range signers {
+		if s.KeyType == signer.KeyType && bytes.Equal(s.PublicKey, signer.PublicKey) {
+			return MultisigSignature{Index: uint8(i), Signature: signature}, nil
+		}
+	}
+	return MultisigSignature{}, ErrMultisigNotASigner
+}
+
+// NewMultisigSigner returns the member entry of the key behind [factory]
+func NewMultisigSigner(factory chain.AuthFactory) (MultisigSigner, error) {
+	signerAuth, err := factory.Sign(nil)
+	if err != nil {
+		return MultisigSigner{}, err
+	}
+	signer, _, err := signerFromAuth(signerAuth)
+	return signer, err
+}
+
+func signerFromAuth(signerAuth chain.Auth) (MultisigSigner, []byte, error) {
+	switch a := signerAuth.(type) {
+	case *auth.ED25519:
+		return MultisigSigner{KeyType: auth.ED25519ID, PublicKey: a.Signer[:]}, a.Signature[:], nil
+	case *auth.SECP256R1:
+		return MultisigSigner{KeyType: auth.SECP256R1ID, PublicKey: a.Signer[:]}, a.Signature[:], nil
+	case *auth.BLS:
+		return MultisigSigner{KeyType: auth.BLSID, PublicKey: bls.PublicKeyToBytes(a.Signer)}, bls.SignatureToBytes(a.Signature), nil
+	default:
+		return MultisigSigner{}, nil, auth.ErrInvalidKeyType
+	}
+}
+
+func newSignerAuth(signer MultisigSigner, signature []byte) (chain.Auth, error) {
+	publicKeyLen, signatureLen, err := keyLens(signer.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	if len(signer.PublicKey) != publicKeyLen {
+		return nil, ErrMultisigPublicKeyInvalid
+	}
+	if len(signature) != signatureLen {
+		return nil, ErrMultisigSignaturesInvalid
+	}
+
+	switch signer.KeyType {
+	case auth.ED25519ID:
+		a := &auth.ED25519{}
+		copy(a.Signer[:], signer.PublicKey)
+		copy(a.Signature[:], signature)
+		return a, nil
+	case auth.SECP256R1ID:
+		a := &auth.SECP256R1{}
+		copy(a.Signer[:], signer.PublicKey)
+		copy(a.Signature[:], signature)
+		return a, nil
+	default:
+		publicKey, err := bls.PublicKeyFromBytes(signer.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := bls.SignatureFromBytes(signature)
+		if err != nil {
+			return nil, err
+		}
+		return &auth.BLS{Signer: publicKey, Signature: sig}, nil
+	}
+}
+
+// VerifyMultisigSigners checks that [threshold] and [signers] describe a
+// valid multisig account
+func VerifyMultisigSigners(threshold uint8, signers []MultisigSigner) error {
+	if len(signers) > MaxMultisigSigners {
+		return ErrMultisigTooManySigners
+	}
+	if threshold == 0 || int(threshold) > len(signers) {
+		return ErrMultisigThresholdInvalid
+	}
+	seen := make(map[string]struct{}, len(signers))
+	for _, signer := range signers {
+		publicKeyLen, _, err := keyLens(signer.KeyType)
+		if err != nil {
+			return err
+		}
+		if len(signer.PublicKey) != publicKeyLen {
+			return ErrMultisigPublicKeyInvalid
+		}
+		if _, ok := seen[string(signer.PublicKey)]; ok {
+			return ErrMultisigDuplicateSigner
+		}
+		seen[string(signer.PublicKey)] = struct{}{}
+	}
+	return nil
+}
+
+// newSignerAddress is the address hypersdk derives for [publicKey] when it
+// signs with its own auth type
+func newSignerAddress(keyType uint8, publicKey []byte) codec.Address {
+	return codec.CreateAddress(keyType, utils.ToID(publicKey))
+}
+
+func keyLens(keyType uint8) (int, int, error) {
+	switch keyType {
+	case auth.ED25519ID:
+		return ed25519.PublicKeyLen, ed25519.SignatureLen, nil
+	case auth.SECP256R1ID:
+		return secp256r1.PublicKeyLen, secp256r1.SignatureLen, nil
+	case auth.BLSID:
+		return bls.PublicKeyLen, bls.SignatureLen, nil
+	default:
+		return 0, 0, auth.ErrInvalidKeyType
+	}
+}
+
+func keyComputeUnits(keyType uint8) uint64 {
+	switch keyType {
+	case auth.ED25519ID:
+		return auth.ED25519ComputeUnits
+	case auth.SECP256R1ID:
+		return auth.SECP256R1ComputeUnits
+	default:
+		return auth.BLSComputeUnits
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/bls"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+)
+
+// newMembers returns one factory per supported key type along with the
+// matching multisig signers
+func newMembers(t *testing.T) ([]chain.AuthFactory, []MultisigSigner) {
+	require := require.New(t)
+
+	ed25519Key, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	secp256r1Key, err := secp256r1.GeneratePrivateKey()
+	require.NoError(err)
+	blsKey, err := bls.GeneratePrivateKey()
+	require.NoError(err)
+
+	factories := []chain.AuthFactory{
+		auth.NewED25519Factory(ed25519Key),
+		auth.NewSECP256R1Factory(secp256r1Key),
+		auth.NewBLSFactory(blsKey),
+	}
+	signers := make([]MultisigSigner, len(factories))
+	for i, factory := range factories {
+		signers[i], err = NewMultisigSigner(factory)
+		require.NoError(err)
+	}
+	return factories, signers
+}
+
+func cosign(t *testing.T, factories []chain.AuthFactory, signers []MultisigSigner, msg []byte) []MultisigSignature {
+	signatures := make([]MultisigSignature, len(factories))
+	for i, factory := range factories {
+		signerAuth, err := factory.Sign(msg)
+		require.NoError(t, err)
+		signatures[i], err = NewMultisigSignature(signers, signerAuth)
+		require.NoError(t, err)
+	}
+	return signatures
+}
+
+func TestMultisig(t *testing.T) {
+	require := require.New(t)
+	msg := []byte("msg")
+	factories, signers := newMembers(t)
+
+	// Co-sign with the SECP256R1 and BLS members in reverse order
+	signatures := cosign(t, []chain.AuthFactory{factories[2], factories[1]}, signers, msg)
+	factory := NewMultisigFactory(2, signers, signatures)
+	multisigAuth, err := factory.Sign(msg)
+	require.NoError(err)
+	require.NoError(multisigAuth.Verify(context.Background(), msg))
+	require.Equal(factory.Address(), multisigAuth.Actor())
+	require.Equal(factory.Address(), multisigAuth.Sponsor())
+	require.Equal(MultisigID, factory.Address()[0])
+
+	// Max units must cover the actual units
+	bandwidth, compute := factory.MaxUnits()
+	require.GreaterOrEqual(bandwidth, uint64(multisigAuth.Size()))
+	require.GreaterOrEqual(compute, multisigAuth.ComputeUnits(nil))
+	require.Equal(uint64(MultisigComputeUnits+auth.SECP256R1ComputeUnits+auth.BLSComputeUnits), multisigAuth.ComputeUnits(nil))
+
+	// Round trip through the packer
+	p := codec.NewWriter(multisigAuth.Size(), multisigAuth.Size())
+	multisigAuth.Marshal(p)
+	require.NoError(p.Err())
+	unmarshalled, err := UnmarshalMultisig(codec.NewReader(p.Bytes(), multisigAuth.Size()))
+	require.NoError(err)
+	require.NoError(unmarshalled.Verify(context.Background(), msg))
+	require.Equal(multisigAuth.Actor(), unmarshalled.Actor())
+}
+
+func TestMultisigAddress(t *testing.T) {
+	require := require.New(t)
+	_, signers := newMembers(t)
+
+	// The address commits to both the threshold and the ordered signers
+	require.NotEqual(NewMultisigAddress(1, signers), NewMultisigAddress(2, signers))
+	require.NotEqual(NewMultisigAddress(2, signers), NewMultisigAddress(2, []MultisigSigner{signers[1], signers[0], signers[2]}))
+}
+
+func TestMultisigInvalid(t *testing.T) {
+	msg := []byte("msg")
+	factories, signers := newMembers(t)
+	signatures := cosign(t, factories, signers, msg)
+	otherFactories, otherSigners := newMembers(t)
+	otherSignatures := cosign(t, otherFactories, otherSigners, msg)
+
+	tests := []struct {
+		name     string
+		multisig *Multisig
+		err      error
+	}{
+		{
+			name: "ZeroThreshold",
+			multisig: &Multisig{
+				Threshold: 0,
+				Signers:   signers,
+			},
+			err: ErrMultisigThresholdInvalid,
+		},
+		{
+			name: "ThresholdAboveSigners",
+			multisig: &Multisig{
+				Threshold:  4,
+				Signers:    signers,
+				Signatures: signatures,
+			},
+			err: ErrMultisigThresholdInvalid,
+		},
+		{
+			name: "DuplicateSigner",
+			multisig: &Multisig{
+				Threshold:  2,
+				Signers:    []MultisigSigner{signers[0], signers[0]},
+				Signatures: []MultisigSignature{signatures[0], {Index: 1, Signature: signatures[0].Signature}},
+			},
+			err: ErrMultisigDuplicateSigner,
+		},
+		{
+			name: "NotEnoughSignatures",
+			multisig: &Multisig{
+				Threshold:  2,
+				Signers:    signers,
+				Signatures: signatures[:1],
+			},
+			err: ErrMultisigSignaturesInvalid,
+		},
+		{
+			name: "RepeatedSignature",
+			multisig: &Multisig{
+				Threshold:  2,
+				Signers:    signers,
+				Signatures: []MultisigSignature{signatures[0], signatures[0]},
+			},
+			err: ErrMultisigSignaturesInvalid,
+		},
+		{
+			name: "SignatureIndexOutOfRange",
+			multisig: &Multisig{
+				Threshold:  1,
+				Signers:    signers,
+				Signatures: []MultisigSignature{{Index: 3, Signature: signatures[0].Signature}},
+			},
+			err: ErrMultisigSignaturesInvalid,
+		},
+		{
+			name: "WrongSignature",
+			multisig: &Multisig{
+				Threshold:  1,
+				Signers:    signers,
+				Signatures: []MultisigSignature{otherSignatures[0]},
+			},
+			err: crypto.ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.multisig.Verify(context.Background(), msg), tt.err)
+		})
+	}
+}
+
+func TestMultisigSignatureNotASigner(t *testing.T) {
+	require := require.New(t)
+	_, signers := newMembers(t)
+	otherFactories, _ := newMembers(t)
+
+	signerAuth, err := otherFactories[0].Sign([]byte("msg"))
+	require.NoError(err)
+	_, err = NewMultisigSignature(signers, signerAuth)
+	require.ErrorIs(err, ErrMultisigNotASigner)
+}
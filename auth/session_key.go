@@ -0,0 +1,285 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var (
+	ErrSessionKeyActionsInvalid = errors.New("session key transaction actions are invalid")
+	ErrMissingActionParser      = errors.New("missing action parser")
+
+	_ chain.Auth        = (*SessionKey)(nil)
+	_ chain.AuthFactory = (*SessionKeyFactory)(nil)
+)
+
+const (
+	SessionKeyComputeUnits = 1
+)
+
+// SessionKey authorizes a transaction for the session account of [Owner], see
+// [NewSessionKeyAccountAddress]. Transactions can't have an actor of another
+// type than their auth so the session account is an account of its own that
+// the owner funds. The owner signs for it with its own key without any
+// restriction, any other key must be registered by the session account
+// through [actions.CreateSessionKey].
+//
+// The auth can't read state so the permissions of a session key are enforced
+// by the [actions.SessionKeyBegin] and [actions.SessionKeyEnd] actions the
+// transaction must start and end with. The auth only checks that these
+// actions refer to the signing key, truthfully declare the other actions of
+// the transaction and list every asset these actions use.
+//
+// Fees of a session key are paid from its fee account, see
+// [NewSessionKeyFeeAddress], so a transaction signed by a key that was never
+// registered can't charge fees to the session account.
+type SessionKey struct {
+	Owner     codec.Address `json:"owner"`
+	KeyType   uint8         `json:"keyType"`
+	PublicKey []byte        `json:"publicKey"`
+	Signature []byte        `json:"signature"`
+
+	actionParser *codec.TypeParser[chain.Action]
+	signer       codec.Address
+	account      codec.Address
+}
+
+// NewSessionKeyAccountAddress is the account [owner] and its session keys
+// act for
+func NewSessionKeyAccountAddress(owner codec.Address) codec.Address {
+	return codec.CreateAddress(SessionKeyID, utils.ToID(owner[:]))
+}
+
+// NewSessionKeyFeeAddress is the account paying the fees of the transactions
+// [sessionKey] signs for [account]
+func NewSessionKeyFeeAddress(account codec.Address, sessionKey codec.Address) codec.Address {
+	v := make([]byte, 0, codec.AddressLen*2)
+	v = append(v, account[:]...)
+	v = append(v, sessionKey[:]...)
+	return codec.CreateAddress(SessionKeyID, utils.ToID(v))
+}
+
+// sessionKey returns the address of the key that signed the transaction
+func (s *SessionKey) sessionKey() codec.Address {
+	if s.signer == codec.EmptyAddress {
+		s.signer = newSignerAddress(s.KeyType, s.PublicKey)
+	}
+	return s.signer
+}
+
+func (*SessionKey) GetTypeID() uint8 {
+	return SessionKeyID
+}
+
+func (s *SessionKey) ComputeUnits(chain.Rules) uint64 {
+	return SessionKeyComputeUnits + keyComputeUnits(s.KeyType)
+}
+
+func (*SessionKey) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (s *SessionKey) Verify(ctx context.Context, msg []byte) error {
+	signerAuth, err := newSignerAuth(MultisigSigner{KeyType: s.KeyType, PublicKey: s.PublicKey}, s.Signature)
+	if err != nil {
+		return err
+	}
+	if err := signerAuth.Verify(ctx, msg); err != nil {
+		return err
+	}
+	if s.sessionKey() == s.Owner {
+		return nil
+	}
+	if s.actionParser == nil {
+		return ErrMissingActionParser
+	}
+	txData, err := chain.UnmarshalTxData(codec.NewReader(msg, len(msg)), s.actionParser)
+	if err != nil {
+		return err
+	}
+	return verifySessionKeyActions(s.sessionKey(), txData.Actions)
+}
+
+func (s *SessionKey) Actor() codec.Address {
+	if s.account == codec.EmptyAddress {
+		s.account = NewSessionKeyAccountAddress(s.Owner)
+	}
+	return s.account
+}
+
+func (s *SessionKey) Sponsor() codec.Address {
+	if s.sessionKey() == s.Owner {
+		return s.Actor()
+	}
+	return NewSessionKeyFeeAddress(s.Actor(), s.sessionKey())
+}
+
+func (s *SessionKey) Size() int {
+	return codec.AddressLen + consts.Uint8Len + len(s.PublicKey) + len(s.Signature)
+}
+
+func (s *SessionKey) Marshal(p *codec.Packer) {
+	p.PackAddress(s.Owner)
+	p.PackByte(s.KeyType)
+	p.PackFixedBytes(s.PublicKey)
+	p.PackFixedBytes(s.Signature)
+}
+
+// UnmarshalSessionKey needs [actionParser] to decode the actions the session
+// key signed
+func UnmarshalSessionKey(actionParser *codec.TypeParser[chain.Action]) func(*codec.Packer) (chain.Auth, error) {
+	return func(p *codec.Packer) (chain.Auth, error) {
+		s := SessionKey{actionParser: actionParser}
+		p.UnpackAddress(&s.Owner)
+		s.KeyType = p.UnpackByte()
+		publicKeyLen, signatureLen, err := keyLens(s.KeyType)
+		if err != nil {
+			return nil, err
+		}
+		s.PublicKey = make([]byte, publicKeyLen)
+		p.UnpackFixedBytes(publicKeyLen, &s.PublicKey)
+		s.Signature = make([]byte, signatureLen)
+		p.UnpackFixedBytes(signatureLen, &s.Signature)
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		s.signer = newSignerAddress(s.KeyType, s.PublicKey)
+		s.account = NewSessionKeyAccountAddress(s.Owner)
+		return &s, nil
+	}
+}
+
+// verifySessionKeyActions checks that [txActions] are wrapped between a
+// [actions.SessionKeyBegin] and a [actions.SessionKeyEnd] for [sessionKey],
+// that the wrapped actions are the ones declared in [actions.SessionKeyBegin]
+// and that it lists NAI and every asset they use
+func verifySessionKeyActions(sessionKey codec.Address, txActions []chain.Action) error {
+	if len(txActions) < 3 {
+		return ErrSessionKeyActionsInvalid
+	}
+	begin, ok := txActions[0].(*actions.SessionKeyBegin)
+	if !ok || begin.SessionKey != sessionKey {
+		return ErrSessionKeyActionsInvalid
+	}
+	end, ok := txActions[len(txActions)-1].(*actions.SessionKeyEnd)
+	if !ok || end.SessionKey != sessionKey || !slices.Equal(begin.AssetAddresses, end.AssetAddresses) {
+		return ErrSessionKeyActionsInvalid
+	}
+	wrapped := txActions[1 : len(txActions)-1]
+	if len(begin.ActionTypeIDs) != len(wrapped) {
+		return ErrSessionKeyActionsInvalid
+	}
+	// NAI is locked or burned by many actions that don't name it
+	if !slices.Contains(begin.AssetAddresses, storage.NAIAddress) {
+		return ErrSessionKeyActionsInvalid
+	}
+	for i, action := range wrapped {
+		switch action.(type) {
+		case *actions.SessionKeyBegin, *actions.SessionKeyEnd:
+			return ErrSessionKeyActionsInvalid
+		case *actions.ContractCall:
+			// Contracts can move any asset so what they spend can't be capped
+			return ErrSessionKeyActionsInvalid
+		case *actions.ApproveAsset:
+			// Allowances are spent later by someone else, past the spend limits
+			return ErrSessionKeyActionsInvalid
+		}
+		if action.GetTypeID() != begin.ActionTypeIDs[i] {
+			return ErrSessionKeyActionsInvalid
+		}
+		assetAddresses, _, _, _ := actionTargets(action)
+		for _, assetAddress := range assetAddresses {
+			if !slices.Contains(begin.AssetAddresses, assetAddress) {
+				return ErrSessionKeyActionsInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// SessionKeyFactory signs transactions for the session account of [owner]
+// with the key behind [factory]. Unless [factory] is the key of [owner],
+// actions must be wrapped with [NewSessionKeyActions].
+type SessionKeyFactory struct {
+	owner   codec.Address
+	factory chain.AuthFactory
+}
+
+func NewSessionKeyFactory(owner codec.Address, factory chain.AuthFactory) *SessionKeyFactory {
+	return &SessionKeyFactory{
+		owner:   owner,
+		factory: factory,
+	}
+}
+
+func (s *SessionKeyFactory) Sign(msg []byte) (chain.Auth, error) {
+	signerAuth, err := s.factory.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	signer, signature, err := signerFromAuth(signerAuth)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionKey{
+		Owner:     s.owner,
+		KeyType:   signer.KeyType,
+		PublicKey: signer.PublicKey,
+		Signature: signature,
+		signer:    signerAuth.Actor(),
+		account:   NewSessionKeyAccountAddress(s.owner),
+	}, nil
+}
+
+func (s *SessionKeyFactory) MaxUnits() (uint64, uint64) {
+	bandwidth, compute := s.factory.MaxUnits()
+	return bandwidth + codec.AddressLen + consts.Uint8Len, compute + SessionKeyComputeUnits
+}
+
+func (s *SessionKeyFactory) Address() codec.Address {
+	return NewSessionKeyAccountAddress(s.owner)
+}
+
+// NewSessionKeyActions wraps [wrapped] so they can be signed with
+// [sessionKey]. [assetAddresses] must list every asset the session key has a
+// spend limit for, NAI and the assets [wrapped] use are added to them.
+func NewSessionKeyActions(sessionKey codec.Address, assetAddresses []codec.Address, wrapped []chain.Action) []chain.Action {
+	assetAddresses = slices.Clone(assetAddresses)
+	addAsset := func(assetAddress codec.Address) {
+		if !slices.Contains(assetAddresses, assetAddress) {
+			assetAddresses = append(assetAddresses, assetAddress)
+		}
+	}
+	addAsset(storage.NAIAddress)
+	actionTypeIDs := make([]byte, len(wrapped))
+	for i, action := range wrapped {
+		actionTypeIDs[i] = action.GetTypeID()
+		targets, _, _, _ := actionTargets(action)
+		for _, assetAddress := range targets {
+			addAsset(assetAddress)
+		}
+	}
+	txActions := make([]chain.Action, 0, len(wrapped)+2)
+	txActions = append(txActions, &actions.SessionKeyBegin{
+		SessionKey:     sessionKey,
+		ActionTypeIDs:  actionTypeIDs,
+		AssetAddresses: assetAddresses,
+	})
+	txActions = append(txActions, wrapped...)
+	return append(txActions, &actions.SessionKeyEnd{
+		SessionKey:     sessionKey,
+		AssetAddresses: assetAddresses,
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func newSessionKeyActionParser(t *testing.T) *codec.TypeParser[chain.Action] {
+	require := require.New(t)
+	actionParser := codec.NewTypeParser[chain.Action]()
+	require.NoError(actionParser.Register(&actions.Transfer{}, actions.UnmarshalTransfer))
+	require.NoError(actionParser.Register(&actions.SessionKeyBegin{}, actions.UnmarshalSessionKeyBegin))
+	require.NoError(actionParser.Register(&actions.SessionKeyEnd{}, actions.UnmarshalSessionKeyEnd))
+	require.NoError(actionParser.Register(&actions.ApproveAsset{}, actions.UnmarshalApproveAsset))
+	return actionParser
+}
+
+// signSessionKeyTx returns the unsigned bytes of a transaction with
+// [txActions] and the session key auth for them
+func signSessionKeyTx(t *testing.T, factory chain.AuthFactory, txActions []chain.Action) ([]byte, chain.Auth) {
+	require := require.New(t)
+	txData := chain.NewTxData(&chain.Base{
+		Timestamp: 1_000,
+		ChainID:   ids.GenerateTestID(),
+		MaxFee:    1_000,
+	}, txActions)
+	msg, err := txData.UnsignedBytes()
+	require.NoError(err)
+	sessionKeyAuth, err := factory.Sign(msg)
+	require.NoError(err)
+	return msg, sessionKeyAuth
+}
+
+func TestSessionKey(t *testing.T) {
+	require := require.New(t)
+	actionParser := newSessionKeyActionParser(t)
+
+	owner := codectest.NewRandomAddress()
+	account := NewSessionKeyAccountAddress(owner)
+	privateKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	signerFactory := auth.NewED25519Factory(privateKey)
+	sessionKey := signerFactory.Address()
+	factory := NewSessionKeyFactory(owner, signerFactory)
+	transfer := &actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}
+
+	msg, sessionKeyAuth := signSessionKeyTx(t, factory, NewSessionKeyActions(sessionKey, nil, []chain.Action{transfer}))
+	sessionKeyAuth.(*SessionKey).actionParser = actionParser
+	require.NoError(sessionKeyAuth.Verify(context.Background(), msg))
+	require.Equal(account, sessionKeyAuth.Actor())
+	require.Equal(account, factory.Address())
+	require.Equal(NewSessionKeyFeeAddress(account, sessionKey), sessionKeyAuth.Sponsor())
+
+	// Max units must cover the actual units
+	bandwidth, compute := factory.MaxUnits()
+	require.GreaterOrEqual(bandwidth, uint64(sessionKeyAuth.Size()))
+	require.GreaterOrEqual(compute, sessionKeyAuth.ComputeUnits(nil))
+
+	// Round trip through the packer
+	p := codec.NewWriter(sessionKeyAuth.Size(), sessionKeyAuth.Size())
+	sessionKeyAuth.Marshal(p)
+	require.NoError(p.Err())
+	unmarshalled, err := UnmarshalSessionKey(actionParser)(codec.NewReader(p.Bytes(), sessionKeyAuth.Size()))
+	require.NoError(err)
+	require.NoError(unmarshalled.Verify(context.Background(), msg))
+	require.Equal(account, unmarshalled.Actor())
+	require.Equal(NewSessionKeyFeeAddress(account, sessionKey), unmarshalled.Sponsor())
+
+	// The signature must match the transaction
+	_, otherAuth := signSessionKeyTx(t, factory, NewSessionKeyActions(sessionKey, nil, []chain.Action{&actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        20,
+	}}))
+	otherAuth.(*SessionKey).actionParser = actionParser
+	require.Error(otherAuth.Verify(context.Background(), msg))
+}
+
+func TestSessionKeyOwner(t *testing.T) {
+	require := require.New(t)
+	actionParser := newSessionKeyActionParser(t)
+
+	// The owner signs for the session account without any wrapping
+	privateKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	ownerFactory := auth.NewED25519Factory(privateKey)
+	owner := ownerFactory.Address()
+	factory := NewSessionKeyFactory(owner, ownerFactory)
+	msg, sessionKeyAuth := signSessionKeyTx(t, factory, []chain.Action{&actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}})
+	sessionKeyAuth.(*SessionKey).actionParser = actionParser
+	require.NoError(sessionKeyAuth.Verify(context.Background(), msg))
+	require.Equal(NewSessionKeyAccountAddress(owner), sessionKeyAuth.Actor())
+	require.Equal(NewSessionKeyAccountAddress(owner), sessionKeyAuth.Sponsor())
+}
+
+func TestSessionKeyTransaction(t *testing.T) {
+	actionParser := newSessionKeyActionParser(t)
+	authParser := codec.NewTypeParser[chain.Auth]()
+	require.NoError(t, authParser.Register(&SessionKey{}, UnmarshalSessionKey(actionParser)))
+
+	ownerPrivateKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	ownerFactory := auth.NewED25519Factory(ownerPrivateKey)
+	owner := ownerFactory.Address()
+	privateKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	signerFactory := auth.NewED25519Factory(privateKey)
+	sessionKey := signerFactory.Address()
+	transfer := &actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}
+
+	tests := []struct {
+		name      string
+		factory   chain.AuthFactory
+		txActions []chain.Action
+	}{
+		{
+			name:      "Owner",
+			factory:   NewSessionKeyFactory(owner, ownerFactory),
+			txActions: []chain.Action{transfer},
+		},
+		{
+			name:      "SessionKey",
+			factory:   NewSessionKeyFactory(owner, signerFactory),
+			txActions: NewSessionKeyActions(sessionKey, nil, []chain.Action{transfer}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			txData := chain.NewTxData(&chain.Base{
+				Timestamp: 1_000,
+				ChainID:   ids.GenerateTestID(),
+				MaxFee:    1_000,
+			}, tt.txActions)
+
+			// Signing reloads the transaction through [chain.UnmarshalTx]
+			// which checks the type of the actor and of the sponsor
+			tx, err := txData.Sign(tt.factory, actionParser, authParser)
+			require.NoError(err)
+			parsed, err := chain.UnmarshalTx(codec.NewReader(tx.Bytes(), len(tx.Bytes())), actionParser, authParser)
+			require.NoError(err)
+			msg, err := parsed.UnsignedBytes()
+			require.NoError(err)
+			require.NoError(parsed.Auth.Verify(context.Background(), msg))
+			require.Equal(NewSessionKeyAccountAddress(owner), parsed.Auth.Actor())
+		})
+	}
+}
+
+func TestSessionKeyInvalidActions(t *testing.T) {
+	actionParser := newSessionKeyActionParser(t)
+
+	owner := codectest.NewRandomAddress()
+	privateKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	signerFactory := auth.NewED25519Factory(privateKey)
+	sessionKey := signerFactory.Address()
+	factory := NewSessionKeyFactory(owner, signerFactory)
+	transfer := &actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}
+	assetAddresses := []codec.Address{storage.NAIAddress}
+	transferAsset := codectest.NewRandomAddress()
+
+	tests := []struct {
+		name      string
+		txActions []chain.Action
+	}{
+		{
+			name:      "NotWrapped",
+			txActions: []chain.Action{transfer},
+		},
+		{
+			name:      "NoWrappedActions",
+			txActions: NewSessionKeyActions(sessionKey, assetAddresses, nil),
+		},
+		{
+			name:      "OtherSessionKey",
+			txActions: NewSessionKeyActions(codectest.NewRandomAddress(), assetAddresses, []chain.Action{transfer}),
+		},
+		{
+			name: "UndeclaredAction",
+			txActions: []chain.Action{
+				&actions.SessionKeyBegin{SessionKey: sessionKey, ActionTypeIDs: []byte{nconsts.TransferID}, AssetAddresses: assetAddresses},
+				transfer,
+				transfer,
+				&actions.SessionKeyEnd{SessionKey: sessionKey, AssetAddresses: assetAddresses},
+			},
+		},
+		{
+			name: "AssetsMismatch",
+			txActions: []chain.Action{
+				&actions.SessionKeyBegin{SessionKey: sessionKey, ActionTypeIDs: []byte{nconsts.TransferID}},
+				transfer,
+				&actions.SessionKeyEnd{SessionKey: sessionKey, AssetAddresses: assetAddresses},
+			},
+		},
+		{
+			name: "UndeclaredAsset",
+			txActions: []chain.Action{
+				&actions.SessionKeyBegin{SessionKey: sessionKey, ActionTypeIDs: []byte{nconsts.TransferID}, AssetAddresses: assetAddresses},
+				&actions.Transfer{AssetAddress: codectest.NewRandomAddress(), To: codectest.NewRandomAddress(), Value: 10},
+				&actions.SessionKeyEnd{SessionKey: sessionKey, AssetAddresses: assetAddresses},
+			},
+		},
+		{
+			name: "MissingNAI",
+			txActions: []chain.Action{
+				&actions.SessionKeyBegin{SessionKey: sessionKey, ActionTypeIDs: []byte{nconsts.TransferID}, AssetAddresses: []codec.Address{transferAsset}},
+				&actions.Transfer{AssetAddress: transferAsset, To: codectest.NewRandomAddress(), Value: 10},
+				&actions.SessionKeyEnd{SessionKey: sessionKey, AssetAddresses: []codec.Address{transferAsset}},
+			},
+		},
+		{
+			name: "ApproveAsset",
+			txActions: []chain.Action{
+				&actions.SessionKeyBegin{SessionKey: sessionKey, ActionTypeIDs: []byte{nconsts.ApproveAssetID}, AssetAddresses: assetAddresses},
+				&actions.ApproveAsset{AssetAddress: storage.NAIAddress, Spender: codectest.NewRandomAddress(), Amount: 10},
+				&actions.SessionKeyEnd{SessionKey: sessionKey, AssetAddresses: assetAddresses},
+			},
+		},
+		{
+			name: "NestedBegin",
+			txActions: []chain.Action{
+				&actions.SessionKeyBegin{SessionKey: sessionKey, ActionTypeIDs: []byte{nconsts.SessionKeyBeginID}, AssetAddresses: assetAddresses},
+				&actions.SessionKeyBegin{SessionKey: sessionKey, AssetAddresses: assetAddresses},
+				&actions.SessionKeyEnd{SessionKey: sessionKey, AssetAddresses: assetAddresses},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, sessionKeyAuth := signSessionKeyTx(t, factory, tt.txActions)
+			sessionKeyAuth.(*SessionKey).actionParser = actionParser
+			require.ErrorIs(t, sessionKeyAuth.Verify(context.Background(), msg), ErrSessionKeyActionsInvalid)
+		})
+	}
+}
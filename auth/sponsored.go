@@ -0,0 +1,465 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+var (
+	ErrSponsorPolicyViolated = errors.New("transaction is not covered by the sponsor policy")
+
+	_ chain.Auth        = (*Sponsored)(nil)
+	_ chain.AuthFactory = (*SponsoredFactory)(nil)
+	_ chain.AuthFactory = (*PresignedFactory)(nil)
+)
+
+const (
+	SponsoredComputeUnits = 1
+)
+
+// SponsorPolicy lists what a sponsor pays fees for. It must match a policy
+// registered with [actions.SetSponsorPolicy].
+type SponsorPolicy struct {
+	// Action type IDs the sponsor pays for
+	ActionTypeIDs []byte `json:"actionTypeIDs"`
+
+	// Assets the actions may use. Any asset if empty.
+	AssetAddresses []codec.Address `json:"assetAddresses"`
+
+	// Datasets the actions may use. Any dataset if empty.
+	DatasetAddresses []codec.Address `json:"datasetAddresses"`
+}
+
+// Address is the sponsor of the transactions signed under [p]. Fees are
+// paid by the sponsor account of [sponsor], see
+// [storage.SponsorAccountAddress].
+func (p *SponsorPolicy) Address(sponsor codec.Address) codec.Address {
+	return storage.SponsorPolicyAddress(sponsor, p.ActionTypeIDs, p.AssetAddresses, p.DatasetAddresses)
+}
+
+func (p *SponsorPolicy) size() int {
+	return consts.IntLen + len(p.ActionTypeIDs) + consts.IntLen + len(p.AssetAddresses)*codec.AddressLen + consts.IntLen + len(p.DatasetAddresses)*codec.AddressLen
+}
+
+func (p *SponsorPolicy) marshal(packer *codec.Packer) {
+	packer.PackBytes(p.ActionTypeIDs)
+	packer.PackInt(uint32(len(p.AssetAddresses)))
+	for _, assetAddress := range p.AssetAddresses {
+		packer.PackAddress(assetAddress)
+	}
+	packer.PackInt(uint32(len(p.DatasetAddresses)))
+	for _, datasetAddress := range p.DatasetAddresses {
+		packer.PackAddress(datasetAddress)
+	}
+}
+
+func (p *SponsorPolicy) unmarshal(packer *codec.Packer) error {
+	packer.UnpackBytes(storage.MaxSponsorPolicyActionTypes, true, &p.ActionTypeIDs)
+	var err error
+	p.AssetAddresses, err = unpackPolicyAddresses(packer, storage.MaxSponsorPolicyAssets)
+	if err != nil {
+		return err
+	}
+	p.DatasetAddresses, err = unpackPolicyAddresses(packer, storage.MaxSponsorPolicyDatasets)
+	return err
+}
+
+func unpackPolicyAddresses(packer *codec.Packer, limit int) ([]codec.Address, error) {
+	numAddresses := int(packer.UnpackInt(false))
+	if numAddresses > limit {
+		return nil, ErrSponsorPolicyViolated
+	}
+	addresses := make([]codec.Address, numAddresses)
+	for i := range addresses {
+		packer.UnpackAddress(&addresses[i])
+	}
+	return addresses, nil
+}
+
+// Sponsored is signed by both the actor of the transaction and a sponsor that
+// pays its fees. The actor and the sponsor of a transaction must be of the
+// type of its auth, so the actor is the sponsor account of the actor key and
+// fees are paid by the sponsor account of the sponsor key, see
+// [storage.SponsorAccountAddress]. The sponsor only pays for the actions its
+// policy allows because the sponsor of the transaction is the policy, which
+// must be registered with [actions.SetSponsorPolicy].
+type Sponsored struct {
+	ActorKeyType   uint8  `json:"actorKeyType"`
+	ActorPublicKey []byte `json:"actorPublicKey"`
+	ActorSignature []byte `json:"actorSignature"`
+
+	SponsorKeyType   uint8  `json:"sponsorKeyType"`
+	SponsorPublicKey []byte `json:"sponsorPublicKey"`
+	SponsorSignature []byte `json:"sponsorSignature"`
+
+	Policy SponsorPolicy `json:"policy"`
+
+	actionParser *codec.TypeParser[chain.Action]
+	actor        codec.Address
+	sponsor      codec.Address
+}
+
+func (*Sponsored) GetTypeID() uint8 {
+	return SponsoredID
+}
+
+func (s *Sponsored) ComputeUnits(chain.Rules) uint64 {
+	return SponsoredComputeUnits + keyComputeUnits(s.ActorKeyType) + keyComputeUnits(s.SponsorKeyType)
+}
+
+func (*Sponsored) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (s *Sponsored) Verify(ctx context.Context, msg []byte) error {
+	actorAuth, err := newSignerAuth(MultisigSigner{KeyType: s.ActorKeyType, PublicKey: s.ActorPublicKey}, s.ActorSignature)
+	if err != nil {
+		return err
+	}
+	if err := actorAuth.Verify(ctx, msg); err != nil {
+		return err
+	}
+	sponsorAuth, err := newSignerAuth(MultisigSigner{KeyType: s.SponsorKeyType, PublicKey: s.SponsorPublicKey}, s.SponsorSignature)
+	if err != nil {
+		return err
+	}
+	if err := sponsorAuth.Verify(ctx, msg); err != nil {
+		return err
+	}
+	if s.actionParser == nil {
+		return ErrMissingActionParser
+	}
+	txData, err := chain.UnmarshalTxData(codec.NewReader(msg, len(msg)), s.actionParser)
+	if err != nil {
+		return err
+	}
+	return verifySponsorPolicy(&s.Policy, txData.Actions)
+}
+
+func (s *Sponsored) Actor() codec.Address {
+	if s.actor == codec.EmptyAddress {
+		s.actor = storage.SponsorAccountAddress(newSignerAddress(s.ActorKeyType, s.ActorPublicKey))
+	}
+	return s.actor
+}
+
+func (s *Sponsored) Sponsor() codec.Address {
+	if s.sponsor == codec.EmptyAddress {
+		s.sponsor = s.Policy.Address(newSignerAddress(s.SponsorKeyType, s.SponsorPublicKey))
+	}
+	return s.sponsor
+}
+
+func (s *Sponsored) Size() int {
+	return consts.Uint8Len + len(s.ActorPublicKey) + len(s.ActorSignature) +
+		consts.Uint8Len + len(s.SponsorPublicKey) + len(s.SponsorSignature) +
+		s.Policy.size()
+}
+
+func (s *Sponsored) Marshal(p *codec.Packer) {
+	p.PackByte(s.ActorKeyType)
+	p.PackFixedBytes(s.ActorPublicKey)
+	p.PackFixedBytes(s.ActorSignature)
+	p.PackByte(s.SponsorKeyType)
+	p.PackFixedBytes(s.SponsorPublicKey)
+	p.PackFixedBytes(s.SponsorSignature)
+	s.Policy.marshal(p)
+}
+
+// UnmarshalSponsored needs [actionParser] to check the actions against the
+// sponsor policy
+func UnmarshalSponsored(actionParser *codec.TypeParser[chain.Action]) func(*codec.Packer) (chain.Auth, error) {
+	return func(p *codec.Packer) (chain.Auth, error) {
+		s := Sponsored{actionParser: actionParser}
+		var err error
+		s.ActorKeyType, s.ActorPublicKey, s.ActorSignature, err = unpackSigner(p)
+		if err != nil {
+			return nil, err
+		}
+		s.SponsorKeyType, s.SponsorPublicKey, s.SponsorSignature, err = unpackSigner(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Policy.unmarshal(p); err != nil {
+			return nil, err
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		s.actor = storage.SponsorAccountAddress(newSignerAddress(s.ActorKeyType, s.ActorPublicKey))
+		s.sponsor = s.Policy.Address(newSignerAddress(s.SponsorKeyType, s.SponsorPublicKey))
+		return &s, nil
+	}
+}
+
+func unpackSigner(p *codec.Packer) (uint8, []byte, []byte, error) {
+	keyType := p.UnpackByte()
+	publicKeyLen, signatureLen, err := keyLens(keyType)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	publicKey := make([]byte, publicKeyLen)
+	p.UnpackFixedBytes(publicKeyLen, &publicKey)
+	signature := make([]byte, signatureLen)
+	p.UnpackFixedBytes(signatureLen, &signature)
+	return keyType, publicKey, signature, nil
+}
+
+// verifySponsorPolicy checks that every action of [txActions] is allowed by
+// [policy]
+func verifySponsorPolicy(policy *SponsorPolicy, txActions []chain.Action) error {
+	// Marketplace assets are derived from the dataset they sell
+	marketplaceAssetAddresses := make([]codec.Address, len(policy.DatasetAddresses))
+	for i, datasetAddress := range policy.DatasetAddresses {
+		marketplaceAssetAddresses[i] = storage.AssetAddressFractional(datasetAddress)
+	}
+
+	for _, action := range txActions {
+		if !slices.Contains(policy.ActionTypeIDs, action.GetTypeID()) {
+			return ErrSponsorPolicyViolated
+		}
+		assetAddresses, datasetAddresses, marketplaceAddresses, ok := actionTargets(action)
+		// Actions whose targets are not known can use any asset or dataset so
+		// they are only sponsored by policies without restrictions
+		if !ok && (len(policy.AssetAddresses) > 0 || len(policy.DatasetAddresses) > 0) {
+			return ErrSponsorPolicyViolated
+		}
+		if len(policy.AssetAddresses) > 0 {
+			for _, assetAddress := range assetAddresses {
+				if !slices.Contains(policy.AssetAddresses, assetAddress) {
+					return ErrSponsorPolicyViolated
+				}
+			}
+		}
+		if len(policy.DatasetAddresses) > 0 {
+			for _, datasetAddress := range datasetAddresses {
+				if !slices.Contains(policy.DatasetAddresses, datasetAddress) {
+					return ErrSponsorPolicyViolated
+				}
+			}
+			for _, marketplaceAddress := range marketplaceAddresses {
+				if !slices.Contains(marketplaceAssetAddresses, marketplaceAddress) {
+					return ErrSponsorPolicyViolated
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// actionTargets returns the assets, datasets and marketplace assets [action]
+// uses, and false if they are not known
+func actionTargets(action chain.Action) ([]codec.Address, []codec.Address, []codec.Address, bool) {
+	switch act := action.(type) {
+	case *actions.Transfer:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.BatchTransfer:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.TransferNFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.ApproveAsset:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.TransferAssetFrom:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.CreateOrder:
+		return []codec.Address{act.InAssetAddress, act.OutAssetAddress}, nil, nil, true
+	case *actions.FillOrder:
+		return []codec.Address{act.InAssetAddress, act.OutAssetAddress}, nil, nil, true
+	case *actions.CloseOrder:
+		return []codec.Address{act.InAssetAddress}, nil, nil, true
+	case *actions.CreateVestingSchedule:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.ClaimVested:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.RevokeVestingSchedule:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.UpdateAsset:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.MintAssetFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.MintAssetNFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.BurnAssetFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.BurnAssetNFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.FractionalizeNFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.RedeemNFT:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.SyncNFTOwner:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.CreateDataset:
+		return nil, []codec.Address{act.AssetAddress}, nil, true
+	case *actions.UpdateDataset:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.InitiateContributeDataset:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.CompleteContributeDataset:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.RejectContributeDataset:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.CancelContributeDataset:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.PublishDatasetVersion:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.ChallengeDatasetContribution:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.RespondDatasetChallenge:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.ClaimDatasetChallenge:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.ReleaseContributionCollateral:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.PublishDatasetMarketplace:
+		return []codec.Address{act.PaymentAssetAddress}, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.UpdateMarketplaceListing:
+		return []codec.Address{act.PaymentAssetAddress}, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.UnpublishDatasetMarketplace:
+		return nil, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.SetMarketplacePaymentAsset:
+		return []codec.Address{act.PaymentAssetAddress}, []codec.Address{act.DatasetAddress}, nil, true
+	case *actions.SubscribeDatasetMarketplace:
+		return []codec.Address{act.PaymentAssetAddress}, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.ClaimMarketplacePayment:
+		return []codec.Address{act.PaymentAssetAddress}, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.OpenUsageChannel:
+		return []codec.Address{act.PaymentAssetAddress}, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.SettleUsage:
+		return []codec.Address{act.PaymentAssetAddress}, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.CloseUsageChannel:
+		return []codec.Address{act.PaymentAssetAddress}, nil, nil, true
+	case *actions.DeliverDatasetKey:
+		return nil, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.SetEncryptionPublicKey:
+		return nil, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.SetDatasetKeyDelegate:
+		return nil, nil, []codec.Address{act.MarketplaceAssetAddress}, true
+	case *actions.ProposeOwnershipTransfer:
+		return ownershipTargets(act.Kind, act.Address)
+	case *actions.AcceptOwnershipTransfer:
+		return ownershipTargets(act.Kind, act.Address)
+	case *actions.GrantMintRole:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	case *actions.RevokeMintRole:
+		return []codec.Address{act.AssetAddress}, nil, nil, true
+	default:
+		return nil, nil, nil, false
+	}
+}
+
+// ownershipTargets returns [address] as an asset, a dataset or a marketplace
+// asset depending on [kind]
+func ownershipTargets(kind uint8, address codec.Address) ([]codec.Address, []codec.Address, []codec.Address, bool) {
+	switch kind {
+	case nconsts.OwnershipKindDataset:
+		return nil, []codec.Address{address}, nil, true
+	case nconsts.OwnershipKindListing:
+		return nil, nil, []codec.Address{address}, true
+	default:
+		return []codec.Address{address}, nil, nil, true
+	}
+}
+
+// SponsoredFactory signs transactions for the sponsor account of [actor] with
+// fees paid under [policy] of the sponsor behind [sponsor]. The sponsor is
+// usually not local, see [NewPresignedFactory].
+type SponsoredFactory struct {
+	actor   chain.AuthFactory
+	sponsor chain.AuthFactory
+	policy  SponsorPolicy
+}
+
+func NewSponsoredFactory(actor chain.AuthFactory, sponsor chain.AuthFactory, policy SponsorPolicy) *SponsoredFactory {
+	return &SponsoredFactory{
+		actor:   actor,
+		sponsor: sponsor,
+		policy:  policy,
+	}
+}
+
+func (s *SponsoredFactory) Sign(msg []byte) (chain.Auth, error) {
+	actorAuth, err := s.actor.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	actorSigner, actorSignature, err := signerFromAuth(actorAuth)
+	if err != nil {
+		return nil, err
+	}
+	sponsorAuth, err := s.sponsor.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	sponsorSigner, sponsorSignature, err := signerFromAuth(sponsorAuth)
+	if err != nil {
+		return nil, err
+	}
+	return &Sponsored{
+		ActorKeyType:     actorSigner.KeyType,
+		ActorPublicKey:   actorSigner.PublicKey,
+		ActorSignature:   actorSignature,
+		SponsorKeyType:   sponsorSigner.KeyType,
+		SponsorPublicKey: sponsorSigner.PublicKey,
+		SponsorSignature: sponsorSignature,
+		Policy:           s.policy,
+		actor:            storage.SponsorAccountAddress(actorAuth.Actor()),
+		sponsor:          s.policy.Address(sponsorAuth.Actor()),
+	}, nil
+}
+
+func (s *SponsoredFactory) MaxUnits() (uint64, uint64) {
+	actorBandwidth, actorCompute := s.actor.MaxUnits()
+	sponsorBandwidth, sponsorCompute := s.sponsor.MaxUnits()
+	return actorBandwidth + sponsorBandwidth + 2*consts.Uint8Len + uint64(s.policy.size()), actorCompute + sponsorCompute + SponsoredComputeUnits
+}
+
+func (s *SponsoredFactory) Address() codec.Address {
+	return storage.SponsorAccountAddress(s.actor.Address())
+}
+
+// PresignedFactory returns a signature collected from a key that is not
+// available locally, such as the signature of a sponsor
+type PresignedFactory struct {
+	signer    MultisigSigner
+	signature []byte
+}
+
+func NewPresignedFactory(signer MultisigSigner, signature []byte) *PresignedFactory {
+	return &PresignedFactory{
+		signer:    signer,
+		signature: signature,
+	}
+}
+
+// NewPresignedSignature splits [signerAuth] into the signer and signature
+// that can be shared and passed to [NewPresignedFactory]
+func NewPresignedSignature(signerAuth chain.Auth) (MultisigSigner, []byte, error) {
+	return signerFromAuth(signerAuth)
+}
+
+func (p *PresignedFactory) Sign([]byte) (chain.Auth, error) {
+	return newSignerAuth(p.signer, p.signature)
+}
+
+func (p *PresignedFactory) MaxUnits() (uint64, uint64) {
+	publicKeyLen, signatureLen, _ := keyLens(p.signer.KeyType)
+	return uint64(publicKeyLen + signatureLen), keyComputeUnits(p.signer.KeyType)
+}
+
+func (p *PresignedFactory) Address() codec.Address {
+	return newSignerAddress(p.signer.KeyType, p.signer.PublicKey)
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+func newSponsoredActionParser(t *testing.T) *codec.TypeParser[chain.Action] {
+	require := require.New(t)
+	actionParser := codec.NewTypeParser[chain.Action]()
+	require.NoError(actionParser.Register(&actions.Transfer{}, actions.UnmarshalTransfer))
+	require.NoError(actionParser.Register(&actions.SubscribeDatasetMarketplace{}, actions.UnmarshalSubscribeDatasetMarketplace))
+	require.NoError(actionParser.Register(&actions.CreateAsset{}, actions.UnmarshalCreateAsset))
+	return actionParser
+}
+
+func newED25519Factory(t *testing.T) *auth.ED25519Factory {
+	privateKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	return auth.NewED25519Factory(privateKey)
+}
+
+func TestSponsored(t *testing.T) {
+	require := require.New(t)
+	actionParser := newSponsoredActionParser(t)
+
+	actorFactory := newED25519Factory(t)
+	sponsorFactory := newED25519Factory(t)
+	policy := SponsorPolicy{
+		ActionTypeIDs:  []byte{nconsts.TransferID},
+		AssetAddresses: []codec.Address{storage.NAIAddress},
+	}
+	policyAddress := storage.SponsorPolicyAddress(sponsorFactory.Address(), policy.ActionTypeIDs, policy.AssetAddresses, policy.DatasetAddresses)
+	factory := NewSponsoredFactory(actorFactory, sponsorFactory, policy)
+	transfer := &actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}
+
+	msg, sponsoredAuth := signSessionKeyTx(t, factory, []chain.Action{transfer})
+	sponsoredAuth.(*Sponsored).actionParser = actionParser
+	require.NoError(sponsoredAuth.Verify(context.Background(), msg))
+	require.Equal(storage.SponsorAccountAddress(actorFactory.Address()), sponsoredAuth.Actor())
+	require.Equal(storage.SponsorAccountAddress(actorFactory.Address()), factory.Address())
+	require.Equal(policyAddress, sponsoredAuth.Sponsor())
+	require.Equal(policyAddress, policy.Address(sponsorFactory.Address()))
+
+	// Max units must cover the actual units
+	bandwidth, compute := factory.MaxUnits()
+	require.GreaterOrEqual(bandwidth, uint64(sponsoredAuth.Size()))
+	require.GreaterOrEqual(compute, sponsoredAuth.ComputeUnits(nil))
+
+	// Round trip through the packer
+	p := codec.NewWriter(sponsoredAuth.Size(), sponsoredAuth.Size())
+	sponsoredAuth.Marshal(p)
+	require.NoError(p.Err())
+	unmarshalled, err := UnmarshalSponsored(actionParser)(codec.NewReader(p.Bytes(), sponsoredAuth.Size()))
+	require.NoError(err)
+	require.NoError(unmarshalled.Verify(context.Background(), msg))
+	require.Equal(storage.SponsorAccountAddress(actorFactory.Address()), unmarshalled.Actor())
+	require.Equal(policyAddress, unmarshalled.Sponsor())
+
+	// Claiming another policy changes the sponsor but not the account paying
+	// the fees
+	otherPolicy := SponsorPolicy{ActionTypeIDs: []byte{nconsts.TransferID}}
+	_, otherAuth := signSessionKeyTx(t, NewSponsoredFactory(actorFactory, sponsorFactory, otherPolicy), []chain.Action{transfer})
+	require.NotEqual(policyAddress, otherAuth.Sponsor())
+	require.Equal(storage.SponsorAccountAddress(sponsorFactory.Address()), storage.SponsorPolicyAccountAddress(policyAddress))
+	require.Equal(storage.SponsorPolicyAccountAddress(policyAddress), storage.SponsorPolicyAccountAddress(otherAuth.Sponsor()))
+
+	// Both signatures must match the transaction
+	_, otherAuth = signSessionKeyTx(t, factory, []chain.Action{transfer, transfer})
+	forged := *sponsoredAuth.(*Sponsored)
+	forged.SponsorSignature = otherAuth.(*Sponsored).SponsorSignature
+	require.Error(forged.Verify(context.Background(), msg))
+	forged = *sponsoredAuth.(*Sponsored)
+	forged.ActorSignature = otherAuth.(*Sponsored).ActorSignature
+	require.Error(forged.Verify(context.Background(), msg))
+}
+
+func TestSponsoredTransaction(t *testing.T) {
+	require := require.New(t)
+	actionParser := newSponsoredActionParser(t)
+	authParser := codec.NewTypeParser[chain.Auth]()
+	require.NoError(authParser.Register(&Sponsored{}, UnmarshalSponsored(actionParser)))
+
+	actorFactory := newED25519Factory(t)
+	sponsorFactory := newED25519Factory(t)
+	policy := SponsorPolicy{ActionTypeIDs: []byte{nconsts.TransferID}}
+	txData := chain.NewTxData(&chain.Base{
+		Timestamp: 1_000,
+		ChainID:   ids.GenerateTestID(),
+		MaxFee:    1_000,
+	}, []chain.Action{&actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}})
+
+	// Signing reloads the transaction through [chain.UnmarshalTx] which
+	// checks the type of the actor and of the sponsor
+	tx, err := txData.Sign(NewSponsoredFactory(actorFactory, sponsorFactory, policy), actionParser, authParser)
+	require.NoError(err)
+	parsed, err := chain.UnmarshalTx(codec.NewReader(tx.Bytes(), len(tx.Bytes())), actionParser, authParser)
+	require.NoError(err)
+	msg, err := parsed.UnsignedBytes()
+	require.NoError(err)
+	require.NoError(parsed.Auth.Verify(context.Background(), msg))
+	require.Equal(storage.SponsorAccountAddress(actorFactory.Address()), parsed.Auth.Actor())
+	require.Equal(policy.Address(sponsorFactory.Address()), parsed.Auth.Sponsor())
+}
+
+func TestSponsoredPresigned(t *testing.T) {
+	require := require.New(t)
+	actionParser := newSponsoredActionParser(t)
+
+	actorFactory := newED25519Factory(t)
+	sponsorFactory := newED25519Factory(t)
+	policy := SponsorPolicy{ActionTypeIDs: []byte{nconsts.TransferID}}
+	transfer := &actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}
+
+	// The sponsor signs the transaction built by the actor
+	msg, _ := signSessionKeyTx(t, actorFactory, []chain.Action{transfer})
+	sponsorAuth, err := sponsorFactory.Sign(msg)
+	require.NoError(err)
+	signer, signature, err := NewPresignedSignature(sponsorAuth)
+	require.NoError(err)
+	presignedFactory := NewPresignedFactory(signer, signature)
+	require.Equal(sponsorFactory.Address(), presignedFactory.Address())
+
+	sponsoredAuth, err := NewSponsoredFactory(actorFactory, presignedFactory, policy).Sign(msg)
+	require.NoError(err)
+	sponsoredAuth.(*Sponsored).actionParser = actionParser
+	require.NoError(sponsoredAuth.Verify(context.Background(), msg))
+	require.Equal(policy.Address(sponsorFactory.Address()), sponsoredAuth.Sponsor())
+}
+
+func TestSponsoredPolicyViolated(t *testing.T) {
+	actionParser := newSponsoredActionParser(t)
+
+	actorFactory := newED25519Factory(t)
+	sponsorFactory := newED25519Factory(t)
+	assetAddress := codectest.NewRandomAddress()
+	datasetAddress := codectest.NewRandomAddress()
+	transfer := &actions.Transfer{
+		AssetAddress: storage.NAIAddress,
+		To:           codectest.NewRandomAddress(),
+		Value:        10,
+	}
+	subscribe := &actions.SubscribeDatasetMarketplace{
+		MarketplaceAssetAddress: storage.AssetAddressFractional(datasetAddress),
+		PaymentAssetAddress:     storage.NAIAddress,
+		NumBlocksToSubscribe:    10,
+	}
+	// CreateAsset does not name the assets it uses
+	createAsset := &actions.CreateAsset{
+		AssetType:                    nconsts.AssetFungibleTokenID,
+		Name:                         "name",
+		Symbol:                       "SYM",
+		Metadata:                     "metadata",
+		MintAdmin:                    codectest.NewRandomAddress(),
+		PauseUnpauseAdmin:            codectest.NewRandomAddress(),
+		FreezeUnfreezeAdmin:          codectest.NewRandomAddress(),
+		EnableDisableKYCAccountAdmin: codectest.NewRandomAddress(),
+	}
+
+	tests := []struct {
+		name      string
+		policy    SponsorPolicy
+		txActions []chain.Action
+		wantErr   error
+	}{
+		{
+			name:      "ActionTypeNotAllowed",
+			policy:    SponsorPolicy{ActionTypeIDs: []byte{nconsts.SubscribeDatasetMarketplaceID}},
+			txActions: []chain.Action{subscribe, transfer},
+			wantErr:   ErrSponsorPolicyViolated,
+		},
+		{
+			name:      "AssetNotAllowed",
+			policy:    SponsorPolicy{ActionTypeIDs: []byte{nconsts.TransferID}, AssetAddresses: []codec.Address{assetAddress}},
+			txActions: []chain.Action{transfer},
+			wantErr:   ErrSponsorPolicyViolated,
+		},
+		{
+			name:      "DatasetNotAllowed",
+			policy:    SponsorPolicy{ActionTypeIDs: []byte{nconsts.SubscribeDatasetMarketplaceID}, DatasetAddresses: []codec.Address{codectest.NewRandomAddress()}},
+			txActions: []chain.Action{subscribe},
+			wantErr:   ErrSponsorPolicyViolated,
+		},
+		{
+			name:      "UntargetableActionWithRestrictions",
+			policy:    SponsorPolicy{ActionTypeIDs: []byte{nconsts.CreateAssetID}, DatasetAddresses: []codec.Address{datasetAddress}},
+			txActions: []chain.Action{createAsset},
+			wantErr:   ErrSponsorPolicyViolated,
+		},
+		{
+			name:      "UntargetableActionWithoutRestrictions",
+			policy:    SponsorPolicy{ActionTypeIDs: []byte{nconsts.CreateAssetID}},
+			txActions: []chain.Action{createAsset},
+		},
+		{
+			name:      "DatasetAllowed",
+			policy:    SponsorPolicy{ActionTypeIDs: []byte{nconsts.SubscribeDatasetMarketplaceID}, AssetAddresses: []codec.Address{storage.NAIAddress}, DatasetAddresses: []codec.Address{datasetAddress}},
+			txActions: []chain.Action{subscribe},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, sponsoredAuth := signSessionKeyTx(t, NewSponsoredFactory(actorFactory, sponsorFactory, tt.policy), tt.txActions)
+			sponsoredAuth.(*Sponsored).actionParser = actionParser
+			require.ErrorIs(t, sponsoredAuth.Verify(context.Background(), msg), tt.wantErr)
+		})
+	}
+}
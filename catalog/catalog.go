@@ -0,0 +1,258 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// MaxPageSize is the most entries returned by a single query
+const MaxPageSize = 1000
+
+const (
+	// SortByAddress orders datasets by address
+	SortByAddress = ""
+	// SortBySubscriptions orders datasets by decreasing number of
+	// marketplace subscriptions
+	SortBySubscriptions = "subscriptions"
+)
+
+var (
+	ErrInvalidSort  = errors.New("invalid sort")
+	ErrInvalidEntry = errors.New("invalid catalog entry")
+)
+
+// Dataset is a dataset of the catalog. Datasets not published to the
+// marketplace have no payment asset, price or subscriptions.
+type Dataset struct {
+	DatasetAddress          string            `json:"datasetAddress"`
+	Name                    string            `json:"name"`
+	Description             string            `json:"description"`
+	Categories              string            `json:"categories"`
+	LicenseName             string            `json:"licenseName"`
+	LicenseSymbol           string            `json:"licenseSymbol"`
+	LicenseURL              string            `json:"licenseURL"`
+	IsCommunityDataset      bool              `json:"isCommunityDataset"`
+	OnMarketplace           bool              `json:"onMarketplace"`
+	Paused                  bool              `json:"paused"` // New subscriptions are paused
+	MarketplaceAssetAddress string            `json:"marketplaceAssetAddress"`
+	PaymentAssetAddress     string            `json:"paymentAssetAddress"`
+	PricePerBlock           uint64            `json:"pricePerBlock"`
+	PaymentAssets           map[string]uint64 `json:"paymentAssets"` // Price per block of every other payment asset
+	Subscriptions           uint64            `json:"subscriptions"`
+	Owner                   string            `json:"owner"`
+}
+
+// Filter selects datasets of the catalog. Zero values match every dataset.
+type Filter struct {
+	// One of the comma separated categories of the dataset, ignoring case
+	Category string `json:"category"`
+	// License symbol of the dataset, ignoring case
+	LicenseSymbol string `json:"licenseSymbol"`
+	Community     *bool  `json:"community"`
+	OnMarketplace *bool  `json:"onMarketplace"`
+	// Only datasets on the marketplace match a payment asset or a price range.
+	// The price range applies to the price in the payment asset if one is
+	// given and to the price in the primary payment asset otherwise.
+	PaymentAssetAddress string `json:"paymentAssetAddress"`
+	MinPrice            uint64 `json:"minPrice"`
+	MaxPrice            uint64 `json:"maxPrice"` // No upper bound if 0
+}
+
+func (f *Filter) match(d *Dataset) bool {
+	if f.Category != "" && !slices.ContainsFunc(strings.Split(d.Categories, ","), func(category string) bool {
+		return strings.EqualFold(strings.TrimSpace(category), f.Category)
+	}) {
+		return false
+	}
+	if f.LicenseSymbol != "" && !strings.EqualFold(d.LicenseSymbol, f.LicenseSymbol) {
+		return false
+	}
+	if f.Community != nil && d.IsCommunityDataset != *f.Community {
+		return false
+	}
+	if f.OnMarketplace != nil && d.OnMarketplace != *f.OnMarketplace {
+		return false
+	}
+	if f.PaymentAssetAddress == "" && f.MinPrice == 0 && f.MaxPrice == 0 {
+		return true
+	}
+	if !d.OnMarketplace {
+		return false
+	}
+	price := d.PricePerBlock
+	if f.PaymentAssetAddress != "" && d.PaymentAssetAddress != f.PaymentAssetAddress {
+		var ok bool
+		if price, ok = d.PaymentAssets[f.PaymentAssetAddress]; !ok {
+			return false
+		}
+	}
+	return price >= f.MinPrice && (f.MaxPrice == 0 || price <= f.MaxPrice)
+}
+
+// Catalog tracks every dataset from the blocks accepted by the node. Datasets
+// are persisted to a database and loaded back when the node restarts.
+// Datasets created before the catalog was enabled, or before a node state
+// synced, are not tracked until they change again.
+type Catalog struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	datasets map[codec.Address]Dataset
+
+	// Datasets by the address of their marketplace asset
+	marketplaces map[codec.Address]codec.Address
+}
+
+// New loads the datasets persisted to [db]
+func New(db database.Database) (*Catalog, error) {
+	c := &Catalog{
+		db:           db,
+		datasets:     make(map[codec.Address]Dataset),
+		marketplaces: make(map[codec.Address]codec.Address),
+	}
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		if len(iter.Key()) != codec.AddressLen {
+			return nil, ErrInvalidEntry
+		}
+		datasetAddress := codec.Address(iter.Key())
+		var dataset Dataset
+		if err := json.Unmarshal(iter.Value(), &dataset); err != nil {
+			return nil, err
+		}
+		marketplaceAssetAddress := codec.EmptyAddress
+		if dataset.OnMarketplace {
+			var err error
+			marketplaceAssetAddress, err = codec.StringToAddress(dataset.MarketplaceAssetAddress)
+			if err != nil {
+				return nil, err
+			}
+		}
+		c.put(datasetAddress, marketplaceAssetAddress, dataset)
+	}
+	return c, iter.Error()
+}
+
+// Put sets [dataset] at [datasetAddress]. [marketplaceAssetAddress] is empty
+// if the dataset is not on the marketplace.
+func (c *Catalog) Put(datasetAddress codec.Address, marketplaceAssetAddress codec.Address, dataset Dataset) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	b, err := json.Marshal(dataset)
+	if err != nil {
+		return err
+	}
+	if err := c.db.Put(datasetAddress[:], b); err != nil {
+		return err
+	}
+	c.removeMarketplace(datasetAddress)
+	c.put(datasetAddress, marketplaceAssetAddress, dataset)
+	return nil
+}
+
+func (c *Catalog) put(datasetAddress codec.Address, marketplaceAssetAddress codec.Address, dataset Dataset) {
+	c.datasets[datasetAddress] = dataset
+	if marketplaceAssetAddress != codec.EmptyAddress {
+		c.marketplaces[marketplaceAssetAddress] = datasetAddress
+	}
+}
+
+// Remove removes the dataset at [datasetAddress]
+func (c *Catalog) Remove(datasetAddress codec.Address) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.datasets[datasetAddress]; !ok {
+		return nil
+	}
+	if err := c.db.Delete(datasetAddress[:]); err != nil {
+		return err
+	}
+	c.removeMarketplace(datasetAddress)
+	delete(c.datasets, datasetAddress)
+	return nil
+}
+
+// removeMarketplace forgets the marketplace asset the dataset at
+// [datasetAddress] was published for, if any
+func (c *Catalog) removeMarketplace(datasetAddress codec.Address) {
+	dataset, ok := c.datasets[datasetAddress]
+	if !ok || !dataset.OnMarketplace {
+		return
+	}
+	// Addresses were stored from valid addresses
+	marketplaceAssetAddress, _ := codec.StringToAddress(dataset.MarketplaceAssetAddress)
+	delete(c.marketplaces, marketplaceAssetAddress)
+}
+
+func (c *Catalog) Close() error {
+	return c.db.Close()
+}
+
+// DatasetOfMarketplace returns the address of the dataset
+// [marketplaceAssetAddress] was published for
+func (c *Catalog) DatasetOfMarketplace(marketplaceAssetAddress codec.Address) (codec.Address, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	datasetAddress, ok := c.marketplaces[marketplaceAssetAddress]
+	return datasetAddress, ok
+}
+
+// Datasets returns at most [limit] datasets matching [filter] ordered by
+// [sortBy] and starting after [cursor]. The returned cursor is empty once
+// there is nothing left.
+func (c *Catalog) Datasets(filter Filter, sortBy string, cursor string, limit int) ([]Dataset, string, error) {
+	if sortBy != SortByAddress && sortBy != SortBySubscriptions {
+		return nil, "", ErrInvalidSort
+	}
+	if limit <= 0 || limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	keys := make([]string, 0, len(c.datasets))
+	byKey := make(map[string]codec.Address, len(c.datasets))
+	for datasetAddress, dataset := range c.datasets {
+		if !filter.match(&dataset) {
+			continue
+		}
+		k := dataset.DatasetAddress
+		if sortBy == SortBySubscriptions {
+			// Zero padded so that the most subscribed datasets sort first
+			k = fmt.Sprintf("%020d%s", math.MaxUint64-dataset.Subscriptions, k)
+		}
+		if k > cursor {
+			keys = append(keys, k)
+			byKey[k] = datasetAddress
+		}
+	}
+	slices.Sort(keys)
+
+	next := ""
+	if len(keys) > limit {
+		keys = keys[:limit]
+		next = keys[limit-1]
+	}
+	page := make([]Dataset, len(keys))
+	for i, k := range keys {
+		page[i] = c.datasets[byKey[k]]
+	}
+	return page, next, nil
+}
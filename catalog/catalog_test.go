@@ -0,0 +1,124 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package catalog
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+)
+
+func TestCatalogFilters(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	c, err := New(db)
+	require.NoError(err)
+	nai := codectest.NewRandomAddress()
+	science := codectest.NewRandomAddress()
+	finance := codectest.NewRandomAddress()
+	private := codectest.NewRandomAddress()
+	marketplace := codectest.NewRandomAddress()
+	usd := codectest.NewRandomAddress()
+
+	require.NoError(c.Put(science, marketplace, Dataset{
+		DatasetAddress:          science.String(),
+		Categories:              "Science, Climate",
+		LicenseSymbol:           "MIT",
+		IsCommunityDataset:      true,
+		OnMarketplace:           true,
+		MarketplaceAssetAddress: marketplace.String(),
+		PaymentAssetAddress:     nai.String(),
+		PricePerBlock:           100,
+		PaymentAssets:           map[string]uint64{usd.String(): 5},
+		Subscriptions:           1,
+	}))
+	require.NoError(c.Put(finance, codec.EmptyAddress, Dataset{
+		DatasetAddress: finance.String(),
+		Categories:     "Finance",
+		LicenseSymbol:  "CC-BY",
+	}))
+	require.NoError(c.Put(private, codec.EmptyAddress, Dataset{
+		DatasetAddress: private.String(),
+		Categories:     "Science",
+		LicenseSymbol:  "MIT",
+	}))
+
+	count := func(filter Filter) int {
+		datasets, _, err := c.Datasets(filter, SortByAddress, "", 0)
+		require.NoError(err)
+		return len(datasets)
+	}
+	yes, no := true, false
+	require.Equal(3, count(Filter{}))
+	require.Equal(2, count(Filter{Category: "science"}))
+	require.Equal(1, count(Filter{Category: "climate"}))
+	require.Equal(2, count(Filter{LicenseSymbol: "mit"}))
+	require.Equal(1, count(Filter{Community: &yes}))
+	require.Equal(2, count(Filter{OnMarketplace: &no}))
+	require.Equal(1, count(Filter{PaymentAssetAddress: nai.String()}))
+	require.Equal(1, count(Filter{MinPrice: 50, MaxPrice: 100}))
+	require.Equal(1, count(Filter{PaymentAssetAddress: usd.String(), MaxPrice: 5}))
+	require.Zero(count(Filter{PaymentAssetAddress: usd.String(), MinPrice: 50}))
+	require.Zero(count(Filter{MinPrice: 101}))
+	require.Zero(count(Filter{MaxPrice: 99}))
+
+	// Datasets are found by their marketplace asset
+	datasetAddress, ok := c.DatasetOfMarketplace(marketplace)
+	require.True(ok)
+	require.Equal(science, datasetAddress)
+
+	// The catalog is loaded back from the database
+	c, err = New(db)
+	require.NoError(err)
+	require.Equal(3, count(Filter{}))
+	require.Equal(1, count(Filter{PaymentAssetAddress: usd.String(), MaxPrice: 5}))
+	datasetAddress, ok = c.DatasetOfMarketplace(marketplace)
+	require.True(ok)
+	require.Equal(science, datasetAddress)
+
+	require.NoError(c.Remove(science))
+	require.Equal(2, count(Filter{}))
+	_, ok = c.DatasetOfMarketplace(marketplace)
+	require.False(ok)
+	c, err = New(db)
+	require.NoError(err)
+	require.Equal(2, count(Filter{}))
+
+	_, _, err = c.Datasets(Filter{}, "name", "", 0)
+	require.ErrorIs(err, ErrInvalidSort)
+}
+
+func TestCatalogSortAndPagination(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	c, err := New(db)
+	require.NoError(err)
+	for i := 0; i < 5; i++ {
+		datasetAddress := codectest.NewRandomAddress()
+		c.Put(datasetAddress, codec.EmptyAddress, Dataset{
+			DatasetAddress: datasetAddress.String(),
+			Subscriptions:  uint64(i),
+		})
+	}
+
+	// Pages follow each other until the cursor is empty
+	subscriptions := []uint64{}
+	cursor := ""
+	for {
+		datasets, next, err := c.Datasets(Filter{}, SortBySubscriptions, cursor, 2)
+		require.NoError(err)
+		for _, dataset := range datasets {
+			subscriptions = append(subscriptions, dataset.Subscriptions)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	require.Equal([]uint64{4, 3, 2, 1, 0}, subscriptions)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/utils"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/event"
+)
+
+const (
+	Namespace = "catalog"
+)
+
+var _ event.SubscriptionFactory[*chain.ExecutedBlock] = (*CatalogSubscriptionFactory)(nil)
+
+type CatalogSubscriptionFactory struct {
+	log       logging.Logger
+	catalog   *Catalog
+	readState storage.ReadState
+}
+
+func (c *CatalogSubscriptionFactory) New() (event.Subscription[*chain.ExecutedBlock], error) {
+	return c, nil
+}
+
+// Accept reads back every dataset the transactions of [blk] may have changed,
+// including the ones whose marketplace asset got new subscriptions
+func (c *CatalogSubscriptionFactory) Accept(blk *chain.ExecutedBlock) error {
+	ctx := context.Background()
+	stateManager := &storage.StateManager{}
+	datasets := make(map[codec.Address]struct{})
+	for i, tx := range blk.Block.Txs {
+		if !blk.Results[i].Success {
+			continue
+		}
+		stateKeys, err := tx.StateKeys(stateManager)
+		if err != nil {
+			c.log.Warn("failed to update catalog", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			continue
+		}
+		for k := range stateKeys {
+			if datasetAddress, ok := storage.ParseDatasetInfoKey([]byte(k)); ok {
+				datasets[datasetAddress] = struct{}{}
+				continue
+			}
+			if assetAddress, ok := storage.ParseAssetInfoKey([]byte(k)); ok {
+				if datasetAddress, ok := c.catalog.DatasetOfMarketplace(assetAddress); ok {
+					datasets[datasetAddress] = struct{}{}
+				}
+			}
+		}
+	}
+	for datasetAddress := range datasets {
+		// The catalog is best effort and must never halt the chain
+		if err := c.acceptDataset(ctx, datasetAddress); err != nil {
+			c.log.Warn("failed to update catalog", zap.Stringer("datasetAddress", datasetAddress), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (c *CatalogSubscriptionFactory) acceptDataset(ctx context.Context, datasetAddress codec.Address) error {
+	name, description, categories, licenseName, licenseSymbol, licenseURL, _, isCommunityDataset, marketplaceAssetAddress, baseAssetAddress, basePrice, _, _, _, _, owner, err := storage.GetDatasetInfoFromState(ctx, c.readState, datasetAddress)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return c.catalog.Remove(datasetAddress)
+	case err != nil:
+		return err
+	}
+	dataset := Dataset{
+		DatasetAddress:     datasetAddress.String(),
+		Name:               string(name),
+		Description:        string(description),
+		Categories:         string(categories),
+		LicenseName:        string(licenseName),
+		LicenseSymbol:      string(licenseSymbol),
+		LicenseURL:         string(licenseURL),
+		IsCommunityDataset: isCommunityDataset,
+		Owner:              owner.String(),
+	}
+	if marketplaceAssetAddress != codec.EmptyAddress {
+		// The marketplace asset keeps count of the subscriptions in its metadata
+		_, _, _, _, metadata, _, _, _, _, _, _, _, _, err := storage.GetAssetInfoFromState(ctx, c.readState, marketplaceAssetAddress)
+		if err != nil {
+			return err
+		}
+		metadataMap, err := utils.BytesToMap(metadata)
+		if err != nil {
+			return err
+		}
+		subscriptions, err := strconv.ParseUint(metadataMap["subscriptions"], 10, 64)
+		if err != nil {
+			return err
+		}
+		paymentAssets, err := storage.GetMarketplacePaymentAssetsFromState(ctx, c.readState, marketplaceAssetAddress)
+		if err != nil {
+			return err
+		}
+		dataset.PaymentAssets = make(map[string]uint64, len(paymentAssets))
+		for _, paymentAsset := range paymentAssets {
+			if !paymentAsset.Accepted || paymentAsset.Address == baseAssetAddress {
+				continue
+			}
+			price, _, _, _, err := storage.GetMarketplacePaymentFromState(ctx, c.readState, marketplaceAssetAddress, paymentAsset.Address)
+			if err != nil {
+				return err
+			}
+			dataset.PaymentAssets[paymentAsset.Address.String()] = price
+		}
+		dataset.OnMarketplace = true
+		dataset.Paused = metadataMap["status"] == storage.MarketplaceStatusPaused
+		dataset.MarketplaceAssetAddress = marketplaceAssetAddress.String()
+		dataset.PaymentAssetAddress = baseAssetAddress.String()
+		dataset.PricePerBlock = basePrice
+		dataset.Subscriptions = subscriptions
+	}
+	return c.catalog.Put(datasetAddress, marketplaceAssetAddress, dataset)
+}
+
+func (c *CatalogSubscriptionFactory) Close() error {
+	return c.catalog.Close()
+}
+
+func NewCatalogSubscriptionFactory(log logging.Logger, catalog *Catalog, readState storage.ReadState) event.SubscriptionFactory[*chain.ExecutedBlock] {
+	return &CatalogSubscriptionFactory{
+		log:       log,
+		catalog:   catalog,
+		readState: readState,
+	}
+}
@@ -6,14 +6,19 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/near/borsh-go"
+	"github.com/nuklai/nuklaivm/abi"
 	"github.com/nuklai/nuklaivm/actions"
 	"github.com/nuklai/nuklaivm/consts"
 	"github.com/nuklai/nuklaivm/storage"
@@ -28,12 +33,16 @@ import (
 	"github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/utils"
 
+	smath "github.com/ava-labs/avalanchego/utils/math"
 	hcli "github.com/ava-labs/hypersdk/cli"
 	hconsts "github.com/ava-labs/hypersdk/consts"
 	nutils "github.com/nuklai/nuklaivm/utils"
 )
 
-var errUnexpectedSimulateActionsOutput = errors.New("returned output from SimulateActions was not actions.Result")
+var (
+	errUnexpectedSimulateActionsOutput = errors.New("returned output from SimulateActions was not actions.Result")
+	errUnexpectedContractCallOutput    = errors.New("returned output was not actions.ContractCallResult")
+)
 
 var actionCmd = &cobra.Command{
 	Use: "action",
@@ -56,6 +65,10 @@ var transferCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		collectionAddress, _, err := getAssetCollection(ctx, ncli, assetAddress)
+		if err != nil {
+			return err
+		}
 
 		// Get balance info
 		balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, assetAddress, true, false, -1)
@@ -82,11 +95,7 @@ var transferCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		result, txID, err := sendAndWait(ctx, []chain.Action{&actions.Transfer{
-			AssetAddress: assetAddress,
-			To:           recipient,
-			Value:        amount,
-		}}, cli, ncli, ws, factory)
+		result, txID, err := sendAndWait(ctx, []chain.Action{transferAction(recipient, assetAddress, collectionAddress, amount)}, cli, ncli, ws, factory)
 		if err != nil {
 			return err
 		}
@@ -95,6 +104,147 @@ var transferCmd = &cobra.Command{
 	},
 }
 
+var batchTransferCmd = &cobra.Command{
+	Use: "batch-transfer [csv file]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Get assetAddress
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+		collectionAddress, _, err := getAssetCollection(ctx, ncli, assetAddress)
+		if err != nil {
+			return err
+		}
+		if collectionAddress != codec.EmptyAddress {
+			return actions.ErrNFTTransferInvalid
+		}
+
+		// Get balance info
+		balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, assetAddress, true, false, -1)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Read recipients
+		transfers, err := readBatchTransferCSV(args[0], decimals)
+		if err != nil {
+			return err
+		}
+		var total uint64
+		for _, transfer := range transfers {
+			utils.Outf("{{blue}}%s{{/}} -> %s\n", nutils.FormatBalance(transfer.Value, decimals), transfer.To)
+			if total, err = smath.Add(total, transfer.Value); err != nil {
+				return err
+			}
+		}
+		utils.Outf("{{yellow}}total:{{/}} %s to %d recipients\n", nutils.FormatBalance(total, decimals), len(transfers))
+		if total > balance {
+			return prompt.ErrInsufficientBalance
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate one transaction per batch of recipients
+		for start := 0; start < len(transfers); start += actions.MaxBatchTransferRecipients {
+			end := min(start+actions.MaxBatchTransferRecipients, len(transfers))
+			result, txID, err := sendAndWait(ctx, []chain.Action{&actions.BatchTransfer{
+				AssetAddress: assetAddress,
+				Transfers:    transfers[start:end],
+			}}, cli, ncli, ws, factory)
+			if err != nil {
+				return err
+			}
+			utils.Outf("{{yellow}}txID:{{/}} %s\n", txID)
+			if err := processResult(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// readBatchTransferCSV reads the recipients of a batch transfer from a CSV
+// file with a "recipient,amount[,memo]" row per transfer. The amount is
+// expressed with [decimals] like in the prompts and a header row is skipped.
+// transferAction returns a [actions.TransferNFT] when [collectionAddress] is
+// set and a [actions.Transfer] otherwise
+func transferAction(to codec.Address, assetAddress codec.Address, collectionAddress codec.Address, amount uint64) chain.Action {
+	if collectionAddress != codec.EmptyAddress {
+		return &actions.TransferNFT{
+			To:                to,
+			AssetAddress:      assetAddress,
+			CollectionAddress: collectionAddress,
+		}
+	}
+	return &actions.Transfer{
+		To:           to,
+		AssetAddress: assetAddress,
+		Value:        amount,
+	}
+}
+
+func readBatchTransferCSV(path string, decimals uint8) ([]actions.BatchTransferEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && len(records[0]) > 0 && strings.EqualFold(records[0][0], "recipient") {
+		records = records[1:]
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s has no recipients", path)
+	}
+
+	transfers := make([]actions.BatchTransferEntry, len(records))
+	for i, record := range records {
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("line %d: expected recipient,amount[,memo]", i+1)
+		}
+		to, err := codec.StringToAddress(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		value, err := nutils.ParseBalance(record[1], decimals)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		transfers[i] = actions.BatchTransferEntry{
+			To:    to,
+			Value: value,
+		}
+		if len(record) == 3 {
+			transfers[i].Memo = record[2]
+		}
+	}
+	return transfers, nil
+}
+
 var publishFileCmd = &cobra.Command{
 	Use: "publishFile",
 	RunE: func(*cobra.Command, []string) error {
@@ -114,6 +264,22 @@ var publishFileCmd = &cobra.Command{
 			return err
 		}
 
+		// Select optional ABI describing the contract functions
+		abiPath, err := prompt.String("abi file (leave empty to skip)", 0, 1000)
+		if err != nil {
+			return err
+		}
+		var contractABI []byte
+		if len(abiPath) > 0 {
+			contractABI, err = os.ReadFile(abiPath)
+			if err != nil {
+				return err
+			}
+			if _, err := abi.Parse(contractABI); err != nil {
+				return err
+			}
+		}
+
 		// Confirm action
 		cont, err := prompt.Continue()
 		if !cont || err != nil {
@@ -123,6 +289,7 @@ var publishFileCmd = &cobra.Command{
 		// Generate transaction
 		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ContractPublish{
 			ContractBytes: bytes,
+			ABI:           contractABI,
 		}}, cli, bcli, ws, factory)
 
 		if result != nil && result.Success {
@@ -138,7 +305,7 @@ var deployCmd = &cobra.Command{
 	Use: "deploy",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		_, _, factory, cli, bcli, ws, err := handler.DefaultActor()
+		_, priv, factory, cli, bcli, ws, err := handler.DefaultActor()
 		if err != nil {
 			return err
 		}
@@ -153,6 +320,16 @@ var deployCmd = &cobra.Command{
 			return err
 		}
 
+		// Keep the ability to upgrade the contract later on
+		upgradeable, err := prompt.Bool("upgradeable")
+		if err != nil {
+			return err
+		}
+		upgradeAuthority := codec.EmptyAddress
+		if upgradeable {
+			upgradeAuthority = priv.Address
+		}
+
 		// Confirm action
 		cont, err := prompt.Continue()
 		if !cont || err != nil {
@@ -161,8 +338,9 @@ var deployCmd = &cobra.Command{
 
 		// Generate transaction
 		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ContractDeploy{
-			ContractID:   contractID,
-			CreationInfo: creationInfo,
+			ContractID:       contractID,
+			CreationInfo:     creationInfo,
+			UpgradeAuthority: upgradeAuthority,
 		}}, cli, bcli, ws, factory)
 		if err != nil {
 			return err
@@ -171,6 +349,127 @@ var deployCmd = &cobra.Command{
 	},
 }
 
+var upgradeCmd = &cobra.Command{
+	Use: "upgrade",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, bcli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contract
+		contractAddress, err := prompt.Address("contract address")
+		if err != nil {
+			return err
+		}
+
+		// Get contract info
+		_, upgradeAuthority, version, _, err := handler.GetContractInfo(ctx, bcli, contractAddress)
+		if err != nil {
+			return err
+		}
+		if upgradeAuthority != priv.Address.String() {
+			utils.Outf("{{red}}%s is not the upgrade authority of contract %s{{/}}\n", priv.Address, contractAddress)
+			return nil
+		}
+
+		// Contract IDs are shown in hex by the contract RPC
+		contractIDHex, err := prompt.String("new contract id in hex", 2, 80)
+		if err != nil {
+			return err
+		}
+		contractID, err := hex.DecodeString(contractIDHex)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ContractUpgrade{
+			ContractAddress: contractAddress,
+			ContractID:      contractID,
+			Version:         version + 1,
+		}}, cli, bcli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var renounceUpgradeCmd = &cobra.Command{
+	Use: "renounce-upgrade",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, bcli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contract
+		contractAddress, err := prompt.Address("contract address")
+		if err != nil {
+			return err
+		}
+
+		// Get contract info
+		_, upgradeAuthority, _, _, err := handler.GetContractInfo(ctx, bcli, contractAddress)
+		if err != nil {
+			return err
+		}
+		if upgradeAuthority != priv.Address.String() {
+			utils.Outf("{{red}}%s is not the upgrade authority of contract %s{{/}}\n", priv.Address, contractAddress)
+			return nil
+		}
+		utils.Outf("{{yellow}}contract %s won't be upgradeable anymore{{/}}\n", contractAddress)
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ContractRenounceUpgrade{
+			ContractAddress: contractAddress,
+		}}, cli, bcli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var contractInfoCmd = &cobra.Command{
+	Use: "contract-info",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select contract
+		contractAddress, err := prompt.Address("contract address")
+		if err != nil {
+			return err
+		}
+
+		// Get contract info
+		_, _, _, _, err = handler.GetContractInfo(ctx, ncli, contractAddress)
+		return err
+	},
+}
+
 var callCmd = &cobra.Command{
 	Use: "call",
 	RunE: func(*cobra.Command, []string) error {
@@ -204,10 +503,34 @@ var callCmd = &cobra.Command{
 			return err
 		}
 
+		// Encode the arguments when the contract was published with an ABI
+		var abiFunction *abi.Function
+		contractABI, err := bcli.ContractABI(ctx, contractAddress.String())
+		if err != nil {
+			utils.Outf("{{yellow}}no ABI found for the contract, calling without arguments{{/}}\n")
+		} else {
+			abiFunction, err = contractABI.Function(function)
+			if err != nil {
+				return err
+			}
+		}
+		var callData []byte
+		if abiFunction != nil && len(abiFunction.Args) > 0 {
+			args, err := prompt.String("arguments (JSON)", 1, actions.MaxCallDataSize)
+			if err != nil {
+				return err
+			}
+			callData, err = abiFunction.EncodeArgs(json.RawMessage(args))
+			if err != nil {
+				return err
+			}
+		}
+
 		action := &actions.ContractCall{
 			ContractAddress: contractAddress,
 			Value:           amount,
 			Function:        function,
+			CallData:        callData,
 			Fuel:            uint64(1000000000),
 		}
 
@@ -246,6 +569,22 @@ var callCmd = &cobra.Command{
 		// Generate transaction
 		result, _, err := sendAndWait(ctx, []chain.Action{action}, cli, bcli, ws, factory)
 
+		if result != nil && result.Success && abiFunction != nil {
+			output, err := (*vm.OutputParser).Unmarshal(codec.NewReader(result.Outputs[0], len(result.Outputs[0])))
+			if err != nil {
+				return err
+			}
+			callResult, ok := output.(*actions.ContractCallResult)
+			if !ok {
+				return errUnexpectedContractCallOutput
+			}
+			value, err := abiFunction.DecodeResult(callResult.Value)
+			if err != nil {
+				return err
+			}
+			utils.Outf("{{green}}output:{{/}} %s\n", value)
+			return nil
+		}
 		if result != nil && result.Success {
 			utils.Outf(hexutils.BytesToHex(result.Outputs[0]) + "\n")
 			switch function {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/utils"
 )
@@ -75,6 +76,29 @@ var createAssetCmd = &cobra.Command{
 		// Add owner
 		owner := priv.Address
 
+		// Add royalty to collections
+		royaltyBasisPoints, royaltyRecipient, royaltyAssetAddress := 0, codec.EmptyAddress, codec.EmptyAddress
+		if assetType != 0 {
+			withRoyalty, err := prompt.Bool("royalty")
+			if err != nil {
+				return err
+			}
+			if withRoyalty {
+				royaltyBasisPoints, err = prompt.Int("royalty basis points", storage.MaxRoyaltyBasisPoints)
+				if err != nil {
+					return err
+				}
+				royaltyRecipient, err = prompt.Address("royalty recipient")
+				if err != nil {
+					return err
+				}
+				royaltyAssetAddress, err = parseAsset("royalty assetAddress")
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		// Confirm action
 		cont, err := prompt.Continue()
 		if !cont || err != nil {
@@ -92,6 +116,9 @@ var createAssetCmd = &cobra.Command{
 			PauseUnpauseAdmin:            owner,
 			FreezeUnfreezeAdmin:          owner,
 			EnableDisableKYCAccountAdmin: owner,
+			RoyaltyBasisPoints:           uint64(royaltyBasisPoints),
+			RoyaltyRecipient:             royaltyRecipient,
+			RoyaltyAssetAddress:          royaltyAssetAddress,
 		}}, cli, ncli, ws, factory)
 		if err != nil {
 			return err
@@ -164,7 +191,11 @@ var mintAssetFTCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if mintAdmin != priv.Address.String() {
+		minter, err := canMint(ctx, ncli, assetAddress, mintAdmin, priv.Address)
+		if err != nil {
+			return err
+		}
+		if !minter {
 			utils.Outf("{{red}}%s has permission to mint asset '%s' with assetID '%s', you are not{{/}}\n", mintAdmin, name, assetAddress)
 			utils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
@@ -236,7 +267,11 @@ var mintAssetNFTCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if mintAdmin != priv.Address.String() {
+		minter, err := canMint(ctx, ncli, assetAddress, mintAdmin, priv.Address)
+		if err != nil {
+			return err
+		}
+		if !minter {
 			utils.Outf("{{red}}%s has permission to mint asset '%s' with assetID '%s', you are not{{/}}\n", mintAdmin, name, assetAddress)
 			utils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
@@ -393,3 +428,342 @@ var burnAssetNFTCmd = &cobra.Command{
 		return processResult(result)
 	},
 }
+
+var fractionalizeNFTCmd = &cobra.Command{
+	Use: "fractionalize-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select nft ID to fractionalize
+		nftAddress, err := prompt.Address("nftAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, _, _, uri, _, err := handler.GetAssetNFTInfo(ctx, ncli, codec.EmptyAddress, nftAddress, false)
+		if err != nil {
+			return err
+		}
+		assetAddress, err := codec.StringToAddress(uri)
+		if err != nil {
+			return err
+		}
+
+		// Select number of shares
+		shares, err := prompt.Int("shares", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.FractionalizeNFT{
+			AssetAddress:    assetAddress,
+			AssetNftAddress: nftAddress,
+			Shares:          uint64(shares),
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		if err := processResult(result); err != nil || !result.Success {
+			return err
+		}
+		utils.Outf("{{green}}shares assetAddress:{{/}} %s\n", storage.AssetAddressFractional(nftAddress))
+		return nil
+	},
+}
+
+var redeemNFTCmd = &cobra.Command{
+	Use: "redeem-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select nft ID to redeem
+		nftAddress, err := prompt.Address("nftAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, _, _, uri, _, err := handler.GetAssetNFTInfo(ctx, ncli, codec.EmptyAddress, nftAddress, false)
+		if err != nil {
+			return err
+		}
+		assetAddress, err := codec.StringToAddress(uri)
+		if err != nil {
+			return err
+		}
+
+		// Every share must be held by the actor
+		shares, _, _, _, _, _, totalSupply, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, storage.AssetAddressFractional(nftAddress), true, false, -1)
+		if err != nil {
+			return err
+		}
+		if shares == 0 || shares != totalSupply {
+			utils.Outf("{{red}}%d of %d shares are held by %s{{/}}\n", shares, totalSupply, priv.Address)
+			return nil
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.RedeemNFT{
+			AssetAddress:    assetAddress,
+			AssetNftAddress: nftAddress,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var syncNFTOwnerCmd = &cobra.Command{
+	Use: "sync-nft-owner",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select nft ID to update
+		nftAddress, err := prompt.Address("nftAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, _, _, uri, owner, err := handler.GetAssetNFTInfo(ctx, ncli, codec.EmptyAddress, nftAddress, false)
+		if err != nil {
+			return err
+		}
+		assetAddress, err := codec.StringToAddress(uri)
+		if err != nil {
+			return err
+		}
+
+		// Select the account that holds the NFT
+		holder, err := prompt.Address("holder")
+		if err != nil {
+			return err
+		}
+		if holder.String() == owner {
+			utils.Outf("{{yellow}}owner is already up to date{{/}}\n")
+			return nil
+		}
+		balance, err := ncli.Balance(ctx, holder.String(), nftAddress.String())
+		if err != nil {
+			return err
+		}
+		if balance != 1 {
+			utils.Outf("{{red}}%s does not hold this NFT{{/}}\n", holder)
+			return nil
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.SyncNFTOwner{
+			AssetAddress:    assetAddress,
+			AssetNftAddress: nftAddress,
+			Owner:           holder,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var approveAssetCmd = &cobra.Command{
+	Use: "approve",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select asset to approve
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, assetAddress, true, false, -1)
+		if err != nil {
+			return err
+		}
+
+		// Select spender
+		spender, err := prompt.Address("spender")
+		if err != nil {
+			return err
+		}
+		if _, _, err := handler.GetAllowanceInfo(ctx, ncli, assetAddress, priv.Address, spender); err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := parseAmount("allowance (0 to revoke)", decimals, consts.MaxUint64)
+		if err != nil {
+			return err
+		}
+
+		// Select expiry block
+		var expiryBlock uint64
+		if amount > 0 {
+			expires, err := prompt.Bool("expires")
+			if err != nil {
+				return err
+			}
+			if expires {
+				currentBlockHeight, _, _, _, _, _, _, err := ncli.EmissionInfo(ctx)
+				if err != nil {
+					return err
+				}
+				rawExpiryBlock, err := prompt.Int(
+					fmt.Sprintf("expiry block(must be after %d)", currentBlockHeight),
+					consts.MaxInt,
+				)
+				if err != nil {
+					return err
+				}
+				expiryBlock = uint64(rawExpiryBlock)
+			}
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ApproveAsset{
+			AssetAddress: assetAddress,
+			Spender:      spender,
+			Amount:       amount,
+			ExpiryBlock:  expiryBlock,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var transferAssetFromCmd = &cobra.Command{
+	Use: "transfer-from",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select asset and owner
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+		collectionAddress, _, err := getAssetCollection(ctx, ncli, assetAddress)
+		if err != nil {
+			return err
+		}
+		owner, err := prompt.Address("owner")
+		if err != nil {
+			return err
+		}
+		balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, owner, assetAddress, true, false, -1)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Get allowance info
+		allowance, _, err := handler.GetAllowanceInfo(ctx, ncli, assetAddress, owner, priv.Address)
+		if allowance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := prompt.Address("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := parseAmount("amount", decimals, min(balance, allowance))
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, txID, err := sendAndWait(ctx, []chain.Action{&actions.TransferAssetFrom{
+			From:              owner,
+			To:                recipient,
+			AssetAddress:      assetAddress,
+			Value:             amount,
+			CollectionAddress: collectionAddress,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}txID:{{/}} %s\n", txID)
+		return processResult(result)
+	},
+}
+
+var allowanceAssetCmd = &cobra.Command{
+	Use: "allowance",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select asset, owner and spender
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+		owner, err := prompt.Address("owner")
+		if err != nil {
+			return err
+		}
+		spender, err := prompt.Address("spender")
+		if err != nil {
+			return err
+		}
+
+		// Get allowance info
+		_, _, err = handler.GetAllowanceInfo(ctx, ncli, assetAddress, owner, spender)
+		return err
+	},
+}
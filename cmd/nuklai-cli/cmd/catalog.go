@@ -0,0 +1,139 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"math"
+
+	"github.com/nuklai/nuklaivm/catalog"
+	"github.com/nuklai/nuklaivm/consts"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nutils "github.com/nuklai/nuklaivm/utils"
+)
+
+var listDatasetCmd = &cobra.Command{
+	Use: "list",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Filter the catalog
+		var filter catalog.Filter
+		filter.Category, err = prompt.String("category (leave empty for any)", 0, 256)
+		if err != nil {
+			return err
+		}
+		filter.LicenseSymbol, err = prompt.String("license symbol (leave empty for any)", 0, 256)
+		if err != nil {
+			return err
+		}
+		filter.Community, err = promptOptionalBool("community dataset")
+		if err != nil {
+			return err
+		}
+		filter.OnMarketplace, err = promptOptionalBool("on marketplace")
+		if err != nil {
+			return err
+		}
+		if filter.OnMarketplace == nil || *filter.OnMarketplace {
+			paymentAsset, err := prompt.String("payment asset (leave empty for any)", 0, 256)
+			if err != nil {
+				return err
+			}
+			if len(paymentAsset) > 0 {
+				paymentAssetAddress, err := nutils.GetAssetAddressBySymbol(paymentAsset)
+				if err != nil {
+					return err
+				}
+				filter.PaymentAssetAddress = paymentAssetAddress.String()
+			}
+			filter.MinPrice, err = parseAmount("min price per block", consts.Decimals, math.MaxUint64)
+			if err != nil {
+				return err
+			}
+			filter.MaxPrice, err = parseAmount("max price per block (0 for no limit)", consts.Decimals, math.MaxUint64)
+			if err != nil {
+				return err
+			}
+		}
+		sortBy := catalog.SortByAddress
+		bySubscriptions, err := prompt.Bool("sort by subscriptions")
+		if err != nil {
+			return err
+		}
+		if bySubscriptions {
+			sortBy = catalog.SortBySubscriptions
+		}
+
+		// List every page of the catalog
+		count, cursor := 0, ""
+		for {
+			datasets, next, err := ncli.DatasetCatalog(ctx, filter, sortBy, cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, dataset := range datasets {
+				utils.Outf(
+					"%d) {{cyan}}datasetAddress:{{/}} %s {{cyan}}name:{{/}} %s {{cyan}}categories:{{/}} %s {{cyan}}license:{{/}} %s {{cyan}}community:{{/}} %t {{cyan}}owner:{{/}} %s\n",
+					count,
+					dataset.DatasetAddress,
+					dataset.Name,
+					dataset.Categories,
+					dataset.LicenseSymbol,
+					dataset.IsCommunityDataset,
+					dataset.Owner,
+				)
+				if dataset.OnMarketplace {
+					utils.Outf(
+						"   {{cyan}}marketplaceAssetAddress:{{/}} %s {{cyan}}paymentAssetAddress:{{/}} %s {{cyan}}pricePerBlock:{{/}} %s {{cyan}}subscriptions:{{/}} %d {{cyan}}paused:{{/}} %t\n",
+						dataset.MarketplaceAssetAddress,
+						dataset.PaymentAssetAddress,
+						nutils.FormatBalance(dataset.PricePerBlock, consts.Decimals),
+						dataset.Subscriptions,
+						dataset.Paused,
+					)
+					for paymentAssetAddress, pricePerBlock := range dataset.PaymentAssets {
+						utils.Outf(
+							"   {{cyan}}paymentAssetAddress:{{/}} %s {{cyan}}pricePerBlock:{{/}} %s\n",
+							paymentAssetAddress,
+							nutils.FormatBalance(pricePerBlock, consts.Decimals),
+						)
+					}
+				}
+				count++
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if count == 0 {
+			utils.Outf("{{yellow}}no tracked datasets match{{/}}\n")
+		}
+		return nil
+	},
+}
+
+// promptOptionalBool asks for yes, no or any and returns nil for any
+func promptOptionalBool(label string) (*bool, error) {
+	utils.Outf("{{cyan}}%s:{{/}} 0) any 1) yes 2) no\n", label)
+	choice, err := prompt.Choice(label, 3)
+	if err != nil {
+		return nil, err
+	}
+	if choice == 0 {
+		return nil, nil
+	}
+	value := choice == 1
+	return &value, nil
+}
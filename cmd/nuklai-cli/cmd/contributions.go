@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/nuklai/nuklaivm/contributions"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var contributionsDatasetCmd = &cobra.Command{
+	Use: "contributions",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		datasetAddress, err := prompt.Address("datasetAddress")
+		if err != nil {
+			return err
+		}
+		pendingOnly, err := prompt.Bool("pending only")
+		if err != nil {
+			return err
+		}
+
+		// List every page of the contributions to the dataset
+		return listContributions(datasetAddress, func(cursor string) ([]contributions.Contribution, string, error) {
+			return ncli.DatasetContributions(ctx, datasetAddress.String(), pendingOnly, cursor, 0)
+		})
+	},
+}
+
+var contributionsOfDatasetCmd = &cobra.Command{
+	Use: "contributions-of [address]",
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var (
+			addr codec.Address
+			err  error
+		)
+		if len(args) != 1 {
+			addr, _, err = handler.h.GetDefaultKey(true)
+		} else {
+			addr, err = codec.StringToAddress(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// List every page of the contributions of the address
+		return listContributions(addr, func(cursor string) ([]contributions.Contribution, string, error) {
+			return ncli.ContributionsByContributor(ctx, addr.String(), false, cursor, 0)
+		})
+	},
+}
+
+// listContributions prints every page of contributions returned by [page]
+// for [address]
+func listContributions(address codec.Address, page func(cursor string) ([]contributions.Contribution, string, error)) error {
+	count, cursor := 0, ""
+	for {
+		entries, next, err := page(cursor)
+		if err != nil {
+			return err
+		}
+		for _, contribution := range entries {
+			status := "pending"
+			if contribution.Active {
+				status = "active"
+			}
+			utils.Outf(
+				"%d) {{cyan}}contributionID:{{/}} %s {{cyan}}datasetAddress:{{/}} %s {{cyan}}contributor:{{/}} %s {{cyan}}dataLocation:{{/}} %s {{cyan}}dataIdentifier:{{/}} %s {{cyan}}status:{{/}} %s {{cyan}}initiatedAt:{{/}} %d\n",
+				count,
+				contribution.ContributionID,
+				contribution.DatasetAddress,
+				contribution.Contributor,
+				contribution.DataLocation,
+				contribution.DataIdentifier,
+				status,
+				contribution.InitiatedAt,
+			)
+			count++
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if count == 0 {
+		utils.Outf("{{yellow}}%s has no tracked contributions{{/}}\n", address)
+	}
+	return nil
+}
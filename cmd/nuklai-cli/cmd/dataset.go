@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
 
 	hutils "github.com/ava-labs/hypersdk/utils"
 )
@@ -190,6 +191,12 @@ var initiateContributeDatasetCmd = &cobra.Command{
 			return err
 		}
 
+		// Commit to the data so the contribution can be challenged
+		dataRoot, dataChunks, err := promptDataCommitment()
+		if err != nil {
+			return err
+		}
+
 		// Confirm action
 		cont, err := prompt.Continue()
 		if !cont || err != nil {
@@ -201,6 +208,8 @@ var initiateContributeDatasetCmd = &cobra.Command{
 			DatasetAddress: datasetAddress,
 			DataLocation:   "default",
 			DataIdentifier: dataIdentifier,
+			DataRoot:       dataRoot,
+			DataChunks:     dataChunks,
 		}}, cli, ncli, ws, factory)
 		if err != nil {
 			return err
@@ -229,7 +238,7 @@ var getDataContributionPendingCmd = &cobra.Command{
 
 		// Get pending data contributions info
 		hutils.Outf("Retrieving pending data contributions info for datasetID: %s\n", contributionID)
-		_, _, _, _, _, err = handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		_, _, _, _, _, _, err = handler.GetDataContributionInfo(ctx, ncli, contributionID)
 		if err != nil {
 			return err
 		}
@@ -282,3 +291,97 @@ var completeContributeDatasetCmd = &cobra.Command{
 		return processResult(result)
 	},
 }
+
+var rejectContributeDatasetCmd = &cobra.Command{
+	Use: "reject-contribute",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contribution ID
+		contributionID, err := prompt.ID("contributionID")
+		if err != nil {
+			return err
+		}
+		datasetAddress, _, _, contributor, _, _, err := handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		if err != nil {
+			return err
+		}
+		datasetAddr, err := codec.StringToAddress(datasetAddress)
+		if err != nil {
+			return err
+		}
+		contributorAddr, err := codec.StringToAddress(contributor)
+		if err != nil {
+			return err
+		}
+
+		// Slash the collateral or refund it
+		slashCollateral, err := prompt.Bool("slash collateral")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.RejectContributeDataset{
+			DatasetContributionID: contributionID.String(),
+			DatasetAddress:        datasetAddr,
+			DatasetContributor:    contributorAddr,
+			SlashCollateral:       slashCollateral,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var cancelContributeDatasetCmd = &cobra.Command{
+	Use: "cancel-contribute",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contribution ID
+		contributionID, err := prompt.ID("contributionID")
+		if err != nil {
+			return err
+		}
+		datasetAddress, _, _, _, _, _, err := handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		if err != nil {
+			return err
+		}
+		datasetAddr, err := codec.StringToAddress(datasetAddress)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.CancelContributeDataset{
+			DatasetContributionID: contributionID.String(),
+			DatasetAddress:        datasetAddr,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
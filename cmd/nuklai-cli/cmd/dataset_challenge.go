@@ -0,0 +1,269 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/dataset"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var challengeContributeDatasetCmd = &cobra.Command{
+	Use: "challenge-contribute",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contribution ID
+		contributionID, err := prompt.ID("contributionID")
+		if err != nil {
+			return err
+		}
+		datasetAddress, _, _, _, _, _, err := handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		if err != nil {
+			return err
+		}
+		datasetAddr, err := codec.StringToAddress(datasetAddress)
+		if err != nil {
+			return err
+		}
+		challenge, err := ncli.DatasetChallenge(ctx, contributionID.String())
+		if err != nil {
+			return err
+		}
+		printDatasetChallenge(challenge)
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ChallengeDatasetContribution{
+			DatasetContributionID: contributionID.String(),
+			DatasetAddress:        datasetAddr,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var respondChallengeDatasetCmd = &cobra.Command{
+	Use: "respond-challenge",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contribution ID
+		contributionID, err := prompt.ID("contributionID")
+		if err != nil {
+			return err
+		}
+		datasetAddress, _, _, _, _, _, err := handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		if err != nil {
+			return err
+		}
+		datasetAddr, err := codec.StringToAddress(datasetAddress)
+		if err != nil {
+			return err
+		}
+		challenge, err := ncli.DatasetChallenge(ctx, contributionID.String())
+		if err != nil {
+			return err
+		}
+		printDatasetChallenge(challenge)
+		if !challenge.Open {
+			return actions.ErrDataChallengeNotFound
+		}
+
+		// Prove the challenged chunk from the contributed data
+		path, err := prompt.String("data file", 1, 1024)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		chunks := dataset.SplitChunks(data)
+		if uint64(len(chunks)) != challenge.DataChunks || challenge.ChallengedChunk >= challenge.DataChunks {
+			return actions.ErrDataRootInvalid
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.RespondDatasetChallenge{
+			DatasetContributionID: contributionID.String(),
+			DatasetAddress:        datasetAddr,
+			Chunk:                 chunks[challenge.ChallengedChunk],
+			Proof:                 dataset.MerkleProof(chunks, challenge.ChallengedChunk),
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var claimChallengeDatasetCmd = &cobra.Command{
+	Use: "claim-challenge",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contribution ID
+		contributionID, err := prompt.ID("contributionID")
+		if err != nil {
+			return err
+		}
+		datasetAddress, _, _, contributor, _, _, err := handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		if err != nil {
+			return err
+		}
+		datasetAddr, err := codec.StringToAddress(datasetAddress)
+		if err != nil {
+			return err
+		}
+		contributorAddr, err := codec.StringToAddress(contributor)
+		if err != nil {
+			return err
+		}
+		challenge, err := ncli.DatasetChallenge(ctx, contributionID.String())
+		if err != nil {
+			return err
+		}
+		printDatasetChallenge(challenge)
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ClaimDatasetChallenge{
+			DatasetContributionID: contributionID.String(),
+			DatasetAddress:        datasetAddr,
+			DatasetContributor:    contributorAddr,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var releaseCollateralDatasetCmd = &cobra.Command{
+	Use: "release-collateral",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select contribution ID
+		contributionID, err := prompt.ID("contributionID")
+		if err != nil {
+			return err
+		}
+		datasetAddress, _, _, _, _, _, err := handler.GetDataContributionInfo(ctx, ncli, contributionID)
+		if err != nil {
+			return err
+		}
+		datasetAddr, err := codec.StringToAddress(datasetAddress)
+		if err != nil {
+			return err
+		}
+		challenge, err := ncli.DatasetChallenge(ctx, contributionID.String())
+		if err != nil {
+			return err
+		}
+		printDatasetChallenge(challenge)
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ReleaseContributionCollateral{
+			DatasetContributionID: contributionID.String(),
+			DatasetAddress:        datasetAddr,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+// promptDataCommitment computes the data root of a local file to commit to
+// when contributing. It returns no root if no file is given.
+func promptDataCommitment() ([]byte, uint64, error) {
+	path, err := prompt.String("data file to commit to (leave empty to skip)", 0, 1024)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(path) == 0 {
+		return nil, 0, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	chunks := dataset.SplitChunks(data)
+	root := dataset.MerkleRoot(chunks)
+	utils.Outf("{{blue}}dataRoot:{{/}} %x {{blue}}dataChunks:{{/}} %d\n", root, len(chunks))
+	return root, uint64(len(chunks)), nil
+}
+
+func printDatasetChallenge(reply *vm.DatasetChallengeReply) {
+	if len(reply.DataRoot) == 0 {
+		utils.Outf("{{yellow}}no data root committed{{/}}\n")
+		return
+	}
+	utils.Outf("{{blue}}dataRoot:{{/}} %s {{blue}}dataChunks:{{/}} %d\n", reply.DataRoot, reply.DataChunks)
+	if reply.LockedCollateral > 0 {
+		utils.Outf("{{blue}}lockedCollateral:{{/}} %d {{blue}}releaseHeight:{{/}} %d\n", reply.LockedCollateral, reply.CollateralReleaseHeight)
+	}
+	if !reply.Open {
+		utils.Outf("{{yellow}}no open challenge{{/}}\n")
+		return
+	}
+	utils.Outf(
+		"{{blue}}challenger:{{/}} %s {{blue}}bond:{{/}} %d {{blue}}challengedChunk:{{/}} %d {{blue}}deadline:{{/}} %d\n",
+		reply.Challenger,
+		reply.Bond,
+		reply.ChallengedChunk,
+		reply.Deadline,
+	)
+}
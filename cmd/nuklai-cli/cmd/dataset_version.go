@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var publishDatasetVersionCmd = &cobra.Command{
+	Use: "publish-version",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		datasetAddress, err := prompt.Address("datasetAddress")
+		if err != nil {
+			return err
+		}
+		latest, err := ncli.DatasetVersion(ctx, datasetAddress.String(), 0)
+		if err != nil {
+			return err
+		}
+		printDatasetVersion(latest)
+
+		contentHash, err := prompt.String("contentHash", 1, storage.MaxDatasetContentHashSize)
+		if err != nil {
+			return err
+		}
+		size, err := prompt.Int("size (in bytes)", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+		changelog, err := prompt.String("changelog (leave empty to skip)", 0, storage.MaxTextSize)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.PublishDatasetVersion{
+			DatasetAddress: datasetAddress,
+			Version:        latest.Versions + 1,
+			ContentHash:    contentHash,
+			Size:           uint64(size),
+			Changelog:      changelog,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var datasetVersionCmd = &cobra.Command{
+	Use: "version",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		datasetAddress, err := prompt.Address("datasetAddress")
+		if err != nil {
+			return err
+		}
+
+		// Look up the latest version or the one current at a block
+		atHeight, err := prompt.Bool("at block height")
+		if err != nil {
+			return err
+		}
+		var reply *vm.DatasetVersionReply
+		if atHeight {
+			height, err := prompt.Int("height", consts.MaxInt)
+			if err != nil {
+				return err
+			}
+			reply, err = ncli.DatasetVersionAtHeight(ctx, datasetAddress.String(), uint64(height))
+			if err != nil {
+				return err
+			}
+		} else {
+			reply, err = ncli.DatasetVersion(ctx, datasetAddress.String(), 0)
+			if err != nil {
+				return err
+			}
+		}
+		printDatasetVersion(reply)
+		return nil
+	},
+}
+
+func printDatasetVersion(reply *vm.DatasetVersionReply) {
+	if reply.Version == 0 {
+		utils.Outf("{{yellow}}no version{{/}} {{blue}}versions:{{/}} %d\n", reply.Versions)
+		return
+	}
+	utils.Outf(
+		"{{blue}}version:{{/}} %d/%d {{blue}}contentHash:{{/}} %s {{blue}}size:{{/}} %d {{blue}}timestamp:{{/}} %d {{blue}}height:{{/}} %d {{blue}}changelog:{{/}} %s\n",
+		reply.Version,
+		reply.Versions,
+		reply.ContentHash,
+		reply.Size,
+		reply.Timestamp,
+		reply.Height,
+		reply.Changelog,
+	)
+}
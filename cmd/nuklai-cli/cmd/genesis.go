@@ -30,10 +30,10 @@ type GenesisCustomAllocation struct {
 }
 
 var genGenesisCmd = &cobra.Command{
-	Use:   "generate [custom allocations file] [emission balancer file] [options]",
+	Use:   "generate [custom allocations file] [emission balancer file] [vesting allocations file (optional)] [options]",
 	Short: "Creates a new genesis in the default location",
 	PreRunE: func(_ *cobra.Command, args []string) error {
-		if len(args) != 2 {
+		if len(args) != 2 && len(args) != 3 {
 			return ErrInvalidArgs
 		}
 		return nil
@@ -71,7 +71,19 @@ var genGenesisCmd = &cobra.Command{
 			return err
 		}
 
-		genesis := genesis.NewGenesis(convertedAllocs, emissionBalancer)
+		// Read vesting allocations file
+		var vestingAllocs []*genesis.VestingAllocation
+		if len(args) == 3 {
+			v, err := os.ReadFile(args[2])
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(v, &vestingAllocs); err != nil {
+				return err
+			}
+		}
+
+		genesis := genesis.NewGenesis(convertedAllocs, vestingAllocs, emissionBalancer)
 		if len(minUnitPrice) > 0 {
 			d, err := fees.ParseDimensions(minUnitPrice)
 			if err != nil {
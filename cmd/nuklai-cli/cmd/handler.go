@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/nuklai/nuklaivm/consts"
 	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/orderbook"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/nuklai/nuklaivm/vm"
 
@@ -496,20 +497,189 @@ func (*Handler) GetDataContributionInfo(
 	ctx context.Context,
 	cli *vm.JSONRPCClient,
 	contributionID ids.ID,
-) (string, string, string, string, bool, error) {
-	datasetAddress, dataLocation, dataIdentifier, contributor, contributionAcceptedByDatasetOwner, err := cli.DatasetContribution(ctx, contributionID.String())
+) (string, string, string, string, bool, uint64, error) {
+	datasetAddress, dataLocation, dataIdentifier, contributor, contributionAcceptedByDatasetOwner, initiatedAt, err := cli.DatasetContribution(ctx, contributionID.String())
 	if err != nil {
-		return "", "", "", "", false, err
+		return "", "", "", "", false, 0, err
 	}
 	utils.Outf(
-		"{{blue}}contribution info: {{/}}\nDatasetAddress=%s DataLocation=%s DataIdentifier=%s Contributor=%s ContributionAcceptedByDatasetOwner=%t\n",
+		"{{blue}}contribution info: {{/}}\nDatasetAddress=%s DataLocation=%s DataIdentifier=%s Contributor=%s ContributionAcceptedByDatasetOwner=%t InitiatedAt=%d\n",
 		datasetAddress,
 		dataLocation,
 		dataIdentifier,
 		contributor,
 		contributionAcceptedByDatasetOwner,
+		initiatedAt,
 	)
-	return datasetAddress, dataLocation, dataIdentifier, contributor, contributionAcceptedByDatasetOwner, nil
+	return datasetAddress, dataLocation, dataIdentifier, contributor, contributionAcceptedByDatasetOwner, initiatedAt, nil
+}
+
+func (*Handler) GetContractInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	contractAddress codec.Address,
+) (string, string, uint64, []vm.ContractUpgrade, error) {
+	contractID, upgradeAuthority, version, upgrades, err := cli.Contract(ctx, contractAddress.String())
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	utils.Outf(
+		"{{blue}}contract info: {{/}}\nContractID=%s UpgradeAuthority=%s Version=%d\n",
+		contractID,
+		upgradeAuthority,
+		version,
+	)
+	for _, upgrade := range upgrades {
+		utils.Outf(
+			"{{blue}}upgrade %d:{{/}} PreviousContractID=%s ContractID=%s UpgradedBy=%s Timestamp=%d ActionID=%s\n",
+			upgrade.Version,
+			upgrade.PreviousContractID,
+			upgrade.ContractID,
+			upgrade.UpgradedBy,
+			upgrade.Timestamp,
+			upgrade.ActionID,
+		)
+	}
+	return contractID, upgradeAuthority, version, upgrades, nil
+}
+
+func (*Handler) GetSessionKeyInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	account codec.Address,
+	sessionKey codec.Address,
+) (uint64, []int, []vm.SessionKeySpendLimit, error) {
+	expiryBlock, actionTypeIDs, spendLimits, err := cli.SessionKey(ctx, account.String(), sessionKey.String())
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	utils.Outf(
+		"{{blue}}session key info: {{/}}\nExpiryBlock=%d ActionTypeIDs=%v\n",
+		expiryBlock,
+		actionTypeIDs,
+	)
+	for _, spendLimit := range spendLimits {
+		utils.Outf(
+			"{{blue}}spend limit:{{/}} AssetAddress=%s Limit=%d Spent=%d\n",
+			spendLimit.AssetAddress,
+			spendLimit.Limit,
+			spendLimit.Spent,
+		)
+	}
+	return expiryBlock, actionTypeIDs, spendLimits, nil
+}
+
+func (*Handler) GetSponsorPolicyInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	policyAddress codec.Address,
+) (string, []int, []string, []string, uint64, error) {
+	sponsor, actionTypeIDs, assetAddresses, datasetAddresses, balance, err := cli.SponsorPolicy(ctx, policyAddress.String())
+	if err != nil {
+		return "", nil, nil, nil, 0, err
+	}
+	utils.Outf(
+		"{{blue}}sponsor policy info: {{/}}\nSponsor=%s ActionTypeIDs=%v AssetAddresses=%v DatasetAddresses=%v Balance=%s %s\n",
+		sponsor,
+		actionTypeIDs,
+		assetAddresses,
+		datasetAddresses,
+		nutils.FormatBalance(balance, consts.Decimals),
+		consts.Symbol,
+	)
+	return sponsor, actionTypeIDs, assetAddresses, datasetAddresses, balance, nil
+}
+
+func (*Handler) GetAllowanceInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	assetAddress codec.Address,
+	owner codec.Address,
+	spender codec.Address,
+) (uint64, uint64, error) {
+	amount, expiryBlock, err := cli.Allowance(ctx, assetAddress.String(), owner.String(), spender.String())
+	if err != nil {
+		return 0, 0, err
+	}
+	if amount == 0 {
+		utils.Outf("{{yellow}}%s has no allowance on %s from %s{{/}}\n", spender, assetAddress, owner)
+		return 0, 0, nil
+	}
+	utils.Outf(
+		"{{blue}}allowance info: {{/}}\nAmount=%d ExpiryBlock=%d\n",
+		amount,
+		expiryBlock,
+	)
+	return amount, expiryBlock, nil
+}
+
+func (*Handler) GetOrderInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	orderAddress codec.Address,
+) (*orderbook.Order, error) {
+	order, err := cli.Order(ctx, orderAddress.String())
+	if err != nil {
+		return nil, err
+	}
+	utils.Outf(
+		"{{blue}}order info: {{/}}\nOwner=%s InAssetAddress=%s InCollectionAddress=%s InTick=%d OutAssetAddress=%s OutTick=%d Remaining=%d\n",
+		order.Owner,
+		order.InAssetAddress,
+		order.InCollectionAddress,
+		order.InTick,
+		order.OutAssetAddress,
+		order.OutTick,
+		order.Remaining,
+	)
+	return order, nil
+}
+
+func (*Handler) GetRoyaltyInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	collectionAddress codec.Address,
+) (*vm.RoyaltyReply, error) {
+	royalty, err := cli.Royalty(ctx, collectionAddress.String())
+	if err != nil {
+		return nil, err
+	}
+	if royalty.BasisPoints == 0 {
+		utils.Outf("{{yellow}}%s has no royalty{{/}}\n", collectionAddress)
+		return royalty, nil
+	}
+	utils.Outf(
+		"{{blue}}royalty info: {{/}}\nBasisPoints=%d Recipient=%s AssetAddress=%s\n",
+		royalty.BasisPoints,
+		royalty.Recipient,
+		royalty.AssetAddress,
+	)
+	return royalty, nil
+}
+
+func (*Handler) GetVestingScheduleInfo(
+	ctx context.Context,
+	cli *vm.JSONRPCClient,
+	vestingAddress codec.Address,
+) (*vm.VestingScheduleReply, error) {
+	schedule, err := cli.VestingSchedule(ctx, vestingAddress.String())
+	if err != nil {
+		return nil, err
+	}
+	utils.Outf(
+		"{{blue}}vesting schedule info: {{/}}\nAssetAddress=%s Beneficiary=%s Funder=%s RevocationAuthority=%s Amount=%d Claimed=%d Claimable=%d StartBlock=%d CliffBlock=%d EndBlock=%d\n",
+		schedule.AssetAddress,
+		schedule.Beneficiary,
+		schedule.Funder,
+		schedule.RevocationAuthority,
+		schedule.Amount,
+		schedule.Claimed,
+		schedule.Claimable,
+		schedule.StartBlock,
+		schedule.CliffBlock,
+		schedule.EndBlock,
+	)
+	return schedule, nil
 }
 
 func (*Handler) GetDatasetInfoFromMarketplace(
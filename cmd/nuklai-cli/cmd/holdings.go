@@ -0,0 +1,212 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/holdings"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var assetsKeyCmd = &cobra.Command{
+	Use: "assets [address]",
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var (
+			addr codec.Address
+			err  error
+		)
+		if len(args) != 1 {
+			addr, _, err = handler.h.GetDefaultKey(true)
+		} else {
+			addr, err = codec.StringToAddress(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// List every page of the assets held by the address
+		count, cursor := 0, ""
+		for {
+			assets, next, err := ncli.AssetsOfOwner(ctx, addr.String(), cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, asset := range assets {
+				utils.Outf("%d) {{cyan}}assetAddress:{{/}} %s {{cyan}}balance:{{/}} %d\n", count, asset.AssetAddress, asset.Balance)
+				count++
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if count == 0 {
+			utils.Outf("{{yellow}}%s holds no tracked assets{{/}}\n", addr)
+		}
+		return nil
+	},
+}
+
+var holdersAssetCmd = &cobra.Command{
+	Use: "holders",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+
+		// List every page of the holders of the asset
+		count, cursor := 0, ""
+		for {
+			holders, next, err := ncli.HoldersOfAsset(ctx, assetAddress.String(), cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, holder := range holders {
+				utils.Outf("%d) {{cyan}}owner:{{/}} %s {{cyan}}balance:{{/}} %d\n", count, holder.Owner, holder.Balance)
+				count++
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if count == 0 {
+			utils.Outf("{{yellow}}%s has no tracked holders{{/}}\n", assetAddress)
+		}
+		return nil
+	},
+}
+
+var nftsAssetCmd = &cobra.Command{
+	Use: "nfts",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		collectionAddress, err := prompt.Address("collectionAddress")
+		if err != nil {
+			return err
+		}
+
+		// List every page of the NFTs of the collection
+		count, cursor := 0, ""
+		for {
+			nfts, next, err := ncli.NFTsOfCollection(ctx, collectionAddress.String(), cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, nft := range nfts {
+				utils.Outf("%d) {{cyan}}nftAddress:{{/}} %s {{cyan}}owner:{{/}} %s\n", count, nft.NFTAddress, nft.Owner)
+				count++
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if count == 0 {
+			utils.Outf("{{yellow}}%s has no tracked NFTs{{/}}\n", collectionAddress)
+		}
+		return nil
+	},
+}
+
+var syncNFTOwnersAssetCmd = &cobra.Command{
+	Use: "sync-nft-owners",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		collectionAddress, err := prompt.Address("collectionAddress")
+		if err != nil {
+			return err
+		}
+
+		// Find the tracked NFTs of the collection whose owner is stale
+		stale := []holdings.NFT{}
+		cursor := ""
+		for {
+			nfts, next, err := ncli.NFTsOfCollection(ctx, collectionAddress.String(), cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, nft := range nfts {
+				_, _, _, _, _, _, _, _, owner, _, _, _, _, err := ncli.Asset(ctx, nft.NFTAddress, false)
+				if err != nil {
+					return err
+				}
+				if nft.Owner != "" && nft.Owner != owner {
+					utils.Outf("{{cyan}}nftAddress:{{/}} %s {{cyan}}owner:{{/}} %s -> %s\n", nft.NFTAddress, owner, nft.Owner)
+					stale = append(stale, nft)
+				}
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if len(stale) == 0 {
+			utils.Outf("{{yellow}}every tracked NFT of %s is up to date{{/}}\n", collectionAddress)
+			return nil
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate one transaction per NFT
+		for _, nft := range stale {
+			nftAddress, err := codec.StringToAddress(nft.NFTAddress)
+			if err != nil {
+				return err
+			}
+			owner, err := codec.StringToAddress(nft.Owner)
+			if err != nil {
+				return err
+			}
+			result, _, err := sendAndWait(ctx, []chain.Action{&actions.SyncNFTOwner{
+				AssetAddress:    collectionAddress,
+				AssetNftAddress: nftAddress,
+				Owner:           owner,
+			}}, cli, ncli, ws, factory)
+			if err != nil {
+				return err
+			}
+			if err := processResult(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
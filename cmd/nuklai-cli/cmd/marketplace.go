@@ -5,12 +5,16 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
+	"math"
 
 	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 
 	hutils "github.com/ava-labs/hypersdk/utils"
@@ -100,6 +104,12 @@ var subscribeDatasetMarketplaceCmd = &cobra.Command{
 			return err
 		}
 
+		// Get the key the data key of the dataset is delivered to
+		encryptionPublicKey, err := promptEncryptionPublicKey()
+		if err != nil {
+			return err
+		}
+
 		// Confirm action
 		cont, err := prompt.Continue()
 		if !cont || err != nil {
@@ -111,6 +121,7 @@ var subscribeDatasetMarketplaceCmd = &cobra.Command{
 			MarketplaceAssetAddress: marketplaceAddress,
 			PaymentAssetAddress:     paymentAssetAddress,
 			NumBlocksToSubscribe:    uint64(numBlocksToSubscribe),
+			EncryptionPublicKey:     encryptionPublicKey,
 		}}, cli, ncli, ws, factory)
 		if err != nil {
 			return err
@@ -182,3 +193,591 @@ var claimPaymentMarketplaceCmd = &cobra.Command{
 		return processResult(result)
 	},
 }
+
+var updateListingMarketplaceCmd = &cobra.Command{
+	Use: "update-listing",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select dataset
+		datasetAddress, err := prompt.Address("datasetAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select paymentAssetAddress of new subscriptions
+		paymentAssetAddress, err := parseAsset("paymentAssetAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, paymentAssetAddress, false, true, -1)
+		if err != nil {
+			return err
+		}
+
+		// Get priceAmountPerBlock of new subscriptions
+		priceAmountPerBlock, err := parseAmount("priceAmountPerBlock", decimals, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+
+		// Pause new subscriptions
+		paused, err := prompt.Bool("pause new subscriptions")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.UpdateMarketplaceListing{
+			DatasetAddress:       datasetAddress,
+			PaymentAssetAddress:  paymentAssetAddress,
+			DatasetPricePerBlock: priceAmountPerBlock,
+			Paused:               paused,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var unpublishDatasetMarketplaceCmd = &cobra.Command{
+	Use: "unpublish",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select dataset
+		datasetAddress, err := prompt.Address("datasetAddress")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.UnpublishDatasetMarketplace{
+			DatasetAddress: datasetAddress,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var paymentAssetMarketplaceCmd = &cobra.Command{
+	Use: "payment-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select dataset
+		datasetAddress, err := prompt.Address("datasetAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select paymentAssetAddress accepted besides the primary one
+		paymentAssetAddress, err := parseAsset("paymentAssetAddress")
+		if err != nil {
+			return err
+		}
+
+		// Accept or stop accepting the asset for new subscriptions
+		accepted, err := prompt.Bool("accept for new subscriptions")
+		if err != nil {
+			return err
+		}
+
+		// Get priceAmountPerBlock of new subscriptions
+		var priceAmountPerBlock uint64
+		if accepted {
+			_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, paymentAssetAddress, false, true, -1)
+			if err != nil {
+				return err
+			}
+			priceAmountPerBlock, err = parseAmount("priceAmountPerBlock", decimals, math.MaxUint64)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.SetMarketplacePaymentAsset{
+			DatasetAddress:       datasetAddress,
+			PaymentAssetAddress:  paymentAssetAddress,
+			DatasetPricePerBlock: priceAmountPerBlock,
+			Accepted:             accepted,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var feeMarketplaceCmd = &cobra.Command{
+	Use: "fee",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		treasuryAddress, marketplaceFeeBasisPoints, err := ncli.MarketplaceFee(ctx)
+		if err != nil {
+			return err
+		}
+		if marketplaceFeeBasisPoints == 0 {
+			hutils.Outf("{{yellow}}no protocol fee is taken on marketplace payments{{/}}\n")
+			return nil
+		}
+		hutils.Outf(
+			"{{blue}}marketplace fee:{{/}} %d.%02d%% {{blue}}treasuryAddress:{{/}} %s\n",
+			marketplaceFeeBasisPoints/100,
+			marketplaceFeeBasisPoints%100,
+			treasuryAddress,
+		)
+		return nil
+	},
+}
+
+var openUsageMarketplaceCmd = &cobra.Command{
+	Use: "open-usage",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select marketplaceAddress
+		marketplaceAddress, err := prompt.Address("marketplaceAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select paymentAssetAddress
+		paymentAssetAddress, err := parseAsset("paymentAssetAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select a nonce that was not used for another channel of the listing
+		nonce, err := prompt.Int("nonce", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+
+		// Get deposit
+		_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, paymentAssetAddress, true, true, -1)
+		if err != nil {
+			return err
+		}
+		deposit, err := parseAmount("deposit", decimals, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+
+		// Get numBlocks after which the deposit can be reclaimed
+		numBlocks, err := prompt.Int("numBlocks", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.OpenUsageChannel{
+			MarketplaceAssetAddress: marketplaceAddress,
+			PaymentAssetAddress:     paymentAssetAddress,
+			Nonce:                   uint64(nonce),
+			Deposit:                 deposit,
+			NumBlocks:               uint64(numBlocks),
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var signUsageMarketplaceCmd = &cobra.Command{
+	Use: "sign-usage",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, _, ncli, _, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select usageChannelAddress
+		usageChannelAddress, err := prompt.Address("usageChannelAddress")
+		if err != nil {
+			return err
+		}
+		channel, err := ncli.UsageChannel(ctx, usageChannelAddress.String())
+		if err != nil {
+			return err
+		}
+		paymentAssetAddress, err := codec.StringToAddress(channel.PaymentAssetAddress)
+		if err != nil {
+			return err
+		}
+
+		// Get the total usage to pay for so far
+		_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, paymentAssetAddress, false, true, -1)
+		if err != nil {
+			return err
+		}
+		amount, err := parseAmount("total usage amount", decimals, channel.Deposit)
+		if err != nil {
+			return err
+		}
+
+		// The receipt is signed offline and handed to the owner of the listing
+		signature, err := factory.Sign(actions.UsageReceiptMessage(usageChannelAddress, amount))
+		if err != nil {
+			return err
+		}
+		hutils.Outf("{{green}}receipt:{{/}} %s\n", hex.EncodeToString(actions.MarshalUsageReceipt(signature)))
+		return nil
+	},
+}
+
+var settleUsageMarketplaceCmd = &cobra.Command{
+	Use: "settle-usage",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select usageChannelAddress
+		usageChannelAddress, err := prompt.Address("usageChannelAddress")
+		if err != nil {
+			return err
+		}
+		channel, err := ncli.UsageChannel(ctx, usageChannelAddress.String())
+		if err != nil {
+			return err
+		}
+		marketplaceAddress, err := codec.StringToAddress(channel.MarketplaceAssetAddress)
+		if err != nil {
+			return err
+		}
+		paymentAssetAddress, err := codec.StringToAddress(channel.PaymentAssetAddress)
+		if err != nil {
+			return err
+		}
+		buyer, err := codec.StringToAddress(channel.Buyer)
+		if err != nil {
+			return err
+		}
+
+		// Get the total usage of the latest receipt
+		_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, paymentAssetAddress, false, true, -1)
+		if err != nil {
+			return err
+		}
+		amount, err := parseAmount("total usage amount", decimals, channel.Deposit)
+		if err != nil {
+			return err
+		}
+		var receipt []byte
+		if amount > channel.Settled {
+			receipt, err = prompt.Bytes("receipt")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Close the channel and refund the rest of the deposit to the buyer
+		closeChannel, err := prompt.Bool("close channel")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.SettleUsage{
+			UsageChannelAddress:     usageChannelAddress,
+			MarketplaceAssetAddress: marketplaceAddress,
+			PaymentAssetAddress:     paymentAssetAddress,
+			Buyer:                   buyer,
+			Amount:                  amount,
+			Receipt:                 receipt,
+			Close:                   closeChannel,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var closeUsageMarketplaceCmd = &cobra.Command{
+	Use: "close-usage",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select usageChannelAddress
+		usageChannelAddress, err := prompt.Address("usageChannelAddress")
+		if err != nil {
+			return err
+		}
+		channel, err := ncli.UsageChannel(ctx, usageChannelAddress.String())
+		if err != nil {
+			return err
+		}
+		paymentAssetAddress, err := codec.StringToAddress(channel.PaymentAssetAddress)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.CloseUsageChannel{
+			UsageChannelAddress: usageChannelAddress,
+			PaymentAssetAddress: paymentAssetAddress,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var deliverKeyMarketplaceCmd = &cobra.Command{
+	Use: "deliver-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select marketplaceAddress
+		marketplaceAddress, err := prompt.Address("marketplaceAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select the subscription and show the key to encrypt the data key to
+		subscriptionNftAddress, err := prompt.Address("subscriptionNftAddress")
+		if err != nil {
+			return err
+		}
+		holder, encryptionPublicKey, _, err := ncli.DatasetKey(ctx, subscriptionNftAddress.String())
+		if err != nil {
+			return err
+		}
+		subscriber, err := codec.StringToAddress(holder)
+		if err != nil {
+			return err
+		}
+		hutils.Outf("{{blue}}subscriber:{{/}} %s\n", holder)
+		hutils.Outf("{{blue}}encryptionPublicKey:{{/}} %s\n", encryptionPublicKey)
+
+		// The data key is encrypted off-chain
+		encryptedKey, err := prompt.Bytes("encryptedKey")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.DeliverDatasetKey{
+			MarketplaceAssetAddress: marketplaceAddress,
+			SubscriptionNftAddress:  subscriptionNftAddress,
+			Subscriber:              subscriber,
+			EncryptedKey:            encryptedKey,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var setEncryptionKeyMarketplaceCmd = &cobra.Command{
+	Use: "set-encryption-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select marketplaceAddress
+		marketplaceAddress, err := prompt.Address("marketplaceAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select the subscription held by the actor
+		subscriptionNftAddress, err := prompt.Address("subscriptionNftAddress")
+		if err != nil {
+			return err
+		}
+		encryptionPublicKey, err := promptEncryptionPublicKey()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.SetEncryptionPublicKey{
+			MarketplaceAssetAddress: marketplaceAddress,
+			SubscriptionNftAddress:  subscriptionNftAddress,
+			EncryptionPublicKey:     encryptionPublicKey,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var setKeyDelegateMarketplaceCmd = &cobra.Command{
+	Use: "set-key-delegate",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select marketplaceAddress
+		marketplaceAddress, err := prompt.Address("marketplaceAddress")
+		if err != nil {
+			return err
+		}
+
+		// Select the account delivering the data keys
+		remove, err := prompt.Bool("remove delegate")
+		if err != nil {
+			return err
+		}
+		delegate := codec.EmptyAddress
+		if !remove {
+			delegate, err = prompt.Address("delegate")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.SetDatasetKeyDelegate{
+			MarketplaceAssetAddress: marketplaceAddress,
+			Delegate:                delegate,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var datasetKeyMarketplaceCmd = &cobra.Command{
+	Use: "dataset-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select the subscription
+		subscriptionNftAddress, err := prompt.Address("subscriptionNftAddress")
+		if err != nil {
+			return err
+		}
+		holder, encryptionPublicKey, encryptedKey, err := ncli.DatasetKey(ctx, subscriptionNftAddress.String())
+		if err != nil {
+			return err
+		}
+		hutils.Outf("{{blue}}holder:{{/}} %s\n", holder)
+		hutils.Outf("{{blue}}encryptionPublicKey:{{/}} %s\n", encryptionPublicKey)
+		if encryptedKey == "" {
+			hutils.Outf("{{yellow}}the data key was not delivered yet{{/}}\n")
+			return nil
+		}
+		hutils.Outf("{{blue}}encryptedKey:{{/}} %s\n", encryptedKey)
+		return nil
+	},
+}
+
+// promptEncryptionPublicKey asks for the hex encoded public key the data key
+// of a dataset is encrypted to, which can be left empty
+func promptEncryptionPublicKey() ([]byte, error) {
+	encryptionPublicKey, err := prompt.String("encryption public key in hex (leave empty for none)", 0, storage.MaxEncryptionPublicKeySize*2)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(encryptionPublicKey)
+}
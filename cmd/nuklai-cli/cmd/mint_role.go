@@ -0,0 +1,153 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var grantMintRoleCmd = &cobra.Command{
+	Use: "grant-minter",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		assetAddress, err := prompt.Address("assetAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, decimals, _, _, _, _, _, _, _, _, _, err := ncli.Asset(ctx, assetAddress.String(), false)
+		if err != nil {
+			return err
+		}
+		account, err := prompt.Address("minter")
+		if err != nil {
+			return err
+		}
+
+		// Select quota
+		var mintPeriod uint64
+		mintQuota, err := parseAmount("mintQuota (0 for unlimited)", decimals, consts.MaxUint64)
+		if err != nil {
+			return err
+		}
+		if mintQuota > 0 {
+			periodic, err := prompt.Bool("reset quota every period")
+			if err != nil {
+				return err
+			}
+			if periodic {
+				period, err := prompt.Int("mintPeriod (in blocks)", consts.MaxInt)
+				if err != nil {
+					return err
+				}
+				mintPeriod = uint64(period)
+			}
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.GrantMintRole{
+			AssetAddress: assetAddress,
+			Account:      account,
+			MintQuota:    mintQuota,
+			MintPeriod:   mintPeriod,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var revokeMintRoleCmd = &cobra.Command{
+	Use: "revoke-minter",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		assetAddress, err := prompt.Address("assetAddress")
+		if err != nil {
+			return err
+		}
+		account, err := prompt.Address("minter")
+		if err != nil {
+			return err
+		}
+		granted, err := getMintRole(ctx, ncli, assetAddress, account)
+		if err != nil {
+			return err
+		}
+		if !granted {
+			utils.Outf("{{red}}%s is not a minter of %s{{/}}\n", account, assetAddress)
+			return nil
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.RevokeMintRole{
+			AssetAddress: assetAddress,
+			Account:      account,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+// getMintRole prints and returns whether [account] is granted the mint role
+// on [assetAddress]
+func getMintRole(ctx context.Context, ncli *vm.JSONRPCClient, assetAddress codec.Address, account codec.Address) (bool, error) {
+	reply, err := ncli.MintRole(ctx, assetAddress.String(), account.String())
+	if err != nil {
+		return false, err
+	}
+	if !reply.Granted {
+		return false, nil
+	}
+	utils.Outf(
+		"{{blue}}mintQuota:{{/}} %d {{blue}}mintPeriod:{{/}} %d {{blue}}periodStart:{{/}} %d {{blue}}minted:{{/}} %d\n",
+		reply.MintQuota,
+		reply.MintPeriod,
+		reply.PeriodStart,
+		reply.Minted,
+	)
+	return true, nil
+}
+
+// canMint returns whether [actor] is the mint admin of [assetAddress] or was
+// granted the minter role on it
+func canMint(ctx context.Context, ncli *vm.JSONRPCClient, assetAddress codec.Address, mintAdmin string, actor codec.Address) (bool, error) {
+	if mintAdmin == actor.String() {
+		return true, nil
+	}
+	return getMintRole(ctx, ncli, assetAddress, actor)
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nauth "github.com/nuklai/nuklaivm/auth"
+)
+
+// multisigConfig is shared with every member of a multisig account and is
+// needed to propose, co-sign and broadcast its transactions
+type multisigConfig struct {
+	Address   string                 `json:"address"`
+	Threshold uint8                  `json:"threshold"`
+	Signers   []nauth.MultisigSigner `json:"signers"`
+}
+
+// multisigProposal is an unsigned transaction of a multisig account along
+// with the signatures collected so far
+type multisigProposal struct {
+	Multisig   multisigConfig            `json:"multisig"`
+	Tx         string                    `json:"tx"`
+	Expiry     int64                     `json:"expiry"`
+	Signatures []nauth.MultisigSignature `json:"signatures"`
+}
+
+var publicKeyCmd = &cobra.Command{
+	Use: "public-key",
+	RunE: func(*cobra.Command, []string) error {
+		_, priv, factory, _, _, _, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		signer, err := nauth.NewMultisigSigner(factory)
+		if err != nil {
+			return err
+		}
+		keyType, err := getKeyType(priv.Address)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}address:{{/}} %s\n", priv.Address)
+		utils.Outf("{{yellow}}public key:{{/}} %s:%s\n", keyType, codec.ToHex(signer.PublicKey))
+		return nil
+	},
+}
+
+var multisigCreateCmd = &cobra.Command{
+	Use: "multisig-create",
+	RunE: func(*cobra.Command, []string) error {
+		numSigners, err := prompt.Int("number of signers", nauth.MaxMultisigSigners)
+		if err != nil {
+			return err
+		}
+		threshold, err := prompt.Int("threshold", numSigners)
+		if err != nil {
+			return err
+		}
+
+		// Signers are provided in the format printed by "key public-key"
+		signers := make([]nauth.MultisigSigner, numSigners)
+		for i := range signers {
+			input, err := prompt.String(fmt.Sprintf("signer %d public key (type:hex)", i), 1, 256)
+			if err != nil {
+				return err
+			}
+			signers[i], err = parseMultisigSigner(input)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := nauth.VerifyMultisigSigners(uint8(threshold), signers); err != nil {
+			return err
+		}
+
+		address := nauth.NewMultisigAddress(uint8(threshold), signers)
+		config := &multisigConfig{
+			Address:   address.String(),
+			Threshold: uint8(threshold),
+			Signers:   signers,
+		}
+		filename := fmt.Sprintf("%s.multisig.json", address)
+		if err := writeJSON(filename, config); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}created multisig address:{{/}} %s\n", address)
+		utils.Outf("{{yellow}}share the config with every signer:{{/}} %s\n", filename)
+		return nil
+	},
+}
+
+var multisigProposeCmd = &cobra.Command{
+	Use: "multisig-propose [transfer/mint-ft/action] [multisig config] [action json]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		switch {
+		case len(args) == 2 && (args[0] == "transfer" || args[0] == "mint-ft"):
+			return nil
+		case len(args) == 3 && args[0] == "action":
+			return nil
+		default:
+			return ErrInvalidArgs
+		}
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var config multisigConfig
+		if err := readJSON(args[1], &config); err != nil {
+			return err
+		}
+		_, _, _, cli, ncli, _, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		address := nauth.NewMultisigAddress(config.Threshold, config.Signers)
+
+		var action chain.Action
+		switch args[0] {
+		case "transfer":
+			assetAddress, err := parseAsset("assetAddress")
+			if err != nil {
+				return err
+			}
+			collectionAddress, _, err := getAssetCollection(ctx, ncli, assetAddress)
+			if err != nil {
+				return err
+			}
+			balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, address, assetAddress, true, false, -1)
+			if balance == 0 || err != nil {
+				return err
+			}
+			recipient, err := prompt.Address("recipient")
+			if err != nil {
+				return err
+			}
+			amount, err := parseAmount("amount", decimals, balance)
+			if err != nil {
+				return err
+			}
+			action = transferAction(recipient, assetAddress, collectionAddress, amount)
+		case "mint-ft":
+			assetAddress, err := prompt.Address("assetAddress")
+			if err != nil {
+				return err
+			}
+			_, _, _, decimals, _, _, _, _, _, mintAdmin, _, _, _, err := ncli.Asset(ctx, assetAddress.String(), false)
+			if err != nil {
+				return err
+			}
+			minter, err := canMint(ctx, ncli, assetAddress, mintAdmin, address)
+			if err != nil {
+				return err
+			}
+			if !minter {
+				utils.Outf("{{red}}%s has permission to mint asset '%s', the multisig does not{{/}}\n", mintAdmin, assetAddress)
+				return nil
+			}
+			recipient, err := prompt.Address("recipient")
+			if err != nil {
+				return err
+			}
+			amount, err := parseAmount("amount", decimals, consts.MaxUint64)
+			if err != nil {
+				return err
+			}
+			action = &actions.MintAssetFT{
+				AssetAddress: assetAddress,
+				To:           recipient,
+				Value:        amount,
+			}
+		case "action":
+			action, err = readActionJSON(args[2])
+			if err != nil {
+				return err
+			}
+		}
+
+		// Build the unsigned transaction the same way the RPC client does
+		parser, err := ncli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		unitPrices, err := cli.UnitPrices(ctx, true)
+		if err != nil {
+			return err
+		}
+		factory := nauth.NewMultisigFactory(config.Threshold, config.Signers, nil)
+		now := time.Now().UnixMilli()
+		rules := parser.Rules(now)
+		units, err := chain.EstimateUnits(rules, []chain.Action{action}, factory)
+		if err != nil {
+			return err
+		}
+		maxFee, err := fees.MulSum(unitPrices, units)
+		if err != nil {
+			return err
+		}
+		txData := chain.NewTxData(&chain.Base{
+			Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
+			ChainID:   rules.GetChainID(),
+			MaxFee:    maxFee,
+		}, []chain.Action{action})
+		unsignedBytes, err := txData.UnsignedBytes()
+		if err != nil {
+			return err
+		}
+
+		proposal := &multisigProposal{
+			Multisig: multisigConfig{
+				Address:   address.String(),
+				Threshold: config.Threshold,
+				Signers:   config.Signers,
+			},
+			Tx:         codec.ToHex(unsignedBytes),
+			Expiry:     txData.Expiry(),
+			Signatures: []nauth.MultisigSignature{},
+		}
+		filename := fmt.Sprintf("%s.proposal.json", utils.ToID(unsignedBytes))
+		if err := writeJSON(filename, proposal); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}created proposal:{{/}} %s\n", filename)
+		utils.Outf(
+			"{{yellow}}collect %d signatures and broadcast before:{{/}} %s\n",
+			config.Threshold,
+			time.UnixMilli(txData.Expiry()).Format(time.RFC3339),
+		)
+		return nil
+	},
+}
+
+var multisigSignCmd = &cobra.Command{
+	Use: "multisig-sign [proposal]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		var proposal multisigProposal
+		if err := readJSON(args[0], &proposal); err != nil {
+			return err
+		}
+		txData, unsignedBytes, err := decodeMultisigProposal(&proposal)
+		if err != nil {
+			return err
+		}
+
+		// Show what is being signed before asking for confirmation
+		utils.Outf("{{yellow}}multisig:{{/}} %s\n", proposal.Multisig.Address)
+		utils.Outf("{{yellow}}expiry:{{/}} %s\n", time.UnixMilli(txData.Expiry()).Format(time.RFC3339))
+		utils.Outf("{{yellow}}max fee:{{/}} %s\n", utils.FormatBalance(txData.MaxFee()))
+		for i, action := range txData.Actions {
+			b, err := json.Marshal(action)
+			if err != nil {
+				return err
+			}
+			utils.Outf("{{yellow}}action %d:{{/}} %T %s\n", i, action, b)
+		}
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, _, factory, _, _, _, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		signerAuth, err := factory.Sign(unsignedBytes)
+		if err != nil {
+			return err
+		}
+		signature, err := nauth.NewMultisigSignature(proposal.Multisig.Signers, signerAuth)
+		if err != nil {
+			return err
+		}
+
+		// Replace any previous signature from the same signer
+		signatures := make([]nauth.MultisigSignature, 0, len(proposal.Signatures)+1)
+		for _, s := range proposal.Signatures {
+			if s.Index != signature.Index {
+				signatures = append(signatures, s)
+			}
+		}
+		proposal.Signatures = append(signatures, signature)
+		if err := writeJSON(args[0], &proposal); err != nil {
+			return err
+		}
+		utils.Outf(
+			"{{green}}signed as signer %d:{{/}} %d/%d signatures collected\n",
+			signature.Index,
+			len(proposal.Signatures),
+			proposal.Multisig.Threshold,
+		)
+		return nil
+	},
+}
+
+var multisigBroadcastCmd = &cobra.Command{
+	Use: "multisig-broadcast [proposal]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var proposal multisigProposal
+		if err := readJSON(args[0], &proposal); err != nil {
+			return err
+		}
+		txData, _, err := decodeMultisigProposal(&proposal)
+		if err != nil {
+			return err
+		}
+		if txData.Expiry() < time.Now().UnixMilli() {
+			return fmt.Errorf("proposal expired at %s", time.UnixMilli(txData.Expiry()).Format(time.RFC3339))
+		}
+
+		factory := nauth.NewMultisigFactory(proposal.Multisig.Threshold, proposal.Multisig.Signers, proposal.Signatures)
+		tx, err := txData.Sign(factory, vm.ActionParser, vm.AuthParser)
+		if err != nil {
+			return err
+		}
+
+		_, _, _, _, _, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		result, txID, err := registerAndWait(ctx, tx, ws)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}txID:{{/}} %s\n", txID)
+		return processResult(result)
+	},
+}
+
+// decodeMultisigProposal returns the unsigned transaction of [proposal] after
+// checking that it belongs to the multisig described in the proposal
+func decodeMultisigProposal(proposal *multisigProposal) (*chain.TransactionData, []byte, error) {
+	address := nauth.NewMultisigAddress(proposal.Multisig.Threshold, proposal.Multisig.Signers)
+	if address.String() != proposal.Multisig.Address {
+		return nil, nil, fmt.Errorf("%w: multisig config does not match %s", ErrInvalidAddress, proposal.Multisig.Address)
+	}
+	unsignedBytes, err := codec.LoadHex(proposal.Tx, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	txData, err := chain.UnmarshalTxData(codec.NewReader(unsignedBytes, consts.NetworkSizeLimit), vm.ActionParser)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txData, unsignedBytes, nil
+}
+
+// multisigAction is the JSON description of any registered action, for
+// instance {"typeID": 0, "action": {"asset_address": "00...", ...}}
+type multisigAction struct {
+	TypeID uint8           `json:"typeID"`
+	Action json.RawMessage `json:"action"`
+}
+
+// readActionJSON builds the registered action described in the file at
+// [path]. The action is packed and parsed again so it is checked the same way
+// the chain will check it.
+func readActionJSON(path string) (chain.Action, error) {
+	var input multisigAction
+	if err := readJSON(path, &input); err != nil {
+		return nil, err
+	}
+	for _, registered := range vm.ActionParser.GetRegisteredTypes() {
+		if registered.GetTypeID() != input.TypeID {
+			continue
+		}
+		action, ok := reflect.New(reflect.TypeOf(registered).Elem()).Interface().(chain.Action)
+		if !ok {
+			break
+		}
+		if err := json.Unmarshal(input.Action, action); err != nil {
+			return nil, err
+		}
+		b, err := chain.MarshalTyped(action)
+		if err != nil {
+			return nil, err
+		}
+		return vm.ActionParser.Unmarshal(codec.NewReader(b, len(b)))
+	}
+	return nil, fmt.Errorf("%w: unknown action type %d", ErrInvalidArgs, input.TypeID)
+}
+
+func parseMultisigSigner(input string) (nauth.MultisigSigner, error) {
+	parts := strings.SplitN(strings.TrimSpace(input), ":", 2)
+	if len(parts) != 2 {
+		return nauth.MultisigSigner{}, fmt.Errorf("%w: expected type:hex", ErrInvalidArgs)
+	}
+	var keyType uint8
+	switch parts[0] {
+	case ed25519Key:
+		keyType = auth.ED25519ID
+	case secp256r1Key:
+		keyType = auth.SECP256R1ID
+	case blsKey:
+		keyType = auth.BLSID
+	default:
+		return nauth.MultisigSigner{}, fmt.Errorf("%w: %s", ErrInvalidKeyType, parts[0])
+	}
+	publicKey, err := codec.LoadHex(parts[1], -1)
+	if err != nil {
+		return nauth.MultisigSigner{}, err
+	}
+	return nauth.MultisigSigner{KeyType: keyType, PublicKey: publicKey}, nil
+}
+
+func readJSON(filename string, v any) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func writeJSON(filename string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, b, 0o600)
+}
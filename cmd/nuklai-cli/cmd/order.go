@@ -0,0 +1,335 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"math"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+	nconsts "github.com/nuklai/nuklaivm/consts"
+	nutils "github.com/nuklai/nuklaivm/utils"
+)
+
+var createOrderCmd = &cobra.Command{
+	Use: "create-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select asset to sell
+		inAssetAddress, err := parseAsset("in assetAddress")
+		if err != nil {
+			return err
+		}
+		balance, _, _, inSymbol, inDecimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, inAssetAddress, true, false, -1)
+		if balance == 0 || err != nil {
+			return err
+		}
+		inCollectionAddress, royaltyCollectionAddress, err := getAssetCollection(ctx, ncli, inAssetAddress)
+		if err != nil {
+			return err
+		}
+		if royaltyCollectionAddress != codec.EmptyAddress {
+			if _, err := handler.GetRoyaltyInfo(ctx, ncli, royaltyCollectionAddress); err != nil {
+				return err
+			}
+		}
+
+		// Select asset to receive
+		outAssetAddress, err := parseAsset("out assetAddress")
+		if err != nil {
+			return err
+		}
+		_, _, _, outSymbol, outDecimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, outAssetAddress, false, false, -1)
+		if err != nil {
+			return err
+		}
+
+		// Select rate and supply
+		inTick, err := parseAmount("in tick", inDecimals, balance)
+		if err != nil {
+			return err
+		}
+		outTick, err := parseAmount("out tick", outDecimals, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		utils.Outf(
+			"{{yellow}}rate:{{/}} %s %s for every %s %s\n",
+			nutils.FormatBalance(inTick, inDecimals),
+			inSymbol,
+			nutils.FormatBalance(outTick, outDecimals),
+			outSymbol,
+		)
+		supply, err := parseAmount("supply (multiple of in tick)", inDecimals, balance)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.CreateOrder{
+			InAssetAddress:      inAssetAddress,
+			InCollectionAddress: inCollectionAddress,
+			InTick:              inTick,
+			OutAssetAddress:     outAssetAddress,
+			OutTick:             outTick,
+			Supply:              supply,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		if err := processResult(result); err != nil || !result.Success {
+			return err
+		}
+		orderAddress := storage.OrderAddress(priv.Address, inAssetAddress, inTick, outAssetAddress, outTick)
+		utils.Outf("{{green}}order address:{{/}} %s\n", orderAddress)
+		return nil
+	},
+}
+
+var fillOrderCmd = &cobra.Command{
+	Use: "fill-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select order
+		orderAddress, err := prompt.Address("orderAddress")
+		if err != nil {
+			return err
+		}
+		order, err := handler.GetOrderInfo(ctx, ncli, orderAddress)
+		if err != nil {
+			return err
+		}
+		owner, err := codec.StringToAddress(order.Owner)
+		if err != nil {
+			return err
+		}
+		inAssetAddress, err := codec.StringToAddress(order.InAssetAddress)
+		if err != nil {
+			return err
+		}
+		outAssetAddress, err := codec.StringToAddress(order.OutAssetAddress)
+		if err != nil {
+			return err
+		}
+		inCollectionAddress, royaltyCollectionAddress, err := getAssetCollection(ctx, ncli, inAssetAddress)
+		if err != nil {
+			return err
+		}
+		royaltyRecipient := codec.EmptyAddress
+		if royaltyCollectionAddress != codec.EmptyAddress {
+			royalty, err := handler.GetRoyaltyInfo(ctx, ncli, royaltyCollectionAddress)
+			if err != nil {
+				return err
+			}
+			if royalty.BasisPoints > 0 {
+				royaltyRecipient, err = codec.StringToAddress(royalty.Recipient)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// Select value to pay
+		balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, outAssetAddress, true, false, -1)
+		if balance == 0 || err != nil {
+			return err
+		}
+		value, err := parseAmount("value (multiple of out tick)", decimals, balance)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.FillOrder{
+			OrderAddress:        orderAddress,
+			Owner:               owner,
+			InAssetAddress:      inAssetAddress,
+			OutAssetAddress:     outAssetAddress,
+			InCollectionAddress: inCollectionAddress,
+			RoyaltyRecipient:    royaltyRecipient,
+			Value:               value,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var closeOrderCmd = &cobra.Command{
+	Use: "close-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select order
+		orderAddress, err := prompt.Address("orderAddress")
+		if err != nil {
+			return err
+		}
+		order, err := handler.GetOrderInfo(ctx, ncli, orderAddress)
+		if err != nil {
+			return err
+		}
+		if order.Owner != priv.Address.String() {
+			utils.Outf("{{red}}%s is the owner of order %s{{/}}\n", order.Owner, orderAddress)
+			return nil
+		}
+		inAssetAddress, err := codec.StringToAddress(order.InAssetAddress)
+		if err != nil {
+			return err
+		}
+		inCollectionAddress, _, err := getAssetCollection(ctx, ncli, inAssetAddress)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.CloseOrder{
+			OrderAddress:        orderAddress,
+			InAssetAddress:      inAssetAddress,
+			InCollectionAddress: inCollectionAddress,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var orderInfoCmd = &cobra.Command{
+	Use: "order-info",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select order
+		orderAddress, err := prompt.Address("orderAddress")
+		if err != nil {
+			return err
+		}
+
+		// Get order info
+		_, err = handler.GetOrderInfo(ctx, ncli, orderAddress)
+		return err
+	},
+}
+
+var ordersCmd = &cobra.Command{
+	Use: "orders",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select asset pair
+		inAssetAddress, err := parseAsset("in assetAddress")
+		if err != nil {
+			return err
+		}
+		outAssetAddress, err := parseAsset("out assetAddress")
+		if err != nil {
+			return err
+		}
+		limit, err := prompt.Int("limit", hconsts.MaxInt)
+		if err != nil {
+			return err
+		}
+
+		// List open orders from the best rate
+		orders, err := ncli.Orders(ctx, inAssetAddress.String(), outAssetAddress.String(), limit)
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			utils.Outf("{{yellow}}no open orders{{/}}\n")
+			return nil
+		}
+		for i, order := range orders {
+			utils.Outf(
+				"%d) {{cyan}}orderAddress:{{/}} %s {{cyan}}owner:{{/}} %s {{cyan}}inTick:{{/}} %d {{cyan}}outTick:{{/}} %d {{cyan}}remaining:{{/}} %d\n",
+				i,
+				order.OrderAddress,
+				order.Owner,
+				order.InTick,
+				order.OutTick,
+				order.Remaining,
+			)
+		}
+		return nil
+	},
+}
+
+// getAssetCollection returns the collection of [assetAddress] when it is an
+// NFT and the collection whose royalty applies to its sales, if any
+func getAssetCollection(ctx context.Context, ncli *vm.JSONRPCClient, assetAddress codec.Address) (codec.Address, codec.Address, error) {
+	assetType, _, _, _, _, uri, _, _, _, _, _, _, _, err := ncli.Asset(ctx, assetAddress.String(), false)
+	if err != nil {
+		return codec.EmptyAddress, codec.EmptyAddress, err
+	}
+	switch assetType {
+	case nconsts.AssetNonFungibleTokenDesc:
+		collectionAddress, err := codec.StringToAddress(uri)
+		if err != nil {
+			return codec.EmptyAddress, codec.EmptyAddress, err
+		}
+		return collectionAddress, collectionAddress, nil
+	case nconsts.AssetFractionalTokenDesc:
+		return codec.EmptyAddress, assetAddress, nil
+	default:
+		return codec.EmptyAddress, codec.EmptyAddress, nil
+	}
+}
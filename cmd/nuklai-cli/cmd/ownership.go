@@ -0,0 +1,221 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+var (
+	ownershipKindNames = []string{"asset", "dataset", "listing"}
+	ownershipRoleNames = []string{"owner", "mintAdmin", "pauseUnpauseAdmin", "freezeUnfreezeAdmin", "enableDisableKYCAccountAdmin"}
+)
+
+var proposeAssetOwnershipCmd = &cobra.Command{
+	Use: "propose-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		return proposeOwnershipTransfer(nconsts.OwnershipKindAsset, "assetAddress")
+	},
+}
+
+var acceptAssetOwnershipCmd = &cobra.Command{
+	Use: "accept-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		return acceptOwnershipTransfer(nconsts.OwnershipKindAsset, "assetAddress")
+	},
+}
+
+var proposeDatasetOwnershipCmd = &cobra.Command{
+	Use: "propose-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		return proposeOwnershipTransfer(nconsts.OwnershipKindDataset, "datasetAddress")
+	},
+}
+
+var acceptDatasetOwnershipCmd = &cobra.Command{
+	Use: "accept-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		return acceptOwnershipTransfer(nconsts.OwnershipKindDataset, "datasetAddress")
+	},
+}
+
+var proposeListingOwnershipCmd = &cobra.Command{
+	Use: "propose-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		return proposeOwnershipTransfer(nconsts.OwnershipKindListing, "marketplaceAssetAddress")
+	},
+}
+
+var acceptListingOwnershipCmd = &cobra.Command{
+	Use: "accept-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		return acceptOwnershipTransfer(nconsts.OwnershipKindListing, "marketplaceAssetAddress")
+	},
+}
+
+// proposeOwnershipTransfer proposes a role of the [kind] at the address
+// prompted with [label] to another account or cancels the pending proposal
+func proposeOwnershipTransfer(kind uint8, label string) error {
+	ctx := context.Background()
+	_, _, factory, cli, ncli, ws, err := handler.DefaultActor()
+	if err != nil {
+		return err
+	}
+
+	address, err := prompt.Address(label)
+	if err != nil {
+		return err
+	}
+	pending, err := getOwnershipTransfers(ctx, ncli, kind, address)
+	if err != nil {
+		return err
+	}
+
+	// Datasets and listings only have an owner
+	role := nconsts.OwnershipRoleOwner
+	if kind == nconsts.OwnershipKindAsset {
+		for i, name := range ownershipRoleNames {
+			utils.Outf("%d) {{cyan}}%s{{/}}\n", i, name)
+		}
+		choice, err := prompt.Choice("role", len(ownershipRoleNames))
+		if err != nil {
+			return err
+		}
+		role = uint8(choice)
+	}
+
+	// A pending proposal can be cancelled instead of replaced
+	var newOwner codec.Address
+	cancel := false
+	if _, ok := pending[role]; ok {
+		cancel, err = prompt.Bool("cancel pending transfer")
+		if err != nil {
+			return err
+		}
+	}
+	if !cancel {
+		newOwner, err = prompt.Address("newOwner")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Confirm action
+	cont, err := prompt.Continue()
+	if !cont || err != nil {
+		return err
+	}
+
+	// Generate transaction
+	result, _, err := sendAndWait(ctx, []chain.Action{&actions.ProposeOwnershipTransfer{
+		Kind:     kind,
+		Address:  address,
+		Role:     role,
+		NewOwner: newOwner,
+	}}, cli, ncli, ws, factory)
+	if err != nil {
+		return err
+	}
+	return processResult(result)
+}
+
+// acceptOwnershipTransfer accepts a role of the [kind] at the address
+// prompted with [label] proposed to the actor
+func acceptOwnershipTransfer(kind uint8, label string) error {
+	ctx := context.Background()
+	_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+	if err != nil {
+		return err
+	}
+
+	address, err := prompt.Address(label)
+	if err != nil {
+		return err
+	}
+	pending, err := getOwnershipTransfers(ctx, ncli, kind, address)
+	if err != nil {
+		return err
+	}
+	roles := []uint8{}
+	for role, newOwner := range pending {
+		if newOwner == priv.Address.String() {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		utils.Outf("{{red}}no ownership transfer of %s is proposed to %s{{/}}\n", address, priv.Address)
+		return nil
+	}
+
+	// Select the role to accept
+	role := roles[0]
+	if len(roles) > 1 {
+		for i, role := range roles {
+			utils.Outf("%d) {{cyan}}%s{{/}}\n", i, ownershipRoleName(role))
+		}
+		choice, err := prompt.Choice("role", len(roles))
+		if err != nil {
+			return err
+		}
+		role = roles[choice]
+	}
+
+	// Confirm action
+	cont, err := prompt.Continue()
+	if !cont || err != nil {
+		return err
+	}
+
+	// Generate transaction
+	result, _, err := sendAndWait(ctx, []chain.Action{&actions.AcceptOwnershipTransfer{
+		Kind:    kind,
+		Address: address,
+		Role:    role,
+	}}, cli, ncli, ws, factory)
+	if err != nil {
+		return err
+	}
+	return processResult(result)
+}
+
+// getOwnershipTransfers prints and returns the pending ownership transfers of
+// the [kind] at [address] by role
+func getOwnershipTransfers(ctx context.Context, ncli *vm.JSONRPCClient, kind uint8, address codec.Address) (map[uint8]string, error) {
+	transfers, err := ncli.OwnershipTransfers(ctx, kind, address.String())
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[uint8]string, len(transfers))
+	for _, transfer := range transfers {
+		utils.Outf("{{yellow}}pending transfer:{{/}} %s -> %s proposed by %s\n", ownershipRoleName(transfer.Role), transfer.NewOwner, transfer.Proposer)
+		pending[transfer.Role] = transfer.NewOwner
+	}
+	return pending, nil
+}
+
+func ownershipKindName(kind uint8) string {
+	if int(kind) < len(ownershipKindNames) {
+		return ownershipKindNames[kind]
+	}
+	return fmt.Sprintf("kind %d", kind)
+}
+
+func ownershipRoleName(role uint8) string {
+	if int(role) < len(ownershipRoleNames) {
+		return ownershipRoleNames[role]
+	}
+	return fmt.Sprintf("role %d", role)
+}
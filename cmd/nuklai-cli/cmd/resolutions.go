@@ -34,6 +34,12 @@ func sendAndWait(
 	if err != nil {
 		return nil, ids.Empty, err
 	}
+	return registerAndWait(ctx, tx, ws)
+}
+
+// registerAndWait issues an already signed [tx] and waits for its result. It
+// may not be used concurrently.
+func registerAndWait(ctx context.Context, tx *chain.Transaction, ws *ws.WebSocketClient) (*chain.Result, ids.ID, error) {
 	if err := ws.RegisterTx(tx); err != nil {
 		return nil, ids.Empty, err
 	}
@@ -87,10 +93,34 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 				summaryStr += fmt.Sprintf(" memo: %s", act.Memo)
 			}
 			summaryStr += "\n"
+		case *actions.BatchTransfer:
+			var total uint64
+			for _, transfer := range act.Transfers {
+				total += transfer.Value
+			}
+			summaryStr = fmt.Sprintf("assetID: %s amount: %d -> %d recipients\n", act.AssetAddress, total, len(act.Transfers))
+		case *actions.TransferNFT:
+			summaryStr = fmt.Sprintf("nftAddress: %s collectionAddress: %s -> %s", act.AssetAddress, act.CollectionAddress, act.To)
+			if len(act.Memo) > 0 {
+				summaryStr += fmt.Sprintf(" memo: %s", act.Memo)
+			}
+			summaryStr += "\n"
+		case *actions.ApproveAsset:
+			summaryStr = fmt.Sprintf("assetAddress: %s spender: %s amount: %d expiryBlock: %d\n", act.AssetAddress, act.Spender, act.Amount, act.ExpiryBlock)
+		case *actions.TransferAssetFrom:
+			summaryStr = fmt.Sprintf("assetID: %s amount: %d %s -> %s", act.AssetAddress, act.Value, act.From, act.To)
+			if len(act.Memo) > 0 {
+				summaryStr += fmt.Sprintf(" memo: %s", act.Memo)
+			}
+			summaryStr += "\n"
 		case *actions.ContractPublish:
 			summaryStr = fmt.Sprintf("contract published with txID: %s\n", tx.ID())
 		case *actions.ContractDeploy:
 			summaryStr = fmt.Sprintf("contractID: %s creationInfo: %s\n", string(act.ContractID), string(act.CreationInfo))
+		case *actions.ContractUpgrade:
+			summaryStr = fmt.Sprintf("contractAddress: %s contractID: %x version: %d\n", act.ContractAddress, []byte(act.ContractID), act.Version)
+		case *actions.ContractRenounceUpgrade:
+			summaryStr = fmt.Sprintf("contractAddress: %s upgrade authority renounced\n", act.ContractAddress)
 		case *actions.ContractCall:
 			summaryStr = fmt.Sprintf("contractAddress: %s value: %d function: %s calldata: %s\n", act.ContractAddress, act.Value, act.Function, string(act.CallData))
 		case *actions.CreateAsset:
@@ -107,6 +137,21 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 			summaryStr = fmt.Sprintf("assetAddress: %s %d -> 🔥\n", act.AssetAddress, act.Value)
 		case *actions.BurnAssetNFT:
 			summaryStr = fmt.Sprintf("assetAddress: %s nftID: %s -> 🔥\n", act.AssetAddress, act.AssetNftAddress)
+		case *actions.FractionalizeNFT:
+			sharesAddress := storage.AssetAddressFractional(act.AssetNftAddress)
+			summaryStr = fmt.Sprintf("assetAddress: %s nftID: %s -> sharesAssetAddress: %s shares: %d\n", act.AssetAddress, act.AssetNftAddress, sharesAddress, act.Shares)
+		case *actions.RedeemNFT:
+			summaryStr = fmt.Sprintf("assetAddress: %s nftID: %s redeemed\n", act.AssetAddress, act.AssetNftAddress)
+		case *actions.SyncNFTOwner:
+			summaryStr = fmt.Sprintf("assetAddress: %s nftID: %s -> owner: %s\n", act.AssetAddress, act.AssetNftAddress, act.Owner)
+		case *actions.ProposeOwnershipTransfer:
+			summaryStr = fmt.Sprintf("%s: %s role: %s -> %s\n", ownershipKindName(act.Kind), act.Address, ownershipRoleName(act.Role), act.NewOwner)
+		case *actions.AcceptOwnershipTransfer:
+			summaryStr = fmt.Sprintf("%s: %s role: %s accepted\n", ownershipKindName(act.Kind), act.Address, ownershipRoleName(act.Role))
+		case *actions.GrantMintRole:
+			summaryStr = fmt.Sprintf("assetAddress: %s mintQuota: %d mintPeriod: %d -> %s\n", act.AssetAddress, act.MintQuota, act.MintPeriod, act.Account)
+		case *actions.RevokeMintRole:
+			summaryStr = fmt.Sprintf("assetAddress: %s minter revoked: %s\n", act.AssetAddress, act.Account)
 		case *actions.RegisterValidatorStake:
 			summaryStr = fmt.Sprintf("nodeID: %s\n", act.NodeID)
 		case *actions.WithdrawValidatorStake:
@@ -129,12 +174,71 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 			datasetContributionID, _ := ids.ToID([]byte(act.DatasetContributionID))
 			nftAddress := codec.CreateAddress(consts.AssetFractionalTokenID, datasetContributionID)
 			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s contributor: %s nftAddress: %s\n", act.DatasetContributionID, act.DatasetAddress, act.DatasetContributor, nftAddress)
+		case *actions.RejectContributeDataset:
+			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s contributor: %s slashCollateral: %t\n", act.DatasetContributionID, act.DatasetAddress, act.DatasetContributor, act.SlashCollateral)
+		case *actions.PublishDatasetVersion:
+			summaryStr = fmt.Sprintf("datasetAddress: %s version: %d contentHash: %s size: %d\n", act.DatasetAddress, act.Version, act.ContentHash, act.Size)
+		case *actions.CancelContributeDataset:
+			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s cancelled\n", act.DatasetContributionID, act.DatasetAddress)
+		case *actions.ChallengeDatasetContribution:
+			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s challenged\n", act.DatasetContributionID, act.DatasetAddress)
+		case *actions.RespondDatasetChallenge:
+			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s chunkSize: %d\n", act.DatasetContributionID, act.DatasetAddress, len(act.Chunk))
+		case *actions.ClaimDatasetChallenge:
+			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s contributor: %s slashed\n", act.DatasetContributionID, act.DatasetAddress, act.DatasetContributor)
+		case *actions.ReleaseContributionCollateral:
+			summaryStr = fmt.Sprintf("datasetContributionID: %s datasetAddress: %s collateral released\n", act.DatasetContributionID, act.DatasetAddress)
 		case *actions.PublishDatasetMarketplace:
 			summaryStr = fmt.Sprintf("datasetAddress: %s paymentAssetAddress: %s datasetPricePerBlock: %d\n", act.DatasetAddress, act.PaymentAssetAddress, act.DatasetPricePerBlock)
+		case *actions.UpdateMarketplaceListing:
+			summaryStr = fmt.Sprintf("datasetAddress: %s paymentAssetAddress: %s datasetPricePerBlock: %d paused: %t\n", act.DatasetAddress, act.PaymentAssetAddress, act.DatasetPricePerBlock, act.Paused)
+		case *actions.UnpublishDatasetMarketplace:
+			summaryStr = fmt.Sprintf("datasetAddress: %s unpublished\n", act.DatasetAddress)
+		case *actions.SetMarketplacePaymentAsset:
+			summaryStr = fmt.Sprintf("datasetAddress: %s paymentAssetAddress: %s datasetPricePerBlock: %d accepted: %t\n", act.DatasetAddress, act.PaymentAssetAddress, act.DatasetPricePerBlock, act.Accepted)
 		case *actions.SubscribeDatasetMarketplace:
-			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s paymentAssetAddress: %s numBlocksToSubscribe: %d\n", act.MarketplaceAssetAddress, act.PaymentAssetAddress, act.NumBlocksToSubscribe)
+			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s paymentAssetAddress: %s numBlocksToSubscribe: %d encryptionPublicKey: %x\n", act.MarketplaceAssetAddress, act.PaymentAssetAddress, act.NumBlocksToSubscribe, act.EncryptionPublicKey)
 		case *actions.ClaimMarketplacePayment:
 			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s paymentAssetAddress: %s\n", act.MarketplaceAssetAddress, act.PaymentAssetAddress)
+		case *actions.OpenUsageChannel:
+			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s paymentAssetAddress: %s nonce: %d deposit: %d numBlocks: %d\n", act.MarketplaceAssetAddress, act.PaymentAssetAddress, act.Nonce, act.Deposit, act.NumBlocks)
+		case *actions.SettleUsage:
+			summaryStr = fmt.Sprintf("usageChannelAddress: %s amount: %d close: %t\n", act.UsageChannelAddress, act.Amount, act.Close)
+		case *actions.CloseUsageChannel:
+			summaryStr = fmt.Sprintf("usageChannelAddress: %s closed\n", act.UsageChannelAddress)
+		case *actions.DeliverDatasetKey:
+			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s subscriptionNftAddress: %s subscriber: %s encryptedKeySize: %d\n", act.MarketplaceAssetAddress, act.SubscriptionNftAddress, act.Subscriber, len(act.EncryptedKey))
+		case *actions.SetEncryptionPublicKey:
+			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s subscriptionNftAddress: %s encryptionPublicKeySize: %d\n", act.MarketplaceAssetAddress, act.SubscriptionNftAddress, len(act.EncryptionPublicKey))
+		case *actions.SetDatasetKeyDelegate:
+			summaryStr = fmt.Sprintf("marketplaceAssetAddress: %s delegate: %s\n", act.MarketplaceAssetAddress, act.Delegate)
+		case *actions.CreateSessionKey:
+			summaryStr = fmt.Sprintf("sessionKey: %s actionTypeIDs: %v spendLimits: %d expiryBlock: %d\n", act.SessionKey, act.ActionTypeIDs, len(act.SpendLimits), act.ExpiryBlock)
+		case *actions.RevokeSessionKey:
+			summaryStr = fmt.Sprintf("sessionKey: %s revoked\n", act.SessionKey)
+		case *actions.SessionKeyBegin:
+			summaryStr = fmt.Sprintf("sessionKey: %s actionTypeIDs: %v\n", act.SessionKey, act.ActionTypeIDs)
+		case *actions.SessionKeyEnd:
+			summaryStr = fmt.Sprintf("sessionKey: %s\n", act.SessionKey)
+		case *actions.SetSponsorPolicy:
+			policyAddress := storage.SponsorPolicyAddress(actor, act.ActionTypeIDs, act.AssetAddresses, act.DatasetAddresses)
+			summaryStr = fmt.Sprintf("policyAddress: %s actionTypeIDs: %v deposit: %d\n", policyAddress, act.ActionTypeIDs, act.Deposit)
+		case *actions.WithdrawSponsorPolicy:
+			summaryStr = fmt.Sprintf("policyAddress: %s withdrawn amount: %d\n", act.PolicyAddress, act.Amount)
+		case *actions.CreateOrder:
+			orderAddress := storage.OrderAddress(actor, act.InAssetAddress, act.InTick, act.OutAssetAddress, act.OutTick)
+			summaryStr = fmt.Sprintf("orderAddress: %s %d %s -> %d %s supply: %d\n", orderAddress, act.InTick, act.InAssetAddress, act.OutTick, act.OutAssetAddress, act.Supply)
+		case *actions.FillOrder:
+			summaryStr = fmt.Sprintf("orderAddress: %s value: %d %s -> %s\n", act.OrderAddress, act.Value, act.OutAssetAddress, act.Owner)
+		case *actions.CloseOrder:
+			summaryStr = fmt.Sprintf("orderAddress: %s closed\n", act.OrderAddress)
+		case *actions.CreateVestingSchedule:
+			vestingAddress := storage.VestingAddress(actor, act.AssetAddress, act.Beneficiary, act.RevocationAuthority, act.Amount, act.StartBlock, act.CliffBlock, act.EndBlock)
+			summaryStr = fmt.Sprintf("vestingAddress: %s assetAddress: %s amount: %d -> %s cliffBlock: %d endBlock: %d\n", vestingAddress, act.AssetAddress, act.Amount, act.Beneficiary, act.CliffBlock, act.EndBlock)
+		case *actions.ClaimVested:
+			summaryStr = fmt.Sprintf("vestingAddress: %s claimed\n", act.VestingAddress)
+		case *actions.RevokeVestingSchedule:
+			summaryStr = fmt.Sprintf("vestingAddress: %s revoked\n", act.VestingAddress)
 		}
 		utils.Outf(
 			"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}summary (%s):{{/}} [%s] {{yellow}}fee (max %.2f%%):{{/}} %s %s {{yellow}}consumed:{{/}} [%s]\n",
@@ -127,7 +127,15 @@ func init() {
 		balanceKeyCmd,
 		balanceAssetKeyCmd,
 		balanceNFTKeyCmd,
+		assetsKeyCmd,
 		vanityAddressCmd,
+		publicKeyCmd,
+		multisigCreateCmd,
+		multisigProposeCmd,
+		multisigSignCmd,
+		multisigBroadcastCmd,
+		sponsorRequestCmd,
+		sponsorApproveCmd,
 	)
 
 	// chain
@@ -148,10 +156,14 @@ func init() {
 	// actions
 	actionCmd.AddCommand(
 		transferCmd,
+		batchTransferCmd,
 
 		callCmd,
 		publishFileCmd,
 		deployCmd,
+		upgradeCmd,
+		renounceUpgradeCmd,
+		contractInfoCmd,
 
 		registerValidatorStakeCmd,
 		getValidatorStakeCmd,
@@ -162,6 +174,26 @@ func init() {
 		getUserStakeCmd,
 		claimUserStakeRewardCmd,
 		undelegateUserStakeCmd,
+
+		createSessionKeyCmd,
+		revokeSessionKeyCmd,
+		sessionKeyInfoCmd,
+		sessionKeyTransferCmd,
+
+		setSponsorPolicyCmd,
+		withdrawSponsorPolicyCmd,
+		sponsorPolicyInfoCmd,
+
+		createOrderCmd,
+		fillOrderCmd,
+		closeOrderCmd,
+		orderInfoCmd,
+		ordersCmd,
+
+		createVestingScheduleCmd,
+		claimVestedCmd,
+		revokeVestingScheduleCmd,
+		vestingInfoCmd,
 	)
 
 	// emission
@@ -179,6 +211,19 @@ func init() {
 		mintAssetNFTCmd,
 		burnAssetFTCmd,
 		burnAssetNFTCmd,
+		fractionalizeNFTCmd,
+		redeemNFTCmd,
+		syncNFTOwnerCmd,
+		holdersAssetCmd,
+		nftsAssetCmd,
+		syncNFTOwnersAssetCmd,
+		approveAssetCmd,
+		transferAssetFromCmd,
+		allowanceAssetCmd,
+		proposeAssetOwnershipCmd,
+		acceptAssetOwnershipCmd,
+		grantMintRoleCmd,
+		revokeMintRoleCmd,
 	)
 
 	// dataset
@@ -187,9 +232,22 @@ func init() {
 		createDatasetFromExistingAssetCmd,
 		updateDatasetCmd,
 		getDatasetCmd,
+		listDatasetCmd,
 		initiateContributeDatasetCmd,
 		getDataContributionPendingCmd,
 		completeContributeDatasetCmd,
+		rejectContributeDatasetCmd,
+		cancelContributeDatasetCmd,
+		contributionsDatasetCmd,
+		contributionsOfDatasetCmd,
+		publishDatasetVersionCmd,
+		datasetVersionCmd,
+		challengeContributeDatasetCmd,
+		respondChallengeDatasetCmd,
+		claimChallengeDatasetCmd,
+		releaseCollateralDatasetCmd,
+		proposeDatasetOwnershipCmd,
+		acceptDatasetOwnershipCmd,
 	)
 
 	// marketplace
@@ -198,6 +256,20 @@ func init() {
 		subscribeDatasetMarketplaceCmd,
 		infoDatasetMarketplaceCmd,
 		claimPaymentMarketplaceCmd,
+		updateListingMarketplaceCmd,
+		unpublishDatasetMarketplaceCmd,
+		paymentAssetMarketplaceCmd,
+		feeMarketplaceCmd,
+		openUsageMarketplaceCmd,
+		signUsageMarketplaceCmd,
+		settleUsageMarketplaceCmd,
+		closeUsageMarketplaceCmd,
+		deliverKeyMarketplaceCmd,
+		setEncryptionKeyMarketplaceCmd,
+		setKeyDelegateMarketplaceCmd,
+		datasetKeyMarketplaceCmd,
+		proposeListingOwnershipCmd,
+		acceptListingOwnershipCmd,
 	)
 
 	// spam
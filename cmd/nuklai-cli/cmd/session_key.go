@@ -0,0 +1,266 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+	nauth "github.com/nuklai/nuklaivm/auth"
+)
+
+var createSessionKeyCmd = &cobra.Command{
+	Use: "create-session-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		account := nauth.NewSessionKeyAccountAddress(priv.Address)
+		utils.Outf("{{yellow}}session account:{{/}} %s\n", account)
+
+		// Select session key
+		sessionKey, err := prompt.Address("session key address")
+		if err != nil {
+			return err
+		}
+
+		// Select allowed action types
+		rawActionTypeIDs, err := prompt.String("allowed action type IDs (comma separated)", 1, 256)
+		if err != nil {
+			return err
+		}
+		actionTypeIDs, err := parseActionTypeIDs(rawActionTypeIDs)
+		if err != nil {
+			return err
+		}
+
+		// Select spend limits
+		numSpendLimits, err := prompt.Int("number of spend limits", 8)
+		if err != nil {
+			return err
+		}
+		spendLimits := make([]actions.SessionKeySpendLimit, numSpendLimits)
+		for i := range spendLimits {
+			assetAddress, err := parseAsset("spend limit assetAddress")
+			if err != nil {
+				return err
+			}
+			_, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, codec.EmptyAddress, assetAddress, false, false, -1)
+			if err != nil {
+				return err
+			}
+			limit, err := parseAmount("spend limit", decimals, hconsts.MaxUint64)
+			if err != nil {
+				return err
+			}
+			spendLimits[i] = actions.SessionKeySpendLimit{
+				AssetAddress: assetAddress,
+				Limit:        limit,
+			}
+		}
+
+		// Select expiry block
+		currentBlockHeight, _, _, _, _, _, _, err := ncli.EmissionInfo(ctx)
+		if err != nil {
+			return err
+		}
+		expiryBlock, err := prompt.Int(
+			fmt.Sprintf("expiry block(must be after %d)", currentBlockHeight),
+			hconsts.MaxInt,
+		)
+		if err != nil {
+			return err
+		}
+		if uint64(expiryBlock) <= currentBlockHeight {
+			return fmt.Errorf("expiry block must be after the current block height (%d)", currentBlockHeight)
+		}
+		utils.Outf("{{yellow}}fees of the session key transactions are paid from the balance of %s{{/}}\n", nauth.NewSessionKeyFeeAddress(account, sessionKey))
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.CreateSessionKey{
+			SessionKey:    sessionKey,
+			ActionTypeIDs: actionTypeIDs,
+			SpendLimits:   spendLimits,
+			ExpiryBlock:   uint64(expiryBlock),
+		}}, cli, ncli, ws, nauth.NewSessionKeyFactory(priv.Address, factory))
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var revokeSessionKeyCmd = &cobra.Command{
+	Use: "revoke-session-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select session key
+		sessionKey, err := prompt.Address("session key address")
+		if err != nil {
+			return err
+		}
+
+		// Get session key info
+		if _, _, _, err := handler.GetSessionKeyInfo(ctx, ncli, nauth.NewSessionKeyAccountAddress(priv.Address), sessionKey); err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.RevokeSessionKey{
+			SessionKey: sessionKey,
+		}}, cli, ncli, ws, nauth.NewSessionKeyFactory(priv.Address, factory))
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var sessionKeyInfoCmd = &cobra.Command{
+	Use: "session-key-info",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select owner and session key
+		owner, err := prompt.Address("owner address")
+		if err != nil {
+			return err
+		}
+		sessionKey, err := prompt.Address("session key address")
+		if err != nil {
+			return err
+		}
+		account := nauth.NewSessionKeyAccountAddress(owner)
+		utils.Outf("{{yellow}}session account:{{/}} %s\n", account)
+		utils.Outf("{{yellow}}fee account:{{/}} %s\n", nauth.NewSessionKeyFeeAddress(account, sessionKey))
+
+		// Get session key info
+		_, _, _, err = handler.GetSessionKeyInfo(ctx, ncli, account, sessionKey)
+		return err
+	},
+}
+
+var sessionKeyTransferCmd = &cobra.Command{
+	Use: "session-key-transfer",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}signing with session key:{{/}} %s\n", priv.Address)
+
+		// Select owner of the session account the session key acts for
+		owner, err := prompt.Address("owner address")
+		if err != nil {
+			return err
+		}
+		account := nauth.NewSessionKeyAccountAddress(owner)
+
+		// Get session key info
+		_, _, spendLimits, err := handler.GetSessionKeyInfo(ctx, ncli, account, priv.Address)
+		if err != nil {
+			return err
+		}
+		assetAddresses := make([]codec.Address, len(spendLimits))
+		for i, spendLimit := range spendLimits {
+			assetAddresses[i], err = codec.StringToAddress(spendLimit.AssetAddress)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Get assetAddress
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+		collectionAddress, _, err := getAssetCollection(ctx, ncli, assetAddress)
+		if err != nil {
+			return err
+		}
+
+		// Get balance info
+		balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, account, assetAddress, true, false, -1)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := prompt.Address("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := parseAmount("amount", decimals, balance)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, txID, err := sendAndWait(ctx, nauth.NewSessionKeyActions(priv.Address, assetAddresses, []chain.Action{transferAction(recipient, assetAddress, collectionAddress, amount)}), cli, ncli, ws, nauth.NewSessionKeyFactory(owner, factory))
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}txID:{{/}} %s\n", txID)
+		return processResult(result)
+	},
+}
+
+func parseActionTypeIDs(raw string) ([]byte, error) {
+	parts := strings.Split(raw, ",")
+	actionTypeIDs := make([]byte, 0, len(parts))
+	for _, part := range parts {
+		actionTypeID, err := strconv.ParseUint(strings.TrimSpace(part), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid action type ID %q: %w", part, err)
+		}
+		actionTypeIDs = append(actionTypeIDs, uint8(actionTypeID))
+	}
+	return actionTypeIDs, nil
+}
@@ -0,0 +1,443 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/consts"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/nuklai/nuklaivm/vm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+	nauth "github.com/nuklai/nuklaivm/auth"
+	nutils "github.com/nuklai/nuklaivm/utils"
+)
+
+// sponsorPolicyConfig is shared by a sponsor with the accounts it pays fees
+// for
+type sponsorPolicyConfig struct {
+	Address string               `json:"address"`
+	Sponsor nauth.MultisigSigner `json:"sponsor"`
+	Policy  nauth.SponsorPolicy  `json:"policy"`
+}
+
+// sponsorRequest is a transaction signed by its actor waiting for the
+// signature of the sponsor
+type sponsorRequest struct {
+	SponsorPolicy  sponsorPolicyConfig  `json:"sponsorPolicy"`
+	Tx             string               `json:"tx"`
+	Expiry         int64                `json:"expiry"`
+	Actor          nauth.MultisigSigner `json:"actor"`
+	ActorSignature []byte               `json:"actorSignature"`
+}
+
+var setSponsorPolicyCmd = &cobra.Command{
+	Use: "set-sponsor-policy",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select sponsored action types
+		rawActionTypeIDs, err := prompt.String("sponsored action type IDs (comma separated)", 1, 256)
+		if err != nil {
+			return err
+		}
+		actionTypeIDs, err := parseActionTypeIDs(rawActionTypeIDs)
+		if err != nil {
+			return err
+		}
+
+		// Select sponsored assets and datasets
+		rawAssetAddresses, err := prompt.String("sponsored assetAddresses (comma separated, empty for any)", 0, 2048)
+		if err != nil {
+			return err
+		}
+		assetAddresses, err := parseAddresses(rawAssetAddresses)
+		if err != nil {
+			return err
+		}
+		rawDatasetAddresses, err := prompt.String("sponsored datasetAddresses (comma separated, empty for any)", 0, 2048)
+		if err != nil {
+			return err
+		}
+		datasetAddresses, err := parseAddresses(rawDatasetAddresses)
+		if err != nil {
+			return err
+		}
+
+		// Select deposit
+		balance, _, _, _, _, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, storage.NAIAddress, true, false, -1)
+		if err != nil {
+			return err
+		}
+		deposit, err := parseAmount("deposit", consts.Decimals, balance)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.SetSponsorPolicy{
+			ActionTypeIDs:    actionTypeIDs,
+			AssetAddresses:   assetAddresses,
+			DatasetAddresses: datasetAddresses,
+			Deposit:          deposit,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		if err := processResult(result); err != nil || !result.Success {
+			return err
+		}
+
+		// Write the config the sponsored accounts need to request signatures
+		sponsor, err := nauth.NewMultisigSigner(factory)
+		if err != nil {
+			return err
+		}
+		policy := nauth.SponsorPolicy{
+			ActionTypeIDs:    actionTypeIDs,
+			AssetAddresses:   assetAddresses,
+			DatasetAddresses: datasetAddresses,
+		}
+		policyAddress := policy.Address(priv.Address)
+		filename := fmt.Sprintf("%s.sponsor.json", policyAddress)
+		if err := writeJSON(filename, &sponsorPolicyConfig{
+			Address: policyAddress.String(),
+			Sponsor: sponsor,
+			Policy:  policy,
+		}); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}sponsor policy address:{{/}} %s\n", policyAddress)
+		utils.Outf("{{green}}sponsor account paying the fees:{{/}} %s\n", storage.SponsorAccountAddress(priv.Address))
+		utils.Outf("{{yellow}}share the config with the sponsored accounts:{{/}} %s\n", filename)
+		return nil
+	},
+}
+
+var withdrawSponsorPolicyCmd = &cobra.Command{
+	Use: "withdraw-sponsor-policy",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select policy
+		policyAddress, err := prompt.Address("sponsor policy address")
+		if err != nil {
+			return err
+		}
+
+		// Get policy info
+		sponsor, _, _, _, balance, err := handler.GetSponsorPolicyInfo(ctx, ncli, policyAddress)
+		if err != nil {
+			return err
+		}
+		if sponsor != priv.Address.String() {
+			utils.Outf("{{red}}%s is the sponsor of policy %s{{/}}\n", sponsor, policyAddress)
+			return nil
+		}
+
+		// Select the amount returned from the sponsor account, the rest keeps
+		// paying for the other policies
+		amount, err := parseAmount("amount", consts.Decimals, balance)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.WithdrawSponsorPolicy{
+			PolicyAddress: policyAddress,
+			Amount:        amount,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var sponsorPolicyInfoCmd = &cobra.Command{
+	Use: "sponsor-policy-info",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select policy
+		policyAddress, err := prompt.Address("sponsor policy address")
+		if err != nil {
+			return err
+		}
+
+		// Get policy info
+		_, _, _, _, _, err = handler.GetSponsorPolicyInfo(ctx, ncli, policyAddress)
+		return err
+	},
+}
+
+var sponsorRequestCmd = &cobra.Command{
+	Use: "sponsor-request [transfer/subscribe] [sponsor policy config]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		switch args[0] {
+		case "transfer", "subscribe":
+			return nil
+		default:
+			return ErrInvalidArgs
+		}
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var config sponsorPolicyConfig
+		if err := readJSON(args[1], &config); err != nil {
+			return err
+		}
+		_, _, factory, cli, ncli, _, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Sponsored transactions act for the sponsor account of the actor
+		sponsoredFactory := nauth.NewSponsoredFactory(factory, nauth.NewPresignedFactory(config.Sponsor, nil), config.Policy)
+		actorAddress := sponsoredFactory.Address()
+		utils.Outf("{{yellow}}sponsored account:{{/}} %s\n", actorAddress)
+
+		var action chain.Action
+		switch args[0] {
+		case "transfer":
+			assetAddress, err := parseAsset("assetAddress")
+			if err != nil {
+				return err
+			}
+			collectionAddress, _, err := getAssetCollection(ctx, ncli, assetAddress)
+			if err != nil {
+				return err
+			}
+			balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, actorAddress, assetAddress, true, false, -1)
+			if balance == 0 || err != nil {
+				return err
+			}
+			recipient, err := prompt.Address("recipient")
+			if err != nil {
+				return err
+			}
+			amount, err := parseAmount("amount", decimals, balance)
+			if err != nil {
+				return err
+			}
+			action = transferAction(recipient, assetAddress, collectionAddress, amount)
+		case "subscribe":
+			datasetAddress, err := prompt.Address("datasetAddress")
+			if err != nil {
+				return err
+			}
+			paymentAssetAddress, err := parseAsset("paymentAssetAddress")
+			if err != nil {
+				return err
+			}
+			numBlocksToSubscribe, err := prompt.Int("numBlocksToSubscribe", hconsts.MaxInt)
+			if err != nil {
+				return err
+			}
+			encryptionPublicKey, err := promptEncryptionPublicKey()
+			if err != nil {
+				return err
+			}
+			action = &actions.SubscribeDatasetMarketplace{
+				MarketplaceAssetAddress: storage.AssetAddressFractional(datasetAddress),
+				PaymentAssetAddress:     paymentAssetAddress,
+				NumBlocksToSubscribe:    uint64(numBlocksToSubscribe),
+				EncryptionPublicKey:     encryptionPublicKey,
+			}
+		}
+
+		// Build the unsigned transaction the same way the RPC client does
+		parser, err := ncli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		unitPrices, err := cli.UnitPrices(ctx, true)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		rules := parser.Rules(now)
+		units, err := chain.EstimateUnits(rules, []chain.Action{action}, sponsoredFactory)
+		if err != nil {
+			return err
+		}
+		maxFee, err := fees.MulSum(unitPrices, units)
+		if err != nil {
+			return err
+		}
+		txData := chain.NewTxData(&chain.Base{
+			Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
+			ChainID:   rules.GetChainID(),
+			MaxFee:    maxFee,
+		}, []chain.Action{action})
+		unsignedBytes, err := txData.UnsignedBytes()
+		if err != nil {
+			return err
+		}
+
+		// Sign as the actor
+		actorAuth, err := factory.Sign(unsignedBytes)
+		if err != nil {
+			return err
+		}
+		actor, actorSignature, err := nauth.NewPresignedSignature(actorAuth)
+		if err != nil {
+			return err
+		}
+
+		request := &sponsorRequest{
+			SponsorPolicy:  config,
+			Tx:             codec.ToHex(unsignedBytes),
+			Expiry:         txData.Expiry(),
+			Actor:          actor,
+			ActorSignature: actorSignature,
+		}
+		filename := fmt.Sprintf("%s.sponsor-request.json", utils.ToID(unsignedBytes))
+		if err := writeJSON(filename, request); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}created sponsor request:{{/}} %s\n", filename)
+		utils.Outf(
+			"{{yellow}}the sponsor must approve it before:{{/}} %s\n",
+			time.UnixMilli(txData.Expiry()).Format(time.RFC3339),
+		)
+		return nil
+	},
+}
+
+var sponsorApproveCmd = &cobra.Command{
+	Use: "sponsor-approve [sponsor request]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var request sponsorRequest
+		if err := readJSON(args[0], &request); err != nil {
+			return err
+		}
+		unsignedBytes, err := codec.LoadHex(request.Tx, -1)
+		if err != nil {
+			return err
+		}
+		txData, err := chain.UnmarshalTxData(codec.NewReader(unsignedBytes, hconsts.NetworkSizeLimit), vm.ActionParser)
+		if err != nil {
+			return err
+		}
+		if txData.Expiry() < time.Now().UnixMilli() {
+			return fmt.Errorf("request expired at %s", time.UnixMilli(txData.Expiry()).Format(time.RFC3339))
+		}
+
+		// Only the sponsor of the policy can approve
+		_, priv, factory, _, _, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		policyAddress := request.SponsorPolicy.Policy.Address(priv.Address)
+		if policyAddress.String() != request.SponsorPolicy.Address {
+			return fmt.Errorf("%w: %s is not the sponsor of policy %s", ErrInvalidAddress, priv.Address, request.SponsorPolicy.Address)
+		}
+
+		// Show what is being paid for before asking for confirmation
+		actorFactory := nauth.NewPresignedFactory(request.Actor, request.ActorSignature)
+		utils.Outf("{{yellow}}actor:{{/}} %s\n", storage.SponsorAccountAddress(actorFactory.Address()))
+		utils.Outf("{{yellow}}sponsor policy:{{/}} %s\n", policyAddress)
+		utils.Outf("{{yellow}}max fee:{{/}} %s %s\n", nutils.FormatBalance(txData.MaxFee(), consts.Decimals), consts.Symbol)
+		for i, action := range txData.Actions {
+			b, err := json.Marshal(action)
+			if err != nil {
+				return err
+			}
+			utils.Outf("{{yellow}}action %d:{{/}} %T %s\n", i, action, b)
+		}
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		sponsoredFactory := nauth.NewSponsoredFactory(actorFactory, factory, request.SponsorPolicy.Policy)
+		tx, err := txData.Sign(sponsoredFactory, vm.ActionParser, vm.AuthParser)
+		if err != nil {
+			return err
+		}
+		if err := tx.VerifyAuth(ctx); err != nil {
+			return err
+		}
+		result, txID, err := registerAndWait(ctx, tx, ws)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}txID:{{/}} %s\n", txID)
+		return processResult(result)
+	},
+}
+
+// parseAddresses parses a comma separated list of addresses. NAI may be used
+// for the native token.
+func parseAddresses(raw string) ([]codec.Address, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	addresses := make([]codec.Address, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == consts.Symbol {
+			addresses[i] = storage.NAIAddress
+			continue
+		}
+		address, err := codec.StringToAddress(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", part, err)
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
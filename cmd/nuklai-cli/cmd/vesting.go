@@ -0,0 +1,235 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli/prompt"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+)
+
+var createVestingScheduleCmd = &cobra.Command{
+	Use: "create-vesting",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select asset to lock
+		assetAddress, err := parseAsset("assetAddress")
+		if err != nil {
+			return err
+		}
+		balance, _, _, _, decimals, _, _, _, _, _, _, _, _, err := handler.GetAssetInfo(ctx, ncli, priv.Address, assetAddress, true, false, -1)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select beneficiary and amount
+		beneficiary, err := prompt.Address("beneficiary")
+		if err != nil {
+			return err
+		}
+		amount, err := parseAmount("amount", decimals, balance)
+		if err != nil {
+			return err
+		}
+
+		// Select schedule
+		currentBlockHeight, _, _, _, _, _, _, err := ncli.EmissionInfo(ctx)
+		if err != nil {
+			return err
+		}
+		startBlock, err := prompt.Int(fmt.Sprintf("start block(current block is %d)", currentBlockHeight), hconsts.MaxInt)
+		if err != nil {
+			return err
+		}
+		cliffBlock, err := prompt.Int(fmt.Sprintf("cliff block(must be at least %d)", startBlock), hconsts.MaxInt)
+		if err != nil {
+			return err
+		}
+		endBlock, err := prompt.Int(fmt.Sprintf("end block(must be at least %d)", max(cliffBlock, startBlock+1)), hconsts.MaxInt)
+		if err != nil {
+			return err
+		}
+		if cliffBlock < startBlock || endBlock <= startBlock || endBlock < cliffBlock {
+			return fmt.Errorf("start block (%d) <= cliff block (%d) <= end block (%d) is required with start block < end block", startBlock, cliffBlock, endBlock)
+		}
+
+		// Select revocation authority
+		revocationAuthority := codec.EmptyAddress
+		revocable, err := prompt.Bool("revocable")
+		if err != nil {
+			return err
+		}
+		if revocable {
+			revocationAuthority, err = prompt.Address("revocation authority")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		create := &actions.CreateVestingSchedule{
+			AssetAddress:        assetAddress,
+			Beneficiary:         beneficiary,
+			Amount:              amount,
+			StartBlock:          uint64(startBlock),
+			CliffBlock:          uint64(cliffBlock),
+			EndBlock:            uint64(endBlock),
+			RevocationAuthority: revocationAuthority,
+		}
+		result, _, err := sendAndWait(ctx, []chain.Action{create}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		if err := processResult(result); err != nil || !result.Success {
+			return err
+		}
+		vestingAddress := storage.VestingAddress(priv.Address, create.AssetAddress, create.Beneficiary, create.RevocationAuthority, create.Amount, create.StartBlock, create.CliffBlock, create.EndBlock)
+		utils.Outf("{{green}}vesting address:{{/}} %s\n", vestingAddress)
+		return nil
+	},
+}
+
+var claimVestedCmd = &cobra.Command{
+	Use: "claim-vested",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select schedule
+		vestingAddress, err := prompt.Address("vestingAddress")
+		if err != nil {
+			return err
+		}
+		schedule, err := handler.GetVestingScheduleInfo(ctx, ncli, vestingAddress)
+		if err != nil {
+			return err
+		}
+		if schedule.Beneficiary != priv.Address.String() {
+			utils.Outf("{{red}}%s is the beneficiary of vesting schedule %s{{/}}\n", schedule.Beneficiary, vestingAddress)
+			return nil
+		}
+		if schedule.Claimable == 0 {
+			utils.Outf("{{red}}nothing vested to claim{{/}}\n")
+			return nil
+		}
+		assetAddress, err := codec.StringToAddress(schedule.AssetAddress)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.ClaimVested{
+			VestingAddress: vestingAddress,
+			AssetAddress:   assetAddress,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var revokeVestingScheduleCmd = &cobra.Command{
+	Use: "revoke-vesting",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, ncli, ws, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select schedule
+		vestingAddress, err := prompt.Address("vestingAddress")
+		if err != nil {
+			return err
+		}
+		schedule, err := handler.GetVestingScheduleInfo(ctx, ncli, vestingAddress)
+		if err != nil {
+			return err
+		}
+		if schedule.RevocationAuthority != priv.Address.String() {
+			utils.Outf("{{red}}%s is not the revocation authority of vesting schedule %s{{/}}\n", priv.Address, vestingAddress)
+			return nil
+		}
+		assetAddress, err := codec.StringToAddress(schedule.AssetAddress)
+		if err != nil {
+			return err
+		}
+		beneficiary, err := codec.StringToAddress(schedule.Beneficiary)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := prompt.Continue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		result, _, err := sendAndWait(ctx, []chain.Action{&actions.RevokeVestingSchedule{
+			VestingAddress: vestingAddress,
+			AssetAddress:   assetAddress,
+			Beneficiary:    beneficiary,
+		}}, cli, ncli, ws, factory)
+		if err != nil {
+			return err
+		}
+		return processResult(result)
+	},
+}
+
+var vestingInfoCmd = &cobra.Command{
+	Use: "vesting-info",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+
+		// Get clients
+		nclients, err := handler.DefaultNuklaiVMJSONRPCClient(checkAllChains)
+		if err != nil {
+			return err
+		}
+		ncli := nclients[0]
+
+		// Select schedule
+		vestingAddress, err := prompt.Address("vestingAddress")
+		if err != nil {
+			return err
+		}
+
+		// Get schedule info
+		_, err = handler.GetVestingScheduleInfo(ctx, ncli, vestingAddress)
+		return err
+	},
+}
@@ -5,29 +5,70 @@ package consts
 
 const (
 	// Action TypeIDs
-	TransferID                    uint8 = iota // 0
-	ContractCallID                             // 1
-	ContractDeployID                           // 2
-	ContractPublishID                          // 3
-	CreateAssetID                              // 4
-	UpdateAssetID                              // 5
-	MintAssetFTID                              // 6
-	MintAssetNFTID                             // 7
-	BurnAssetFTID                              // 8
-	BurnAssetNFTID                             // 9
-	RegisterValidatorStakeID                   // 10
-	WithdrawValidatorStakeID                   // 11
-	ClaimValidatorStakeRewardsID               // 12
-	DelegateUserStakeID                        // 13
-	UndelegateUserStakeID                      // 14
-	ClaimDelegationStakeRewardsID              // 15
-	CreateDatasetID                            // 16
-	UpdateDatasetID                            // 17
-	InitiateContributeDatasetID                // 18
-	CompleteContributeDatasetID                // 19
-	PublishDatasetMarketplaceID                // 20
-	SubscribeDatasetMarketplaceID              // 21
-	ClaimMarketplacePaymentID                  // 22
+	TransferID                      uint8 = iota // 0
+	ContractCallID                               // 1
+	ContractDeployID                             // 2
+	ContractPublishID                            // 3
+	CreateAssetID                                // 4
+	UpdateAssetID                                // 5
+	MintAssetFTID                                // 6
+	MintAssetNFTID                               // 7
+	BurnAssetFTID                                // 8
+	BurnAssetNFTID                               // 9
+	RegisterValidatorStakeID                     // 10
+	WithdrawValidatorStakeID                     // 11
+	ClaimValidatorStakeRewardsID                 // 12
+	DelegateUserStakeID                          // 13
+	UndelegateUserStakeID                        // 14
+	ClaimDelegationStakeRewardsID                // 15
+	CreateDatasetID                              // 16
+	UpdateDatasetID                              // 17
+	InitiateContributeDatasetID                  // 18
+	CompleteContributeDatasetID                  // 19
+	PublishDatasetMarketplaceID                  // 20
+	SubscribeDatasetMarketplaceID                // 21
+	ClaimMarketplacePaymentID                    // 22
+	ContractUpgradeID                            // 23
+	ContractRenounceUpgradeID                    // 24
+	CreateSessionKeyID                           // 25
+	RevokeSessionKeyID                           // 26
+	SessionKeyBeginID                            // 27
+	SessionKeyEndID                              // 28
+	SetSponsorPolicyID                           // 29
+	WithdrawSponsorPolicyID                      // 30
+	ApproveAssetID                               // 31
+	TransferAssetFromID                          // 32
+	BatchTransferID                              // 33
+	CreateOrderID                                // 34
+	FillOrderID                                  // 35
+	CloseOrderID                                 // 36
+	CreateVestingScheduleID                      // 37
+	ClaimVestedID                                // 38
+	RevokeVestingScheduleID                      // 39
+	FractionalizeNFTID                           // 40
+	RedeemNFTID                                  // 41
+	SyncNFTOwnerID                               // 42
+	ProposeOwnershipTransferID                   // 43
+	AcceptOwnershipTransferID                    // 44
+	GrantMintRoleID                              // 45
+	RevokeMintRoleID                             // 46
+	RejectContributeDatasetID                    // 47
+	CancelContributeDatasetID                    // 48
+	PublishDatasetVersionID                      // 49
+	ChallengeDatasetContributionID               // 50
+	RespondDatasetChallengeID                    // 51
+	ClaimDatasetChallengeID                      // 52
+	UpdateMarketplaceListingID                   // 53
+	UnpublishDatasetMarketplaceID                // 54
+	SetMarketplacePaymentAssetID                 // 55
+	OpenUsageChannelID                           // 56
+	SettleUsageID                                // 57
+	CloseUsageChannelID                          // 58
+	DeliverDatasetKeyID                          // 59
+	ReleaseContributionCollateralID              // 60
+	SetEncryptionPublicKeyID                     // 61
+	SetDatasetKeyDelegateID                      // 62
+	TransferNFTID                                // 63
 )
 
 const (
@@ -43,3 +84,32 @@ const (
 	AssetFractionalTokenDesc  = "Fractional Token"   // #nosec
 	AssetMarketplaceTokenDesc = "Marketplace Token"  // #nose
 )
+
+const (
+	// Kinds of what an ownership transfer hands off
+	OwnershipKindAsset   uint8 = iota // 0
+	OwnershipKindDataset              // 1
+	OwnershipKindListing              // 2
+)
+
+const (
+	// Roles an ownership transfer hands off. Datasets and marketplace
+	// listings only have an owner.
+	OwnershipRoleOwner                        uint8 = iota // 0
+	OwnershipRoleMintAdmin                                 // 1
+	OwnershipRolePauseUnpauseAdmin                         // 2
+	OwnershipRoleFreezeUnfreezeAdmin                       // 3
+	OwnershipRoleEnableDisableKYCAccountAdmin              // 4
+)
+
+const (
+	// TypeID of the sponsor accounts and sponsor policies used by sponsored
+	// transactions. It must be the sponsored auth type because the actor and
+	// the sponsor of a transaction must be of the type of its auth.
+	SponsoredAddressID uint8 = 0x05
+
+	// TypeIDs of addresses that are not controlled by any key
+	OrderAddressID        uint8 = 0xf1
+	VestingAddressID      uint8 = 0xf2
+	UsageChannelAddressID uint8 = 0xf3
+)
@@ -0,0 +1,210 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contributions
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// MaxPageSize is the most entries returned by a single query
+const MaxPageSize = 1000
+
+// Contribution is a contribution to a dataset. Pending contributions are not
+// active until the dataset owner completes them.
+type Contribution struct {
+	ContributionID string `json:"contributionID"`
+	DatasetAddress string `json:"datasetAddress"`
+	Contributor    string `json:"contributor"`
+	DataLocation   string `json:"dataLocation"`
+	DataIdentifier string `json:"dataIdentifier"`
+	Active         bool   `json:"active"`
+	InitiatedAt    uint64 `json:"initiatedAt"`
+}
+
+// Contributions tracks the contributions of every dataset and contributor
+// from the blocks accepted by the node. Contributions are persisted to a
+// database and loaded back when the node restarts. Contributions made before
+// the index was enabled, or before a node state synced, are not tracked until
+// they change again.
+type Contributions struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	contributions map[ids.ID]Contribution
+
+	// Contribution IDs by dataset and by contributor
+	datasets     map[codec.Address]map[ids.ID]struct{}
+	contributors map[codec.Address]map[ids.ID]struct{}
+}
+
+// New loads the contributions persisted to [db]
+func New(db database.Database) (*Contributions, error) {
+	c := &Contributions{
+		db:            db,
+		contributions: make(map[ids.ID]Contribution),
+		datasets:      make(map[codec.Address]map[ids.ID]struct{}),
+		contributors:  make(map[codec.Address]map[ids.ID]struct{}),
+	}
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		contributionID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		var contribution Contribution
+		if err := json.Unmarshal(iter.Value(), &contribution); err != nil {
+			return nil, err
+		}
+		datasetAddress, err := codec.StringToAddress(contribution.DatasetAddress)
+		if err != nil {
+			return nil, err
+		}
+		contributor, err := codec.StringToAddress(contribution.Contributor)
+		if err != nil {
+			return nil, err
+		}
+		c.put(contributionID, datasetAddress, contributor, contribution)
+	}
+	return c, iter.Error()
+}
+
+// Put sets the contribution [contributionID] of [contributor] to
+// [datasetAddress]
+func (c *Contributions) Put(
+	contributionID ids.ID,
+	datasetAddress codec.Address,
+	contributor codec.Address,
+	dataLocation string,
+	dataIdentifier string,
+	active bool,
+	initiatedAt uint64,
+) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	contribution := Contribution{
+		ContributionID: contributionID.String(),
+		DatasetAddress: datasetAddress.String(),
+		Contributor:    contributor.String(),
+		DataLocation:   dataLocation,
+		DataIdentifier: dataIdentifier,
+		Active:         active,
+		InitiatedAt:    initiatedAt,
+	}
+	b, err := json.Marshal(contribution)
+	if err != nil {
+		return err
+	}
+	if err := c.db.Put(contributionID[:], b); err != nil {
+		return err
+	}
+	c.put(contributionID, datasetAddress, contributor, contribution)
+	return nil
+}
+
+func (c *Contributions) put(contributionID ids.ID, datasetAddress codec.Address, contributor codec.Address, contribution Contribution) {
+	c.contributions[contributionID] = contribution
+	putID(c.datasets, datasetAddress, contributionID)
+	putID(c.contributors, contributor, contributionID)
+}
+
+// Remove removes the rejected or cancelled contribution [contributionID]
+func (c *Contributions) Remove(contributionID ids.ID) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	contribution, ok := c.contributions[contributionID]
+	if !ok {
+		return nil
+	}
+	if err := c.db.Delete(contributionID[:]); err != nil {
+		return err
+	}
+	delete(c.contributions, contributionID)
+	// Addresses were stored from valid addresses
+	datasetAddress, _ := codec.StringToAddress(contribution.DatasetAddress)
+	contributor, _ := codec.StringToAddress(contribution.Contributor)
+	deleteID(c.datasets, datasetAddress, contributionID)
+	deleteID(c.contributors, contributor, contributionID)
+	return nil
+}
+
+func (c *Contributions) Close() error {
+	return c.db.Close()
+}
+
+// DatasetContributions returns at most [limit] contributions to
+// [datasetAddress] ordered by ID and starting after [cursor]. Only pending
+// contributions are returned if [pendingOnly]. The returned cursor is empty
+// once there is nothing left.
+func (c *Contributions) DatasetContributions(datasetAddress codec.Address, pendingOnly bool, cursor string, limit int) ([]Contribution, string) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.page(c.datasets[datasetAddress], pendingOnly, cursor, limit)
+}
+
+// ContributionsByContributor returns at most [limit] contributions of
+// [contributor] ordered by ID and starting after [cursor]
+func (c *Contributions) ContributionsByContributor(contributor codec.Address, pendingOnly bool, cursor string, limit int) ([]Contribution, string) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.page(c.contributors[contributor], pendingOnly, cursor, limit)
+}
+
+// page returns at most [limit] contributions of [contributionIDs] after
+// [cursor] in the order of their ID and the cursor of the next page
+func (c *Contributions) page(contributionIDs map[ids.ID]struct{}, pendingOnly bool, cursor string, limit int) ([]Contribution, string) {
+	if limit <= 0 || limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	keys := make([]string, 0, len(contributionIDs))
+	for contributionID := range contributionIDs {
+		if pendingOnly && c.contributions[contributionID].Active {
+			continue
+		}
+		if s := contributionID.String(); s > cursor {
+			keys = append(keys, s)
+		}
+	}
+	slices.Sort(keys)
+
+	next := ""
+	if len(keys) > limit {
+		keys = keys[:limit]
+		next = keys[limit-1]
+	}
+	page := make([]Contribution, len(keys))
+	for i, k := range keys {
+		// Keys are the string form of valid IDs
+		contributionID, _ := ids.FromString(k)
+		page[i] = c.contributions[contributionID]
+	}
+	return page, next
+}
+
+func putID(m map[codec.Address]map[ids.ID]struct{}, k codec.Address, contributionID ids.ID) {
+	contributionIDs, ok := m[k]
+	if !ok {
+		contributionIDs = make(map[ids.ID]struct{})
+		m[k] = contributionIDs
+	}
+	contributionIDs[contributionID] = struct{}{}
+}
+
+func deleteID(m map[codec.Address]map[ids.ID]struct{}, k codec.Address, contributionID ids.ID) {
+	delete(m[k], contributionID)
+	if len(m[k]) == 0 {
+		delete(m, k)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contributions
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec/codectest"
+)
+
+func TestContributions(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	c, err := New(db)
+	require.NoError(err)
+	dataset := codectest.NewRandomAddress()
+	contributor := codectest.NewRandomAddress()
+	other := codectest.NewRandomAddress()
+	pending := ids.GenerateTestID()
+	active := ids.GenerateTestID()
+	elsewhere := ids.GenerateTestID()
+
+	// Contributions are tracked by dataset and by contributor
+	require.NoError(c.Put(pending, dataset, contributor, "default", "id-1", false, 10))
+	require.NoError(c.Put(active, dataset, other, "default", "id-2", true, 11))
+	require.NoError(c.Put(elsewhere, codectest.NewRandomAddress(), contributor, "default", "id-3", false, 12))
+	contributions, next := c.DatasetContributions(dataset, false, "", 0)
+	require.Len(contributions, 2)
+	require.Empty(next)
+	contributions, _ = c.ContributionsByContributor(contributor, false, "", 0)
+	require.Len(contributions, 2)
+
+	// Pending contributions can be listed on their own
+	contributions, _ = c.DatasetContributions(dataset, true, "", 0)
+	require.Equal([]Contribution{{
+		ContributionID: pending.String(),
+		DatasetAddress: dataset.String(),
+		Contributor:    contributor.String(),
+		DataLocation:   "default",
+		DataIdentifier: "id-1",
+		InitiatedAt:    10,
+	}}, contributions)
+
+	// Completing a contribution updates its status
+	require.NoError(c.Put(pending, dataset, contributor, "default", "id-1", true, 10))
+	contributions, _ = c.DatasetContributions(dataset, true, "", 0)
+	require.Empty(contributions)
+
+	// Rejected or cancelled contributions are removed
+	require.NoError(c.Remove(elsewhere))
+	contributions, _ = c.ContributionsByContributor(contributor, false, "", 0)
+	require.Len(contributions, 1)
+	require.Equal(pending.String(), contributions[0].ContributionID)
+
+	// Contributions are loaded back after a restart
+	reloaded, err := New(db)
+	require.NoError(err)
+	reloadedContributions, _ := reloaded.ContributionsByContributor(contributor, false, "", 0)
+	require.Equal(contributions, reloadedContributions)
+	reloadedContributions, _ = reloaded.DatasetContributions(dataset, false, "", 0)
+	require.Len(reloadedContributions, 2)
+}
+
+func TestContributionsPagination(t *testing.T) {
+	require := require.New(t)
+	c, err := New(memdb.New())
+	require.NoError(err)
+	dataset := codectest.NewRandomAddress()
+	for i := 0; i < 5; i++ {
+		require.NoError(c.Put(ids.GenerateTestID(), dataset, codectest.NewRandomAddress(), "default", "id", false, 0))
+	}
+
+	// Pages follow each other until the cursor is empty
+	seen := map[string]struct{}{}
+	cursor := ""
+	for {
+		contributions, next := c.DatasetContributions(dataset, false, cursor, 2)
+		require.LessOrEqual(len(contributions), 2)
+		for _, contribution := range contributions {
+			seen[contribution.ContributionID] = struct{}{}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	require.Len(seen, 5)
+}
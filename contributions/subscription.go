@@ -0,0 +1,85 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contributions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/nuklai/nuklaivm/storage"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/event"
+)
+
+const (
+	Namespace = "contributions"
+)
+
+var _ event.SubscriptionFactory[*chain.ExecutedBlock] = (*ContributionsSubscriptionFactory)(nil)
+
+type ContributionsSubscriptionFactory struct {
+	log           logging.Logger
+	contributions *Contributions
+	readState     storage.ReadState
+}
+
+func (c *ContributionsSubscriptionFactory) New() (event.Subscription[*chain.ExecutedBlock], error) {
+	return c, nil
+}
+
+// Accept reads back every contribution the transactions of [blk] may have
+// changed
+func (c *ContributionsSubscriptionFactory) Accept(blk *chain.ExecutedBlock) error {
+	ctx := context.Background()
+	stateManager := &storage.StateManager{}
+	for i, tx := range blk.Block.Txs {
+		if !blk.Results[i].Success {
+			continue
+		}
+		stateKeys, err := tx.StateKeys(stateManager)
+		if err != nil {
+			c.log.Warn("failed to update contributions", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			continue
+		}
+		for k := range stateKeys {
+			contributionID, ok := storage.ParseDatasetContributionInfoKey([]byte(k))
+			if !ok {
+				continue
+			}
+			// Contributions are best effort and must never halt the chain
+			if err := c.acceptContribution(ctx, contributionID); err != nil {
+				c.log.Warn("failed to update contributions", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+func (c *ContributionsSubscriptionFactory) acceptContribution(ctx context.Context, contributionID ids.ID) error {
+	datasetAddress, dataLocation, dataIdentifier, contributor, active, initiatedAt, err := storage.GetDatasetContributionInfoFromState(ctx, c.readState, contributionID)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return c.contributions.Remove(contributionID)
+	case err != nil:
+		return err
+	}
+	return c.contributions.Put(contributionID, datasetAddress, contributor, string(dataLocation), string(dataIdentifier), active, initiatedAt)
+}
+
+func (c *ContributionsSubscriptionFactory) Close() error {
+	return c.contributions.Close()
+}
+
+func NewContributionsSubscriptionFactory(log logging.Logger, contributions *Contributions, readState storage.ReadState) event.SubscriptionFactory[*chain.ExecutedBlock] {
+	return &ContributionsSubscriptionFactory{
+		log:           log,
+		contributions: contributions,
+		readState:     readState,
+	}
+}
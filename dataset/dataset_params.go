@@ -11,6 +11,9 @@ import (
 	hutils "github.com/ava-labs/hypersdk/utils"
 )
 
+// DataChunkSize is the size of the chunks contributed data is committed to
+const DataChunkSize = 1024
+
 type DatasetConfig struct {
 	// Collateral Asset Address for data contribution
 	CollateralAssetAddressForDataContribution codec.Address `json:"collateralAssetAddressForDataContribution"`
@@ -20,14 +23,33 @@ type DatasetConfig struct {
 
 	// Minimum amount of blocks to subscribe to
 	MinBlocksToSubscribe uint64 `json:"minBlocksToSubscribe"`
+
+	// Blocks after which a contributor can cancel a pending contribution and
+	// get the collateral back
+	ContributionTimeoutBlocks uint64 `json:"contributionTimeoutBlocks"`
+
+	// Bond a challenger of a contribution posts in the collateral asset
+	ChallengeBondAmount uint64 `json:"challengeBondAmount"`
+
+	// Blocks a contributor has to answer a challenge
+	ChallengeResponseBlocks uint64 `json:"challengeResponseBlocks"`
+
+	// Blocks after its completion during which a contribution that committed
+	// to its data can be challenged. Its collateral stays locked until then.
+	ChallengeWindowBlocks uint64 `json:"challengeWindowBlocks"`
 }
 
 func GetDatasetConfig() DatasetConfig {
 	collateralAmountForDataContribution, _ := hutils.ParseBalance("1") // 1 NAI
+	challengeBondAmount, _ := hutils.ParseBalance("0.1")               // 0.1 NAI
 
 	return DatasetConfig{
 		CollateralAssetAddressForDataContribution: storage.NAIAddress, // Using NAI as collateral
 		CollateralAmountForDataContribution:       collateralAmountForDataContribution,
-		MinBlocksToSubscribe:                      5, // TODO: 720(1 hour) for production
+		MinBlocksToSubscribe:                      5,  // TODO: 720(1 hour) for production
+		ContributionTimeoutBlocks:                 10, // TODO: 17280(1 day) for production
+		ChallengeBondAmount:                       challengeBondAmount,
+		ChallengeResponseBlocks:                   10, // TODO: 720(1 hour) for production
+		ChallengeWindowBlocks:                     50, // TODO: 120960(1 week) for production
 	}
 }
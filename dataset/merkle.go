@@ -0,0 +1,117 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dataset
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// HashLen is the length of the nodes of the Merkle tree of contributed data
+const HashLen = sha256.Size
+
+// Contributed data is split into chunks of DataChunkSize bytes, the last one
+// being shorter. The chunks are the leaves of a binary Merkle tree hashed with
+// SHA-256 in which a node without a sibling is paired with itself.
+
+// SplitChunks splits [data] into chunks of [DataChunkSize] bytes
+func SplitChunks(data []byte) [][]byte {
+	chunks := make([][]byte, 0, (len(data)+DataChunkSize-1)/DataChunkSize)
+	for len(data) > DataChunkSize {
+		chunks = append(chunks, data[:DataChunkSize])
+		data = data[DataChunkSize:]
+	}
+	return append(chunks, data)
+}
+
+// MerkleDepth returns the number of levels above the leaves of a tree of
+// [chunks] leaves, which is the number of hashes in a proof
+func MerkleDepth(chunks uint64) int {
+	if chunks <= 1 {
+		return 0
+	}
+	return bits.Len64(chunks - 1)
+}
+
+// MerkleRoot returns the root of the tree of [chunks]
+func MerkleRoot(chunks [][]byte) []byte {
+	level := leaves(chunks)
+	for len(level) > 1 {
+		level = parents(level)
+	}
+	return level[0]
+}
+
+// MerkleProof returns the sibling hashes from the leaf of chunk [index] up to
+// the root of the tree of [chunks]
+func MerkleProof(chunks [][]byte, index uint64) []byte {
+	proof := []byte{}
+	level := leaves(chunks)
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling >= uint64(len(level)) {
+			sibling = index
+		}
+		proof = append(proof, level[sibling]...)
+		level = parents(level)
+		index >>= 1
+	}
+	return proof
+}
+
+// VerifyMerkleProof returns true if [chunk] is the leaf [index] of the tree of
+// [chunks] leaves whose root is [root]
+func VerifyMerkleProof(root []byte, chunks uint64, index uint64, chunk []byte, proof []byte) bool {
+	if index >= chunks || len(proof) != MerkleDepth(chunks)*HashLen {
+		return false
+	}
+	node := sha256.Sum256(chunk)
+	for offset := 0; offset < len(proof); offset += HashLen {
+		if index&1 == 0 {
+			node = hashPair(node[:], proof[offset:offset+HashLen])
+		} else {
+			node = hashPair(proof[offset:offset+HashLen], node[:])
+		}
+		index >>= 1
+	}
+	return bytes.Equal(node[:], root)
+}
+
+// ChallengedChunk returns the chunk out of [chunks] a challenge made by the
+// action [actionID] asks for. Action IDs are not known before the challenge is
+// issued so contributors cannot only keep the chunks that will be asked for.
+func ChallengedChunk(actionID ids.ID, chunks uint64) uint64 {
+	seed := sha256.Sum256(actionID[:])
+	return binary.BigEndian.Uint64(seed[:]) % chunks
+}
+
+func leaves(chunks [][]byte) [][]byte {
+	level := make([][]byte, len(chunks))
+	for i, chunk := range chunks {
+		leaf := sha256.Sum256(chunk)
+		level[i] = leaf[:]
+	}
+	return level
+}
+
+func parents(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		right := level[i]
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		parent := hashPair(level[i], right)
+		next = append(next, parent[:])
+	}
+	return next
+}
+
+func hashPair(left []byte, right []byte) [HashLen]byte {
+	return sha256.Sum256(append(append(make([]byte, 0, 2*HashLen), left...), right...))
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dataset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleProof(t *testing.T) {
+	require := require.New(t)
+
+	// Every chunk of trees of any width can be proven
+	for _, size := range []int{1, DataChunkSize, 3*DataChunkSize + 1, 5 * DataChunkSize} {
+		data := bytes.Repeat([]byte{0x1}, size)
+		chunks := SplitChunks(data)
+		root := MerkleRoot(chunks)
+		for index := range chunks {
+			proof := MerkleProof(chunks, uint64(index))
+			require.True(VerifyMerkleProof(root, uint64(len(chunks)), uint64(index), chunks[index], proof))
+		}
+	}
+
+	// Wrong chunks, indexes and proofs are rejected
+	chunks := SplitChunks(bytes.Repeat([]byte{0x2}, 3*DataChunkSize))
+	chunks[1] = bytes.Repeat([]byte{0x3}, DataChunkSize)
+	root := MerkleRoot(chunks)
+	proof := MerkleProof(chunks, 1)
+	require.False(VerifyMerkleProof(root, 3, 1, chunks[0], proof))
+	require.False(VerifyMerkleProof(root, 3, 0, chunks[1], proof))
+	require.False(VerifyMerkleProof(root, 3, 3, chunks[1], proof))
+	require.False(VerifyMerkleProof(root, 3, 1, chunks[1], proof[:HashLen]))
+}
+
+func TestChallengedChunk(t *testing.T) {
+	require := require.New(t)
+
+	actionID := ids.GenerateTestID()
+	require.Equal(ChallengedChunk(actionID, 7), ChallengedChunk(actionID, 7))
+	require.Less(ChallengedChunk(actionID, 7), uint64(7))
+	require.Zero(ChallengedChunk(actionID, 1))
+}
@@ -18,8 +18,6 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/vm"
-
-	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var _ Tracker = (*Emission)(nil)
@@ -31,6 +29,7 @@ type Emission struct {
 	TotalSupply     uint64          `json:"totalSupply"`     // Total supply of NAI
 	MaxSupply       uint64          `json:"maxSupply"`       // Max supply of NAI
 	EmissionAccount EmissionAccount `json:"emissionAccount"` // Emission Account Info
+	Treasury        Treasury        `json:"treasury"`        // Treasury Info
 
 	validators  map[ids.NodeID]*Validator
 	TotalStaked uint64 `json:"totalStaked"` // Total staked NAI
@@ -53,19 +52,26 @@ func NewEmission(log logging.Logger, vm *vm.VM) (*Emission, error) {
 		return nil, err
 	}
 	// Get totalSupply
-	totalSupply := uint64(0)
-	for _, alloc := range genesis.CustomAllocation {
-		supply, err := safemath.Add(totalSupply, alloc.Balance)
-		if err != nil {
-			return nil, err
-		}
-		totalSupply = supply
+	totalSupply, err := genesis.TotalAllocation()
+	if err != nil {
+		return nil, err
 	}
 	emissionAddress, err := codec.StringToAddress(genesis.EmissionBalancer.EmissionAddress)
 	if err != nil {
 		return nil, err
 	}
 	maxSupply := genesis.EmissionBalancer.MaxSupply
+	var treasury Treasury
+	if genesis.EmissionBalancer.MarketplaceFeeBasisPoints > 0 {
+		treasuryAddress, err := codec.StringToAddress(genesis.EmissionBalancer.TreasuryAddress)
+		if err != nil {
+			return nil, err
+		}
+		treasury = Treasury{
+			Address:                   treasuryAddress,
+			MarketplaceFeeBasisPoints: genesis.EmissionBalancer.MarketplaceFeeBasisPoints,
+		}
+	}
 
 	once.Do(func() {
 		if maxSupply == 0 {
@@ -80,6 +86,7 @@ func NewEmission(log logging.Logger, vm *vm.VM) (*Emission, error) {
 			EmissionAccount: EmissionAccount{ // Setup the emission account with the provided address
 				Address: emissionAddress,
 			},
+			Treasury:   treasury,
 			validators: make(map[ids.NodeID]*Validator),
 			EpochTracker: EpochTracker{
 				BaseAPR:        0.25, // 25% APR
@@ -629,6 +636,11 @@ func (e *Emission) GetEmissionValidators() map[ids.NodeID]*Validator {
 	return e.validators
 }
 
+// GetTreasury returns the treasury credited with the marketplace fee
+func (e *Emission) GetTreasury() Treasury {
+	return e.Treasury
+}
+
 func (e *Emission) GetInfo() (emissionAccount EmissionAccount, totalSupply uint64, maxSupply uint64, totalStaked uint64, epochTracker EpochTracker) {
 	return e.EmissionAccount, e.TotalSupply, e.MaxSupply, e.TotalStaked, e.EpochTracker
 }
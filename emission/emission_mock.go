@@ -23,6 +23,7 @@ type MockEmission struct {
 	StakeRewards            uint64
 	LastAcceptedBlockHeight uint64
 	Validator               *Validator
+	Treasury                Treasury
 }
 
 func MockNewEmission(mockEmission *MockEmission) *MockEmission {
@@ -101,6 +102,10 @@ func (m *MockEmission) GetEmissionValidators() map[ids.NodeID]*Validator {
 	return nil
 }
 
+func (m *MockEmission) GetTreasury() Treasury {
+	return m.Treasury
+}
+
 func (m *MockEmission) GetInfo() (emissionAccount EmissionAccount, totalSupply uint64, maxSupply uint64, totalStaked uint64, epochTracker EpochTracker) {
 	return EmissionAccount{}, 0, 0, 0, EpochTracker{}
 }
@@ -45,6 +45,12 @@ type EmissionAccount struct {
 	AccumulatedReward uint64        `json:"accumulatedReward"`
 }
 
+// Treasury is credited with the protocol fee taken on marketplace payments
+type Treasury struct {
+	Address                   codec.Address `json:"address"`
+	MarketplaceFeeBasisPoints uint64        `json:"marketplaceFeeBasisPoints"`
+}
+
 type EpochTracker struct {
 	BaseAPR        float64 `json:"baseAPR"`        // Base APR to use
 	BaseValidators uint64  `json:"baseValidators"` // Base number of validators to use
@@ -32,6 +32,7 @@ type Tracker interface {
 	GetLastAcceptedBlockTimestamp() time.Time
 	GetLastAcceptedBlockHeight() uint64
 	GetEmissionValidators() map[ids.NodeID]*Validator
+	GetTreasury() Treasury
 	GetInfo() (emissionAccount EmissionAccount, totalSupply uint64, maxSupply uint64, totalStaked uint64, epochTracker EpochTracker)
 }
 
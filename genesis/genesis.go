@@ -6,6 +6,8 @@ package genesis
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
@@ -17,9 +19,14 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/genesis"
 	"github.com/ava-labs/hypersdk/state"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var (
+	ErrVestingAllocationInvalid = errors.New("vesting allocation is invalid")
+	ErrTreasuryInvalid          = errors.New("treasury is invalid")
+
 	_ genesis.Genesis               = (*Genesis)(nil)
 	_ genesis.GenesisAndRuleFactory = (*GenesisFactory)(nil)
 )
@@ -27,28 +34,83 @@ var (
 type EmissionBalancer struct {
 	MaxSupply       uint64 `json:"maxSupply"`       // Max supply of NAI
 	EmissionAddress string `json:"emissionAddress"` // Emission address
+
+	// Treasury credited with the protocol fee taken on marketplace payments.
+	// No fee is taken if the treasury is not set.
+	TreasuryAddress           string `json:"treasuryAddress,omitempty"`
+	MarketplaceFeeBasisPoints uint64 `json:"marketplaceFeeBasisPoints,omitempty"` // Out of 10,000
+}
+
+// VestingAllocation is NAI locked at genesis for [Beneficiary] with the same
+// schedule as [actions.CreateVestingSchedule]
+type VestingAllocation struct {
+	Beneficiary         codec.Address `json:"beneficiary"`
+	Balance             uint64        `json:"balance"`
+	StartBlock          uint64        `json:"startBlock"`
+	CliffBlock          uint64        `json:"cliffBlock"`
+	EndBlock            uint64        `json:"endBlock"`
+	RevocationAuthority codec.Address `json:"revocationAuthority"` // Optional
+}
+
+// Address of the account holding the allocation until it is claimed
+func (v *VestingAllocation) Address() codec.Address {
+	return storage.VestingAddress(codec.EmptyAddress, storage.NAIAddress, v.Beneficiary, v.RevocationAuthority, v.Balance, v.StartBlock, v.CliffBlock, v.EndBlock)
 }
 
 type Genesis struct {
 	*genesis.DefaultGenesis
-	EmissionBalancer *EmissionBalancer `json:"emissionBalancer"`
+	EmissionBalancer  *EmissionBalancer    `json:"emissionBalancer"`
+	VestingAllocation []*VestingAllocation `json:"vestingAllocation"`
 }
 
-func NewGenesis(customAllocations []*genesis.CustomAllocation, emissionBalancer EmissionBalancer) *Genesis {
+func NewGenesis(customAllocations []*genesis.CustomAllocation, vestingAllocations []*VestingAllocation, emissionBalancer EmissionBalancer) *Genesis {
 	// Initialize the DefaultGenesis part using the NewDefaultGenesis function
 	defaultGenesis := genesis.NewDefaultGenesis(customAllocations)
 
 	// Return a new Genesis object, including EmissionBalancer initialization
 	return &Genesis{
-		DefaultGenesis:   defaultGenesis,
-		EmissionBalancer: &emissionBalancer,
+		DefaultGenesis:    defaultGenesis,
+		EmissionBalancer:  &emissionBalancer,
+		VestingAllocation: vestingAllocations,
+	}
+}
+
+// TotalAllocation returns the NAI minted at genesis by the custom and vesting
+// allocations
+func (g *Genesis) TotalAllocation() (uint64, error) {
+	var (
+		supply uint64
+		err    error
+	)
+	for _, alloc := range g.CustomAllocation {
+		supply, err = safemath.Add(supply, alloc.Balance)
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, alloc := range g.VestingAllocation {
+		supply, err = safemath.Add(supply, alloc.Balance)
+		if err != nil {
+			return 0, err
+		}
 	}
+	return supply, nil
 }
 
 func (g *Genesis) InitializeState(ctx context.Context, tracer trace.Tracer, mu state.Mutable, balanceHandler chain.BalanceHandler) error {
 	_, span := tracer.Start(ctx, "Nuklai Genesis.InitializeState")
 	defer span.End()
 
+	// Ensure the marketplace fee can be credited to the treasury
+	if g.EmissionBalancer.MarketplaceFeeBasisPoints > storage.MaxMarketplaceFeeBasisPoints {
+		return fmt.Errorf("%w: marketplaceFeeBasisPoints=%d", ErrTreasuryInvalid, g.EmissionBalancer.MarketplaceFeeBasisPoints)
+	}
+	if g.EmissionBalancer.MarketplaceFeeBasisPoints > 0 {
+		if _, err := codec.StringToAddress(g.EmissionBalancer.TreasuryAddress); err != nil {
+			return fmt.Errorf("%w: treasuryAddress=%s", ErrTreasuryInvalid, g.EmissionBalancer.TreasuryAddress)
+		}
+	}
+
 	// Set the asset info for NAI using storage.SetAsset
 	if err := storage.SetAssetInfo(
 		ctx,
@@ -72,7 +134,31 @@ func (g *Genesis) InitializeState(ctx context.Context, tracer trace.Tracer, mu s
 	}
 
 	// Initialize state from the DefaultGenesis first
-	return g.DefaultGenesis.InitializeState(ctx, tracer, mu, balanceHandler)
+	if err := g.DefaultGenesis.InitializeState(ctx, tracer, mu, balanceHandler); err != nil {
+		return err
+	}
+
+	// Lock the vesting allocations in their schedules
+	for _, alloc := range g.VestingAllocation {
+		if alloc.Balance == 0 || alloc.StartBlock >= alloc.EndBlock || alloc.CliffBlock < alloc.StartBlock || alloc.CliffBlock > alloc.EndBlock {
+			return fmt.Errorf("%w: beneficiary=%s", ErrVestingAllocationInvalid, alloc.Beneficiary)
+		}
+		vestingAddress := alloc.Address()
+		exists, _, _, _, _, _, _, _, _, _, err := storage.GetVestingScheduleNoController(ctx, mu, vestingAddress)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: duplicate allocation for beneficiary=%s", ErrVestingAllocationInvalid, alloc.Beneficiary)
+		}
+		if err := storage.SetVestingSchedule(ctx, mu, vestingAddress, storage.NAIAddress, alloc.Beneficiary, codec.EmptyAddress, alloc.RevocationAuthority, alloc.Balance, 0, alloc.StartBlock, alloc.CliffBlock, alloc.EndBlock); err != nil {
+			return err
+		}
+		if err := balanceHandler.AddBalance(ctx, vestingAddress, mu, alloc.Balance, true); err != nil {
+			return fmt.Errorf("%w: addr=%s, bal=%d", err, vestingAddress, alloc.Balance)
+		}
+	}
+	return nil
 }
 
 func (g *Genesis) GetStateBranchFactor() merkledb.BranchFactor {
@@ -0,0 +1,279 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package holdings
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// MaxPageSize is the most entries returned by a single query
+const MaxPageSize = 1000
+
+var ErrInvalidEntry = errors.New("invalid holdings entry")
+
+// Prefixes of the entries persisted to the database
+const (
+	balancePrefix byte = iota // asset|owner -> balance
+	nftPrefix                 // nft -> collection
+)
+
+// AssetBalance is the balance of an asset held by an owner
+type AssetBalance struct {
+	AssetAddress string `json:"assetAddress"`
+	Balance      uint64 `json:"balance"`
+}
+
+// Holder is an owner of an asset and its balance
+type Holder struct {
+	Owner   string `json:"owner"`
+	Balance uint64 `json:"balance"`
+}
+
+// NFT is an NFT of a collection and its current owner
+type NFT struct {
+	NFTAddress string `json:"nftAddress"`
+	Owner      string `json:"owner"`
+}
+
+// Holdings tracks the balances of every owner and asset, fungible or not,
+// from the blocks accepted by the node. Holdings are persisted to a database
+// and loaded back when the node restarts. Balances set before the holdings
+// were enabled, or before a node state synced, are not tracked until they
+// change again.
+type Holdings struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	// Balances by owner and by asset
+	owners  map[codec.Address]map[codec.Address]uint64
+	holders map[codec.Address]map[codec.Address]uint64
+
+	// NFTs by collection and the collection of every NFT seen so far.
+	// Collections are their own collection.
+	collections map[codec.Address]map[codec.Address]struct{}
+	nfts        map[codec.Address]codec.Address
+}
+
+// New loads the holdings persisted to [db]
+func New(db database.Database) (*Holdings, error) {
+	h := &Holdings{
+		db:          db,
+		owners:      make(map[codec.Address]map[codec.Address]uint64),
+		holders:     make(map[codec.Address]map[codec.Address]uint64),
+		collections: make(map[codec.Address]map[codec.Address]struct{}),
+		nfts:        make(map[codec.Address]codec.Address),
+	}
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		k, v := iter.Key(), iter.Value()
+		switch {
+		case len(k) == 1+2*codec.AddressLen && k[0] == balancePrefix && len(v) == consts.Uint64Len:
+			asset := codec.Address(k[1 : 1+codec.AddressLen])
+			owner := codec.Address(k[1+codec.AddressLen:])
+			balance := binary.BigEndian.Uint64(v)
+			putBalance(h.owners, owner, asset, balance)
+			putBalance(h.holders, asset, owner, balance)
+		case len(k) == 1+codec.AddressLen && k[0] == nftPrefix && len(v) == codec.AddressLen:
+			h.putNFT(codec.Address(v), codec.Address(k[1:]))
+		default:
+			return nil, ErrInvalidEntry
+		}
+	}
+	return h, iter.Error()
+}
+
+func balanceKey(asset codec.Address, owner codec.Address) []byte {
+	k := make([]byte, 1+2*codec.AddressLen)
+	k[0] = balancePrefix
+	copy(k[1:], asset[:])
+	copy(k[1+codec.AddressLen:], owner[:])
+	return k
+}
+
+func nftKey(nft codec.Address) []byte {
+	k := make([]byte, 1+codec.AddressLen)
+	k[0] = nftPrefix
+	copy(k[1:], nft[:])
+	return k
+}
+
+// Put sets the [balance] of [asset] held by [owner]. A balance of zero removes
+// the entry.
+func (h *Holdings) Put(asset codec.Address, owner codec.Address, balance uint64) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if balance == 0 {
+		if err := h.db.Delete(balanceKey(asset, owner)); err != nil {
+			return err
+		}
+		deleteBalance(h.owners, owner, asset)
+		deleteBalance(h.holders, asset, owner)
+		return nil
+	}
+	if err := h.db.Put(balanceKey(asset, owner), binary.BigEndian.AppendUint64(nil, balance)); err != nil {
+		return err
+	}
+	putBalance(h.owners, owner, asset, balance)
+	putBalance(h.holders, asset, owner, balance)
+	return nil
+}
+
+// HasCollection returns true if the collection of [nft] is already known
+func (h *Holdings) HasCollection(nft codec.Address) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	_, ok := h.nfts[nft]
+	return ok
+}
+
+// PutNFT records that [nft] belongs to [collection]
+func (h *Holdings) PutNFT(collection codec.Address, nft codec.Address) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if err := h.db.Put(nftKey(nft), collection[:]); err != nil {
+		return err
+	}
+	h.putNFT(collection, nft)
+	return nil
+}
+
+func (h *Holdings) putNFT(collection codec.Address, nft codec.Address) {
+	h.nfts[nft] = collection
+	if collection == nft {
+		return
+	}
+	nfts, ok := h.collections[collection]
+	if !ok {
+		nfts = make(map[codec.Address]struct{})
+		h.collections[collection] = nfts
+	}
+	nfts[nft] = struct{}{}
+}
+
+// RemoveNFT removes a burnt [nft] from its collection
+func (h *Holdings) RemoveNFT(nft codec.Address) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	collection, ok := h.nfts[nft]
+	if !ok {
+		return nil
+	}
+	if err := h.db.Delete(nftKey(nft)); err != nil {
+		return err
+	}
+	delete(h.nfts, nft)
+	delete(h.collections[collection], nft)
+	if len(h.collections[collection]) == 0 {
+		delete(h.collections, collection)
+	}
+	return nil
+}
+
+func (h *Holdings) Close() error {
+	return h.db.Close()
+}
+
+// AssetsOfOwner returns at most [limit] assets held by [owner] ordered by
+// address and starting after [cursor]. The returned cursor is empty once there
+// is nothing left.
+func (h *Holdings) AssetsOfOwner(owner codec.Address, cursor string, limit int) ([]AssetBalance, string) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	balances := h.owners[owner]
+	page, next := paginate(balances, cursor, limit)
+	assets := make([]AssetBalance, len(page))
+	for i, asset := range page {
+		assets[i] = AssetBalance{AssetAddress: asset.String(), Balance: balances[asset]}
+	}
+	return assets, next
+}
+
+// HoldersOfAsset returns at most [limit] owners of [asset] ordered by address
+// and starting after [cursor]
+func (h *Holdings) HoldersOfAsset(asset codec.Address, cursor string, limit int) ([]Holder, string) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	balances := h.holders[asset]
+	page, next := paginate(balances, cursor, limit)
+	holders := make([]Holder, len(page))
+	for i, owner := range page {
+		holders[i] = Holder{Owner: owner.String(), Balance: balances[owner]}
+	}
+	return holders, next
+}
+
+// NFTsOfCollection returns at most [limit] NFTs of [collection] ordered by
+// address and starting after [cursor]
+func (h *Holdings) NFTsOfCollection(collection codec.Address, cursor string, limit int) ([]NFT, string) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	page, next := paginate(h.collections[collection], cursor, limit)
+	nfts := make([]NFT, len(page))
+	for i, nft := range page {
+		nfts[i] = NFT{NFTAddress: nft.String()}
+		for owner := range h.holders[nft] {
+			nfts[i].Owner = owner.String()
+		}
+	}
+	return nfts, next
+}
+
+func putBalance(m map[codec.Address]map[codec.Address]uint64, k1 codec.Address, k2 codec.Address, balance uint64) {
+	balances, ok := m[k1]
+	if !ok {
+		balances = make(map[codec.Address]uint64)
+		m[k1] = balances
+	}
+	balances[k2] = balance
+}
+
+func deleteBalance(m map[codec.Address]map[codec.Address]uint64, k1 codec.Address, k2 codec.Address) {
+	delete(m[k1], k2)
+	if len(m[k1]) == 0 {
+		delete(m, k1)
+	}
+}
+
+// paginate returns at most [limit] keys of [m] after [cursor] in the order of
+// their string form and the cursor of the next page
+func paginate[V any](m map[codec.Address]V, cursor string, limit int) ([]codec.Address, string) {
+	if limit <= 0 || limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if s := k.String(); s > cursor {
+			keys = append(keys, s)
+		}
+	}
+	slices.Sort(keys)
+
+	next := ""
+	if len(keys) > limit {
+		keys = keys[:limit]
+		next = keys[limit-1]
+	}
+	page := make([]codec.Address, len(keys))
+	for i, k := range keys {
+		// Keys are the string form of valid addresses
+		page[i], _ = codec.StringToAddress(k)
+	}
+	return page, next
+}
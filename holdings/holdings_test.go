@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package holdings
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec/codectest"
+)
+
+func TestHoldings(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	h, err := New(db)
+	require.NoError(err)
+	owner := codectest.NewRandomAddress()
+	other := codectest.NewRandomAddress()
+	asset := codectest.NewRandomAddress()
+	collection := codectest.NewRandomAddress()
+	nft := codectest.NewRandomAddress()
+
+	// Balances are tracked by owner and by asset
+	require.NoError(h.Put(asset, owner, 10))
+	require.NoError(h.Put(asset, other, 5))
+	require.NoError(h.PutNFT(collection, nft))
+	require.NoError(h.Put(nft, owner, 1))
+	assets, next := h.AssetsOfOwner(owner, "", 0)
+	require.Len(assets, 2)
+	require.Empty(next)
+	holders, _ := h.HoldersOfAsset(asset, "", 0)
+	require.Len(holders, 2)
+	nfts, _ := h.NFTsOfCollection(collection, "", 0)
+	require.Equal([]NFT{{NFTAddress: nft.String(), Owner: owner.String()}}, nfts)
+	require.True(h.HasCollection(nft))
+
+	// Holdings are loaded back after a restart
+	reloaded, err := New(db)
+	require.NoError(err)
+	reloadedAssets, _ := reloaded.AssetsOfOwner(owner, "", 0)
+	require.Equal(assets, reloadedAssets)
+	reloadedNFTs, _ := reloaded.NFTsOfCollection(collection, "", 0)
+	require.Equal(nfts, reloadedNFTs)
+	require.True(reloaded.HasCollection(nft))
+
+	// Transfers move the NFT to its new owner
+	require.NoError(h.Put(nft, owner, 0))
+	require.NoError(h.Put(nft, other, 1))
+	nfts, _ = h.NFTsOfCollection(collection, "", 0)
+	require.Equal([]NFT{{NFTAddress: nft.String(), Owner: other.String()}}, nfts)
+	assets, _ = h.AssetsOfOwner(owner, "", 0)
+	require.Equal([]AssetBalance{{AssetAddress: asset.String(), Balance: 10}}, assets)
+
+	// Burnt NFTs leave their collection
+	require.NoError(h.Put(nft, other, 0))
+	require.NoError(h.RemoveNFT(nft))
+	nfts, _ = h.NFTsOfCollection(collection, "", 0)
+	require.Empty(nfts)
+	require.False(h.HasCollection(nft))
+	reloaded, err = New(db)
+	require.NoError(err)
+	require.False(reloaded.HasCollection(nft))
+
+	// Collections are not listed as their own NFTs
+	require.NoError(h.PutNFT(collection, collection))
+	require.NoError(h.Put(collection, owner, 1))
+	nfts, _ = h.NFTsOfCollection(collection, "", 0)
+	require.Empty(nfts)
+	require.True(h.HasCollection(collection))
+}
+
+func TestHoldingsPagination(t *testing.T) {
+	require := require.New(t)
+	h, err := New(memdb.New())
+	require.NoError(err)
+	asset := codectest.NewRandomAddress()
+	for i := 0; i < 5; i++ {
+		require.NoError(h.Put(asset, codectest.NewRandomAddress(), uint64(i+1)))
+	}
+
+	// Pages follow each other in address order without overlapping
+	seen := make(map[string]struct{})
+	last, cursor := "", ""
+	for pages := 0; ; pages++ {
+		require.Less(pages, 3)
+		holders, next := h.HoldersOfAsset(asset, cursor, 2)
+		for _, holder := range holders {
+			require.Greater(holder.Owner, last)
+			last = holder.Owner
+			seen[holder.Owner] = struct{}{}
+		}
+		if next == "" {
+			require.Len(holders, 1)
+			break
+		}
+		require.Len(holders, 2)
+		cursor = next
+	}
+	require.Len(seen, 5)
+}
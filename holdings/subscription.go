@@ -0,0 +1,109 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package holdings
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/nuklai/nuklaivm/storage"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/event"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	Namespace = "holdings"
+)
+
+var _ event.SubscriptionFactory[*chain.ExecutedBlock] = (*HoldingsSubscriptionFactory)(nil)
+
+type HoldingsSubscriptionFactory struct {
+	log       logging.Logger
+	holdings  *Holdings
+	readState storage.ReadState
+}
+
+func (h *HoldingsSubscriptionFactory) New() (event.Subscription[*chain.ExecutedBlock], error) {
+	return h, nil
+}
+
+// Accept reads back every balance the transactions of [blk] may have changed.
+// Fees are charged even when a transaction fails so failed transactions are
+// read back too.
+func (h *HoldingsSubscriptionFactory) Accept(blk *chain.ExecutedBlock) error {
+	ctx := context.Background()
+	stateManager := &storage.StateManager{}
+	for _, tx := range blk.Block.Txs {
+		stateKeys, err := tx.StateKeys(stateManager)
+		if err != nil {
+			h.log.Warn("failed to update holdings", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			continue
+		}
+		for k := range stateKeys {
+			asset, owner, ok := storage.ParseAssetAccountBalanceKey([]byte(k))
+			if !ok {
+				continue
+			}
+			// Holdings are best effort and must never halt the chain
+			if err := h.acceptBalance(ctx, asset, owner); err != nil {
+				h.log.Warn("failed to update holdings", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+func (h *HoldingsSubscriptionFactory) acceptBalance(ctx context.Context, asset codec.Address, owner codec.Address) error {
+	balance, err := storage.GetAssetAccountBalanceFromState(ctx, h.readState, asset, owner)
+	if err != nil {
+		return err
+	}
+
+	if err := h.holdings.Put(asset, owner, balance); err != nil {
+		return err
+	}
+	if asset[0] != nconsts.AssetNonFungibleTokenID {
+		return nil
+	}
+
+	// NFTs point to their collection with their uri while collections point
+	// to themselves. Burnt NFTs have no info left.
+	hasCollection := h.holdings.HasCollection(asset)
+	if balance > 0 && hasCollection {
+		return nil
+	}
+	_, _, _, _, _, uri, _, _, _, _, _, _, _, err := storage.GetAssetInfoFromState(ctx, h.readState, asset)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return h.holdings.RemoveNFT(asset)
+	case err != nil:
+		return err
+	case hasCollection:
+		return nil
+	}
+	collection, err := codec.StringToAddress(string(uri))
+	if err != nil {
+		collection = asset
+	}
+	return h.holdings.PutNFT(collection, asset)
+}
+
+func (h *HoldingsSubscriptionFactory) Close() error {
+	return h.holdings.Close()
+}
+
+func NewHoldingsSubscriptionFactory(log logging.Logger, holdings *Holdings, readState storage.ReadState) event.SubscriptionFactory[*chain.ExecutedBlock] {
+	return &HoldingsSubscriptionFactory{
+		log:       log,
+		holdings:  holdings,
+		readState: readState,
+	}
+}
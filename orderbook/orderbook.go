@@ -0,0 +1,172 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package orderbook
+
+import (
+	"encoding/json"
+	"math/bits"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// Order is an open order of the order book
+type Order struct {
+	OrderAddress        string `json:"orderAddress"`
+	Owner               string `json:"owner"`
+	InAssetAddress      string `json:"inAssetAddress"`
+	InCollectionAddress string `json:"inCollectionAddress,omitempty"` // Set when an NFT is sold
+	InTick              uint64 `json:"inTick"`
+	OutAssetAddress     string `json:"outAssetAddress"`
+	OutTick             uint64 `json:"outTick"`
+	Remaining           uint64 `json:"remaining"`
+}
+
+// OrderBook tracks the open orders of every asset pair from the blocks
+// accepted by the node. Orders are persisted to a database and loaded back
+// when the node restarts. Orders created before the order book was enabled,
+// or before a node state synced, are not tracked until they are topped up
+// with another [actions.CreateOrder].
+type OrderBook struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	// Orders by pair and by order address
+	pairs  map[string]map[codec.Address]*Order
+	orders map[codec.Address]string
+}
+
+// New loads the orders persisted to [db]
+func New(db database.Database) (*OrderBook, error) {
+	o := &OrderBook{
+		db:     db,
+		pairs:  make(map[string]map[codec.Address]*Order),
+		orders: make(map[codec.Address]string),
+	}
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		orderAddress, err := codec.ToAddress(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		var order Order
+		if err := json.Unmarshal(iter.Value(), &order); err != nil {
+			return nil, err
+		}
+		o.put(orderAddress, &order)
+	}
+	return o, iter.Error()
+}
+
+// Pair is the key of the orders selling [in] for [out]
+func Pair(in codec.Address, out codec.Address) string {
+	return in.String() + "-" + out.String()
+}
+
+// Put adds [order] or updates it if it is already tracked
+func (o *OrderBook) Put(orderAddress codec.Address, order *Order) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if err := o.persist(orderAddress, order); err != nil {
+		return err
+	}
+	o.put(orderAddress, order)
+	return nil
+}
+
+func (o *OrderBook) put(orderAddress codec.Address, order *Order) {
+	pair := order.InAssetAddress + "-" + order.OutAssetAddress
+	orders, ok := o.pairs[pair]
+	if !ok {
+		orders = make(map[codec.Address]*Order)
+		o.pairs[pair] = orders
+	}
+	orders[orderAddress] = order
+	o.orders[orderAddress] = pair
+}
+
+func (o *OrderBook) persist(orderAddress codec.Address, order *Order) error {
+	b, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+	return o.db.Put(orderAddress[:], b)
+}
+
+// UpdateRemaining sets the amount left in [orderAddress] and returns false if
+// the order is not tracked
+func (o *OrderBook) UpdateRemaining(orderAddress codec.Address, remaining uint64) (bool, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	pair, ok := o.orders[orderAddress]
+	if !ok {
+		return false, nil
+	}
+	order := *o.pairs[pair][orderAddress]
+	order.Remaining = remaining
+	if err := o.persist(orderAddress, &order); err != nil {
+		return false, err
+	}
+	o.pairs[pair][orderAddress] = &order
+	return true, nil
+}
+
+func (o *OrderBook) Remove(orderAddress codec.Address) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	pair, ok := o.orders[orderAddress]
+	if !ok {
+		return nil
+	}
+	if err := o.db.Delete(orderAddress[:]); err != nil {
+		return err
+	}
+	delete(o.orders, orderAddress)
+	delete(o.pairs[pair], orderAddress)
+	if len(o.pairs[pair]) == 0 {
+		delete(o.pairs, pair)
+	}
+	return nil
+}
+
+func (o *OrderBook) Close() error {
+	return o.db.Close()
+}
+
+// Orders returns at most [limit] open orders of [pair] starting with the
+// cheapest for takers
+func (o *OrderBook) Orders(pair string, limit int) []Order {
+	o.lock.RLock()
+	orders := make([]Order, 0, len(o.pairs[pair]))
+	for _, order := range o.pairs[pair] {
+		orders = append(orders, *order)
+	}
+	o.lock.RUnlock()
+
+	slices.SortFunc(orders, func(a, b Order) int {
+		// Compare the out paid per in: a.OutTick/a.InTick vs b.OutTick/b.InTick
+		aHi, aLo := bits.Mul64(a.OutTick, b.InTick)
+		bHi, bLo := bits.Mul64(b.OutTick, a.InTick)
+		switch {
+		case aHi < bHi || (aHi == bHi && aLo < bLo):
+			return -1
+		case aHi > bHi || (aHi == bHi && aLo > bLo):
+			return 1
+		default:
+			return strings.Compare(a.OrderAddress, b.OrderAddress)
+		}
+	})
+	if limit > 0 && len(orders) > limit {
+		orders = orders[:limit]
+	}
+	return orders
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+)
+
+func TestOrderBook(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	book, err := New(db)
+	require.NoError(err)
+	in := codectest.NewRandomAddress()
+	out := codectest.NewRandomAddress()
+	pair := Pair(in, out)
+
+	newOrder := func(inTick uint64, outTick uint64) (codec.Address, *Order) {
+		orderAddress := codectest.NewRandomAddress()
+		return orderAddress, &Order{
+			OrderAddress:    orderAddress.String(),
+			Owner:           codectest.NewRandomAddress().String(),
+			InAssetAddress:  in.String(),
+			InTick:          inTick,
+			OutAssetAddress: out.String(),
+			OutTick:         outTick,
+			Remaining:       100,
+		}
+	}
+
+	// Orders are listed from the lowest out paid per in
+	expensive, expensiveOrder := newOrder(1, 3)
+	cheap, cheapOrder := newOrder(2, 1)
+	middle, middleOrder := newOrder(1, 1)
+	require.NoError(book.Put(expensive, expensiveOrder))
+	require.NoError(book.Put(cheap, cheapOrder))
+	require.NoError(book.Put(middle, middleOrder))
+	orders := book.Orders(pair, 0)
+	require.Len(orders, 3)
+	require.Equal(cheap.String(), orders[0].OrderAddress)
+	require.Equal(middle.String(), orders[1].OrderAddress)
+	require.Equal(expensive.String(), orders[2].OrderAddress)
+	require.Len(book.Orders(pair, 2), 2)
+	require.Empty(book.Orders(Pair(out, in), 0))
+
+	// Fills update the remaining amount
+	updated, err := book.UpdateRemaining(cheap, 40)
+	require.NoError(err)
+	require.True(updated)
+	require.Equal(uint64(40), book.Orders(pair, 1)[0].Remaining)
+	updated, err = book.UpdateRemaining(codectest.NewRandomAddress(), 40)
+	require.NoError(err)
+	require.False(updated)
+
+	// Orders are loaded back after a restart
+	reloaded, err := New(db)
+	require.NoError(err)
+	require.Equal(book.Orders(pair, 0), reloaded.Orders(pair, 0))
+
+	// Closed orders are removed
+	require.NoError(book.Remove(cheap))
+	require.NoError(book.Remove(middle))
+	require.NoError(book.Remove(expensive))
+	require.Empty(book.Orders(pair, 0))
+	require.Empty(book.pairs)
+	require.Empty(book.orders)
+	reloaded, err = New(db)
+	require.NoError(err)
+	require.Empty(reloaded.orders)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package orderbook
+
+import (
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/storage"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/event"
+)
+
+const (
+	Namespace = "orderbook"
+)
+
+var _ event.SubscriptionFactory[*chain.ExecutedBlock] = (*OrderBookSubscriptionFactory)(nil)
+
+type OrderBookSubscriptionFactory struct {
+	log       logging.Logger
+	orderBook *OrderBook
+}
+
+func (o *OrderBookSubscriptionFactory) New() (event.Subscription[*chain.ExecutedBlock], error) {
+	return o, nil
+}
+
+func (o *OrderBookSubscriptionFactory) Accept(blk *chain.ExecutedBlock) error {
+	for i, tx := range blk.Block.Txs {
+		result := blk.Results[i]
+		if !result.Success {
+			continue
+		}
+		actor := tx.Auth.Actor()
+		for j, action := range tx.Actions {
+			if err := o.acceptAction(actor, action, result.Outputs[j]); err != nil {
+				// The order book is best effort and must never halt the chain
+				o.log.Warn("failed to update order book", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+func (o *OrderBookSubscriptionFactory) acceptAction(actor codec.Address, action chain.Action, output []byte) error {
+	switch act := action.(type) {
+	case *actions.CreateOrder:
+		typed, err := actions.UnmarshalCreateOrderResult(newOutputReader(output))
+		if err != nil {
+			return err
+		}
+		orderAddress := storage.OrderAddress(actor, act.InAssetAddress, act.InTick, act.OutAssetAddress, act.OutTick)
+		order := &Order{
+			OrderAddress:    orderAddress.String(),
+			Owner:           actor.String(),
+			InAssetAddress:  act.InAssetAddress.String(),
+			InTick:          act.InTick,
+			OutAssetAddress: act.OutAssetAddress.String(),
+			OutTick:         act.OutTick,
+			Remaining:       typed.(*actions.CreateOrderResult).Remaining,
+		}
+		if act.InCollectionAddress != codec.EmptyAddress {
+			order.InCollectionAddress = act.InCollectionAddress.String()
+		}
+		return o.orderBook.Put(orderAddress, order)
+	case *actions.FillOrder:
+		typed, err := actions.UnmarshalFillOrderResult(newOutputReader(output))
+		if err != nil {
+			return err
+		}
+		remaining := typed.(*actions.FillOrderResult).Remaining
+		if remaining == 0 {
+			return o.orderBook.Remove(act.OrderAddress)
+		}
+		_, err = o.orderBook.UpdateRemaining(act.OrderAddress, remaining)
+		return err
+	case *actions.CloseOrder:
+		return o.orderBook.Remove(act.OrderAddress)
+	}
+	return nil
+}
+
+// newOutputReader skips the type ID the outputs are prefixed with
+func newOutputReader(output []byte) *codec.Packer {
+	if len(output) == 0 {
+		return codec.NewReader(output, 0)
+	}
+	return codec.NewReader(output[1:], len(output)-1)
+}
+
+func (o *OrderBookSubscriptionFactory) Close() error {
+	return o.orderBook.Close()
+}
+
+func NewOrderBookSubscriptionFactory(log logging.Logger, orderBook *OrderBook) event.SubscriptionFactory[*chain.ExecutedBlock] {
+	return &OrderBookSubscriptionFactory{
+		log:       log,
+		orderBook: orderBook,
+	}
+}
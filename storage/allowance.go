@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	AssetAllowanceChunks uint16 = 1
+)
+
+func AssetAllowanceKey(assetAddress codec.Address, owner codec.Address, spender codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen*3+consts.Uint16Len)                    // Length of prefix + assetAddress + owner + spender + AssetAllowanceChunks
+	k[0] = assetAllowancePrefix                                                // assetAllowancePrefix is a constant representing the asset allowance category
+	copy(k[1:], assetAddress[:])                                               // Copy the assetAddress
+	copy(k[1+codec.AddressLen:], owner[:])                                     // Copy the owner
+	copy(k[1+codec.AddressLen*2:], spender[:])                                 // Copy the spender
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen*3:], AssetAllowanceChunks) // Adding AssetAllowanceChunks
+	return
+}
+
+// SetAssetAllowance lets [spender] move up to [amount] of [assetAddress] out
+// of the account of [owner] until [expiryBlock] (inclusive). An [expiryBlock]
+// of 0 never expires.
+func SetAssetAllowance(ctx context.Context, mu state.Mutable, assetAddress codec.Address, owner codec.Address, spender codec.Address, amount uint64, expiryBlock uint64) error {
+	k := AssetAllowanceKey(assetAddress, owner, spender)
+	v := make([]byte, consts.Uint64Len*2)
+	binary.BigEndian.PutUint64(v, amount)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], expiryBlock)
+	return mu.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetAssetAllowanceFromState(ctx context.Context, f ReadState, assetAddress codec.Address, owner codec.Address, spender codec.Address) (uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{AssetAllowanceKey(assetAddress, owner, spender)})
+	return innerGetAssetAllowance(values[0], errs[0])
+}
+
+func GetAssetAllowanceNoController(ctx context.Context, im state.Immutable, assetAddress codec.Address, owner codec.Address, spender codec.Address) (uint64, uint64, error) {
+	v, err := im.GetValue(ctx, AssetAllowanceKey(assetAddress, owner, spender))
+	return innerGetAssetAllowance(v, err)
+}
+
+func innerGetAssetAllowance(v []byte, err error) (uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(v), binary.BigEndian.Uint64(v[consts.Uint64Len:]), nil
+}
+
+func DeleteAssetAllowance(ctx context.Context, mu state.Mutable, assetAddress codec.Address, owner codec.Address, spender codec.Address) error {
+	return mu.Remove(ctx, AssetAllowanceKey(assetAddress, owner, spender))
+}
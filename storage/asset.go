@@ -72,6 +72,17 @@ func AssetInfoKey(assetAddress codec.Address) (k []byte) {
 	return
 }
 
+// ParseAssetInfoKey returns the asset address of a key built by
+// [AssetInfoKey]
+func ParseAssetInfoKey(k []byte) (codec.Address, bool) {
+	if len(k) != 1+codec.AddressLen+consts.Uint16Len || k[0] != assetInfoPrefix {
+		return codec.EmptyAddress, false
+	}
+	var assetAddress codec.Address
+	copy(assetAddress[:], k[1:])
+	return assetAddress, true
+}
+
 func AssetAccountBalanceKey(asset codec.Address, account codec.Address) []byte {
 	k := make([]byte, 1+codec.AddressLen+codec.AddressLen+consts.Uint16Len)
 	k[0] = assetAccountBalancePrefix
@@ -81,6 +92,18 @@ func AssetAccountBalanceKey(asset codec.Address, account codec.Address) []byte {
 	return k
 }
 
+// ParseAssetAccountBalanceKey returns the asset and the account of a key built
+// by [AssetAccountBalanceKey]
+func ParseAssetAccountBalanceKey(k []byte) (codec.Address, codec.Address, bool) {
+	if len(k) != 1+codec.AddressLen+codec.AddressLen+consts.Uint16Len || k[0] != assetAccountBalancePrefix {
+		return codec.EmptyAddress, codec.EmptyAddress, false
+	}
+	var asset, account codec.Address
+	copy(asset[:], k[1:])
+	copy(account[:], k[1+codec.AddressLen:])
+	return asset, account, true
+}
+
 func SetAssetInfo(
 	ctx context.Context,
 	mu state.Mutable,
@@ -375,15 +398,35 @@ func TransferAsset(
 	if err != nil {
 		return 0, 0, err
 	}
-	if assetType == nconsts.AssetNonFungibleTokenID {
-		nftCollectionAddress, err := codec.ToAddress(nftCollectionAddressBytes)
+	// The collection tracks how many of its NFTs each account holds
+	if assetType == nconsts.AssetNonFungibleTokenID && assetAddress.String() != string(nftCollectionAddressBytes) {
+		nftCollectionAddress, err := codec.StringToAddress(string(nftCollectionAddressBytes))
+		if err != nil {
+			return 0, 0, err
+		}
+		fromCollectionBalance, err := GetAssetAccountBalanceNoController(ctx, mu, nftCollectionAddress, from)
+		if err != nil {
+			return 0, 0, err
+		}
+		toCollectionBalance, err := GetAssetAccountBalanceNoController(ctx, mu, nftCollectionAddress, to)
+		if err != nil {
+			return 0, 0, err
+		}
+		newFromCollectionBalance, err := smath.Sub(fromCollectionBalance, value)
 		if err != nil {
 			return 0, 0, err
 		}
-		if err = SetAssetAccountBalance(ctx, mu, nftCollectionAddress, from, newFromBalance); err != nil {
+		newToCollectionBalance, err := smath.Add(toCollectionBalance, value)
+		if err != nil {
+			return 0, 0, err
+		}
+		if err = SetAssetAccountBalance(ctx, mu, nftCollectionAddress, from, newFromCollectionBalance); err != nil {
+			return 0, 0, err
+		}
+		if err = SetAssetAccountBalance(ctx, mu, nftCollectionAddress, to, newToCollectionBalance); err != nil {
 			return 0, 0, err
 		}
-		if err = SetAssetAccountBalance(ctx, mu, nftCollectionAddress, to, newToBalance); err != nil {
+		if err = SetNFTOwner(ctx, mu, assetAddress, to); err != nil {
 			return 0, 0, err
 		}
 	}
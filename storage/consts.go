@@ -32,6 +32,34 @@ const (
 	assetNFTPrefix                // 0xb
 	datasetInfoPrefix             // 0xc
 	marketplaceContributionPrefix // 0xd
+
+	contractUpgradeAuthorityPrefix // 0xe
+	contractUpgradePrefix          // 0xf
+	contractABIPrefix              // 0x10
+
+	sessionKeyPrefix    // 0x11
+	sponsorPolicyPrefix // 0x12
+
+	assetAllowancePrefix    // 0x13
+	orderPrefix             // 0x14
+	vestingPrefix           // 0x15
+	royaltyPrefix           // 0x16
+	nftOwnershipPrefix      // 0x17
+	ownershipTransferPrefix // 0x18
+	mintRolePrefix          // 0x19
+	datasetVersionPrefix    // 0x1a
+	datasetVersionsPrefix   // 0x1b
+	dataCommitmentPrefix    // 0x1c
+	dataChallengePrefix     // 0x1d
+	usageChannelPrefix      // 0x1e
+	datasetKeyPrefix        // 0x1f
+
+	sessionKeySnapshotPrefix // 0x20
+	dataCollateralPrefix     // 0x21
+
+	marketplacePaymentPrefix       // 0x22
+	marketplacePaymentAssetsPrefix // 0x23
+	datasetKeyDelegatePrefix       // 0x24
 )
 
 var (
@@ -43,6 +71,12 @@ var (
 const (
 	MarketplaceAssetName   = "NMAsset"
 	MarketplaceAssetSymbol = "NMA"
+
+	// Status of a listing kept in the metadata of its marketplace asset.
+	// Listings published before statuses existed have none and are active.
+	MarketplaceStatusActive      = "active"
+	MarketplaceStatusPaused      = "paused"
+	MarketplaceStatusUnpublished = "unpublished"
 )
 
 var NAIAddress codec.Address
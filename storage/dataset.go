@@ -37,6 +37,17 @@ func DatasetInfoKey(datasetAddress codec.Address) (k []byte) {
 	return
 }
 
+// ParseDatasetInfoKey returns the dataset address of a key built by
+// [DatasetInfoKey]
+func ParseDatasetInfoKey(k []byte) (codec.Address, bool) {
+	if len(k) != 1+codec.AddressLen+consts.Uint16Len || k[0] != datasetInfoPrefix {
+		return codec.EmptyAddress, false
+	}
+	var datasetAddress codec.Address
+	copy(datasetAddress[:], k[1:])
+	return datasetAddress, true
+}
+
 func SetDatasetInfo(ctx context.Context, mu state.Mutable, datasetAddress codec.Address, name []byte, description []byte, categories []byte, licenseName []byte, licenseSymbol []byte, licenseURL []byte, metadata []byte, isCommunityDataset bool, marketplaceAssetAddress codec.Address, baseAssetAddress codec.Address, basePrice uint64, revenueModelDataShare uint8, revenueModelMetadataShare uint8, revenueModeldataOwnerCut uint8, revenueModelMetadataOwnerCut uint8, owner codec.Address) error {
 	// Setup
 	k := DatasetInfoKey(datasetAddress)
@@ -228,12 +239,23 @@ func DatasetContributionInfoKey(contributionID ids.ID) (k []byte) {
 	return
 }
 
-func SetDatasetContributionInfo(ctx context.Context, mu state.Mutable, contributionID ids.ID, datasetAddress codec.Address, dataLocation, dataIdentifier []byte, contributor codec.Address, active bool) error {
+// ParseDatasetContributionInfoKey returns the contribution ID of a key built by
+// [DatasetContributionInfoKey]
+func ParseDatasetContributionInfoKey(k []byte) (ids.ID, bool) {
+	if len(k) != 1+ids.IDLen+consts.Uint16Len || k[0] != marketplaceContributionPrefix {
+		return ids.Empty, false
+	}
+	var contributionID ids.ID
+	copy(contributionID[:], k[1:])
+	return contributionID, true
+}
+
+func SetDatasetContributionInfo(ctx context.Context, mu state.Mutable, contributionID ids.ID, datasetAddress codec.Address, dataLocation, dataIdentifier []byte, contributor codec.Address, active bool, initiatedAt uint64) error {
 	// Setup
 	k := DatasetContributionInfoKey(contributionID)
 	dataLocationLen := len(dataLocation)
 	dataIdentifierLen := len(dataIdentifier)
-	contributionInfoSize := codec.AddressLen + consts.Uint16Len + dataLocationLen + consts.Uint16Len + dataIdentifierLen + codec.AddressLen + consts.BoolLen
+	contributionInfoSize := codec.AddressLen + consts.Uint16Len + dataLocationLen + consts.Uint16Len + dataIdentifierLen + codec.AddressLen + consts.BoolLen + consts.Uint64Len
 	v := make([]byte, contributionInfoSize)
 
 	// Populate
@@ -255,15 +277,17 @@ func SetDatasetContributionInfo(ctx context.Context, mu state.Mutable, contribut
 	} else {
 		v[offset] = failureByte
 	}
+	offset += consts.BoolLen
+	binary.BigEndian.PutUint64(v[offset:], initiatedAt)
 
 	return mu.Insert(ctx, k, v)
 }
 
 // Used to serve RPC queries
-func GetDatasetContributionInfoFromState(ctx context.Context, f ReadState, contributionID ids.ID) (codec.Address, []byte, []byte, codec.Address, bool, error) {
+func GetDatasetContributionInfoFromState(ctx context.Context, f ReadState, contributionID ids.ID) (codec.Address, []byte, []byte, codec.Address, bool, uint64, error) {
 	values, errs := f(ctx, [][]byte{DatasetContributionInfoKey(contributionID)})
 	if errs[0] != nil {
-		return codec.EmptyAddress, nil, nil, codec.EmptyAddress, false, errs[0]
+		return codec.EmptyAddress, nil, nil, codec.EmptyAddress, false, 0, errs[0]
 	}
 	return innerGetDatasetContributionInfo(values[0])
 }
@@ -272,16 +296,16 @@ func GetDatasetContributionInfoNoController(
 	ctx context.Context,
 	im state.Immutable,
 	contributionID ids.ID,
-) (codec.Address, []byte, []byte, codec.Address, bool, error) {
+) (codec.Address, []byte, []byte, codec.Address, bool, uint64, error) {
 	k := DatasetContributionInfoKey(contributionID)
 	v, err := im.GetValue(ctx, k)
 	if err != nil {
-		return codec.EmptyAddress, nil, nil, codec.EmptyAddress, false, err
+		return codec.EmptyAddress, nil, nil, codec.EmptyAddress, false, 0, err
 	}
 	return innerGetDatasetContributionInfo(v)
 }
 
-func innerGetDatasetContributionInfo(v []byte) (codec.Address, []byte, []byte, codec.Address, bool, error) {
+func innerGetDatasetContributionInfo(v []byte) (codec.Address, []byte, []byte, codec.Address, bool, uint64, error) {
 	// Extract
 	offset := uint16(0)
 	var datasetAddress codec.Address
@@ -299,8 +323,15 @@ func innerGetDatasetContributionInfo(v []byte) (codec.Address, []byte, []byte, c
 	copy(contributor[:], v[offset:])
 	offset += codec.AddressLen
 	active := v[offset] == successByte
+	offset += consts.BoolLen
+	// Contributions initiated before the block was recorded count as
+	// initiated at genesis
+	var initiatedAt uint64
+	if len(v) >= int(offset)+consts.Uint64Len {
+		initiatedAt = binary.BigEndian.Uint64(v[offset:])
+	}
 
-	return datasetAddress, dataLocation, dataIdentifier, contributor, active, nil
+	return datasetAddress, dataLocation, dataIdentifier, contributor, active, initiatedAt, nil
 }
 
 func DeleteDatasetContributionInfo(ctx context.Context, mu state.Mutable, contributionID ids.ID) error {
@@ -0,0 +1,167 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	DataCommitmentChunks uint16 = 1
+	DataChallengeChunks  uint16 = 1
+	DataCollateralChunks uint16 = 1
+
+	DataRootLen = 32
+
+	dataCommitmentSize = DataRootLen + consts.Uint64Len
+	dataChallengeSize  = codec.AddressLen + consts.Uint64Len*3
+	dataCollateralSize = consts.Uint64Len * 2
+)
+
+// DataCommitmentKey stores the Merkle root of the data of the contribution
+// [contributionID] and its number of chunks
+func DataCommitmentKey(contributionID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)                    // Length of prefix + contributionID + DataCommitmentChunks
+	k[0] = dataCommitmentPrefix                                       // dataCommitmentPrefix is a constant representing the data commitment category
+	copy(k[1:], contributionID[:])                                    // Copy the contributionID
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], DataCommitmentChunks) // Adding DataCommitmentChunks
+	return
+}
+
+func SetDataCommitment(ctx context.Context, mu state.Mutable, contributionID ids.ID, root []byte, chunks uint64) error {
+	v := make([]byte, dataCommitmentSize)
+	copy(v, root)
+	binary.BigEndian.PutUint64(v[DataRootLen:], chunks)
+	return mu.Insert(ctx, DataCommitmentKey(contributionID), v)
+}
+
+// Used to serve RPC queries
+func GetDataCommitmentFromState(ctx context.Context, f ReadState, contributionID ids.ID) (bool, []byte, uint64, error) {
+	values, errs := f(ctx, [][]byte{DataCommitmentKey(contributionID)})
+	return innerGetDataCommitment(values[0], errs[0])
+}
+
+func GetDataCommitmentNoController(ctx context.Context, im state.Immutable, contributionID ids.ID) (bool, []byte, uint64, error) {
+	v, err := im.GetValue(ctx, DataCommitmentKey(contributionID))
+	return innerGetDataCommitment(v, err)
+}
+
+func innerGetDataCommitment(v []byte, err error) (bool, []byte, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, 0, nil
+	}
+	if err != nil {
+		return false, nil, 0, err
+	}
+	return true, v[:DataRootLen], binary.BigEndian.Uint64(v[DataRootLen:]), nil
+}
+
+func DeleteDataCommitment(ctx context.Context, mu state.Mutable, contributionID ids.ID) error {
+	return mu.Remove(ctx, DataCommitmentKey(contributionID))
+}
+
+// DataChallengeKey stores the open challenge of the contribution
+// [contributionID]
+func DataChallengeKey(contributionID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)                   // Length of prefix + contributionID + DataChallengeChunks
+	k[0] = dataChallengePrefix                                       // dataChallengePrefix is a constant representing the data challenge category
+	copy(k[1:], contributionID[:])                                   // Copy the contributionID
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], DataChallengeChunks) // Adding DataChallengeChunks
+	return
+}
+
+// SetDataChallenge records that [challenger] posted [bond] to challenge the
+// contribution [contributionID] to prove it holds chunk [chunk] by block
+// [deadline]
+func SetDataChallenge(ctx context.Context, mu state.Mutable, contributionID ids.ID, challenger codec.Address, bond uint64, chunk uint64, deadline uint64) error {
+	v := make([]byte, dataChallengeSize)
+	copy(v, challenger[:])
+	binary.BigEndian.PutUint64(v[codec.AddressLen:], bond)
+	binary.BigEndian.PutUint64(v[codec.AddressLen+consts.Uint64Len:], chunk)
+	binary.BigEndian.PutUint64(v[codec.AddressLen+consts.Uint64Len*2:], deadline)
+	return mu.Insert(ctx, DataChallengeKey(contributionID), v)
+}
+
+// Used to serve RPC queries
+func GetDataChallengeFromState(ctx context.Context, f ReadState, contributionID ids.ID) (bool, codec.Address, uint64, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{DataChallengeKey(contributionID)})
+	return innerGetDataChallenge(values[0], errs[0])
+}
+
+func GetDataChallengeNoController(ctx context.Context, im state.Immutable, contributionID ids.ID) (bool, codec.Address, uint64, uint64, uint64, error) {
+	v, err := im.GetValue(ctx, DataChallengeKey(contributionID))
+	return innerGetDataChallenge(v, err)
+}
+
+func innerGetDataChallenge(v []byte, err error) (bool, codec.Address, uint64, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, 0, 0, 0, err
+	}
+	var challenger codec.Address
+	copy(challenger[:], v)
+	bond := binary.BigEndian.Uint64(v[codec.AddressLen:])
+	chunk := binary.BigEndian.Uint64(v[codec.AddressLen+consts.Uint64Len:])
+	deadline := binary.BigEndian.Uint64(v[codec.AddressLen+consts.Uint64Len*2:])
+	return true, challenger, bond, chunk, deadline, nil
+}
+
+func DeleteDataChallenge(ctx context.Context, mu state.Mutable, contributionID ids.ID) error {
+	return mu.Remove(ctx, DataChallengeKey(contributionID))
+}
+
+// DataCollateralKey stores the collateral of the contribution
+// [contributionID] that stays locked while it can be challenged
+func DataCollateralKey(contributionID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)                    // Length of prefix + contributionID + DataCollateralChunks
+	k[0] = dataCollateralPrefix                                       // dataCollateralPrefix is a constant representing the data collateral category
+	copy(k[1:], contributionID[:])                                    // Copy the contributionID
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], DataCollateralChunks) // Adding DataCollateralChunks
+	return
+}
+
+// SetDataCollateral records that [amount] of the collateral of the
+// contribution [contributionID] is locked until block [releaseHeight]
+func SetDataCollateral(ctx context.Context, mu state.Mutable, contributionID ids.ID, amount uint64, releaseHeight uint64) error {
+	v := make([]byte, dataCollateralSize)
+	binary.BigEndian.PutUint64(v, amount)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], releaseHeight)
+	return mu.Insert(ctx, DataCollateralKey(contributionID), v)
+}
+
+// Used to serve RPC queries
+func GetDataCollateralFromState(ctx context.Context, f ReadState, contributionID ids.ID) (bool, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{DataCollateralKey(contributionID)})
+	return innerGetDataCollateral(values[0], errs[0])
+}
+
+func GetDataCollateralNoController(ctx context.Context, im state.Immutable, contributionID ids.ID) (bool, uint64, uint64, error) {
+	v, err := im.GetValue(ctx, DataCollateralKey(contributionID))
+	return innerGetDataCollateral(v, err)
+}
+
+func innerGetDataCollateral(v []byte, err error) (bool, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, 0, nil
+	}
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return true, binary.BigEndian.Uint64(v), binary.BigEndian.Uint64(v[consts.Uint64Len:]), nil
+}
+
+func DeleteDataCollateral(ctx context.Context, mu state.Mutable, contributionID ids.ID) error {
+	return mu.Remove(ctx, DataCollateralKey(contributionID))
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	DatasetKeyChunks         uint16 = 11
+	DatasetKeyDelegateChunks uint16 = 2
+)
+
+const (
+	MaxEncryptionPublicKeySize = 128
+	MaxEncryptedDatasetKeySize = 512
+)
+
+// DatasetKeyKey stores the encryption public key the holder of the
+// subscription [nftAddress] registered, along with the holder, and the data
+// key of the dataset encrypted to it
+func DatasetKeyKey(nftAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                // Length of prefix + nftAddress + DatasetKeyChunks
+	k[0] = datasetKeyPrefix                                              // datasetKeyPrefix is a constant representing the dataset key category
+	copy(k[1:], nftAddress[:])                                           // Copy the nftAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], DatasetKeyChunks) // Adding DatasetKeyChunks
+	return
+}
+
+// SetDatasetKey records [encryptionPublicKey] registered by [holder] for the
+// subscription [nftAddress] along with [encryptedKey], the data key of the
+// dataset encrypted to it. [encryptedKey] is empty until the key is delivered.
+func SetDatasetKey(ctx context.Context, mu state.Mutable, nftAddress codec.Address, holder codec.Address, encryptionPublicKey []byte, encryptedKey []byte) error {
+	v := make([]byte, codec.AddressLen+consts.Uint16Len+len(encryptionPublicKey)+consts.Uint16Len+len(encryptedKey))
+	offset := 0
+	copy(v[offset:], holder[:])
+	offset += codec.AddressLen
+	binary.BigEndian.PutUint16(v[offset:], uint16(len(encryptionPublicKey)))
+	offset += consts.Uint16Len
+	copy(v[offset:], encryptionPublicKey)
+	offset += len(encryptionPublicKey)
+	binary.BigEndian.PutUint16(v[offset:], uint16(len(encryptedKey)))
+	offset += consts.Uint16Len
+	copy(v[offset:], encryptedKey)
+	return mu.Insert(ctx, DatasetKeyKey(nftAddress), v)
+}
+
+// Used to serve RPC queries
+func GetDatasetKeyFromState(ctx context.Context, f ReadState, nftAddress codec.Address) (bool, codec.Address, []byte, []byte, error) {
+	values, errs := f(ctx, [][]byte{DatasetKeyKey(nftAddress)})
+	return innerGetDatasetKey(values[0], errs[0])
+}
+
+func GetDatasetKeyNoController(ctx context.Context, im state.Immutable, nftAddress codec.Address) (bool, codec.Address, []byte, []byte, error) {
+	v, err := im.GetValue(ctx, DatasetKeyKey(nftAddress))
+	return innerGetDatasetKey(v, err)
+}
+
+func innerGetDatasetKey(v []byte, err error) (bool, codec.Address, []byte, []byte, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, nil, nil, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, nil, nil, err
+	}
+	var holder codec.Address
+	offset := 0
+	copy(holder[:], v[offset:])
+	offset += codec.AddressLen
+	encryptionPublicKeyLen := int(binary.BigEndian.Uint16(v[offset:]))
+	offset += consts.Uint16Len
+	encryptionPublicKey := v[offset : offset+encryptionPublicKeyLen]
+	offset += encryptionPublicKeyLen
+	encryptedKeyLen := int(binary.BigEndian.Uint16(v[offset:]))
+	offset += consts.Uint16Len
+	encryptedKey := v[offset : offset+encryptedKeyLen]
+	return true, holder, encryptionPublicKey, encryptedKey, nil
+}
+
+// DatasetKeyDelegateKey stores the account the owner of the listing
+// [marketplaceAssetAddress] lets deliver data keys on its behalf
+func DatasetKeyDelegateKey(marketplaceAssetAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                        // Length of prefix + marketplaceAssetAddress + DatasetKeyDelegateChunks
+	k[0] = datasetKeyDelegatePrefix                                              // datasetKeyDelegatePrefix is a constant representing the dataset key delegate category
+	copy(k[1:], marketplaceAssetAddress[:])                                      // Copy the marketplaceAssetAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], DatasetKeyDelegateChunks) // Adding DatasetKeyDelegateChunks
+	return
+}
+
+// SetDatasetKeyDelegate records [delegate] as the account delivering the data
+// keys of the listing [marketplaceAssetAddress] for [owner]. The delegate is
+// only valid while [owner] owns the listing.
+func SetDatasetKeyDelegate(ctx context.Context, mu state.Mutable, marketplaceAssetAddress codec.Address, owner codec.Address, delegate codec.Address) error {
+	v := make([]byte, codec.AddressLen*2)
+	copy(v, owner[:])
+	copy(v[codec.AddressLen:], delegate[:])
+	return mu.Insert(ctx, DatasetKeyDelegateKey(marketplaceAssetAddress), v)
+}
+
+// Used to serve RPC queries
+func GetDatasetKeyDelegateFromState(ctx context.Context, f ReadState, marketplaceAssetAddress codec.Address) (codec.Address, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{DatasetKeyDelegateKey(marketplaceAssetAddress)})
+	return innerGetDatasetKeyDelegate(values[0], errs[0])
+}
+
+func GetDatasetKeyDelegateNoController(ctx context.Context, im state.Immutable, marketplaceAssetAddress codec.Address) (codec.Address, codec.Address, error) {
+	v, err := im.GetValue(ctx, DatasetKeyDelegateKey(marketplaceAssetAddress))
+	return innerGetDatasetKeyDelegate(v, err)
+}
+
+func innerGetDatasetKeyDelegate(v []byte, err error) (codec.Address, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return codec.EmptyAddress, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return codec.EmptyAddress, codec.EmptyAddress, err
+	}
+	var owner, delegate codec.Address
+	copy(owner[:], v)
+	copy(delegate[:], v[codec.AddressLen:])
+	return owner, delegate, nil
+}
+
+func DeleteDatasetKeyDelegate(ctx context.Context, mu state.Mutable, marketplaceAssetAddress codec.Address) error {
+	return mu.Remove(ctx, DatasetKeyDelegateKey(marketplaceAssetAddress))
+}
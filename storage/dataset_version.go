@@ -0,0 +1,177 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	DatasetVersionChunks  uint16 = 7
+	DatasetVersionsChunks uint16 = 1
+)
+
+const (
+	MaxDatasetContentHashSize = 128
+)
+
+// DatasetVersionKey stores [version] of [datasetAddress]. Versions start at 1.
+func DatasetVersionKey(datasetAddress codec.Address, version uint64) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint64Len+consts.Uint16Len)                    // Length of prefix + datasetAddress + version + DatasetVersionChunks
+	k[0] = datasetVersionPrefix                                                               // datasetVersionPrefix is a constant representing the dataset version category
+	copy(k[1:], datasetAddress[:])                                                            // Copy the datasetAddress
+	binary.BigEndian.PutUint64(k[1+codec.AddressLen:], version)                               // Adding the version
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen+consts.Uint64Len:], DatasetVersionChunks) // Adding DatasetVersionChunks
+	return
+}
+
+// DatasetVersionsKey stores the number of versions of [datasetAddress]
+func DatasetVersionsKey(datasetAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                     // Length of prefix + datasetAddress + DatasetVersionsChunks
+	k[0] = datasetVersionsPrefix                                              // datasetVersionsPrefix is a constant representing the dataset versions category
+	copy(k[1:], datasetAddress[:])                                            // Copy the datasetAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], DatasetVersionsChunks) // Adding DatasetVersionsChunks
+	return
+}
+
+// SetDatasetVersion sets [version] of [datasetAddress] whose content hashes to
+// [contentHash] as its latest version. The version is current from block
+// [height] until the next one is set.
+func SetDatasetVersion(
+	ctx context.Context,
+	mu state.Mutable,
+	datasetAddress codec.Address,
+	version uint64,
+	contentHash []byte,
+	size uint64,
+	timestamp int64,
+	height uint64,
+	changelog []byte,
+) error {
+	v := make([]byte, consts.Uint16Len+len(contentHash)+consts.Uint64Len*2+consts.Int64Len+consts.Uint16Len+len(changelog))
+	offset := 0
+	binary.BigEndian.PutUint16(v[offset:], uint16(len(contentHash)))
+	offset += consts.Uint16Len
+	copy(v[offset:], contentHash)
+	offset += len(contentHash)
+	binary.BigEndian.PutUint64(v[offset:], size)
+	offset += consts.Uint64Len
+	binary.BigEndian.PutUint64(v[offset:], uint64(timestamp))
+	offset += consts.Int64Len
+	binary.BigEndian.PutUint64(v[offset:], height)
+	offset += consts.Uint64Len
+	binary.BigEndian.PutUint16(v[offset:], uint16(len(changelog)))
+	offset += consts.Uint16Len
+	copy(v[offset:], changelog)
+	if err := mu.Insert(ctx, DatasetVersionKey(datasetAddress, version), v); err != nil {
+		return err
+	}
+
+	versions := make([]byte, consts.Uint64Len)
+	binary.BigEndian.PutUint64(versions, version)
+	return mu.Insert(ctx, DatasetVersionsKey(datasetAddress), versions)
+}
+
+// Used to serve RPC queries
+func GetDatasetVersionsFromState(ctx context.Context, f ReadState, datasetAddress codec.Address) (uint64, error) {
+	values, errs := f(ctx, [][]byte{DatasetVersionsKey(datasetAddress)})
+	return innerGetDatasetVersions(values[0], errs[0])
+}
+
+func GetDatasetVersionsNoController(ctx context.Context, im state.Immutable, datasetAddress codec.Address) (uint64, error) {
+	v, err := im.GetValue(ctx, DatasetVersionsKey(datasetAddress))
+	return innerGetDatasetVersions(v, err)
+}
+
+func innerGetDatasetVersions(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// Used to serve RPC queries
+func GetDatasetVersionFromState(
+	ctx context.Context,
+	f ReadState,
+	datasetAddress codec.Address,
+	version uint64,
+) ([]byte, uint64, int64, uint64, []byte, error) {
+	values, errs := f(ctx, [][]byte{DatasetVersionKey(datasetAddress, version)})
+	if errs[0] != nil {
+		return nil, 0, 0, 0, nil, errs[0]
+	}
+	return innerGetDatasetVersion(values[0])
+}
+
+func GetDatasetVersionNoController(
+	ctx context.Context,
+	im state.Immutable,
+	datasetAddress codec.Address,
+	version uint64,
+) ([]byte, uint64, int64, uint64, []byte, error) {
+	v, err := im.GetValue(ctx, DatasetVersionKey(datasetAddress, version))
+	if err != nil {
+		return nil, 0, 0, 0, nil, err
+	}
+	return innerGetDatasetVersion(v)
+}
+
+func innerGetDatasetVersion(v []byte) ([]byte, uint64, int64, uint64, []byte, error) {
+	offset := uint16(0)
+	contentHashLen := binary.BigEndian.Uint16(v[offset:])
+	offset += consts.Uint16Len
+	contentHash := v[offset : offset+contentHashLen]
+	offset += contentHashLen
+	size := binary.BigEndian.Uint64(v[offset:])
+	offset += consts.Uint64Len
+	timestamp := int64(binary.BigEndian.Uint64(v[offset:]))
+	offset += consts.Int64Len
+	height := binary.BigEndian.Uint64(v[offset:])
+	offset += consts.Uint64Len
+	changelogLen := binary.BigEndian.Uint16(v[offset:])
+	offset += consts.Uint16Len
+	changelog := v[offset : offset+changelogLen]
+	return contentHash, size, timestamp, height, changelog, nil
+}
+
+// GetDatasetVersionAtHeightFromState returns the version of [datasetAddress]
+// that was current at block [height] or 0 if it had no version yet. Used to
+// serve RPC queries.
+func GetDatasetVersionAtHeightFromState(ctx context.Context, f ReadState, datasetAddress codec.Address, height uint64) (uint64, error) {
+	versions, err := GetDatasetVersionsFromState(ctx, f, datasetAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	// Versions are added in block order so the last one added at or before
+	// [height] is found with a binary search
+	low, high := uint64(1), versions
+	current := uint64(0)
+	for low <= high {
+		mid := low + (high-low)/2
+		_, _, _, addedAt, _, err := GetDatasetVersionFromState(ctx, f, datasetAddress, mid)
+		if err != nil {
+			return 0, err
+		}
+		if addedAt <= height {
+			current = mid
+			low = mid + 1
+		} else {
+			high = mid - 1
+		}
+	}
+	return current, nil
+}
@@ -10,4 +10,7 @@ var (
 	ErrInvalidBalance           = errors.New("invalid balance")
 	ErrMaxSupplyExceeded        = errors.New("max supply exceeded")
 	ErrInsufficientAssetBalance = errors.New("insufficient asset balance")
+	ErrSponsorPolicyNotFound    = errors.New("sponsor policy not found")
+	ErrTooManyPaymentAssets     = errors.New("too many payment assets")
+	ErrPaymentAssetNotTracked   = errors.New("payment asset not tracked by the listing")
 )
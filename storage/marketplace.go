@@ -0,0 +1,282 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// MaxMarketplaceFeeBasisPoints is the highest take rate of the protocol on
+// marketplace payments, 10%
+const MaxMarketplaceFeeBasisPoints = 1_000
+
+// MaxMarketplacePaymentAssets is the most payment assets a listing keeps
+// track of besides its primary payment asset, counting the assets it no
+// longer accepts but still has payments left to claim in
+const MaxMarketplacePaymentAssets = 8
+
+const (
+	MarketplacePaymentChunks       uint16 = 1
+	MarketplacePaymentAssetsChunks uint16 = 5 // Up to 1+MaxMarketplacePaymentAssets entries
+
+	marketplacePaymentSize      = consts.Uint64Len * 4
+	marketplacePaymentAssetSize = codec.AddressLen + 1
+
+	marketplacePaymentAccepted byte = 1 << 0
+	marketplacePaymentPending  byte = 1 << 1
+)
+
+// MarketplaceFeeAmount returns the protocol fee owed on a marketplace payment
+// of [amount] for a take rate of [basisPoints], rounded down
+func MarketplaceFeeAmount(amount uint64, basisPoints uint64) uint64 {
+	// amount * basisPoints / 10_000 is at most amount so the division can't
+	// overflow
+	hi, lo := bits.Mul64(amount, min(basisPoints, MaxMarketplaceFeeBasisPoints))
+	fee, _ := bits.Div64(hi, lo, 10_000)
+	return fee
+}
+
+// MarketplacePaymentAsset is a payment asset a listing keeps track of
+type MarketplacePaymentAsset struct {
+	Address codec.Address
+	// New subscriptions can pay with the asset
+	Accepted bool
+	// Payments made in the asset are left to claim
+	Pending bool
+}
+
+// MarketplacePaymentAssetsKey stores the payment assets the listing
+// [marketplaceAsset] keeps track of. A payment asset has a price and payments
+// in [MarketplacePaymentKey] only as long as it is part of them.
+func MarketplacePaymentAssetsKey(marketplaceAsset codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                              // Length of prefix + marketplaceAsset + MarketplacePaymentAssetsChunks
+	k[0] = marketplacePaymentAssetsPrefix                                              // marketplacePaymentAssetsPrefix is a constant representing the marketplace payment assets category
+	copy(k[1:], marketplaceAsset[:])                                                   // Copy the marketplaceAsset
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], MarketplacePaymentAssetsChunks) // Adding MarketplacePaymentAssetsChunks
+	return
+}
+
+func SetMarketplacePaymentAssets(ctx context.Context, mu state.Mutable, marketplaceAsset codec.Address, paymentAssets []MarketplacePaymentAsset) error {
+	k := MarketplacePaymentAssetsKey(marketplaceAsset)
+	if len(paymentAssets) == 0 {
+		return mu.Remove(ctx, k)
+	}
+	v := make([]byte, len(paymentAssets)*marketplacePaymentAssetSize)
+	for i, paymentAsset := range paymentAssets {
+		entry := v[i*marketplacePaymentAssetSize:]
+		copy(entry, paymentAsset.Address[:])
+		if paymentAsset.Accepted {
+			entry[codec.AddressLen] |= marketplacePaymentAccepted
+		}
+		if paymentAsset.Pending {
+			entry[codec.AddressLen] |= marketplacePaymentPending
+		}
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetMarketplacePaymentAssetsFromState(ctx context.Context, f ReadState, marketplaceAsset codec.Address) ([]MarketplacePaymentAsset, error) {
+	values, errs := f(ctx, [][]byte{MarketplacePaymentAssetsKey(marketplaceAsset)})
+	return innerGetMarketplacePaymentAssets(values[0], errs[0])
+}
+
+func GetMarketplacePaymentAssetsNoController(ctx context.Context, im state.Immutable, marketplaceAsset codec.Address) ([]MarketplacePaymentAsset, error) {
+	v, err := im.GetValue(ctx, MarketplacePaymentAssetsKey(marketplaceAsset))
+	return innerGetMarketplacePaymentAssets(v, err)
+}
+
+func innerGetMarketplacePaymentAssets(v []byte, err error) ([]MarketplacePaymentAsset, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	paymentAssets := make([]MarketplacePaymentAsset, len(v)/marketplacePaymentAssetSize)
+	for i := range paymentAssets {
+		entry := v[i*marketplacePaymentAssetSize:]
+		copy(paymentAssets[i].Address[:], entry)
+		paymentAssets[i].Accepted = entry[codec.AddressLen]&marketplacePaymentAccepted != 0
+		paymentAssets[i].Pending = entry[codec.AddressLen]&marketplacePaymentPending != 0
+	}
+	return paymentAssets, nil
+}
+
+// MarketplacePaymentKey stores the price per block of the listing
+// [marketplaceAsset] in [paymentAsset] and the payments made in it
+func MarketplacePaymentKey(marketplaceAsset codec.Address, paymentAsset codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen*2+consts.Uint16Len)                        // Length of prefix + marketplaceAsset + paymentAsset + MarketplacePaymentChunks
+	k[0] = marketplacePaymentPrefix                                                // marketplacePaymentPrefix is a constant representing the marketplace payment category
+	copy(k[1:], marketplaceAsset[:])                                               // Copy the marketplaceAsset
+	copy(k[1+codec.AddressLen:], paymentAsset[:])                                  // Copy the paymentAsset
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen*2:], MarketplacePaymentChunks) // Adding MarketplacePaymentChunks
+	return
+}
+
+// SetMarketplacePayment records that the listing [marketplaceAsset] sells
+// new subscriptions for [pricePerBlock] of [paymentAsset] and that
+// [remaining] of the payments made in it is left to claim, [claimed] having
+// been claimed up to block [lastClaimedBlock]
+func SetMarketplacePayment(ctx context.Context, mu state.Mutable, marketplaceAsset codec.Address, paymentAsset codec.Address, pricePerBlock uint64, remaining uint64, claimed uint64, lastClaimedBlock uint64) error {
+	v := make([]byte, marketplacePaymentSize)
+	binary.BigEndian.PutUint64(v, pricePerBlock)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], remaining)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len*2:], claimed)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len*3:], lastClaimedBlock)
+	return mu.Insert(ctx, MarketplacePaymentKey(marketplaceAsset, paymentAsset), v)
+}
+
+// Used to serve RPC queries
+func GetMarketplacePaymentFromState(ctx context.Context, f ReadState, marketplaceAsset codec.Address, paymentAsset codec.Address) (uint64, uint64, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{MarketplacePaymentKey(marketplaceAsset, paymentAsset)})
+	return innerGetMarketplacePayment(values[0], errs[0])
+}
+
+func GetMarketplacePaymentNoController(ctx context.Context, im state.Immutable, marketplaceAsset codec.Address, paymentAsset codec.Address) (uint64, uint64, uint64, uint64, error) {
+	v, err := im.GetValue(ctx, MarketplacePaymentKey(marketplaceAsset, paymentAsset))
+	return innerGetMarketplacePayment(v, err)
+}
+
+func innerGetMarketplacePayment(v []byte, err error) (uint64, uint64, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	pricePerBlock := binary.BigEndian.Uint64(v)
+	remaining := binary.BigEndian.Uint64(v[consts.Uint64Len:])
+	claimed := binary.BigEndian.Uint64(v[consts.Uint64Len*2:])
+	lastClaimedBlock := binary.BigEndian.Uint64(v[consts.Uint64Len*3:])
+	return pricePerBlock, remaining, claimed, lastClaimedBlock, nil
+}
+
+// MarketplacePaymentAssetOf returns the payment asset [paymentAsset] of
+// [paymentAssets] and whether the listing keeps track of it
+func MarketplacePaymentAssetOf(paymentAssets []MarketplacePaymentAsset, paymentAsset codec.Address) (MarketplacePaymentAsset, bool) {
+	i := slices.IndexFunc(paymentAssets, func(a MarketplacePaymentAsset) bool {
+		return a.Address == paymentAsset
+	})
+	if i < 0 {
+		return MarketplacePaymentAsset{}, false
+	}
+	return paymentAssets[i], true
+}
+
+// MarketplacePaymentPending returns whether any payment of the listing
+// [marketplaceAsset] is left to claim
+func MarketplacePaymentPending(ctx context.Context, im state.Immutable, marketplaceAsset codec.Address) (bool, error) {
+	paymentAssets, err := GetMarketplacePaymentAssetsNoController(ctx, im, marketplaceAsset)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(paymentAssets, func(a MarketplacePaymentAsset) bool {
+		return a.Pending
+	}), nil
+}
+
+// AcceptMarketplacePayment makes the listing [marketplaceAsset] sell new
+// subscriptions for [pricePerBlock] of [paymentAsset]. The payments already
+// made in an asset the listing keeps track of are kept, while an asset it
+// starts tracking starts with no payments.
+func AcceptMarketplacePayment(ctx context.Context, mu state.Mutable, marketplaceAsset codec.Address, paymentAsset codec.Address, pricePerBlock uint64) error {
+	paymentAssets, err := GetMarketplacePaymentAssetsNoController(ctx, mu, marketplaceAsset)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(paymentAssets, func(a MarketplacePaymentAsset) bool {
+		return a.Address == paymentAsset
+	})
+	if i < 0 {
+		if len(paymentAssets) > MaxMarketplacePaymentAssets {
+			return ErrTooManyPaymentAssets
+		}
+		paymentAssets = append(paymentAssets, MarketplacePaymentAsset{Address: paymentAsset, Accepted: true})
+		if err := SetMarketplacePaymentAssets(ctx, mu, marketplaceAsset, paymentAssets); err != nil {
+			return err
+		}
+		return SetMarketplacePayment(ctx, mu, marketplaceAsset, paymentAsset, pricePerBlock, 0, 0, 0)
+	}
+	if !paymentAssets[i].Accepted {
+		paymentAssets[i].Accepted = true
+		if err := SetMarketplacePaymentAssets(ctx, mu, marketplaceAsset, paymentAssets); err != nil {
+			return err
+		}
+	}
+	_, remaining, claimed, lastClaimedBlock, err := GetMarketplacePaymentNoController(ctx, mu, marketplaceAsset, paymentAsset)
+	if err != nil {
+		return err
+	}
+	return SetMarketplacePayment(ctx, mu, marketplaceAsset, paymentAsset, pricePerBlock, remaining, claimed, lastClaimedBlock)
+}
+
+// RejectMarketplacePayment stops the listing [marketplaceAsset] from selling
+// new subscriptions for [paymentAsset]. Payments left to claim in it can still
+// be claimed, after which the listing stops tracking it. It only updates
+// [MarketplacePaymentAssetsKey].
+func RejectMarketplacePayment(ctx context.Context, mu state.Mutable, marketplaceAsset codec.Address, paymentAsset codec.Address) error {
+	paymentAssets, err := GetMarketplacePaymentAssetsNoController(ctx, mu, marketplaceAsset)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(paymentAssets, func(a MarketplacePaymentAsset) bool {
+		return a.Address == paymentAsset
+	})
+	if i < 0 {
+		return nil
+	}
+	if paymentAssets[i].Pending {
+		paymentAssets[i].Accepted = false
+	} else {
+		paymentAssets = slices.Delete(paymentAssets, i, i+1)
+	}
+	return SetMarketplacePaymentAssets(ctx, mu, marketplaceAsset, paymentAssets)
+}
+
+// UpdateMarketplacePayment records that [remaining] of the payments of the
+// listing [marketplaceAsset] in [paymentAsset] is left to claim, [claimed]
+// having been claimed up to block [lastClaimedBlock]. The listing stops
+// tracking an asset it no longer accepts once everything is claimed.
+func UpdateMarketplacePayment(ctx context.Context, mu state.Mutable, marketplaceAsset codec.Address, paymentAsset codec.Address, remaining uint64, claimed uint64, lastClaimedBlock uint64) error {
+	paymentAssets, err := GetMarketplacePaymentAssetsNoController(ctx, mu, marketplaceAsset)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(paymentAssets, func(a MarketplacePaymentAsset) bool {
+		return a.Address == paymentAsset
+	})
+	if i < 0 {
+		return ErrPaymentAssetNotTracked
+	}
+	pending := remaining > 0
+	if !pending && !paymentAssets[i].Accepted {
+		paymentAssets = slices.Delete(paymentAssets, i, i+1)
+		if err := SetMarketplacePaymentAssets(ctx, mu, marketplaceAsset, paymentAssets); err != nil {
+			return err
+		}
+		return mu.Remove(ctx, MarketplacePaymentKey(marketplaceAsset, paymentAsset))
+	}
+	if paymentAssets[i].Pending != pending {
+		paymentAssets[i].Pending = pending
+		if err := SetMarketplacePaymentAssets(ctx, mu, marketplaceAsset, paymentAssets); err != nil {
+			return err
+		}
+	}
+	pricePerBlock, _, _, _, err := GetMarketplacePaymentNoController(ctx, mu, marketplaceAsset, paymentAsset)
+	if err != nil {
+		return err
+	}
+	return SetMarketplacePayment(ctx, mu, marketplaceAsset, paymentAsset, pricePerBlock, remaining, claimed, lastClaimedBlock)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	MintRoleChunks uint16 = 1
+
+	mintRoleSize = consts.Uint64Len * 4
+)
+
+// MintRoleKey stores the mint quota of [account] granted the mint role of
+// [asset]
+func MintRoleKey(asset codec.Address, account codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen*2+consts.Uint16Len)              // Length of prefix + asset + account + MintRoleChunks
+	k[0] = mintRolePrefix                                                // mintRolePrefix is a constant representing the mint role category
+	copy(k[1:], asset[:])                                                // Copy the asset
+	copy(k[1+codec.AddressLen:], account[:])                             // Copy the account
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen*2:], MintRoleChunks) // Adding MintRoleChunks
+	return
+}
+
+// MintPeriodStart returns the start of the mint period of [period] blocks
+// [height] is in given the start of an earlier period. A [period] of zero
+// never ends.
+func MintPeriodStart(periodStart uint64, period uint64, height uint64) uint64 {
+	if period == 0 || height < periodStart+period {
+		return periodStart
+	}
+	return height - (height-periodStart)%period
+}
+
+// SetMintRole grants the mint role of [asset] to [account]. Minters can mint at
+// most [quota] every [period] blocks and have minted [minted] during the
+// period that started at [periodStart]. A [quota] of zero is unlimited.
+func SetMintRole(
+	ctx context.Context,
+	mu state.Mutable,
+	asset codec.Address,
+	account codec.Address,
+	quota uint64,
+	period uint64,
+	periodStart uint64,
+	minted uint64,
+) error {
+	v := make([]byte, mintRoleSize)
+	binary.BigEndian.PutUint64(v, quota)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], period)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len*2:], periodStart)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len*3:], minted)
+	return mu.Insert(ctx, MintRoleKey(asset, account), v)
+}
+
+// Used to serve RPC queries
+func GetMintRoleFromState(
+	ctx context.Context,
+	f ReadState,
+	asset codec.Address,
+	account codec.Address,
+) (bool, uint64, uint64, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{MintRoleKey(asset, account)})
+	return innerGetMintRole(values[0], errs[0])
+}
+
+func GetMintRoleNoController(
+	ctx context.Context,
+	im state.Immutable,
+	asset codec.Address,
+	account codec.Address,
+) (bool, uint64, uint64, uint64, uint64, error) {
+	v, err := im.GetValue(ctx, MintRoleKey(asset, account))
+	return innerGetMintRole(v, err)
+}
+
+func innerGetMintRole(v []byte, err error) (bool, uint64, uint64, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, 0, 0, 0, 0, err
+	}
+	quota := binary.BigEndian.Uint64(v)
+	period := binary.BigEndian.Uint64(v[consts.Uint64Len:])
+	periodStart := binary.BigEndian.Uint64(v[consts.Uint64Len*2:])
+	minted := binary.BigEndian.Uint64(v[consts.Uint64Len*3:])
+	return true, quota, period, periodStart, minted, nil
+}
+
+func DeleteMintRole(ctx context.Context, mu state.Mutable, asset codec.Address, account codec.Address) error {
+	return mu.Remove(ctx, MintRoleKey(asset, account))
+}
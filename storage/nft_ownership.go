@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	NFTOwnershipChunks uint16 = 2
+)
+
+// AddNFTTransferStateKeys adds the keys [TransferAsset] uses on top of the
+// balances of [nftAddress] to move it from [from] to [to]
+func AddNFTTransferStateKeys(stateKeys state.Keys, nftAddress codec.Address, collectionAddress codec.Address, from codec.Address, to codec.Address) {
+	stateKeys.Add(string(AssetInfoKey(nftAddress)), state.Read|state.Write)
+	stateKeys.Add(string(NFTOwnershipKey(nftAddress)), state.All)
+	stateKeys.Add(string(AssetAccountBalanceKey(collectionAddress, from)), state.Read|state.Write)
+	stateKeys.Add(string(AssetAccountBalanceKey(collectionAddress, to)), state.All)
+}
+
+// NFTOwnershipKey stores the last ownership change of an NFT
+func NFTOwnershipKey(nftAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                  // Length of prefix + nftAddress + NFTOwnershipChunks
+	k[0] = nftOwnershipPrefix                                              // nftOwnershipPrefix is a constant representing the NFT ownership category
+	copy(k[1:1+codec.AddressLen], nftAddress[:])                           // Copy the nftAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], NFTOwnershipChunks) // Adding NFTOwnershipChunks
+	return
+}
+
+// SetNFTOwner sets [owner] as the owner in the asset info of [nftAddress] and
+// records the change along with the previous owner
+func SetNFTOwner(ctx context.Context, mu state.Mutable, nftAddress codec.Address, owner codec.Address) error {
+	assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, previousOwner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin, err := GetAssetInfoNoController(ctx, mu, nftAddress)
+	if err != nil {
+		return err
+	}
+	if previousOwner == owner {
+		return nil
+	}
+	if err := SetAssetInfo(ctx, mu, nftAddress, assetType, name, symbol, decimals, metadata, uri, totalSupply, maxSupply, owner, mintAdmin, pauseUnpauseAdmin, freezeUnfreezeAdmin, enableDisableKYCAccountAdmin); err != nil {
+		return err
+	}
+
+	_, _, _, changes, err := GetNFTOwnershipNoController(ctx, mu, nftAddress)
+	if err != nil {
+		return err
+	}
+	v := make([]byte, codec.AddressLen*2+consts.Uint64Len)
+	copy(v, previousOwner[:])
+	copy(v[codec.AddressLen:], owner[:])
+	binary.BigEndian.PutUint64(v[codec.AddressLen*2:], changes+1)
+	return mu.Insert(ctx, NFTOwnershipKey(nftAddress), v)
+}
+
+// Used to serve RPC queries
+func GetNFTOwnershipFromState(
+	ctx context.Context,
+	f ReadState,
+	nftAddress codec.Address,
+) (bool, codec.Address, codec.Address, uint64, error) {
+	values, errs := f(ctx, [][]byte{NFTOwnershipKey(nftAddress)})
+	return innerGetNFTOwnership(values[0], errs[0])
+}
+
+func GetNFTOwnershipNoController(
+	ctx context.Context,
+	im state.Immutable,
+	nftAddress codec.Address,
+) (bool, codec.Address, codec.Address, uint64, error) {
+	v, err := im.GetValue(ctx, NFTOwnershipKey(nftAddress))
+	return innerGetNFTOwnership(v, err)
+}
+
+func innerGetNFTOwnership(v []byte, err error) (bool, codec.Address, codec.Address, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, codec.EmptyAddress, 0, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, codec.EmptyAddress, 0, err
+	}
+
+	var previousOwner, owner codec.Address
+	copy(previousOwner[:], v)
+	copy(owner[:], v[codec.AddressLen:])
+	changes := binary.BigEndian.Uint64(v[codec.AddressLen*2:])
+	return true, previousOwner, owner, changes, nil
+}
+
+func DeleteNFTOwnership(ctx context.Context, mu state.Mutable, nftAddress codec.Address) error {
+	return mu.Remove(ctx, NFTOwnershipKey(nftAddress))
+}
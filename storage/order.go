@@ -0,0 +1,97 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	OrderChunks uint16 = 2
+)
+
+// OrderAddress identifies the order of [owner] selling [inTick] of [in] for
+// every [outTick] of [out]. It is also the account holding the [in] locked by
+// the owner until the order is filled or closed.
+func OrderAddress(owner codec.Address, in codec.Address, inTick uint64, out codec.Address, outTick uint64) codec.Address {
+	return codec.CreateAddress(nconsts.OrderAddressID, utils.ToID(packOrder(in, inTick, out, outTick, owner)))
+}
+
+func OrderKey(orderAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)           // Length of prefix + orderAddress + OrderChunks
+	k[0] = orderPrefix                                              // orderPrefix is a constant representing the order category
+	copy(k[1:1+codec.AddressLen], orderAddress[:])                  // Copy the orderAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], OrderChunks) // Adding OrderChunks
+	return
+}
+
+func SetOrder(ctx context.Context, mu state.Mutable, owner codec.Address, in codec.Address, inTick uint64, out codec.Address, outTick uint64) (codec.Address, error) {
+	v := packOrder(in, inTick, out, outTick, owner)
+	orderAddress := codec.CreateAddress(nconsts.OrderAddressID, utils.ToID(v))
+	return orderAddress, mu.Insert(ctx, OrderKey(orderAddress), v)
+}
+
+func packOrder(in codec.Address, inTick uint64, out codec.Address, outTick uint64, owner codec.Address) []byte {
+	v := make([]byte, codec.AddressLen+consts.Uint64Len+codec.AddressLen+consts.Uint64Len+codec.AddressLen)
+
+	offset := 0
+	copy(v[offset:], in[:])
+	offset += codec.AddressLen
+	binary.BigEndian.PutUint64(v[offset:], inTick)
+	offset += consts.Uint64Len
+	copy(v[offset:], out[:])
+	offset += codec.AddressLen
+	binary.BigEndian.PutUint64(v[offset:], outTick)
+	offset += consts.Uint64Len
+	copy(v[offset:], owner[:])
+	return v
+}
+
+// Used to serve RPC queries
+func GetOrderFromState(ctx context.Context, f ReadState, orderAddress codec.Address) (bool, codec.Address, uint64, codec.Address, uint64, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{OrderKey(orderAddress)})
+	return innerGetOrder(values[0], errs[0])
+}
+
+func GetOrderNoController(ctx context.Context, im state.Immutable, orderAddress codec.Address) (bool, codec.Address, uint64, codec.Address, uint64, codec.Address, error) {
+	v, err := im.GetValue(ctx, OrderKey(orderAddress))
+	return innerGetOrder(v, err)
+}
+
+func innerGetOrder(v []byte, err error) (bool, codec.Address, uint64, codec.Address, uint64, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, 0, codec.EmptyAddress, 0, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, 0, codec.EmptyAddress, 0, codec.EmptyAddress, err
+	}
+
+	offset := 0
+	var in, out, owner codec.Address
+	copy(in[:], v[offset:offset+codec.AddressLen])
+	offset += codec.AddressLen
+	inTick := binary.BigEndian.Uint64(v[offset:])
+	offset += consts.Uint64Len
+	copy(out[:], v[offset:offset+codec.AddressLen])
+	offset += codec.AddressLen
+	outTick := binary.BigEndian.Uint64(v[offset:])
+	offset += consts.Uint64Len
+	copy(owner[:], v[offset:offset+codec.AddressLen])
+	return true, in, inTick, out, outTick, owner, nil
+}
+
+func DeleteOrder(ctx context.Context, mu state.Mutable, orderAddress codec.Address) error {
+	return mu.Remove(ctx, OrderKey(orderAddress))
+}
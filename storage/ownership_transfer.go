@@ -0,0 +1,79 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	OwnershipTransferChunks uint16 = 2
+)
+
+// OwnershipTransferKey stores the account [role] of the [kind] at [address]
+// is proposed to until it accepts it, and the owner that proposed it
+func OwnershipTransferKey(kind uint8, address codec.Address, role uint8) (k []byte) {
+	k = make([]byte, 1+consts.Uint8Len+codec.AddressLen+consts.Uint8Len+consts.Uint16Len) // Length of prefix + kind + address + role + OwnershipTransferChunks
+	k[0] = ownershipTransferPrefix                                                        // ownershipTransferPrefix is a constant representing the ownership transfer category
+	k[1] = kind                                                                           // Adding the kind
+	copy(k[2:2+codec.AddressLen], address[:])                                             // Copy the address
+	k[2+codec.AddressLen] = role                                                          // Adding the role
+	binary.BigEndian.PutUint16(k[3+codec.AddressLen:], OwnershipTransferChunks)           // Adding OwnershipTransferChunks
+	return
+}
+
+func SetOwnershipTransfer(ctx context.Context, mu state.Mutable, kind uint8, address codec.Address, role uint8, newOwner codec.Address, proposer codec.Address) error {
+	v := make([]byte, 2*codec.AddressLen)
+	copy(v, newOwner[:])
+	copy(v[codec.AddressLen:], proposer[:])
+	return mu.Insert(ctx, OwnershipTransferKey(kind, address, role), v)
+}
+
+// Used to serve RPC queries
+func GetOwnershipTransferFromState(
+	ctx context.Context,
+	f ReadState,
+	kind uint8,
+	address codec.Address,
+	role uint8,
+) (bool, codec.Address, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{OwnershipTransferKey(kind, address, role)})
+	return innerGetOwnershipTransfer(values[0], errs[0])
+}
+
+func GetOwnershipTransferNoController(
+	ctx context.Context,
+	im state.Immutable,
+	kind uint8,
+	address codec.Address,
+	role uint8,
+) (bool, codec.Address, codec.Address, error) {
+	v, err := im.GetValue(ctx, OwnershipTransferKey(kind, address, role))
+	return innerGetOwnershipTransfer(v, err)
+}
+
+func innerGetOwnershipTransfer(v []byte, err error) (bool, codec.Address, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, codec.EmptyAddress, err
+	}
+	var newOwner, proposer codec.Address
+	copy(newOwner[:], v[:codec.AddressLen])
+	copy(proposer[:], v[codec.AddressLen:])
+	return true, newOwner, proposer, nil
+}
+
+func DeleteOwnershipTransfer(ctx context.Context, mu state.Mutable, kind uint8, address codec.Address, role uint8) error {
+	return mu.Remove(ctx, OwnershipTransferKey(kind, address, role))
+}
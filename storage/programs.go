@@ -6,17 +6,31 @@ package storage
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/keys"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/x/contracts/runtime"
 )
 
+const (
+	ContractUpgradeAuthorityChunks uint16 = 1
+	ContractUpgradeChunks          uint16 = 3
+	ContractABIChunks              uint16 = MaxContractABISize / 64
+)
+
+const (
+	MaxContractIDSize  = 40
+	MaxContractABISize = 16 * units.KiB
+)
+
 // [accountStatePrefix] + [account]
 func accountStateKey(account codec.Address) (k []byte) {
 	k = make([]byte, 2+codec.AddressLen)
@@ -51,6 +65,33 @@ func StoreContract(
 	return key, mu.Insert(ctx, key, contractBytes)
 }
 
+// ContractABIKey is the key of the ABI published along with [contractID]
+func ContractABIKey(contractID runtime.ContractID) (k []byte) {
+	k = make([]byte, 1+len(contractID)+consts.Uint16Len)
+	k[0] = contractABIPrefix
+	copy(k[1:], contractID)
+	binary.BigEndian.PutUint16(k[1+len(contractID):], ContractABIChunks)
+	return
+}
+
+func StoreContractABI(
+	ctx context.Context,
+	mu state.Mutable,
+	contractID runtime.ContractID,
+	abi []byte,
+) error {
+	return mu.Insert(ctx, ContractABIKey(contractID), abi)
+}
+
+// Used to serve RPC queries
+func GetContractABIFromState(ctx context.Context, f ReadState, contractID runtime.ContractID) ([]byte, error) {
+	values, errs := f(ctx, [][]byte{ContractABIKey(contractID)})
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	return values[0], nil
+}
+
 func GetAddressForDeploy(typeID uint8, creationData []byte) codec.Address {
 	digest := sha256.Sum256(creationData)
 	return codec.CreateAddress(typeID, digest)
@@ -133,3 +174,159 @@ func (s *prefixedStateMutable) Insert(ctx context.Context, key []byte, value []b
 func (s *prefixedStateMutable) Remove(ctx context.Context, key []byte) error {
 	return s.inner.Remove(ctx, s.prefixKey(key))
 }
+
+// ValidContractID reports whether [contractID] has the shape of a key returned by
+// [StoreContract].
+func ValidContractID(contractID runtime.ContractID) bool {
+	return len(contractID) == 1+ids.IDLen+consts.Uint16Len && contractID[0] == contractsPrefix
+}
+
+func ContractUpgradeAuthorityKey(contractAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                              // Length of prefix + contractAddress + ContractUpgradeAuthorityChunks
+	k[0] = contractUpgradeAuthorityPrefix                                              // contractUpgradeAuthorityPrefix is a constant representing the contract upgrade authority category
+	copy(k[1:1+codec.AddressLen], contractAddress[:])                                  // Copy the contractAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], ContractUpgradeAuthorityChunks) // Adding ContractUpgradeAuthorityChunks
+	return
+}
+
+// SetContractUpgradeAuthority stores the address allowed to upgrade [contractAddress]
+// along with the number of upgrades performed so far. An empty [authority] means the
+// contract can no longer be upgraded.
+func SetContractUpgradeAuthority(ctx context.Context, mu state.Mutable, contractAddress codec.Address, authority codec.Address, version uint64) error {
+	k := ContractUpgradeAuthorityKey(contractAddress)
+	v := make([]byte, codec.AddressLen+consts.Uint64Len)
+	copy(v, authority[:])
+	binary.BigEndian.PutUint64(v[codec.AddressLen:], version)
+	return mu.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetContractUpgradeAuthorityFromState(ctx context.Context, f ReadState, contractAddress codec.Address) (codec.Address, uint64, error) {
+	values, errs := f(ctx, [][]byte{ContractUpgradeAuthorityKey(contractAddress)})
+	return innerGetContractUpgradeAuthority(values[0], errs[0])
+}
+
+func GetContractUpgradeAuthorityNoController(ctx context.Context, im state.Immutable, contractAddress codec.Address) (codec.Address, uint64, error) {
+	v, err := im.GetValue(ctx, ContractUpgradeAuthorityKey(contractAddress))
+	return innerGetContractUpgradeAuthority(v, err)
+}
+
+func innerGetContractUpgradeAuthority(v []byte, err error) (codec.Address, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return codec.EmptyAddress, 0, nil
+	}
+	if err != nil {
+		return codec.EmptyAddress, 0, err
+	}
+	var authority codec.Address
+	copy(authority[:], v[:codec.AddressLen])
+	version := binary.BigEndian.Uint64(v[codec.AddressLen:])
+	return authority, version, nil
+}
+
+func ContractUpgradeKey(contractAddress codec.Address, version uint64) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint64Len+consts.Uint16Len)                     // Length of prefix + contractAddress + version + ContractUpgradeChunks
+	k[0] = contractUpgradePrefix                                                               // contractUpgradePrefix is a constant representing the contract upgrade history category
+	copy(k[1:1+codec.AddressLen], contractAddress[:])                                          // Copy the contractAddress
+	binary.BigEndian.PutUint64(k[1+codec.AddressLen:], version)                                // Copy the version
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen+consts.Uint64Len:], ContractUpgradeChunks) // Adding ContractUpgradeChunks
+	return
+}
+
+// SetContractUpgrade records the [version]th upgrade of [contractAddress] so the
+// contract history can be audited later on.
+func SetContractUpgrade(
+	ctx context.Context,
+	mu state.Mutable,
+	contractAddress codec.Address,
+	version uint64,
+	previousContractID runtime.ContractID,
+	newContractID runtime.ContractID,
+	authority codec.Address,
+	timestamp int64,
+	actionID ids.ID,
+) error {
+	// Setup
+	k := ContractUpgradeKey(contractAddress, version)
+	previousContractIDLen := len(previousContractID)
+	newContractIDLen := len(newContractID)
+	upgradeSize := consts.Uint16Len + previousContractIDLen + consts.Uint16Len + newContractIDLen + codec.AddressLen + consts.Int64Len + ids.IDLen
+	v := make([]byte, upgradeSize)
+
+	// Populate
+	offset := 0
+	binary.BigEndian.PutUint16(v[offset:], uint16(previousContractIDLen))
+	offset += consts.Uint16Len
+	copy(v[offset:], previousContractID)
+	offset += previousContractIDLen
+	binary.BigEndian.PutUint16(v[offset:], uint16(newContractIDLen))
+	offset += consts.Uint16Len
+	copy(v[offset:], newContractID)
+	offset += newContractIDLen
+	copy(v[offset:], authority[:])
+	offset += codec.AddressLen
+	binary.BigEndian.PutUint64(v[offset:], uint64(timestamp))
+	offset += consts.Int64Len
+	copy(v[offset:], actionID[:])
+
+	return mu.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetContractUpgradeFromState(
+	ctx context.Context,
+	f ReadState,
+	contractAddress codec.Address,
+	version uint64,
+) (runtime.ContractID, runtime.ContractID, codec.Address, int64, ids.ID, error) {
+	values, errs := f(ctx, [][]byte{ContractUpgradeKey(contractAddress, version)})
+	if errs[0] != nil {
+		return nil, nil, codec.EmptyAddress, 0, ids.Empty, errs[0]
+	}
+	return innerGetContractUpgrade(values[0])
+}
+
+func GetContractUpgradeNoController(
+	ctx context.Context,
+	im state.Immutable,
+	contractAddress codec.Address,
+	version uint64,
+) (runtime.ContractID, runtime.ContractID, codec.Address, int64, ids.ID, error) {
+	v, err := im.GetValue(ctx, ContractUpgradeKey(contractAddress, version))
+	if err != nil {
+		return nil, nil, codec.EmptyAddress, 0, ids.Empty, err
+	}
+	return innerGetContractUpgrade(v)
+}
+
+func innerGetContractUpgrade(v []byte) (runtime.ContractID, runtime.ContractID, codec.Address, int64, ids.ID, error) {
+	// Extract
+	offset := uint16(0)
+	previousContractIDLen := binary.BigEndian.Uint16(v[offset:])
+	offset += consts.Uint16Len
+	previousContractID := v[offset : offset+previousContractIDLen]
+	offset += previousContractIDLen
+	newContractIDLen := binary.BigEndian.Uint16(v[offset:])
+	offset += consts.Uint16Len
+	newContractID := v[offset : offset+newContractIDLen]
+	offset += newContractIDLen
+	var authority codec.Address
+	copy(authority[:], v[offset:])
+	offset += codec.AddressLen
+	timestamp := int64(binary.BigEndian.Uint64(v[offset:]))
+	offset += consts.Int64Len
+	var actionID ids.ID
+	copy(actionID[:], v[offset:])
+
+	return previousContractID, newContractID, authority, timestamp, actionID, nil
+}
+
+// Used to serve RPC queries
+func GetAccountContractFromState(ctx context.Context, f ReadState, account codec.Address) (runtime.ContractID, error) {
+	key, _ := keys.Encode(AccountContractKey(account), 36)
+	values, errs := f(ctx, [][]byte{key})
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	return values[0], nil
+}
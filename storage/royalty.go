@@ -0,0 +1,91 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	RoyaltyChunks uint16 = 2
+
+	// MaxRoyaltyBasisPoints is a royalty of 100%
+	MaxRoyaltyBasisPoints = 10_000
+)
+
+// RoyaltyAmount returns the part of [price] owed for a royalty of
+// [basisPoints], rounded down
+func RoyaltyAmount(price uint64, basisPoints uint64) uint64 {
+	// price * basisPoints / MaxRoyaltyBasisPoints is at most price so the
+	// division can't overflow
+	hi, lo := bits.Mul64(price, min(basisPoints, MaxRoyaltyBasisPoints))
+	royalty, _ := bits.Div64(hi, lo, MaxRoyaltyBasisPoints)
+	return royalty
+}
+
+func RoyaltyKey(collectionAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)             // Length of prefix + collectionAddress + RoyaltyChunks
+	k[0] = royaltyPrefix                                              // royaltyPrefix is a constant representing the royalty category
+	copy(k[1:1+codec.AddressLen], collectionAddress[:])               // Copy the collectionAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], RoyaltyChunks) // Adding RoyaltyChunks
+	return
+}
+
+func SetRoyalty(
+	ctx context.Context,
+	mu state.Mutable,
+	collectionAddress codec.Address,
+	basisPoints uint64,
+	recipient codec.Address,
+	paymentAsset codec.Address,
+) error {
+	v := make([]byte, consts.Uint64Len+codec.AddressLen*2)
+	binary.BigEndian.PutUint64(v, basisPoints)
+	copy(v[consts.Uint64Len:], recipient[:])
+	copy(v[consts.Uint64Len+codec.AddressLen:], paymentAsset[:])
+	return mu.Insert(ctx, RoyaltyKey(collectionAddress), v)
+}
+
+// Used to serve RPC queries
+func GetRoyaltyFromState(
+	ctx context.Context,
+	f ReadState,
+	collectionAddress codec.Address,
+) (bool, uint64, codec.Address, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{RoyaltyKey(collectionAddress)})
+	return innerGetRoyalty(values[0], errs[0])
+}
+
+func GetRoyaltyNoController(
+	ctx context.Context,
+	im state.Immutable,
+	collectionAddress codec.Address,
+) (bool, uint64, codec.Address, codec.Address, error) {
+	v, err := im.GetValue(ctx, RoyaltyKey(collectionAddress))
+	return innerGetRoyalty(v, err)
+}
+
+func innerGetRoyalty(v []byte, err error) (bool, uint64, codec.Address, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, codec.EmptyAddress, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return false, 0, codec.EmptyAddress, codec.EmptyAddress, err
+	}
+
+	basisPoints := binary.BigEndian.Uint64(v)
+	var recipient, paymentAsset codec.Address
+	copy(recipient[:], v[consts.Uint64Len:])
+	copy(paymentAsset[:], v[consts.Uint64Len+codec.AddressLen:])
+	return true, basisPoints, recipient, paymentAsset, nil
+}
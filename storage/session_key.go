@@ -0,0 +1,172 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	SessionKeyChunks         uint16 = 8
+	SessionKeySnapshotChunks uint16 = 6
+)
+
+const (
+	MaxSessionKeyActionTypes = 32
+	MaxSessionKeySpendLimits = 8
+)
+
+// SessionKeySpendLimit caps how much of [AssetAddress] a session key can move
+// out of the account over its lifetime
+type SessionKeySpendLimit struct {
+	AssetAddress codec.Address `json:"asset_address"`
+	Limit        uint64        `json:"limit"`
+	Spent        uint64        `json:"spent"`
+}
+
+// SessionKeyBalance is the balance of [AssetAddress] the account held at the
+// start of the transaction currently using a session key
+type SessionKeyBalance struct {
+	AssetAddress codec.Address
+	Balance      uint64
+}
+
+func SessionKeyKey(account codec.Address, sessionKey codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+codec.AddressLen+consts.Uint16Len) // Length of prefix + account + sessionKey + SessionKeyChunks
+	k[0] = sessionKeyPrefix                                                // sessionKeyPrefix is a constant representing the session key category
+	copy(k[1:1+codec.AddressLen], account[:])                              // Copy the account
+	copy(k[1+codec.AddressLen:1+codec.AddressLen*2], sessionKey[:])        // Copy the sessionKey
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen*2:], SessionKeyChunks) // Adding SessionKeyChunks
+	return
+}
+
+// SetSessionKey authorizes [sessionKey] to act for [account] with the given
+// action types and spend limits until [expiryBlock] (inclusive)
+func SetSessionKey(ctx context.Context, mu state.Mutable, account codec.Address, sessionKey codec.Address, expiryBlock uint64, actionTypeIDs []byte, spendLimits []SessionKeySpendLimit) error {
+	k := SessionKeyKey(account, sessionKey)
+	v := make([]byte, consts.Uint64Len+consts.Uint8Len+len(actionTypeIDs)+consts.Uint8Len+len(spendLimits)*(codec.AddressLen+2*consts.Uint64Len))
+
+	offset := 0
+	binary.BigEndian.PutUint64(v[offset:], expiryBlock)
+	offset += consts.Uint64Len
+	v[offset] = uint8(len(actionTypeIDs))
+	offset += consts.Uint8Len
+	copy(v[offset:], actionTypeIDs)
+	offset += len(actionTypeIDs)
+	v[offset] = uint8(len(spendLimits))
+	offset += consts.Uint8Len
+	for _, spendLimit := range spendLimits {
+		copy(v[offset:], spendLimit.AssetAddress[:])
+		offset += codec.AddressLen
+		binary.BigEndian.PutUint64(v[offset:], spendLimit.Limit)
+		offset += consts.Uint64Len
+		binary.BigEndian.PutUint64(v[offset:], spendLimit.Spent)
+		offset += consts.Uint64Len
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetSessionKeyFromState(ctx context.Context, f ReadState, account codec.Address, sessionKey codec.Address) (bool, uint64, []byte, []SessionKeySpendLimit, error) {
+	values, errs := f(ctx, [][]byte{SessionKeyKey(account, sessionKey)})
+	return innerGetSessionKey(values[0], errs[0])
+}
+
+func GetSessionKeyNoController(ctx context.Context, im state.Immutable, account codec.Address, sessionKey codec.Address) (bool, uint64, []byte, []SessionKeySpendLimit, error) {
+	v, err := im.GetValue(ctx, SessionKeyKey(account, sessionKey))
+	return innerGetSessionKey(v, err)
+}
+
+func innerGetSessionKey(v []byte, err error) (bool, uint64, []byte, []SessionKeySpendLimit, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, nil, err
+	}
+
+	offset := 0
+	expiryBlock := binary.BigEndian.Uint64(v[offset:])
+	offset += consts.Uint64Len
+	actionTypeIDsLen := int(v[offset])
+	offset += consts.Uint8Len
+	actionTypeIDs := make([]byte, actionTypeIDsLen)
+	copy(actionTypeIDs, v[offset:offset+actionTypeIDsLen])
+	offset += actionTypeIDsLen
+	spendLimits := make([]SessionKeySpendLimit, v[offset])
+	offset += consts.Uint8Len
+	for i := range spendLimits {
+		copy(spendLimits[i].AssetAddress[:], v[offset:offset+codec.AddressLen])
+		offset += codec.AddressLen
+		spendLimits[i].Limit = binary.BigEndian.Uint64(v[offset:])
+		offset += consts.Uint64Len
+		spendLimits[i].Spent = binary.BigEndian.Uint64(v[offset:])
+		offset += consts.Uint64Len
+	}
+	return true, expiryBlock, actionTypeIDs, spendLimits, nil
+}
+
+func DeleteSessionKey(ctx context.Context, mu state.Mutable, account codec.Address, sessionKey codec.Address) error {
+	return mu.Remove(ctx, SessionKeyKey(account, sessionKey))
+}
+
+func SessionKeySnapshotKey(account codec.Address, sessionKey codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+codec.AddressLen+consts.Uint16Len)         // Length of prefix + account + sessionKey + SessionKeySnapshotChunks
+	k[0] = sessionKeySnapshotPrefix                                                // sessionKeySnapshotPrefix is a constant representing the session key snapshot category
+	copy(k[1:1+codec.AddressLen], account[:])                                      // Copy the account
+	copy(k[1+codec.AddressLen:1+codec.AddressLen*2], sessionKey[:])                // Copy the sessionKey
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen*2:], SessionKeySnapshotChunks) // Adding SessionKeySnapshotChunks
+	return
+}
+
+// SetSessionKeySnapshot records [balances] of [account] for the transaction
+// [sessionKey] is currently signing
+func SetSessionKeySnapshot(ctx context.Context, mu state.Mutable, account codec.Address, sessionKey codec.Address, balances []SessionKeyBalance) error {
+	k := SessionKeySnapshotKey(account, sessionKey)
+	v := make([]byte, consts.Uint8Len+len(balances)*(codec.AddressLen+consts.Uint64Len))
+
+	offset := 0
+	v[offset] = uint8(len(balances))
+	offset += consts.Uint8Len
+	for _, balance := range balances {
+		copy(v[offset:], balance.AssetAddress[:])
+		offset += codec.AddressLen
+		binary.BigEndian.PutUint64(v[offset:], balance.Balance)
+		offset += consts.Uint64Len
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+func GetSessionKeySnapshotNoController(ctx context.Context, im state.Immutable, account codec.Address, sessionKey codec.Address) (bool, []SessionKeyBalance, error) {
+	v, err := im.GetValue(ctx, SessionKeySnapshotKey(account, sessionKey))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	offset := 0
+	balances := make([]SessionKeyBalance, v[offset])
+	offset += consts.Uint8Len
+	for i := range balances {
+		copy(balances[i].AssetAddress[:], v[offset:offset+codec.AddressLen])
+		offset += codec.AddressLen
+		balances[i].Balance = binary.BigEndian.Uint64(v[offset:])
+		offset += consts.Uint64Len
+	}
+	return true, balances, nil
+}
+
+func DeleteSessionKeySnapshot(ctx context.Context, mu state.Mutable, account codec.Address, sessionKey codec.Address) error {
+	return mu.Remove(ctx, SessionKeySnapshotKey(account, sessionKey))
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	SponsorPolicyChunks uint16 = 18
+)
+
+const (
+	MaxSponsorPolicyActionTypes = 32
+	MaxSponsorPolicyAssets      = 16
+	MaxSponsorPolicyDatasets    = 16
+)
+
+const (
+	// sponsorIDLen is the length of the part of a policy address identifying
+	// its sponsor
+	sponsorIDLen = 16
+)
+
+// SponsorAccountAddress is the account of [owner] for sponsored transactions.
+// It pays the fees of every policy of [owner] and is the actor of the
+// sponsored transactions [owner] signs. Transactions can't have an actor or a
+// sponsor of another type than their auth so this is an account of its own.
+func SponsorAccountAddress(owner codec.Address) codec.Address {
+	id := utils.ToID(owner[:])
+	return sponsorAccountAddress(id[:sponsorIDLen])
+}
+
+func sponsorAccountAddress(sponsorID []byte) codec.Address {
+	return codec.CreateAddress(nconsts.SponsoredAddressID, utils.ToID(sponsorID))
+}
+
+// SponsorPolicyAddress identifies a policy of [sponsor]. It starts with an
+// identifier of [sponsor] so the account paying its fees can be derived from
+// it, see [SponsorPolicyAccountAddress], and ends with a commitment to the
+// whole policy so a transaction claiming another policy is charged to a
+// policy that was never registered.
+func SponsorPolicyAddress(sponsor codec.Address, actionTypeIDs []byte, assetAddresses []codec.Address, datasetAddresses []codec.Address) codec.Address {
+	sponsorID := utils.ToID(sponsor[:])
+	policyID := utils.ToID(packSponsorPolicy(sponsor, actionTypeIDs, assetAddresses, datasetAddresses))
+	var id ids.ID
+	copy(id[:sponsorIDLen], sponsorID[:sponsorIDLen])
+	copy(id[sponsorIDLen:], policyID[:ids.IDLen-sponsorIDLen])
+	return codec.CreateAddress(nconsts.SponsoredAddressID, id)
+}
+
+// SponsorPolicyAccountAddress is the account of the sponsor of
+// [policyAddress] paying its fees
+func SponsorPolicyAccountAddress(policyAddress codec.Address) codec.Address {
+	return sponsorAccountAddress(policyAddress[1 : 1+sponsorIDLen])
+}
+
+// IsSponsorPolicyAddress tells if [address] may be a sponsor policy. Sponsor
+// policies are the only addresses of their type used as the sponsor of a
+// transaction.
+func IsSponsorPolicyAddress(address codec.Address) bool {
+	return address[0] == nconsts.SponsoredAddressID
+}
+
+func SponsorPolicyKey(policyAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                   // Length of prefix + policyAddress + SponsorPolicyChunks
+	k[0] = sponsorPolicyPrefix                                              // sponsorPolicyPrefix is a constant representing the sponsor policy category
+	copy(k[1:1+codec.AddressLen], policyAddress[:])                         // Copy the policyAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], SponsorPolicyChunks) // Adding SponsorPolicyChunks
+	return
+}
+
+func SetSponsorPolicy(ctx context.Context, mu state.Mutable, sponsor codec.Address, actionTypeIDs []byte, assetAddresses []codec.Address, datasetAddresses []codec.Address) (codec.Address, error) {
+	v := packSponsorPolicy(sponsor, actionTypeIDs, assetAddresses, datasetAddresses)
+	policyAddress := SponsorPolicyAddress(sponsor, actionTypeIDs, assetAddresses, datasetAddresses)
+	return policyAddress, mu.Insert(ctx, SponsorPolicyKey(policyAddress), v)
+}
+
+func packSponsorPolicy(sponsor codec.Address, actionTypeIDs []byte, assetAddresses []codec.Address, datasetAddresses []codec.Address) []byte {
+	v := make([]byte, codec.AddressLen+consts.Uint8Len+len(actionTypeIDs)+consts.Uint8Len+len(assetAddresses)*codec.AddressLen+consts.Uint8Len+len(datasetAddresses)*codec.AddressLen)
+
+	offset := 0
+	copy(v[offset:], sponsor[:])
+	offset += codec.AddressLen
+	v[offset] = uint8(len(actionTypeIDs))
+	offset += consts.Uint8Len
+	copy(v[offset:], actionTypeIDs)
+	offset += len(actionTypeIDs)
+	v[offset] = uint8(len(assetAddresses))
+	offset += consts.Uint8Len
+	for _, assetAddress := range assetAddresses {
+		copy(v[offset:], assetAddress[:])
+		offset += codec.AddressLen
+	}
+	v[offset] = uint8(len(datasetAddresses))
+	offset += consts.Uint8Len
+	for _, datasetAddress := range datasetAddresses {
+		copy(v[offset:], datasetAddress[:])
+		offset += codec.AddressLen
+	}
+	return v
+}
+
+// Used to serve RPC queries
+func GetSponsorPolicyFromState(ctx context.Context, f ReadState, policyAddress codec.Address) (bool, codec.Address, []byte, []codec.Address, []codec.Address, error) {
+	values, errs := f(ctx, [][]byte{SponsorPolicyKey(policyAddress)})
+	return innerGetSponsorPolicy(values[0], errs[0])
+}
+
+func GetSponsorPolicyNoController(ctx context.Context, im state.Immutable, policyAddress codec.Address) (bool, codec.Address, []byte, []codec.Address, []codec.Address, error) {
+	v, err := im.GetValue(ctx, SponsorPolicyKey(policyAddress))
+	return innerGetSponsorPolicy(v, err)
+}
+
+func innerGetSponsorPolicy(v []byte, err error) (bool, codec.Address, []byte, []codec.Address, []codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, nil, nil, nil, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, nil, nil, nil, err
+	}
+
+	offset := 0
+	var sponsor codec.Address
+	copy(sponsor[:], v[offset:offset+codec.AddressLen])
+	offset += codec.AddressLen
+	actionTypeIDsLen := int(v[offset])
+	offset += consts.Uint8Len
+	actionTypeIDs := make([]byte, actionTypeIDsLen)
+	copy(actionTypeIDs, v[offset:offset+actionTypeIDsLen])
+	offset += actionTypeIDsLen
+	assetAddresses := make([]codec.Address, v[offset])
+	offset += consts.Uint8Len
+	for i := range assetAddresses {
+		copy(assetAddresses[i][:], v[offset:offset+codec.AddressLen])
+		offset += codec.AddressLen
+	}
+	datasetAddresses := make([]codec.Address, v[offset])
+	offset += consts.Uint8Len
+	for i := range datasetAddresses {
+		copy(datasetAddresses[i][:], v[offset:offset+codec.AddressLen])
+		offset += codec.AddressLen
+	}
+	return true, sponsor, actionTypeIDs, assetAddresses, datasetAddresses, nil
+}
+
+func DeleteSponsorPolicy(ctx context.Context, mu state.Mutable, policyAddress codec.Address) error {
+	return mu.Remove(ctx, SponsorPolicyKey(policyAddress))
+}
@@ -21,7 +21,17 @@ func (*StateManager) CanDeduct(
 	im state.Immutable,
 	amount uint64,
 ) error {
-	bal, err := GetAssetAccountBalanceNoController(ctx, im, NAIAddress, addr)
+	// Sponsor policies stop paying fees as soon as they are withdrawn
+	if IsSponsorPolicyAddress(addr) {
+		exists, _, _, _, _, err := GetSponsorPolicyNoController(ctx, im, addr)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrSponsorPolicyNotFound
+		}
+	}
+	bal, err := GetAssetAccountBalanceNoController(ctx, im, NAIAddress, feeAccount(addr))
 	if err != nil {
 		return err
 	}
@@ -37,7 +47,7 @@ func (*StateManager) Deduct(
 	mu state.Mutable,
 	amount uint64,
 ) error {
-	_, err := BurnAsset(ctx, mu, NAIAddress, addr, amount)
+	_, err := BurnAsset(ctx, mu, NAIAddress, feeAccount(addr), amount)
 	return err
 }
 
@@ -53,10 +63,23 @@ func (*StateManager) AddBalance(
 }
 
 func (*StateManager) SponsorStateKeys(addr codec.Address) state.Keys {
-	return state.Keys{
-		string(AssetInfoKey(NAIAddress)):                 state.All,
-		string(AssetAccountBalanceKey(NAIAddress, addr)): state.All,
+	stateKeys := state.Keys{
+		string(AssetInfoKey(NAIAddress)):                             state.All,
+		string(AssetAccountBalanceKey(NAIAddress, feeAccount(addr))): state.All,
+	}
+	if IsSponsorPolicyAddress(addr) {
+		stateKeys.Add(string(SponsorPolicyKey(addr)), state.Read)
+	}
+	return stateKeys
+}
+
+// feeAccount is the account paying the fees of [sponsor]. The fees of a
+// sponsor policy are paid by the account of its sponsor.
+func feeAccount(sponsor codec.Address) codec.Address {
+	if IsSponsorPolicyAddress(sponsor) {
+		return SponsorPolicyAccountAddress(sponsor)
 	}
+	return sponsor
 }
 
 func (*StateManager) HeightKey() []byte {
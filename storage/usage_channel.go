@@ -0,0 +1,94 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	UsageChannelChunks uint16 = 2
+
+	usageChannelSize = codec.AddressLen*3 + consts.Uint64Len*3 + consts.BoolLen
+)
+
+// UsageChannelAddress identifies the usage channel [buyer] opened with
+// [nonce] to pay for the listing [marketplaceAsset] in [paymentAsset]. It is
+// also the account holding the deposit of the channel.
+func UsageChannelAddress(marketplaceAsset codec.Address, paymentAsset codec.Address, buyer codec.Address, nonce uint64) codec.Address {
+	v := make([]byte, codec.AddressLen*3+consts.Uint64Len)
+	copy(v, marketplaceAsset[:])
+	copy(v[codec.AddressLen:], paymentAsset[:])
+	copy(v[codec.AddressLen*2:], buyer[:])
+	binary.BigEndian.PutUint64(v[codec.AddressLen*3:], nonce)
+	return codec.CreateAddress(nconsts.UsageChannelAddressID, utils.ToID(v))
+}
+
+// UsageChannelKey stores the deposit a buyer locked to pay for the usage of a
+// dataset listed on the marketplace
+func UsageChannelKey(channelAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                  // Length of prefix + channelAddress + UsageChannelChunks
+	k[0] = usageChannelPrefix                                              // usageChannelPrefix is a constant representing the usage channel category
+	copy(k[1:], channelAddress[:])                                         // Copy the channelAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], UsageChannelChunks) // Adding UsageChannelChunks
+	return
+}
+
+// SetUsageChannel records that [buyer] locked [deposit] of [paymentAsset] to
+// pay for the usage of the listing [marketplaceAsset] until
+// [expirationBlock], of which [settled] was already paid. Closed channels are
+// kept so that they can never be opened again and their receipts replayed.
+func SetUsageChannel(ctx context.Context, mu state.Mutable, channelAddress codec.Address, marketplaceAsset codec.Address, paymentAsset codec.Address, buyer codec.Address, deposit uint64, settled uint64, expirationBlock uint64, closed bool) error {
+	v := make([]byte, usageChannelSize)
+	copy(v, marketplaceAsset[:])
+	copy(v[codec.AddressLen:], paymentAsset[:])
+	copy(v[codec.AddressLen*2:], buyer[:])
+	binary.BigEndian.PutUint64(v[codec.AddressLen*3:], deposit)
+	binary.BigEndian.PutUint64(v[codec.AddressLen*3+consts.Uint64Len:], settled)
+	binary.BigEndian.PutUint64(v[codec.AddressLen*3+consts.Uint64Len*2:], expirationBlock)
+	if closed {
+		v[codec.AddressLen*3+consts.Uint64Len*3] = 1
+	}
+	return mu.Insert(ctx, UsageChannelKey(channelAddress), v)
+}
+
+// Used to serve RPC queries
+func GetUsageChannelFromState(ctx context.Context, f ReadState, channelAddress codec.Address) (bool, codec.Address, codec.Address, codec.Address, uint64, uint64, uint64, bool, error) {
+	values, errs := f(ctx, [][]byte{UsageChannelKey(channelAddress)})
+	return innerGetUsageChannel(values[0], errs[0])
+}
+
+func GetUsageChannelNoController(ctx context.Context, im state.Immutable, channelAddress codec.Address) (bool, codec.Address, codec.Address, codec.Address, uint64, uint64, uint64, bool, error) {
+	v, err := im.GetValue(ctx, UsageChannelKey(channelAddress))
+	return innerGetUsageChannel(v, err)
+}
+
+func innerGetUsageChannel(v []byte, err error) (bool, codec.Address, codec.Address, codec.Address, uint64, uint64, uint64, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, 0, 0, 0, false, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, 0, 0, 0, false, err
+	}
+	var marketplaceAsset, paymentAsset, buyer codec.Address
+	copy(marketplaceAsset[:], v)
+	copy(paymentAsset[:], v[codec.AddressLen:])
+	copy(buyer[:], v[codec.AddressLen*2:])
+	deposit := binary.BigEndian.Uint64(v[codec.AddressLen*3:])
+	settled := binary.BigEndian.Uint64(v[codec.AddressLen*3+consts.Uint64Len:])
+	expirationBlock := binary.BigEndian.Uint64(v[codec.AddressLen*3+consts.Uint64Len*2:])
+	closed := v[codec.AddressLen*3+consts.Uint64Len*3] == 1
+	return true, marketplaceAsset, paymentAsset, buyer, deposit, settled, expirationBlock, closed, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2024, Nuklai. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+
+	nconsts "github.com/nuklai/nuklaivm/consts"
+)
+
+const (
+	VestingScheduleChunks uint16 = 3
+
+	vestingScheduleSize = codec.AddressLen*4 + consts.Uint64Len*5
+)
+
+// VestingAddress identifies the schedule funded by [funder] releasing [amount]
+// of [asset] to [beneficiary]. It is also the account holding the amount that
+// is still locked.
+func VestingAddress(
+	funder codec.Address,
+	asset codec.Address,
+	beneficiary codec.Address,
+	revocationAuthority codec.Address,
+	amount uint64,
+	startBlock uint64,
+	cliffBlock uint64,
+	endBlock uint64,
+) codec.Address {
+	v := packVestingSchedule(asset, beneficiary, funder, revocationAuthority, amount, 0, startBlock, cliffBlock, endBlock)
+	return codec.CreateAddress(nconsts.VestingAddressID, utils.ToID(v))
+}
+
+// VestedAmount returns how much of [amount] is unlocked at [height]. Nothing
+// is unlocked before [cliffBlock] and the amount is released linearly from
+// [startBlock] until everything is unlocked at [endBlock].
+func VestedAmount(amount uint64, startBlock uint64, cliffBlock uint64, endBlock uint64, height uint64) uint64 {
+	switch {
+	case height < cliffBlock || height <= startBlock:
+		return 0
+	case height >= endBlock:
+		return amount
+	}
+	// amount * (height - startBlock) / (endBlock - startBlock) is less than
+	// amount so the division can't overflow
+	hi, lo := bits.Mul64(amount, height-startBlock)
+	vested, _ := bits.Div64(hi, lo, endBlock-startBlock)
+	return vested
+}
+
+func VestingScheduleKey(vestingAddress codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)                     // Length of prefix + vestingAddress + VestingScheduleChunks
+	k[0] = vestingPrefix                                                      // vestingPrefix is a constant representing the vesting category
+	copy(k[1:1+codec.AddressLen], vestingAddress[:])                          // Copy the vestingAddress
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], VestingScheduleChunks) // Adding VestingScheduleChunks
+	return
+}
+
+func SetVestingSchedule(
+	ctx context.Context,
+	mu state.Mutable,
+	vestingAddress codec.Address,
+	asset codec.Address,
+	beneficiary codec.Address,
+	funder codec.Address,
+	revocationAuthority codec.Address,
+	amount uint64,
+	claimed uint64,
+	startBlock uint64,
+	cliffBlock uint64,
+	endBlock uint64,
+) error {
+	v := packVestingSchedule(asset, beneficiary, funder, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock)
+	return mu.Insert(ctx, VestingScheduleKey(vestingAddress), v)
+}
+
+func packVestingSchedule(
+	asset codec.Address,
+	beneficiary codec.Address,
+	funder codec.Address,
+	revocationAuthority codec.Address,
+	amount uint64,
+	claimed uint64,
+	startBlock uint64,
+	cliffBlock uint64,
+	endBlock uint64,
+) []byte {
+	v := make([]byte, vestingScheduleSize)
+
+	offset := 0
+	for _, addr := range []codec.Address{asset, beneficiary, funder, revocationAuthority} {
+		copy(v[offset:], addr[:])
+		offset += codec.AddressLen
+	}
+	for _, n := range []uint64{amount, claimed, startBlock, cliffBlock, endBlock} {
+		binary.BigEndian.PutUint64(v[offset:], n)
+		offset += consts.Uint64Len
+	}
+	return v
+}
+
+// Used to serve RPC queries
+func GetVestingScheduleFromState(
+	ctx context.Context,
+	f ReadState,
+	vestingAddress codec.Address,
+) (bool, codec.Address, codec.Address, codec.Address, codec.Address, uint64, uint64, uint64, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{VestingScheduleKey(vestingAddress)})
+	return innerGetVestingSchedule(values[0], errs[0])
+}
+
+func GetVestingScheduleNoController(
+	ctx context.Context,
+	im state.Immutable,
+	vestingAddress codec.Address,
+) (bool, codec.Address, codec.Address, codec.Address, codec.Address, uint64, uint64, uint64, uint64, uint64, error) {
+	v, err := im.GetValue(ctx, VestingScheduleKey(vestingAddress))
+	return innerGetVestingSchedule(v, err)
+}
+
+func innerGetVestingSchedule(
+	v []byte,
+	err error,
+) (bool, codec.Address, codec.Address, codec.Address, codec.Address, uint64, uint64, uint64, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, 0, 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, codec.EmptyAddress, 0, 0, 0, 0, 0, err
+	}
+
+	offset := 0
+	var asset, beneficiary, funder, revocationAuthority codec.Address
+	for _, addr := range []*codec.Address{&asset, &beneficiary, &funder, &revocationAuthority} {
+		copy(addr[:], v[offset:offset+codec.AddressLen])
+		offset += codec.AddressLen
+	}
+	var amount, claimed, startBlock, cliffBlock, endBlock uint64
+	for _, n := range []*uint64{&amount, &claimed, &startBlock, &cliffBlock, &endBlock} {
+		*n = binary.BigEndian.Uint64(v[offset:])
+		offset += consts.Uint64Len
+	}
+	return true, asset, beneficiary, funder, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock, nil
+}
+
+func DeleteVestingSchedule(ctx context.Context, mu state.Mutable, vestingAddress codec.Address) error {
+	return mu.Remove(ctx, VestingScheduleKey(vestingAddress))
+}
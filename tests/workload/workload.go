@@ -103,7 +103,7 @@ func New(minBlockGap int64, initialAddress, emissionAddress string, testMode boo
 		EmissionAddress: emissionBalancerAddress,
 	}
 
-	genesis := ngenesis.NewGenesis(customAllocs, emissionBalancer)
+	genesis := ngenesis.NewGenesis(customAllocs, nil, emissionBalancer)
 	// Set WindowTargetUnits to MaxUint64 for all dimensions to iterate full mempool during block building.
 	genesis.Rules.WindowTargetUnits = fees.Dimensions{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64}
 	// Set all limits to MaxUint64 to avoid limiting block size for all dimensions except bandwidth. Must limit bandwidth to avoid building
@@ -11,9 +11,14 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/abi"
+	"github.com/nuklai/nuklaivm/catalog"
 	"github.com/nuklai/nuklaivm/consts"
+	"github.com/nuklai/nuklaivm/contributions"
 	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/genesis"
+	"github.com/nuklai/nuklaivm/holdings"
+	"github.com/nuklai/nuklaivm/orderbook"
 	"github.com/nuklai/nuklaivm/storage"
 
 	"github.com/ava-labs/hypersdk/api/jsonrpc"
@@ -139,7 +144,7 @@ func (cli *JSONRPCClient) Dataset(
 	return resp.Name, resp.Description, resp.Categories, resp.LicenseName, resp.LicenseSymbol, resp.LicenseURL, resp.Metadata, resp.IsCommunityDataset, resp.MarketplaceAssetAddress, resp.BaseAssetAddress, resp.BasePrice, resp.RevenueModelDataShare, resp.RevenueModelMetadataShare, resp.RevenueModelDataOwnerCut, resp.RevenueModelMetadataOwnerCut, resp.Owner, nil
 }
 
-func (cli *JSONRPCClient) DatasetContribution(ctx context.Context, contributionID string) (string, string, string, string, bool, error) {
+func (cli *JSONRPCClient) DatasetContribution(ctx context.Context, contributionID string) (string, string, string, string, bool, uint64, error) {
 	resp := new(DatasetContributionReply)
 	err := cli.requester.SendRequest(
 		ctx,
@@ -150,10 +155,10 @@ func (cli *JSONRPCClient) DatasetContribution(ctx context.Context, contributionI
 		resp,
 	)
 	if err != nil {
-		return "", "", "", "", false, err
+		return "", "", "", "", false, 0, err
 	}
 
-	return resp.DatasetAddress, resp.DataLocation, resp.DataIdentifier, resp.Contributor, resp.Active, nil
+	return resp.DatasetAddress, resp.DataLocation, resp.DataIdentifier, resp.Contributor, resp.Active, resp.InitiatedAt, nil
 }
 
 func (cli *JSONRPCClient) EmissionInfo(ctx context.Context) (uint64, uint64, uint64, uint64, uint64, EmissionAccount, emission.EpochTracker, error) {
@@ -171,6 +176,20 @@ func (cli *JSONRPCClient) EmissionInfo(ctx context.Context) (uint64, uint64, uin
 	return resp.CurrentBlockHeight, resp.TotalSupply, resp.MaxSupply, resp.TotalStaked, resp.RewardsPerEpoch, resp.EmissionAccount, resp.EpochTracker, err
 }
 
+func (cli *JSONRPCClient) MarketplaceFee(ctx context.Context) (string, uint64, error) {
+	resp := new(MarketplaceFeeReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"marketplaceFee",
+		nil,
+		resp,
+	)
+	if err != nil {
+		return "", 0, err
+	}
+	return resp.TreasuryAddress, resp.MarketplaceFeeBasisPoints, nil
+}
+
 func (cli *JSONRPCClient) AllValidators(ctx context.Context) ([]*emission.Validator, error) {
 	resp := new(ValidatorsReply)
 	err := cli.requester.SendRequest(
@@ -232,6 +251,320 @@ func (cli *JSONRPCClient) UserStake(ctx context.Context, owner string, nodeID st
 	return resp.StakeStartBlock, resp.StakeEndBlock, resp.StakedAmount, resp.RewardAddress, resp.OwnerAddress, err
 }
 
+func (cli *JSONRPCClient) Contract(ctx context.Context, contractAddress string) (string, string, uint64, []ContractUpgrade, error) {
+	resp := new(ContractReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"contract",
+		&ContractArgs{
+			ContractAddress: contractAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	return resp.ContractID, resp.UpgradeAuthority, resp.Version, resp.Upgrades, nil
+}
+
+func (cli *JSONRPCClient) ContractABI(ctx context.Context, contractAddress string) (*abi.ABI, error) {
+	resp := new(ContractABIReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"contractABI",
+		&ContractABIArgs{
+			ContractAddress: contractAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Parse(resp.ABI)
+}
+
+func (cli *JSONRPCClient) SessionKey(ctx context.Context, account string, sessionKey string) (uint64, []int, []SessionKeySpendLimit, error) {
+	resp := new(SessionKeyReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"sessionKey",
+		&SessionKeyArgs{
+			Account:    account,
+			SessionKey: sessionKey,
+		},
+		resp,
+	)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return resp.ExpiryBlock, resp.ActionTypeIDs, resp.SpendLimits, nil
+}
+
+func (cli *JSONRPCClient) SponsorPolicy(ctx context.Context, policyAddress string) (string, []int, []string, []string, uint64, error) {
+	resp := new(SponsorPolicyReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"sponsorPolicy",
+		&SponsorPolicyArgs{
+			PolicyAddress: policyAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return "", nil, nil, nil, 0, err
+	}
+	return resp.Sponsor, resp.ActionTypeIDs, resp.AssetAddresses, resp.DatasetAddresses, resp.Balance, nil
+}
+
+func (cli *JSONRPCClient) Allowance(ctx context.Context, asset string, owner string, spender string) (uint64, uint64, error) {
+	resp := new(AllowanceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"allowance",
+		&AllowanceArgs{
+			Asset:   asset,
+			Owner:   owner,
+			Spender: spender,
+		},
+		resp,
+	)
+	return resp.Amount, resp.ExpiryBlock, err
+}
+
+func (cli *JSONRPCClient) Order(ctx context.Context, orderAddress string) (*orderbook.Order, error) {
+	resp := new(OrderReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"order",
+		&OrderArgs{
+			OrderAddress: orderAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Order, nil
+}
+
+func (cli *JSONRPCClient) Orders(ctx context.Context, inAsset string, outAsset string, limit int) ([]orderbook.Order, error) {
+	resp := new(OrdersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"orders",
+		&OrdersArgs{
+			InAsset:  inAsset,
+			OutAsset: outAsset,
+			Limit:    limit,
+		},
+		resp,
+	)
+	return resp.Orders, err
+}
+
+func (cli *JSONRPCClient) VestingSchedule(ctx context.Context, vestingAddress string) (*VestingScheduleReply, error) {
+	resp := new(VestingScheduleReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"vestingSchedule",
+		&VestingScheduleArgs{
+			VestingAddress: vestingAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) UsageChannel(ctx context.Context, usageChannelAddress string) (*UsageChannelReply, error) {
+	resp := new(UsageChannelReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"usageChannel",
+		&UsageChannelArgs{
+			UsageChannelAddress: usageChannelAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) DatasetKey(ctx context.Context, subscriptionNftAddress string) (string, string, string, error) {
+	resp := new(DatasetKeyReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetKey",
+		&DatasetKeyArgs{
+			SubscriptionNftAddress: subscriptionNftAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return "", "", "", err
+	}
+	return resp.Holder, resp.EncryptionPublicKey, resp.EncryptedKey, nil
+}
+
+func (cli *JSONRPCClient) DatasetKeyDelegate(ctx context.Context, marketplaceAssetAddress string) (string, error) {
+	resp := new(DatasetKeyDelegateReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetKeyDelegate",
+		&DatasetKeyDelegateArgs{
+			MarketplaceAssetAddress: marketplaceAssetAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return "", err
+	}
+	return resp.Delegate, nil
+}
+
+func (cli *JSONRPCClient) Royalty(ctx context.Context, collectionAddress string) (*RoyaltyReply, error) {
+	resp := new(RoyaltyReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"royalty",
+		&RoyaltyArgs{
+			CollectionAddress: collectionAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) NFTOwnership(ctx context.Context, nftAddress string) (*NFTOwnershipReply, error) {
+	resp := new(NFTOwnershipReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nFTOwnership",
+		&NFTOwnershipArgs{
+			NFTAddress: nftAddress,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) OwnershipTransfers(ctx context.Context, kind uint8, address string) ([]OwnershipTransfer, error) {
+	resp := new(OwnershipTransfersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"ownershipTransfers",
+		&OwnershipTransfersArgs{
+			Kind:    kind,
+			Address: address,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Transfers, nil
+}
+
+func (cli *JSONRPCClient) MintRole(ctx context.Context, assetAddress string, account string) (*MintRoleReply, error) {
+	resp := new(MintRoleReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"mintRole",
+		&MintRoleArgs{
+			AssetAddress: assetAddress,
+			Account:      account,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) AssetsOfOwner(ctx context.Context, owner string, cursor string, limit int) ([]holdings.AssetBalance, string, error) {
+	resp := new(AssetsOfOwnerReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"assetsOfOwner",
+		&AssetsOfOwnerArgs{
+			Owner:  owner,
+			Cursor: cursor,
+			Limit:  limit,
+		},
+		resp,
+	)
+	return resp.Assets, resp.NextCursor, err
+}
+
+func (cli *JSONRPCClient) HoldersOfAsset(ctx context.Context, asset string, cursor string, limit int) ([]holdings.Holder, string, error) {
+	resp := new(HoldersOfAssetReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"holdersOfAsset",
+		&HoldersOfAssetArgs{
+			Asset:  asset,
+			Cursor: cursor,
+			Limit:  limit,
+		},
+		resp,
+	)
+	return resp.Holders, resp.NextCursor, err
+}
+
+func (cli *JSONRPCClient) NFTsOfCollection(ctx context.Context, collectionAddress string, cursor string, limit int) ([]holdings.NFT, string, error) {
+	resp := new(NFTsOfCollectionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nFTsOfCollection", // Only the first letter of the method is capitalized
+		&NFTsOfCollectionArgs{
+			CollectionAddress: collectionAddress,
+			Cursor:            cursor,
+			Limit:             limit,
+		},
+		resp,
+	)
+	return resp.NFTs, resp.NextCursor, err
+}
+
+// EncodeContractCall uses the ABI of the contract to borsh encode the JSON
+// arguments of [function] into call data
+func (cli *JSONRPCClient) EncodeContractCall(ctx context.Context, contractAddress string, function string, args json.RawMessage) ([]byte, error) {
+	contractABI, err := cli.ContractABI(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	f, err := contractABI.Function(function)
+	if err != nil {
+		return nil, err
+	}
+	return f.EncodeArgs(args)
+}
+
+// DecodeContractResult uses the ABI of the contract to convert the borsh
+// encoded value returned by [function] to JSON
+func (cli *JSONRPCClient) DecodeContractResult(ctx context.Context, contractAddress string, function string, result []byte) (json.RawMessage, error) {
+	contractABI, err := cli.ContractABI(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	f, err := contractABI.Function(function)
+	if err != nil {
+		return nil, err
+	}
+	return f.DecodeResult(result)
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
@@ -301,3 +634,101 @@ func CreateParser(genesisBytes []byte) (chain.Parser, error) {
 	}
 	return NewParser(&genesis), nil
 }
+
+func (cli *JSONRPCClient) DatasetContributions(ctx context.Context, datasetAddress string, pendingOnly bool, cursor string, limit int) ([]contributions.Contribution, string, error) {
+	resp := new(ContributionsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetContributions",
+		&DatasetContributionsArgs{
+			DatasetAddress: datasetAddress,
+			PendingOnly:    pendingOnly,
+			Cursor:         cursor,
+			Limit:          limit,
+		},
+		resp,
+	)
+	return resp.Contributions, resp.NextCursor, err
+}
+
+func (cli *JSONRPCClient) ContributionsByContributor(ctx context.Context, contributor string, pendingOnly bool, cursor string, limit int) ([]contributions.Contribution, string, error) {
+	resp := new(ContributionsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"contributionsByContributor",
+		&ContributionsByContributorArgs{
+			Contributor: contributor,
+			PendingOnly: pendingOnly,
+			Cursor:      cursor,
+			Limit:       limit,
+		},
+		resp,
+	)
+	return resp.Contributions, resp.NextCursor, err
+}
+
+func (cli *JSONRPCClient) DatasetVersion(ctx context.Context, datasetAddress string, version uint64) (*DatasetVersionReply, error) {
+	resp := new(DatasetVersionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetVersion",
+		&DatasetVersionArgs{
+			DatasetAddress: datasetAddress,
+			Version:        version,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) DatasetVersionAtHeight(ctx context.Context, datasetAddress string, height uint64) (*DatasetVersionReply, error) {
+	resp := new(DatasetVersionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetVersionAtHeight",
+		&DatasetVersionAtHeightArgs{
+			DatasetAddress: datasetAddress,
+			Height:         height,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) DatasetChallenge(ctx context.Context, contributionID string) (*DatasetChallengeReply, error) {
+	resp := new(DatasetChallengeReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetChallenge",
+		&DatasetChallengeArgs{
+			ContributionID: contributionID,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (cli *JSONRPCClient) DatasetCatalog(ctx context.Context, filter catalog.Filter, sortBy string, cursor string, limit int) ([]catalog.Dataset, string, error) {
+	resp := new(DatasetCatalogReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"datasetCatalog",
+		&DatasetCatalogArgs{
+			Filter: filter,
+			SortBy: sortBy,
+			Cursor: cursor,
+			Limit:  limit,
+		},
+		resp,
+	)
+	return resp.Datasets, resp.NextCursor, err
+}
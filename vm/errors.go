@@ -8,4 +8,15 @@ import "errors"
 var (
 	ErrValidatorStakeNotFound = errors.New("validator stake not found")
 	ErrDelegatorStakeNotFound = errors.New("delegator stake not found")
+	ErrContractABINotFound    = errors.New("contract ABI not found")
+	ErrSessionKeyNotFound     = errors.New("session key not found")
+	ErrSponsorPolicyNotFound  = errors.New("sponsor policy not found")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderBookDisabled      = errors.New("order book is disabled")
+	ErrVestingNotFound        = errors.New("vesting schedule not found")
+	ErrHoldingsDisabled       = errors.New("holdings index is disabled")
+	ErrContributionsDisabled  = errors.New("contributions index is disabled")
+	ErrCatalogDisabled        = errors.New("catalog index is disabled")
+	ErrUsageChannelNotFound   = errors.New("usage channel not found")
+	ErrDatasetKeyNotFound     = errors.New("no encryption public key registered for subscription")
 )
@@ -4,8 +4,15 @@
 package vm
 
 import (
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/nuklai/nuklaivm/catalog"
 	"github.com/nuklai/nuklaivm/config"
+	"github.com/nuklai/nuklaivm/contributions"
 	"github.com/nuklai/nuklaivm/emission"
+	"github.com/nuklai/nuklaivm/holdings"
+	"github.com/nuklai/nuklaivm/orderbook"
 
 	"github.com/ava-labs/hypersdk/api/indexer"
 	"github.com/ava-labs/hypersdk/extension/externalsubscriber"
@@ -79,3 +86,75 @@ func WithEmissionBalancer() vm.Option {
 		return nil
 	})
 }
+
+func WithOrderBook() vm.Option {
+	return vm.NewOption(Namespace+orderbook.Namespace, NewDefaultConfig(), func(v *vm.VM, config Config) error {
+		if !config.Enabled {
+			return nil
+		}
+		db, err := pebbledb.New(filepath.Join(v.DataDir, orderbook.Namespace), nil, v.Logger(), nil)
+		if err != nil {
+			return err
+		}
+		orderBook, err = orderbook.New(db)
+		if err != nil {
+			return err
+		}
+		vm.WithBlockSubscriptions(orderbook.NewOrderBookSubscriptionFactory(v.Logger(), orderBook))(v)
+		return nil
+	})
+}
+
+func WithHoldings() vm.Option {
+	return vm.NewOption(Namespace+holdings.Namespace, NewDefaultConfig(), func(v *vm.VM, config Config) error {
+		if !config.Enabled {
+			return nil
+		}
+		db, err := pebbledb.New(filepath.Join(v.DataDir, holdings.Namespace), nil, v.Logger(), nil)
+		if err != nil {
+			return err
+		}
+		assetHoldings, err = holdings.New(db)
+		if err != nil {
+			return err
+		}
+		vm.WithBlockSubscriptions(holdings.NewHoldingsSubscriptionFactory(v.Logger(), assetHoldings, v.ReadState))(v)
+		return nil
+	})
+}
+
+func WithContributions() vm.Option {
+	return vm.NewOption(Namespace+contributions.Namespace, NewDefaultConfig(), func(v *vm.VM, config Config) error {
+		if !config.Enabled {
+			return nil
+		}
+		db, err := pebbledb.New(filepath.Join(v.DataDir, contributions.Namespace), nil, v.Logger(), nil)
+		if err != nil {
+			return err
+		}
+		contributionIdx, err = contributions.New(db)
+		if err != nil {
+			return err
+		}
+		vm.WithBlockSubscriptions(contributions.NewContributionsSubscriptionFactory(v.Logger(), contributionIdx, v.ReadState))(v)
+		return nil
+	})
+}
+
+func WithCatalog() vm.Option {
+	return vm.NewOption(Namespace+catalog.Namespace, NewDefaultConfig(), func(v *vm.VM, config Config) error {
+		if !config.Enabled {
+			return nil
+		}
+		db, err := pebbledb.New(filepath.Join(v.DataDir, catalog.Namespace), nil, v.Logger(), nil)
+		if err != nil {
+			return err
+		}
+		datasetCatalog, err = catalog.New(db)
+		if err != nil {
+			return err
+		}
+		vm.WithBlockSubscriptions(catalog.NewCatalogSubscriptionFactory(v.Logger(), datasetCatalog, v.ReadState))(v)
+		return nil
+	})
+}
@@ -4,12 +4,21 @@
 package vm
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/nuklai/nuklaivm/catalog"
 	"github.com/nuklai/nuklaivm/consts"
+	"github.com/nuklai/nuklaivm/contributions"
 	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/genesis"
+	"github.com/nuklai/nuklaivm/holdings"
+	"github.com/nuklai/nuklaivm/orderbook"
 	"github.com/nuklai/nuklaivm/storage"
 	"github.com/nuklai/nuklaivm/utils"
 
@@ -201,6 +210,7 @@ type DatasetContributionReply struct {
 	DataIdentifier string `json:"dataIdentifier"`
 	Contributor    string `json:"contributor"`
 	Active         bool   `json:"active"`
+	InitiatedAt    uint64 `json:"initiatedAt"`
 }
 
 func (j *JSONRPCServer) DatasetContribution(req *http.Request, args *DatasetContributionArgs, reply *DatasetContributionReply) (err error) {
@@ -212,7 +222,7 @@ func (j *JSONRPCServer) DatasetContribution(req *http.Request, args *DatasetCont
 		return err
 	}
 
-	datasetAddress, dataLocation, dataIdentifier, contributor, active, err := storage.GetDatasetContributionInfoFromState(ctx, j.vm.ReadState, contributionID)
+	datasetAddress, dataLocation, dataIdentifier, contributor, active, initiatedAt, err := storage.GetDatasetContributionInfoFromState(ctx, j.vm.ReadState, contributionID)
 	if err != nil {
 		return err
 	}
@@ -221,6 +231,7 @@ func (j *JSONRPCServer) DatasetContribution(req *http.Request, args *DatasetCont
 	reply.DataIdentifier = string(dataIdentifier)
 	reply.Contributor = contributor.String()
 	reply.Active = active
+	reply.InitiatedAt = initiatedAt
 
 	return nil
 }
@@ -258,6 +269,25 @@ func (j *JSONRPCServer) EmissionInfo(req *http.Request, _ *struct{}, reply *Emis
 	return nil
 }
 
+type MarketplaceFeeReply struct {
+	TreasuryAddress           string `json:"treasuryAddress"` // Empty if no fee is taken
+	MarketplaceFeeBasisPoints uint64 `json:"marketplaceFeeBasisPoints"`
+}
+
+// MarketplaceFee returns the take rate of the protocol on marketplace
+// payments and the treasury it is credited to
+func (j *JSONRPCServer) MarketplaceFee(req *http.Request, _ *struct{}, reply *MarketplaceFeeReply) (err error) {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.MarketplaceFee")
+	defer span.End()
+
+	treasury := emissionTracker.GetTreasury()
+	if treasury.MarketplaceFeeBasisPoints > 0 {
+		reply.TreasuryAddress = treasury.Address.String()
+	}
+	reply.MarketplaceFeeBasisPoints = treasury.MarketplaceFeeBasisPoints
+	return nil
+}
+
 type ValidatorsReply struct {
 	Validators []*emission.Validator `json:"validators"`
 }
@@ -355,3 +385,897 @@ func (j *JSONRPCServer) UserStake(req *http.Request, args *UserStakeArgs, reply
 	reply.OwnerAddress = ownerAddress.String()
 	return nil
 }
+
+type ContractArgs struct {
+	ContractAddress string `json:"contractAddress"`
+}
+
+type ContractUpgrade struct {
+	Version            uint64 `json:"version"`
+	PreviousContractID string `json:"previousContractID"` // Hex encoded contract ID before the upgrade
+	ContractID         string `json:"contractID"`         // Hex encoded contract ID after the upgrade
+	UpgradedBy         string `json:"upgradedBy"`
+	Timestamp          int64  `json:"timestamp"`
+	ActionID           string `json:"actionID"`
+}
+
+type ContractReply struct {
+	ContractID       string            `json:"contractID"`       // Hex encoded contract ID the account currently points to
+	UpgradeAuthority string            `json:"upgradeAuthority"` // Empty if the contract can't be upgraded
+	Version          uint64            `json:"version"`          // Number of upgrades applied so far
+	Upgrades         []ContractUpgrade `json:"upgrades"`
+}
+
+func (j *JSONRPCServer) Contract(req *http.Request, args *ContractArgs, reply *ContractReply) (err error) {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.Contract")
+	defer span.End()
+
+	contractAddress, err := codec.StringToAddress(args.ContractAddress)
+	if err != nil {
+		return err
+	}
+
+	contractID, err := storage.GetAccountContractFromState(ctx, j.vm.ReadState, contractAddress)
+	if err != nil {
+		return err
+	}
+	upgradeAuthority, version, err := storage.GetContractUpgradeAuthorityFromState(ctx, j.vm.ReadState, contractAddress)
+	if err != nil {
+		return err
+	}
+
+	reply.ContractID = hex.EncodeToString(contractID)
+	if upgradeAuthority != codec.EmptyAddress {
+		reply.UpgradeAuthority = upgradeAuthority.String()
+	}
+	reply.Version = version
+	reply.Upgrades = make([]ContractUpgrade, 0, version)
+	for v := uint64(1); v <= version; v++ {
+		previousContractID, newContractID, upgradedBy, timestamp, actionID, err := storage.GetContractUpgradeFromState(ctx, j.vm.ReadState, contractAddress, v)
+		if err != nil {
+			return err
+		}
+		reply.Upgrades = append(reply.Upgrades, ContractUpgrade{
+			Version:            v,
+			PreviousContractID: hex.EncodeToString(previousContractID),
+			ContractID:         hex.EncodeToString(newContractID),
+			UpgradedBy:         upgradedBy.String(),
+			Timestamp:          timestamp,
+			ActionID:           actionID.String(),
+		})
+	}
+	return nil
+}
+
+type ContractABIArgs struct {
+	ContractAddress string `json:"contractAddress"`
+}
+
+type ContractABIReply struct {
+	ContractID string          `json:"contractID"` // Hex encoded contract ID the account currently points to
+	ABI        json.RawMessage `json:"abi"`
+}
+
+func (j *JSONRPCServer) ContractABI(req *http.Request, args *ContractABIArgs, reply *ContractABIReply) (err error) {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.ContractABI")
+	defer span.End()
+
+	contractAddress, err := codec.StringToAddress(args.ContractAddress)
+	if err != nil {
+		return err
+	}
+
+	contractID, err := storage.GetAccountContractFromState(ctx, j.vm.ReadState, contractAddress)
+	if err != nil {
+		return err
+	}
+	contractABI, err := storage.GetContractABIFromState(ctx, j.vm.ReadState, contractID)
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrContractABINotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	reply.ContractID = hex.EncodeToString(contractID)
+	reply.ABI = contractABI
+	return nil
+}
+
+type SessionKeyArgs struct {
+	Account    string `json:"account"`
+	SessionKey string `json:"sessionKey"`
+}
+
+type SessionKeySpendLimit struct {
+	AssetAddress string `json:"assetAddress"`
+	Limit        uint64 `json:"limit"`
+	Spent        uint64 `json:"spent"`
+}
+
+type SessionKeyReply struct {
+	ExpiryBlock   uint64                 `json:"expiryBlock"`   // Last block at which the session key can be used
+	ActionTypeIDs []int                  `json:"actionTypeIDs"` // Action types the session key is allowed to use
+	SpendLimits   []SessionKeySpendLimit `json:"spendLimits"`
+}
+
+func (j *JSONRPCServer) SessionKey(req *http.Request, args *SessionKeyArgs, reply *SessionKeyReply) (err error) {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.SessionKey")
+	defer span.End()
+
+	account, err := codec.StringToAddress(args.Account)
+	if err != nil {
+		return err
+	}
+	sessionKey, err := codec.StringToAddress(args.SessionKey)
+	if err != nil {
+		return err
+	}
+
+	exists, expiryBlock, actionTypeIDs, spendLimits, err := storage.GetSessionKeyFromState(ctx, j.vm.ReadState, account, sessionKey)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionKeyNotFound
+	}
+
+	reply.ExpiryBlock = expiryBlock
+	reply.ActionTypeIDs = make([]int, len(actionTypeIDs))
+	for i, actionTypeID := range actionTypeIDs {
+		reply.ActionTypeIDs[i] = int(actionTypeID)
+	}
+	reply.SpendLimits = make([]SessionKeySpendLimit, len(spendLimits))
+	for i, spendLimit := range spendLimits {
+		reply.SpendLimits[i] = SessionKeySpendLimit{
+			AssetAddress: spendLimit.AssetAddress.String(),
+			Limit:        spendLimit.Limit,
+			Spent:        spendLimit.Spent,
+		}
+	}
+	return nil
+}
+
+type SponsorPolicyArgs struct {
+	PolicyAddress string `json:"policyAddress"`
+}
+
+type SponsorPolicyReply struct {
+	Sponsor          string   `json:"sponsor"`
+	ActionTypeIDs    []int    `json:"actionTypeIDs"`    // Action types the sponsor pays for
+	AssetAddresses   []string `json:"assetAddresses"`   // Any asset if empty
+	DatasetAddresses []string `json:"datasetAddresses"` // Any dataset if empty
+	Balance          uint64   `json:"balance"`          // NAI left on the sponsor account to pay fees
+}
+
+func (j *JSONRPCServer) SponsorPolicy(req *http.Request, args *SponsorPolicyArgs, reply *SponsorPolicyReply) (err error) {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.SponsorPolicy")
+	defer span.End()
+
+	policyAddress, err := codec.StringToAddress(args.PolicyAddress)
+	if err != nil {
+		return err
+	}
+
+	exists, sponsor, actionTypeIDs, assetAddresses, datasetAddresses, err := storage.GetSponsorPolicyFromState(ctx, j.vm.ReadState, policyAddress)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSponsorPolicyNotFound
+	}
+	balance, err := storage.GetAssetAccountBalanceFromState(ctx, j.vm.ReadState, storage.NAIAddress, storage.SponsorPolicyAccountAddress(policyAddress))
+	if err != nil {
+		return err
+	}
+
+	reply.Sponsor = sponsor.String()
+	reply.ActionTypeIDs = make([]int, len(actionTypeIDs))
+	for i, actionTypeID := range actionTypeIDs {
+		reply.ActionTypeIDs[i] = int(actionTypeID)
+	}
+	reply.AssetAddresses = make([]string, len(assetAddresses))
+	for i, assetAddress := range assetAddresses {
+		reply.AssetAddresses[i] = assetAddress.String()
+	}
+	reply.DatasetAddresses = make([]string, len(datasetAddresses))
+	for i, datasetAddress := range datasetAddresses {
+		reply.DatasetAddresses[i] = datasetAddress.String()
+	}
+	reply.Balance = balance
+	return nil
+}
+
+type AllowanceArgs struct {
+	Asset   string `json:"asset"`
+	Owner   string `json:"owner"`
+	Spender string `json:"spender"`
+}
+
+type AllowanceReply struct {
+	Amount      uint64 `json:"amount"`
+	ExpiryBlock uint64 `json:"expiryBlock"` // 0 if the allowance never expires
+}
+
+func (j *JSONRPCServer) Allowance(req *http.Request, args *AllowanceArgs, reply *AllowanceReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.Allowance")
+	defer span.End()
+
+	assetAddress, err := utils.GetAssetAddressBySymbol(args.Asset)
+	if err != nil {
+		return err
+	}
+	owner, err := codec.StringToAddress(args.Owner)
+	if err != nil {
+		return err
+	}
+	spender, err := codec.StringToAddress(args.Spender)
+	if err != nil {
+		return err
+	}
+
+	amount, expiryBlock, err := storage.GetAssetAllowanceFromState(ctx, j.vm.ReadState, assetAddress, owner, spender)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	reply.ExpiryBlock = expiryBlock
+	return nil
+}
+
+type OrderArgs struct {
+	OrderAddress string `json:"orderAddress"`
+}
+
+type OrderReply struct {
+	Order orderbook.Order `json:"order"`
+}
+
+func (j *JSONRPCServer) Order(req *http.Request, args *OrderArgs, reply *OrderReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.Order")
+	defer span.End()
+
+	orderAddress, err := codec.StringToAddress(args.OrderAddress)
+	if err != nil {
+		return err
+	}
+
+	exists, in, inTick, out, outTick, owner, err := storage.GetOrderFromState(ctx, j.vm.ReadState, orderAddress)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrOrderNotFound
+	}
+	remaining, err := storage.GetAssetAccountBalanceFromState(ctx, j.vm.ReadState, in, orderAddress)
+	if err != nil {
+		return err
+	}
+	// NFTs are sold along with the collection they belong to
+	inCollection := ""
+	inType, _, _, _, _, inURI, _, _, _, _, _, _, _, err := storage.GetAssetInfoFromState(ctx, j.vm.ReadState, in)
+	if err != nil {
+		return err
+	}
+	if inType == consts.AssetNonFungibleTokenID {
+		inCollection = string(inURI)
+	}
+
+	reply.Order = orderbook.Order{
+		OrderAddress:        orderAddress.String(),
+		Owner:               owner.String(),
+		InAssetAddress:      in.String(),
+		InCollectionAddress: inCollection,
+		InTick:              inTick,
+		OutAssetAddress:     out.String(),
+		OutTick:             outTick,
+		Remaining:           remaining,
+	}
+	return nil
+}
+
+type OrdersArgs struct {
+	InAsset  string `json:"inAsset"`
+	OutAsset string `json:"outAsset"`
+	Limit    int    `json:"limit"` // All the orders if 0
+}
+
+type OrdersReply struct {
+	Orders []orderbook.Order `json:"orders"`
+}
+
+func (j *JSONRPCServer) Orders(req *http.Request, args *OrdersArgs, reply *OrdersReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.Orders")
+	defer span.End()
+
+	if orderBook == nil {
+		return ErrOrderBookDisabled
+	}
+	in, err := utils.GetAssetAddressBySymbol(args.InAsset)
+	if err != nil {
+		return err
+	}
+	out, err := utils.GetAssetAddressBySymbol(args.OutAsset)
+	if err != nil {
+		return err
+	}
+	reply.Orders = orderBook.Orders(orderbook.Pair(in, out), args.Limit)
+	return nil
+}
+
+type VestingScheduleArgs struct {
+	VestingAddress string `json:"vestingAddress"`
+}
+
+type VestingScheduleReply struct {
+	AssetAddress        string `json:"assetAddress"`
+	Beneficiary         string `json:"beneficiary"`
+	Funder              string `json:"funder"`
+	RevocationAuthority string `json:"revocationAuthority"`
+	Amount              uint64 `json:"amount"`
+	Claimed             uint64 `json:"claimed"`
+	StartBlock          uint64 `json:"startBlock"`
+	CliffBlock          uint64 `json:"cliffBlock"`
+	EndBlock            uint64 `json:"endBlock"`
+	Claimable           uint64 `json:"claimable"`
+}
+
+func (j *JSONRPCServer) VestingSchedule(req *http.Request, args *VestingScheduleArgs, reply *VestingScheduleReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.VestingSchedule")
+	defer span.End()
+
+	vestingAddress, err := codec.StringToAddress(args.VestingAddress)
+	if err != nil {
+		return err
+	}
+
+	exists, asset, beneficiary, funder, revocationAuthority, amount, claimed, startBlock, cliffBlock, endBlock, err := storage.GetVestingScheduleFromState(ctx, j.vm.ReadState, vestingAddress)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrVestingNotFound
+	}
+
+	reply.AssetAddress = asset.String()
+	reply.Beneficiary = beneficiary.String()
+	reply.Funder = funder.String()
+	reply.RevocationAuthority = revocationAuthority.String()
+	reply.Amount = amount
+	reply.Claimed = claimed
+	reply.StartBlock = startBlock
+	reply.CliffBlock = cliffBlock
+	reply.EndBlock = endBlock
+	reply.Claimable = storage.VestedAmount(amount, startBlock, cliffBlock, endBlock, emissionTracker.GetLastAcceptedBlockHeight()) - claimed
+	return nil
+}
+
+type UsageChannelArgs struct {
+	UsageChannelAddress string `json:"usageChannelAddress"`
+}
+
+type UsageChannelReply struct {
+	MarketplaceAssetAddress string `json:"marketplaceAssetAddress"`
+	PaymentAssetAddress     string `json:"paymentAssetAddress"`
+	Buyer                   string `json:"buyer"`
+	Deposit                 uint64 `json:"deposit"`
+	Settled                 uint64 `json:"settled"`
+	ExpirationBlock         uint64 `json:"expirationBlock"`
+	Closed                  bool   `json:"closed"`
+}
+
+func (j *JSONRPCServer) UsageChannel(req *http.Request, args *UsageChannelArgs, reply *UsageChannelReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.UsageChannel")
+	defer span.End()
+
+	channelAddress, err := codec.StringToAddress(args.UsageChannelAddress)
+	if err != nil {
+		return err
+	}
+
+	exists, marketplaceAsset, paymentAsset, buyer, deposit, settled, expirationBlock, closed, err := storage.GetUsageChannelFromState(ctx, j.vm.ReadState, channelAddress)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrUsageChannelNotFound
+	}
+
+	reply.MarketplaceAssetAddress = marketplaceAsset.String()
+	reply.PaymentAssetAddress = paymentAsset.String()
+	reply.Buyer = buyer.String()
+	reply.Deposit = deposit
+	reply.Settled = settled
+	reply.ExpirationBlock = expirationBlock
+	reply.Closed = closed
+	return nil
+}
+
+type DatasetKeyArgs struct {
+	SubscriptionNftAddress string `json:"subscriptionNftAddress"`
+}
+
+type DatasetKeyReply struct {
+	Holder              string `json:"holder"` // Account that registered the encryption public key
+	EncryptionPublicKey string `json:"encryptionPublicKey"`
+	EncryptedKey        string `json:"encryptedKey"` // Empty until the data key is delivered
+}
+
+func (j *JSONRPCServer) DatasetKey(req *http.Request, args *DatasetKeyArgs, reply *DatasetKeyReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetKey")
+	defer span.End()
+
+	nftAddress, err := codec.StringToAddress(args.SubscriptionNftAddress)
+	if err != nil {
+		return err
+	}
+
+	exists, holder, encryptionPublicKey, encryptedKey, err := storage.GetDatasetKeyFromState(ctx, j.vm.ReadState, nftAddress)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrDatasetKeyNotFound
+	}
+
+	reply.Holder = holder.String()
+	reply.EncryptionPublicKey = hex.EncodeToString(encryptionPublicKey)
+	reply.EncryptedKey = hex.EncodeToString(encryptedKey)
+	return nil
+}
+
+type DatasetKeyDelegateArgs struct {
+	MarketplaceAssetAddress string `json:"marketplaceAssetAddress"`
+}
+
+type DatasetKeyDelegateReply struct {
+	Delegate string `json:"delegate"` // Empty when only the owner delivers data keys
+}
+
+func (j *JSONRPCServer) DatasetKeyDelegate(req *http.Request, args *DatasetKeyDelegateArgs, reply *DatasetKeyDelegateReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetKeyDelegate")
+	defer span.End()
+
+	marketplaceAssetAddress, err := codec.StringToAddress(args.MarketplaceAssetAddress)
+	if err != nil {
+		return err
+	}
+
+	delegator, delegate, err := storage.GetDatasetKeyDelegateFromState(ctx, j.vm.ReadState, marketplaceAssetAddress)
+	if err != nil {
+		return err
+	}
+	_, _, _, _, _, _, _, _, owner, _, _, _, _, err := storage.GetAssetInfoFromState(ctx, j.vm.ReadState, marketplaceAssetAddress)
+	if err != nil {
+		return err
+	}
+	// Delegates set by a previous owner are not valid anymore
+	if delegate != codec.EmptyAddress && delegator == owner {
+		reply.Delegate = delegate.String()
+	}
+	return nil
+}
+
+type RoyaltyArgs struct {
+	CollectionAddress string `json:"collectionAddress"`
+}
+
+type RoyaltyReply struct {
+	BasisPoints  uint64 `json:"basisPoints"`
+	Recipient    string `json:"recipient"`
+	AssetAddress string `json:"assetAddress"`
+}
+
+func (j *JSONRPCServer) Royalty(req *http.Request, args *RoyaltyArgs, reply *RoyaltyReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.Royalty")
+	defer span.End()
+
+	collectionAddress, err := codec.StringToAddress(args.CollectionAddress)
+	if err != nil {
+		return err
+	}
+
+	// Collections without royalty reply with zero basis points
+	exists, basisPoints, recipient, asset, err := storage.GetRoyaltyFromState(ctx, j.vm.ReadState, collectionAddress)
+	if err != nil || !exists {
+		return err
+	}
+	reply.BasisPoints = basisPoints
+	reply.Recipient = recipient.String()
+	reply.AssetAddress = asset.String()
+	return nil
+}
+
+type NFTOwnershipArgs struct {
+	NFTAddress string `json:"nftAddress"`
+}
+
+type NFTOwnershipReply struct {
+	PreviousOwner string `json:"previousOwner"`
+	Owner         string `json:"owner"`
+	Changes       uint64 `json:"changes"`
+}
+
+func (j *JSONRPCServer) NFTOwnership(req *http.Request, args *NFTOwnershipArgs, reply *NFTOwnershipReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.NFTOwnership")
+	defer span.End()
+
+	nftAddress, err := codec.StringToAddress(args.NFTAddress)
+	if err != nil {
+		return err
+	}
+
+	// NFTs that never changed owner reply with zero changes
+	exists, previousOwner, owner, changes, err := storage.GetNFTOwnershipFromState(ctx, j.vm.ReadState, nftAddress)
+	if err != nil || !exists {
+		return err
+	}
+	reply.PreviousOwner = previousOwner.String()
+	reply.Owner = owner.String()
+	reply.Changes = changes
+	return nil
+}
+
+type OwnershipTransfersArgs struct {
+	Kind    uint8  `json:"kind"`
+	Address string `json:"address"`
+}
+
+type OwnershipTransfer struct {
+	Role     uint8  `json:"role"`
+	NewOwner string `json:"newOwner"`
+	Proposer string `json:"proposer"` // Proposals of a previous owner can't be accepted
+}
+
+type OwnershipTransfersReply struct {
+	Transfers []OwnershipTransfer `json:"transfers"`
+}
+
+func (j *JSONRPCServer) OwnershipTransfers(req *http.Request, args *OwnershipTransfersArgs, reply *OwnershipTransfersReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.OwnershipTransfers")
+	defer span.End()
+
+	address, err := codec.StringToAddress(args.Address)
+	if err != nil {
+		return err
+	}
+
+	// Datasets and listings only have an owner
+	lastRole := consts.OwnershipRoleEnableDisableKYCAccountAdmin
+	if args.Kind != consts.OwnershipKindAsset {
+		lastRole = consts.OwnershipRoleOwner
+	}
+	reply.Transfers = []OwnershipTransfer{}
+	for role := consts.OwnershipRoleOwner; role <= lastRole; role++ {
+		exists, newOwner, proposer, err := storage.GetOwnershipTransferFromState(ctx, j.vm.ReadState, args.Kind, address, role)
+		if err != nil {
+			return err
+		}
+		if exists {
+			reply.Transfers = append(reply.Transfers, OwnershipTransfer{Role: role, NewOwner: newOwner.String(), Proposer: proposer.String()})
+		}
+	}
+	return nil
+}
+
+type MintRoleArgs struct {
+	AssetAddress string `json:"assetAddress"`
+	Account      string `json:"account"`
+}
+
+type MintRoleReply struct {
+	Granted     bool   `json:"granted"`
+	MintQuota   uint64 `json:"mintQuota"`
+	MintPeriod  uint64 `json:"mintPeriod"`
+	PeriodStart uint64 `json:"periodStart"`
+	Minted      uint64 `json:"minted"`
+}
+
+func (j *JSONRPCServer) MintRole(req *http.Request, args *MintRoleArgs, reply *MintRoleReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.MintRole")
+	defer span.End()
+
+	assetAddress, err := codec.StringToAddress(args.AssetAddress)
+	if err != nil {
+		return err
+	}
+	account, err := codec.StringToAddress(args.Account)
+	if err != nil {
+		return err
+	}
+	granted, quota, period, periodStart, minted, err := storage.GetMintRoleFromState(ctx, j.vm.ReadState, assetAddress, account)
+	if err != nil {
+		return err
+	}
+	reply.Granted = granted
+	reply.MintQuota = quota
+	reply.MintPeriod = period
+	reply.PeriodStart = periodStart
+	reply.Minted = minted
+	return nil
+}
+
+type AssetsOfOwnerArgs struct {
+	Owner  string `json:"owner"`
+	Cursor string `json:"cursor"` // Empty for the first page
+	Limit  int    `json:"limit"`  // holdings.MaxPageSize if 0
+}
+
+type AssetsOfOwnerReply struct {
+	Assets     []holdings.AssetBalance `json:"assets"`
+	NextCursor string                  `json:"nextCursor"` // Empty on the last page
+}
+
+func (j *JSONRPCServer) AssetsOfOwner(req *http.Request, args *AssetsOfOwnerArgs, reply *AssetsOfOwnerReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.AssetsOfOwner")
+	defer span.End()
+
+	if assetHoldings == nil {
+		return ErrHoldingsDisabled
+	}
+	owner, err := codec.StringToAddress(args.Owner)
+	if err != nil {
+		return err
+	}
+	reply.Assets, reply.NextCursor = assetHoldings.AssetsOfOwner(owner, args.Cursor, args.Limit)
+	return nil
+}
+
+type HoldersOfAssetArgs struct {
+	Asset  string `json:"asset"`
+	Cursor string `json:"cursor"` // Empty for the first page
+	Limit  int    `json:"limit"`  // holdings.MaxPageSize if 0
+}
+
+type HoldersOfAssetReply struct {
+	Holders    []holdings.Holder `json:"holders"`
+	NextCursor string            `json:"nextCursor"` // Empty on the last page
+}
+
+func (j *JSONRPCServer) HoldersOfAsset(req *http.Request, args *HoldersOfAssetArgs, reply *HoldersOfAssetReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.HoldersOfAsset")
+	defer span.End()
+
+	if assetHoldings == nil {
+		return ErrHoldingsDisabled
+	}
+	assetAddress, err := utils.GetAssetAddressBySymbol(args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Holders, reply.NextCursor = assetHoldings.HoldersOfAsset(assetAddress, args.Cursor, args.Limit)
+	return nil
+}
+
+type NFTsOfCollectionArgs struct {
+	CollectionAddress string `json:"collectionAddress"`
+	Cursor            string `json:"cursor"` // Empty for the first page
+	Limit             int    `json:"limit"`  // holdings.MaxPageSize if 0
+}
+
+type NFTsOfCollectionReply struct {
+	NFTs       []holdings.NFT `json:"nfts"`
+	NextCursor string         `json:"nextCursor"` // Empty on the last page
+}
+
+func (j *JSONRPCServer) NFTsOfCollection(req *http.Request, args *NFTsOfCollectionArgs, reply *NFTsOfCollectionReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.NFTsOfCollection")
+	defer span.End()
+
+	if assetHoldings == nil {
+		return ErrHoldingsDisabled
+	}
+	collectionAddress, err := codec.StringToAddress(args.CollectionAddress)
+	if err != nil {
+		return err
+	}
+	reply.NFTs, reply.NextCursor = assetHoldings.NFTsOfCollection(collectionAddress, args.Cursor, args.Limit)
+	return nil
+}
+
+type DatasetContributionsArgs struct {
+	DatasetAddress string `json:"datasetAddress"`
+	PendingOnly    bool   `json:"pendingOnly"`
+	Cursor         string `json:"cursor"` // Empty for the first page
+	Limit          int    `json:"limit"`  // contributions.MaxPageSize if 0
+}
+
+type ContributionsReply struct {
+	Contributions []contributions.Contribution `json:"contributions"`
+	NextCursor    string                       `json:"nextCursor"` // Empty on the last page
+}
+
+func (j *JSONRPCServer) DatasetContributions(req *http.Request, args *DatasetContributionsArgs, reply *ContributionsReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetContributions")
+	defer span.End()
+
+	if contributionIdx == nil {
+		return ErrContributionsDisabled
+	}
+	datasetAddress, err := codec.StringToAddress(args.DatasetAddress)
+	if err != nil {
+		return err
+	}
+	reply.Contributions, reply.NextCursor = contributionIdx.DatasetContributions(datasetAddress, args.PendingOnly, args.Cursor, args.Limit)
+	return nil
+}
+
+type ContributionsByContributorArgs struct {
+	Contributor string `json:"contributor"`
+	PendingOnly bool   `json:"pendingOnly"`
+	Cursor      string `json:"cursor"` // Empty for the first page
+	Limit       int    `json:"limit"`  // contributions.MaxPageSize if 0
+}
+
+func (j *JSONRPCServer) ContributionsByContributor(req *http.Request, args *ContributionsByContributorArgs, reply *ContributionsReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.ContributionsByContributor")
+	defer span.End()
+
+	if contributionIdx == nil {
+		return ErrContributionsDisabled
+	}
+	contributor, err := codec.StringToAddress(args.Contributor)
+	if err != nil {
+		return err
+	}
+	reply.Contributions, reply.NextCursor = contributionIdx.ContributionsByContributor(contributor, args.PendingOnly, args.Cursor, args.Limit)
+	return nil
+}
+
+type DatasetVersionArgs struct {
+	DatasetAddress string `json:"datasetAddress"`
+	Version        uint64 `json:"version"` // Latest version if 0
+}
+
+type DatasetVersionReply struct {
+	Version     uint64 `json:"version"` // 0 if the dataset has no version
+	Versions    uint64 `json:"versions"`
+	ContentHash string `json:"contentHash"`
+	Size        uint64 `json:"size"`
+	Timestamp   int64  `json:"timestamp"`
+	Height      uint64 `json:"height"` // Block from which the version is current
+	Changelog   string `json:"changelog"`
+}
+
+func (j *JSONRPCServer) DatasetVersion(req *http.Request, args *DatasetVersionArgs, reply *DatasetVersionReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetVersion")
+	defer span.End()
+
+	datasetAddress, err := codec.StringToAddress(args.DatasetAddress)
+	if err != nil {
+		return err
+	}
+	version := args.Version
+	if version == 0 {
+		version, err = storage.GetDatasetVersionsFromState(ctx, j.vm.ReadState, datasetAddress)
+		if err != nil {
+			return err
+		}
+	}
+	return j.datasetVersion(ctx, datasetAddress, version, reply)
+}
+
+type DatasetVersionAtHeightArgs struct {
+	DatasetAddress string `json:"datasetAddress"`
+	Height         uint64 `json:"height"`
+}
+
+func (j *JSONRPCServer) DatasetVersionAtHeight(req *http.Request, args *DatasetVersionAtHeightArgs, reply *DatasetVersionReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetVersionAtHeight")
+	defer span.End()
+
+	datasetAddress, err := codec.StringToAddress(args.DatasetAddress)
+	if err != nil {
+		return err
+	}
+	version, err := storage.GetDatasetVersionAtHeightFromState(ctx, j.vm.ReadState, datasetAddress, args.Height)
+	if err != nil {
+		return err
+	}
+	return j.datasetVersion(ctx, datasetAddress, version, reply)
+}
+
+// datasetVersion fills [reply] with [version] of [datasetAddress]. Version 0
+// leaves it empty.
+func (j *JSONRPCServer) datasetVersion(ctx context.Context, datasetAddress codec.Address, version uint64, reply *DatasetVersionReply) error {
+	versions, err := storage.GetDatasetVersionsFromState(ctx, j.vm.ReadState, datasetAddress)
+	if err != nil {
+		return err
+	}
+	reply.Versions = versions
+	if version == 0 {
+		return nil
+	}
+	contentHash, size, timestamp, height, changelog, err := storage.GetDatasetVersionFromState(ctx, j.vm.ReadState, datasetAddress, version)
+	if err != nil {
+		return err
+	}
+	reply.Version = version
+	reply.ContentHash = string(contentHash)
+	reply.Size = size
+	reply.Timestamp = timestamp
+	reply.Height = height
+	reply.Changelog = string(changelog)
+	return nil
+}
+
+type DatasetChallengeArgs struct {
+	ContributionID string `json:"contributionID"`
+}
+
+type DatasetChallengeReply struct {
+	DataRoot   string `json:"dataRoot"` // Empty if the contribution did not commit to a data root
+	DataChunks uint64 `json:"dataChunks"`
+
+	// Collateral of the contributor locked until the challenge window ends at
+	// block CollateralReleaseHeight
+	LockedCollateral        uint64 `json:"lockedCollateral"`
+	CollateralReleaseHeight uint64 `json:"collateralReleaseHeight"`
+
+	Open            bool   `json:"open"`
+	Challenger      string `json:"challenger"`
+	Bond            uint64 `json:"bond"`
+	ChallengedChunk uint64 `json:"challengedChunk"`
+	Deadline        uint64 `json:"deadline"`
+}
+
+func (j *JSONRPCServer) DatasetChallenge(req *http.Request, args *DatasetChallengeArgs, reply *DatasetChallengeReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetChallenge")
+	defer span.End()
+
+	contributionID, err := ids.FromString(args.ContributionID)
+	if err != nil {
+		return err
+	}
+	exists, root, chunks, err := storage.GetDataCommitmentFromState(ctx, j.vm.ReadState, contributionID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		reply.DataRoot = hex.EncodeToString(root)
+		reply.DataChunks = chunks
+	}
+	locked, amount, releaseHeight, err := storage.GetDataCollateralFromState(ctx, j.vm.ReadState, contributionID)
+	if err != nil {
+		return err
+	}
+	if locked {
+		reply.LockedCollateral = amount
+		reply.CollateralReleaseHeight = releaseHeight
+	}
+	open, challenger, bond, chunk, deadline, err := storage.GetDataChallengeFromState(ctx, j.vm.ReadState, contributionID)
+	if err != nil {
+		return err
+	}
+	if open {
+		reply.Open = true
+		reply.Challenger = challenger.String()
+		reply.Bond = bond
+		reply.ChallengedChunk = chunk
+		reply.Deadline = deadline
+	}
+	return nil
+}
+
+type DatasetCatalogArgs struct {
+	Filter catalog.Filter `json:"filter"`
+	SortBy string         `json:"sortBy"` // By address if empty or "subscriptions"
+	Cursor string         `json:"cursor"` // Empty for the first page
+	Limit  int            `json:"limit"`  // catalog.MaxPageSize if 0
+}
+
+type DatasetCatalogReply struct {
+	Datasets   []catalog.Dataset `json:"datasets"`
+	NextCursor string            `json:"nextCursor"` // Empty on the last page
+}
+
+func (j *JSONRPCServer) DatasetCatalog(req *http.Request, args *DatasetCatalogArgs, reply *DatasetCatalogReply) (err error) {
+	_, span := j.vm.Tracer().Start(req.Context(), "Server.DatasetCatalog")
+	defer span.End()
+
+	if datasetCatalog == nil {
+		return ErrCatalogDisabled
+	}
+	reply.Datasets, reply.NextCursor, err = datasetCatalog.Datasets(args.Filter, args.SortBy, args.Cursor, args.Limit)
+	return err
+}
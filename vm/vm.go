@@ -6,10 +6,14 @@ package vm
 import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/nuklai/nuklaivm/actions"
+	"github.com/nuklai/nuklaivm/catalog"
 	"github.com/nuklai/nuklaivm/config"
 	"github.com/nuklai/nuklaivm/consts"
+	"github.com/nuklai/nuklaivm/contributions"
 	"github.com/nuklai/nuklaivm/emission"
 	"github.com/nuklai/nuklaivm/genesis"
+	"github.com/nuklai/nuklaivm/holdings"
+	"github.com/nuklai/nuklaivm/orderbook"
 	"github.com/nuklai/nuklaivm/storage"
 
 	"github.com/ava-labs/hypersdk/api/jsonrpc"
@@ -21,6 +25,7 @@ import (
 	"github.com/ava-labs/hypersdk/x/contracts/runtime"
 
 	staterpc "github.com/ava-labs/hypersdk/api/state"
+	nauth "github.com/nuklai/nuklaivm/auth"
 )
 
 var (
@@ -28,6 +33,10 @@ var (
 	AuthParser      *codec.TypeParser[chain.Auth]
 	OutputParser    *codec.TypeParser[codec.Typed]
 	emissionTracker emission.Tracker
+	orderBook       *orderbook.OrderBook
+	assetHoldings   *holdings.Holdings
+	contributionIdx *contributions.Contributions
+	datasetCatalog  *catalog.Catalog
 	wasmRuntime     *runtime.WasmRuntime
 )
 
@@ -64,11 +73,55 @@ func init() {
 		ActionParser.Register(&actions.PublishDatasetMarketplace{}, actions.UnmarshalPublishDatasetMarketplace),
 		ActionParser.Register(&actions.SubscribeDatasetMarketplace{}, actions.UnmarshalSubscribeDatasetMarketplace),
 		ActionParser.Register(&actions.ClaimMarketplacePayment{}, actions.UnmarshalClaimMarketplacePayment),
+		ActionParser.Register(&actions.ContractUpgrade{}, actions.UnmarshalContractUpgrade),
+		ActionParser.Register(&actions.ContractRenounceUpgrade{}, actions.UnmarshalContractRenounceUpgrade),
+		ActionParser.Register(&actions.CreateSessionKey{}, actions.UnmarshalCreateSessionKey),
+		ActionParser.Register(&actions.RevokeSessionKey{}, actions.UnmarshalRevokeSessionKey),
+		ActionParser.Register(&actions.SessionKeyBegin{}, actions.UnmarshalSessionKeyBegin),
+		ActionParser.Register(&actions.SessionKeyEnd{}, actions.UnmarshalSessionKeyEnd),
+		ActionParser.Register(&actions.SetSponsorPolicy{}, actions.UnmarshalSetSponsorPolicy),
+		ActionParser.Register(&actions.WithdrawSponsorPolicy{}, actions.UnmarshalWithdrawSponsorPolicy),
+		ActionParser.Register(&actions.ApproveAsset{}, actions.UnmarshalApproveAsset),
+		ActionParser.Register(&actions.TransferAssetFrom{}, actions.UnmarshalTransferAssetFrom),
+		ActionParser.Register(&actions.BatchTransfer{}, actions.UnmarshalBatchTransfer),
+		ActionParser.Register(&actions.CreateOrder{}, actions.UnmarshalCreateOrder),
+		ActionParser.Register(&actions.FillOrder{}, actions.UnmarshalFillOrder),
+		ActionParser.Register(&actions.CloseOrder{}, actions.UnmarshalCloseOrder),
+		ActionParser.Register(&actions.CreateVestingSchedule{}, actions.UnmarshalCreateVestingSchedule),
+		ActionParser.Register(&actions.ClaimVested{}, actions.UnmarshalClaimVested),
+		ActionParser.Register(&actions.RevokeVestingSchedule{}, actions.UnmarshalRevokeVestingSchedule),
+		ActionParser.Register(&actions.FractionalizeNFT{}, actions.UnmarshalFractionalizeNFT),
+		ActionParser.Register(&actions.RedeemNFT{}, actions.UnmarshalRedeemNFT),
+		ActionParser.Register(&actions.SyncNFTOwner{}, actions.UnmarshalSyncNFTOwner),
+		ActionParser.Register(&actions.ProposeOwnershipTransfer{}, actions.UnmarshalProposeOwnershipTransfer),
+		ActionParser.Register(&actions.AcceptOwnershipTransfer{}, actions.UnmarshalAcceptOwnershipTransfer),
+		ActionParser.Register(&actions.GrantMintRole{}, actions.UnmarshalGrantMintRole),
+		ActionParser.Register(&actions.RevokeMintRole{}, actions.UnmarshalRevokeMintRole),
+		ActionParser.Register(&actions.RejectContributeDataset{}, actions.UnmarshalRejectContributeDataset),
+		ActionParser.Register(&actions.CancelContributeDataset{}, actions.UnmarshalCancelContributeDataset),
+		ActionParser.Register(&actions.PublishDatasetVersion{}, actions.UnmarshalPublishDatasetVersion),
+		ActionParser.Register(&actions.ChallengeDatasetContribution{}, actions.UnmarshalChallengeDatasetContribution),
+		ActionParser.Register(&actions.RespondDatasetChallenge{}, actions.UnmarshalRespondDatasetChallenge),
+		ActionParser.Register(&actions.ClaimDatasetChallenge{}, actions.UnmarshalClaimDatasetChallenge),
+		ActionParser.Register(&actions.UpdateMarketplaceListing{}, actions.UnmarshalUpdateMarketplaceListing),
+		ActionParser.Register(&actions.UnpublishDatasetMarketplace{}, actions.UnmarshalUnpublishDatasetMarketplace),
+		ActionParser.Register(&actions.SetMarketplacePaymentAsset{}, actions.UnmarshalSetMarketplacePaymentAsset),
+		ActionParser.Register(&actions.OpenUsageChannel{}, actions.UnmarshalOpenUsageChannel),
+		ActionParser.Register(&actions.SettleUsage{}, actions.UnmarshalSettleUsage),
+		ActionParser.Register(&actions.CloseUsageChannel{}, actions.UnmarshalCloseUsageChannel),
+		ActionParser.Register(&actions.DeliverDatasetKey{}, actions.UnmarshalDeliverDatasetKey),
+		ActionParser.Register(&actions.ReleaseContributionCollateral{}, actions.UnmarshalReleaseContributionCollateral),
+		ActionParser.Register(&actions.SetEncryptionPublicKey{}, actions.UnmarshalSetEncryptionPublicKey),
+		ActionParser.Register(&actions.SetDatasetKeyDelegate{}, actions.UnmarshalSetDatasetKeyDelegate),
+		ActionParser.Register(&actions.TransferNFT{}, actions.UnmarshalTransferNFT),
 
 		// When registering new auth, ALWAYS make sure to append at the end.
 		AuthParser.Register(&auth.ED25519{}, auth.UnmarshalED25519),
 		AuthParser.Register(&auth.SECP256R1{}, auth.UnmarshalSECP256R1),
 		AuthParser.Register(&auth.BLS{}, auth.UnmarshalBLS),
+		AuthParser.Register(&nauth.Multisig{}, nauth.UnmarshalMultisig),
+		AuthParser.Register(&nauth.SessionKey{}, nauth.UnmarshalSessionKey(ActionParser)),
+		AuthParser.Register(&nauth.Sponsored{}, nauth.UnmarshalSponsored(ActionParser)),
 
 		OutputParser.Register(&actions.TransferResult{}, actions.UnmarshalTransferResult),
 		OutputParser.Register(&actions.ContractCallResult{}, nil),
@@ -93,6 +146,47 @@ func init() {
 		OutputParser.Register(&actions.PublishDatasetMarketplaceResult{}, actions.UnmarshalPublishDatasetMarketplaceResult),
 		OutputParser.Register(&actions.SubscribeDatasetMarketplaceResult{}, actions.UnmarshalSubscribeDatasetMarketplaceResult),
 		OutputParser.Register(&actions.ClaimMarketplacePaymentResult{}, actions.UnmarshalClaimMarketplacePaymentResult),
+		OutputParser.Register(&actions.ContractUpgradeResult{}, actions.UnmarshalContractUpgradeResult),
+		OutputParser.Register(&actions.ContractRenounceUpgradeResult{}, actions.UnmarshalContractRenounceUpgradeResult),
+		OutputParser.Register(&actions.CreateSessionKeyResult{}, actions.UnmarshalCreateSessionKeyResult),
+		OutputParser.Register(&actions.RevokeSessionKeyResult{}, actions.UnmarshalRevokeSessionKeyResult),
+		OutputParser.Register(&actions.SessionKeyBeginResult{}, actions.UnmarshalSessionKeyBeginResult),
+		OutputParser.Register(&actions.SessionKeyEndResult{}, actions.UnmarshalSessionKeyEndResult),
+		OutputParser.Register(&actions.SetSponsorPolicyResult{}, actions.UnmarshalSetSponsorPolicyResult),
+		OutputParser.Register(&actions.WithdrawSponsorPolicyResult{}, actions.UnmarshalWithdrawSponsorPolicyResult),
+		OutputParser.Register(&actions.ApproveAssetResult{}, actions.UnmarshalApproveAssetResult),
+		OutputParser.Register(&actions.TransferAssetFromResult{}, actions.UnmarshalTransferAssetFromResult),
+		OutputParser.Register(&actions.BatchTransferResult{}, actions.UnmarshalBatchTransferResult),
+		OutputParser.Register(&actions.CreateOrderResult{}, actions.UnmarshalCreateOrderResult),
+		OutputParser.Register(&actions.FillOrderResult{}, actions.UnmarshalFillOrderResult),
+		OutputParser.Register(&actions.CloseOrderResult{}, actions.UnmarshalCloseOrderResult),
+		OutputParser.Register(&actions.CreateVestingScheduleResult{}, actions.UnmarshalCreateVestingScheduleResult),
+		OutputParser.Register(&actions.ClaimVestedResult{}, actions.UnmarshalClaimVestedResult),
+		OutputParser.Register(&actions.RevokeVestingScheduleResult{}, actions.UnmarshalRevokeVestingScheduleResult),
+		OutputParser.Register(&actions.FractionalizeNFTResult{}, actions.UnmarshalFractionalizeNFTResult),
+		OutputParser.Register(&actions.RedeemNFTResult{}, actions.UnmarshalRedeemNFTResult),
+		OutputParser.Register(&actions.SyncNFTOwnerResult{}, actions.UnmarshalSyncNFTOwnerResult),
+		OutputParser.Register(&actions.ProposeOwnershipTransferResult{}, actions.UnmarshalProposeOwnershipTransferResult),
+		OutputParser.Register(&actions.AcceptOwnershipTransferResult{}, actions.UnmarshalAcceptOwnershipTransferResult),
+		OutputParser.Register(&actions.GrantMintRoleResult{}, actions.UnmarshalGrantMintRoleResult),
+		OutputParser.Register(&actions.RevokeMintRoleResult{}, actions.UnmarshalRevokeMintRoleResult),
+		OutputParser.Register(&actions.RejectContributeDatasetResult{}, actions.UnmarshalRejectContributeDatasetResult),
+		OutputParser.Register(&actions.CancelContributeDatasetResult{}, actions.UnmarshalCancelContributeDatasetResult),
+		OutputParser.Register(&actions.PublishDatasetVersionResult{}, actions.UnmarshalPublishDatasetVersionResult),
+		OutputParser.Register(&actions.ChallengeDatasetContributionResult{}, actions.UnmarshalChallengeDatasetContributionResult),
+		OutputParser.Register(&actions.RespondDatasetChallengeResult{}, actions.UnmarshalRespondDatasetChallengeResult),
+		OutputParser.Register(&actions.ClaimDatasetChallengeResult{}, actions.UnmarshalClaimDatasetChallengeResult),
+		OutputParser.Register(&actions.UpdateMarketplaceListingResult{}, actions.UnmarshalUpdateMarketplaceListingResult),
+		OutputParser.Register(&actions.UnpublishDatasetMarketplaceResult{}, actions.UnmarshalUnpublishDatasetMarketplaceResult),
+		OutputParser.Register(&actions.SetMarketplacePaymentAssetResult{}, actions.UnmarshalSetMarketplacePaymentAssetResult),
+		OutputParser.Register(&actions.OpenUsageChannelResult{}, actions.UnmarshalOpenUsageChannelResult),
+		OutputParser.Register(&actions.SettleUsageResult{}, actions.UnmarshalSettleUsageResult),
+		OutputParser.Register(&actions.CloseUsageChannelResult{}, actions.UnmarshalCloseUsageChannelResult),
+		OutputParser.Register(&actions.DeliverDatasetKeyResult{}, actions.UnmarshalDeliverDatasetKeyResult),
+		OutputParser.Register(&actions.ReleaseContributionCollateralResult{}, actions.UnmarshalReleaseContributionCollateralResult),
+		OutputParser.Register(&actions.SetEncryptionPublicKeyResult{}, actions.UnmarshalSetEncryptionPublicKeyResult),
+		OutputParser.Register(&actions.SetDatasetKeyDelegateResult{}, actions.UnmarshalSetDatasetKeyDelegateResult),
+		OutputParser.Register(&actions.TransferNFTResult{}, actions.UnmarshalTransferNFTResult),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -120,6 +214,10 @@ func NewWithOptions(options ...vm.Option) (*vm.VM, error) {
 		WithIndexer(cfg),
 		WithExternalSubscriber(cfg),
 		WithEmissionBalancer(),
+		WithOrderBook(),
+		WithHoldings(),
+		WithContributions(),
+		WithCatalog(),
 	}, options...)
 	return vm.New(
 		consts.Version,